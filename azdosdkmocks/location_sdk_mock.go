@@ -0,0 +1,169 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/microsoft/azure-devops-go-api/azuredevops/v7/location (interfaces: Client)
+
+// Package azdosdkmocks is a generated GoMock package.
+package azdosdkmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	location "github.com/microsoft/azure-devops-go-api/azuredevops/v7/location"
+)
+
+// MockLocationClient is a mock of Client interface.
+type MockLocationClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationClientMockRecorder
+}
+
+// MockLocationClientMockRecorder is the mock recorder for MockLocationClient.
+type MockLocationClientMockRecorder struct {
+	mock *MockLocationClient
+}
+
+// NewMockLocationClient creates a new mock instance.
+func NewMockLocationClient(ctrl *gomock.Controller) *MockLocationClient {
+	mock := &MockLocationClient{ctrl: ctrl}
+	mock.recorder = &MockLocationClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationClient) EXPECT() *MockLocationClientMockRecorder {
+	return m.recorder
+}
+
+// DeleteServiceDefinition mocks base method.
+func (m *MockLocationClient) DeleteServiceDefinition(arg0 context.Context, arg1 location.DeleteServiceDefinitionArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteServiceDefinition", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteServiceDefinition indicates an expected call of DeleteServiceDefinition.
+func (mr *MockLocationClientMockRecorder) DeleteServiceDefinition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceDefinition", reflect.TypeOf((*MockLocationClient)(nil).DeleteServiceDefinition), arg0, arg1)
+}
+
+// GetConnectionData mocks base method.
+func (m *MockLocationClient) GetConnectionData(arg0 context.Context, arg1 location.GetConnectionDataArgs) (*location.ConnectionData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnectionData", arg0, arg1)
+	ret0, _ := ret[0].(*location.ConnectionData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConnectionData indicates an expected call of GetConnectionData.
+func (mr *MockLocationClientMockRecorder) GetConnectionData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectionData", reflect.TypeOf((*MockLocationClient)(nil).GetConnectionData), arg0, arg1)
+}
+
+// GetResourceArea mocks base method.
+func (m *MockLocationClient) GetResourceArea(arg0 context.Context, arg1 location.GetResourceAreaArgs) (*location.ResourceAreaInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourceArea", arg0, arg1)
+	ret0, _ := ret[0].(*location.ResourceAreaInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourceArea indicates an expected call of GetResourceArea.
+func (mr *MockLocationClientMockRecorder) GetResourceArea(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceArea", reflect.TypeOf((*MockLocationClient)(nil).GetResourceArea), arg0, arg1)
+}
+
+// GetResourceAreaByHost mocks base method.
+func (m *MockLocationClient) GetResourceAreaByHost(arg0 context.Context, arg1 location.GetResourceAreaByHostArgs) (*location.ResourceAreaInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourceAreaByHost", arg0, arg1)
+	ret0, _ := ret[0].(*location.ResourceAreaInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourceAreaByHost indicates an expected call of GetResourceAreaByHost.
+func (mr *MockLocationClientMockRecorder) GetResourceAreaByHost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceAreaByHost", reflect.TypeOf((*MockLocationClient)(nil).GetResourceAreaByHost), arg0, arg1)
+}
+
+// GetResourceAreas mocks base method.
+func (m *MockLocationClient) GetResourceAreas(arg0 context.Context, arg1 location.GetResourceAreasArgs) (*[]location.ResourceAreaInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourceAreas", arg0, arg1)
+	ret0, _ := ret[0].(*[]location.ResourceAreaInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourceAreas indicates an expected call of GetResourceAreas.
+func (mr *MockLocationClientMockRecorder) GetResourceAreas(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceAreas", reflect.TypeOf((*MockLocationClient)(nil).GetResourceAreas), arg0, arg1)
+}
+
+// GetResourceAreasByHost mocks base method.
+func (m *MockLocationClient) GetResourceAreasByHost(arg0 context.Context, arg1 location.GetResourceAreasByHostArgs) (*[]location.ResourceAreaInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourceAreasByHost", arg0, arg1)
+	ret0, _ := ret[0].(*[]location.ResourceAreaInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourceAreasByHost indicates an expected call of GetResourceAreasByHost.
+func (mr *MockLocationClientMockRecorder) GetResourceAreasByHost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceAreasByHost", reflect.TypeOf((*MockLocationClient)(nil).GetResourceAreasByHost), arg0, arg1)
+}
+
+// GetServiceDefinition mocks base method.
+func (m *MockLocationClient) GetServiceDefinition(arg0 context.Context, arg1 location.GetServiceDefinitionArgs) (*location.ServiceDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceDefinition", arg0, arg1)
+	ret0, _ := ret[0].(*location.ServiceDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceDefinition indicates an expected call of GetServiceDefinition.
+func (mr *MockLocationClientMockRecorder) GetServiceDefinition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceDefinition", reflect.TypeOf((*MockLocationClient)(nil).GetServiceDefinition), arg0, arg1)
+}
+
+// GetServiceDefinitions mocks base method.
+func (m *MockLocationClient) GetServiceDefinitions(arg0 context.Context, arg1 location.GetServiceDefinitionsArgs) (*[]location.ServiceDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceDefinitions", arg0, arg1)
+	ret0, _ := ret[0].(*[]location.ServiceDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceDefinitions indicates an expected call of GetServiceDefinitions.
+func (mr *MockLocationClientMockRecorder) GetServiceDefinitions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceDefinitions", reflect.TypeOf((*MockLocationClient)(nil).GetServiceDefinitions), arg0, arg1)
+}
+
+// UpdateServiceDefinitions mocks base method.
+func (m *MockLocationClient) UpdateServiceDefinitions(arg0 context.Context, arg1 location.UpdateServiceDefinitionsArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateServiceDefinitions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateServiceDefinitions indicates an expected call of UpdateServiceDefinitions.
+func (mr *MockLocationClientMockRecorder) UpdateServiceDefinitions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServiceDefinitions", reflect.TypeOf((*MockLocationClient)(nil).UpdateServiceDefinitions), arg0, arg1)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/organizationpolicy (interfaces: Client)
+
+// Package azdosdkmocks is a generated GoMock package.
+package azdosdkmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	organizationpolicy "github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/organizationpolicy"
+)
+
+// MockOrganizationpolicyClient is a mock of Client interface.
+type MockOrganizationpolicyClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationpolicyClientMockRecorder
+}
+
+// MockOrganizationpolicyClientMockRecorder is the mock recorder for MockOrganizationpolicyClient.
+type MockOrganizationpolicyClientMockRecorder struct {
+	mock *MockOrganizationpolicyClient
+}
+
+// NewMockOrganizationpolicyClient creates a new mock instance.
+func NewMockOrganizationpolicyClient(ctrl *gomock.Controller) *MockOrganizationpolicyClient {
+	mock := &MockOrganizationpolicyClient{ctrl: ctrl}
+	mock.recorder = &MockOrganizationpolicyClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationpolicyClient) EXPECT() *MockOrganizationpolicyClientMockRecorder {
+	return m.recorder
+}
+
+// GetPolicy mocks base method.
+func (m *MockOrganizationpolicyClient) GetPolicy(arg0 context.Context, arg1 organizationpolicy.GetPolicyArgs) (*organizationpolicy.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicy", arg0, arg1)
+	ret0, _ := ret[0].(*organizationpolicy.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicy indicates an expected call of GetPolicy.
+func (mr *MockOrganizationpolicyClientMockRecorder) GetPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicy", reflect.TypeOf((*MockOrganizationpolicyClient)(nil).GetPolicy), arg0, arg1)
+}
+
+// UpdatePolicy mocks base method.
+func (m *MockOrganizationpolicyClient) UpdatePolicy(arg0 context.Context, arg1 organizationpolicy.UpdatePolicyArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePolicy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePolicy indicates an expected call of UpdatePolicy.
+func (mr *MockOrganizationpolicyClientMockRecorder) UpdatePolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePolicy", reflect.TypeOf((*MockOrganizationpolicyClient)(nil).UpdatePolicy), arg0, arg1)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/taskagentextras (interfaces: Client)
+
+// Package azdosdkmocks is a generated GoMock package.
+package azdosdkmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	taskagent "github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	taskagentextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/taskagentextras"
+)
+
+// MockTaskagentextrasClient is a mock of Client interface.
+type MockTaskagentextrasClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskagentextrasClientMockRecorder
+}
+
+// MockTaskagentextrasClientMockRecorder is the mock recorder for MockTaskagentextrasClient.
+type MockTaskagentextrasClientMockRecorder struct {
+	mock *MockTaskagentextrasClient
+}
+
+// NewMockTaskagentextrasClient creates a new mock instance.
+func NewMockTaskagentextrasClient(ctrl *gomock.Controller) *MockTaskagentextrasClient {
+	mock := &MockTaskagentextrasClient{ctrl: ctrl}
+	mock.recorder = &MockTaskagentextrasClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskagentextrasClient) EXPECT() *MockTaskagentextrasClientMockRecorder {
+	return m.recorder
+}
+
+// CreateAgentPoolMaintenanceDefinition mocks base method.
+func (m *MockTaskagentextrasClient) CreateAgentPoolMaintenanceDefinition(arg0 context.Context, arg1 taskagentextras.CreateAgentPoolMaintenanceDefinitionArgs) (*taskagent.TaskAgentPoolMaintenanceDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAgentPoolMaintenanceDefinition", arg0, arg1)
+	ret0, _ := ret[0].(*taskagent.TaskAgentPoolMaintenanceDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAgentPoolMaintenanceDefinition indicates an expected call of CreateAgentPoolMaintenanceDefinition.
+func (mr *MockTaskagentextrasClientMockRecorder) CreateAgentPoolMaintenanceDefinition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAgentPoolMaintenanceDefinition", reflect.TypeOf((*MockTaskagentextrasClient)(nil).CreateAgentPoolMaintenanceDefinition), arg0, arg1)
+}
+
+// DeleteAgentPoolMaintenanceDefinition mocks base method.
+func (m *MockTaskagentextrasClient) DeleteAgentPoolMaintenanceDefinition(arg0 context.Context, arg1 taskagentextras.DeleteAgentPoolMaintenanceDefinitionArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAgentPoolMaintenanceDefinition", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAgentPoolMaintenanceDefinition indicates an expected call of DeleteAgentPoolMaintenanceDefinition.
+func (mr *MockTaskagentextrasClientMockRecorder) DeleteAgentPoolMaintenanceDefinition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAgentPoolMaintenanceDefinition", reflect.TypeOf((*MockTaskagentextrasClient)(nil).DeleteAgentPoolMaintenanceDefinition), arg0, arg1)
+}
+
+// GetAgentPoolMaintenanceDefinitions mocks base method.
+func (m *MockTaskagentextrasClient) GetAgentPoolMaintenanceDefinitions(arg0 context.Context, arg1 taskagentextras.GetAgentPoolMaintenanceDefinitionsArgs) (*[]taskagent.TaskAgentPoolMaintenanceDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAgentPoolMaintenanceDefinitions", arg0, arg1)
+	ret0, _ := ret[0].(*[]taskagent.TaskAgentPoolMaintenanceDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAgentPoolMaintenanceDefinitions indicates an expected call of GetAgentPoolMaintenanceDefinitions.
+func (mr *MockTaskagentextrasClientMockRecorder) GetAgentPoolMaintenanceDefinitions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgentPoolMaintenanceDefinitions", reflect.TypeOf((*MockTaskagentextrasClient)(nil).GetAgentPoolMaintenanceDefinitions), arg0, arg1)
+}
+
+// UpdateAgentPoolMaintenanceDefinition mocks base method.
+func (m *MockTaskagentextrasClient) UpdateAgentPoolMaintenanceDefinition(arg0 context.Context, arg1 taskagentextras.UpdateAgentPoolMaintenanceDefinitionArgs) (*taskagent.TaskAgentPoolMaintenanceDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAgentPoolMaintenanceDefinition", arg0, arg1)
+	ret0, _ := ret[0].(*taskagent.TaskAgentPoolMaintenanceDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAgentPoolMaintenanceDefinition indicates an expected call of UpdateAgentPoolMaintenanceDefinition.
+func (mr *MockTaskagentextrasClientMockRecorder) UpdateAgentPoolMaintenanceDefinition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAgentPoolMaintenanceDefinition", reflect.TypeOf((*MockTaskagentextrasClient)(nil).UpdateAgentPoolMaintenanceDefinition), arg0, arg1)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/tokens (interfaces: Client)
+
+// Package azdosdkmocks is a generated GoMock package.
+package azdosdkmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	tokens "github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/tokens"
+)
+
+// MockTokensClient is a mock of Client interface.
+type MockTokensClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokensClientMockRecorder
+}
+
+// MockTokensClientMockRecorder is the mock recorder for MockTokensClient.
+type MockTokensClientMockRecorder struct {
+	mock *MockTokensClient
+}
+
+// NewMockTokensClient creates a new mock instance.
+func NewMockTokensClient(ctrl *gomock.Controller) *MockTokensClient {
+	mock := &MockTokensClient{ctrl: ctrl}
+	mock.recorder = &MockTokensClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokensClient) EXPECT() *MockTokensClientMockRecorder {
+	return m.recorder
+}
+
+// CreatePersonalAccessToken mocks base method.
+func (m *MockTokensClient) CreatePersonalAccessToken(arg0 context.Context, arg1 tokens.CreatePersonalAccessTokenArgs) (*tokens.PatTokenResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePersonalAccessToken", arg0, arg1)
+	ret0, _ := ret[0].(*tokens.PatTokenResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePersonalAccessToken indicates an expected call of CreatePersonalAccessToken.
+func (mr *MockTokensClientMockRecorder) CreatePersonalAccessToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePersonalAccessToken", reflect.TypeOf((*MockTokensClient)(nil).CreatePersonalAccessToken), arg0, arg1)
+}
+
+// RevokePersonalAccessToken mocks base method.
+func (m *MockTokensClient) RevokePersonalAccessToken(arg0 context.Context, arg1 tokens.RevokePersonalAccessTokenArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokePersonalAccessToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokePersonalAccessToken indicates an expected call of RevokePersonalAccessToken.
+func (mr *MockTokensClientMockRecorder) RevokePersonalAccessToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokePersonalAccessToken", reflect.TypeOf((*MockTokensClient)(nil).RevokePersonalAccessToken), arg0, arg1)
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"log"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/security"
+)
+
+// aclBatcher coalesces the ACL updates of concurrently applied permission resources. Terraform applies
+// resources in parallel, so the updates of the permission resources submitted while an update of the same
+// security namespace is being written are sent together, with a single request per token instead of a request
+// per access control entry. An update of a namespace without a pending write is sent right away.
+type aclBatcher struct {
+	lock       sync.Mutex
+	namespaces map[uuid.UUID]*aclQueue
+}
+
+// aclQueue holds the updates of a security namespace submitted while a batch of the namespace is written
+type aclQueue struct {
+	writing bool
+	pending *aclBatch
+}
+
+// aclBatch holds the entries to write by token, until they are written
+type aclBatch struct {
+	tokens []string
+	aces   map[string][]security.AccessControlEntry
+	done   chan struct{}
+	errs   map[string]error
+}
+
+func newACLBatcher() *aclBatcher {
+	return &aclBatcher{
+		namespaces: map[uuid.UUID]*aclQueue{},
+	}
+}
+
+// SetAccessControlEntriesBatched writes access control entries of a token, replacing the existing entries
+// of their descriptors. The entries of other descriptors are kept. The entries are sent together with the
+// updates of the token submitted while an earlier update of the namespace is written, and the call returns once
+// the entries of the token have been written. Entries are sent right away when the client does not batch updates.
+func (c *AggregatedClient) SetAccessControlEntriesBatched(namespaceID uuid.UUID, token string, aces []security.AccessControlEntry) error {
+	if c.aclBatcher == nil {
+		return c.setAccessControlEntries(namespaceID, token, aces)
+	}
+	return c.aclBatcher.submit(c, namespaceID, token, aces)
+}
+
+// submit adds the entries to the pending batch of the namespace and waits until the batch is written. The
+// batches of a namespace are written one after the other, so that the entries of a later batch win.
+func (b *aclBatcher) submit(c *AggregatedClient, namespaceID uuid.UUID, token string, aces []security.AccessControlEntry) error {
+	b.lock.Lock()
+	queue, ok := b.namespaces[namespaceID]
+	if !ok {
+		queue = &aclQueue{}
+		b.namespaces[namespaceID] = queue
+	}
+	if queue.pending == nil {
+		queue.pending = &aclBatch{
+			aces: map[string][]security.AccessControlEntry{},
+			done: make(chan struct{}),
+			errs: map[string]error{},
+		}
+	}
+	batch := queue.pending
+	if _, ok := batch.aces[token]; !ok {
+		batch.tokens = append(batch.tokens, token)
+	}
+	batch.aces[token] = append(batch.aces[token], aces...)
+	if !queue.writing {
+		queue.writing = true
+		go b.write(c, namespaceID, queue)
+	}
+	b.lock.Unlock()
+
+	<-batch.done
+	return batch.errs[token]
+}
+
+// write writes the pending batches of the namespace until no update is pending anymore
+func (b *aclBatcher) write(c *AggregatedClient, namespaceID uuid.UUID, queue *aclQueue) {
+	for {
+		b.lock.Lock()
+		batch := queue.pending
+		queue.pending = nil
+		if batch == nil {
+			queue.writing = false
+			delete(b.namespaces, namespaceID)
+			b.lock.Unlock()
+			return
+		}
+		b.lock.Unlock()
+
+		log.Printf("[DEBUG] Writing the ACEs of %d tokens of security namespace %s", len(batch.tokens), namespaceID)
+		for _, token := range batch.tokens {
+			// a failing token only fails the updates of that token
+			batch.errs[token] = c.setAccessControlEntries(namespaceID, token, batch.aces[token])
+		}
+		close(batch.done)
+	}
+}
+
+// setAccessControlEntries writes the entries of a token with a single request. Only the entries of the
+// descriptors are replaced, so that entries written concurrently for other descriptors are kept.
+func (c *AggregatedClient) setAccessControlEntries(namespaceID uuid.UUID, token string, aces []security.AccessControlEntry) error {
+	aces = latestAccessControlEntries(aces)
+	if len(aces) == 0 {
+		return nil
+	}
+
+	merge := false
+	container := struct {
+		Token                *string                        `json:"token,omitempty"`
+		Merge                *bool                          `json:"merge,omitempty"`
+		AccessControlEntries *[]security.AccessControlEntry `json:"accessControlEntries,omitempty"`
+	}{
+		Token:                &token,
+		Merge:                &merge,
+		AccessControlEntries: &aces,
+	}
+	_, err := c.SecurityClient.SetAccessControlEntries(c.Ctx, security.SetAccessControlEntriesArgs{
+		SecurityNamespaceId: &namespaceID,
+		Container:           container,
+	})
+	return err
+}
+
+// latestAccessControlEntries returns the last entry of every descriptor, in the order the descriptors were added
+func latestAccessControlEntries(aces []security.AccessControlEntry) []security.AccessControlEntry {
+	latest := make([]security.AccessControlEntry, 0, len(aces))
+	index := map[string]int{}
+	for _, ace := range aces {
+		ace.ExtendedInfo = nil
+		if i, ok := index[*ace.Descriptor]; ok {
+			latest[i] = ace
+			continue
+		}
+		index[*ace.Descriptor] = len(latest)
+		latest = append(latest, ace)
+	}
+	return latest
+}
@@ -0,0 +1,276 @@
+//go:build (all || client) && !exclude_client
+// +build all client
+// +build !exclude_client
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/security"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestACE(descriptor string, allow int) security.AccessControlEntry {
+	deny := 0
+	return security.AccessControlEntry{Descriptor: &descriptor, Allow: &allow, Deny: &deny}
+}
+
+// writtenACEs is the container of a request which sets access control entries
+type writtenACEs struct {
+	Token                string                        `json:"token"`
+	Merge                bool                          `json:"merge"`
+	AccessControlEntries []security.AccessControlEntry `json:"accessControlEntries"`
+}
+
+func writtenEntries(t *testing.T, args security.SetAccessControlEntriesArgs) writtenACEs {
+	body, err := json.Marshal(args.Container)
+	require.NoError(t, err)
+	var written writtenACEs
+	require.NoError(t, json.Unmarshal(body, &written))
+	return written
+}
+
+// waitForWrite waits until a batch of the namespace is written and no update is pending
+func waitForWrite(t *testing.T, b *aclBatcher, namespaceID uuid.UUID) {
+	require.Eventually(t, func() bool {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		queue, ok := b.namespaces[namespaceID]
+		return ok && queue.writing && queue.pending == nil
+	}, 5*time.Second, time.Millisecond)
+}
+
+// waitForPendingTokens waits until updates of the number of tokens are pending in the namespace
+func waitForPendingTokens(t *testing.T, b *aclBatcher, namespaceID uuid.UUID, count int) {
+	require.Eventually(t, func() bool {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		queue, ok := b.namespaces[namespaceID]
+		return ok && queue.pending != nil && len(queue.pending.tokens) == count
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestSetAccessControlEntriesBatched_WritesRightAwayWhenIdle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	securityClient := azdosdkmocks.NewMockSecurityClient(ctrl)
+	clients := &AggregatedClient{SecurityClient: securityClient, Ctx: context.Background(), aclBatcher: newACLBatcher()}
+
+	namespaceID := uuid.New()
+	token := "repoV2/p/r1"
+	securityClient.EXPECT().
+		SetAccessControlEntries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args security.SetAccessControlEntriesArgs) (*[]security.AccessControlEntry, error) {
+			require.Equal(t, namespaceID, *args.SecurityNamespaceId)
+			written := writtenEntries(t, args)
+			require.Equal(t, token, written.Token)
+			require.False(t, written.Merge)
+			require.Len(t, written.AccessControlEntries, 1)
+			return nil, nil
+		}).
+		Times(1)
+
+	start := time.Now()
+	require.Nil(t, clients.SetAccessControlEntriesBatched(namespaceID, token, []security.AccessControlEntry{newTestACE("principal", 2)}))
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestSetAccessControlEntriesBatched_WritesUpdatesQueuedDuringWriteWithRequestPerToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	securityClient := azdosdkmocks.NewMockSecurityClient(ctrl)
+	batcher := newACLBatcher()
+	clients := &AggregatedClient{SecurityClient: securityClient, Ctx: context.Background(), aclBatcher: batcher}
+
+	namespaceID := uuid.New()
+	release := make(chan struct{})
+	var writtenLock sync.Mutex
+	written := map[string][]security.AccessControlEntry{}
+	securityClient.EXPECT().
+		SetAccessControlEntries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args security.SetAccessControlEntriesArgs) (*[]security.AccessControlEntry, error) {
+			entries := writtenEntries(t, args)
+			if entries.Token == "repoV2/p/r0" {
+				<-release
+			}
+			writtenLock.Lock()
+			defer writtenLock.Unlock()
+			written[entries.Token] = append(written[entries.Token], entries.AccessControlEntries...)
+			return nil, nil
+		}).
+		Times(3)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		require.Nil(t, clients.SetAccessControlEntriesBatched(namespaceID, "repoV2/p/r0", []security.AccessControlEntry{newTestACE("principal", 1)}))
+	}()
+	// the first update is written right away, the next ones are queued until it is written
+	waitForWrite(t, batcher, namespaceID)
+
+	for token, allow := range map[string]int{"repoV2/p/r1": 2, "repoV2/p/r2": 4} {
+		wg.Add(1)
+		go func(token string, allow int) {
+			defer wg.Done()
+			require.Nil(t, clients.SetAccessControlEntriesBatched(namespaceID, token, []security.AccessControlEntry{newTestACE("principal", allow)}))
+		}(token, allow)
+	}
+	waitForPendingTokens(t, batcher, namespaceID, 2)
+	close(release)
+	wg.Wait()
+
+	require.Len(t, written, 3)
+	require.Equal(t, 2, *written["repoV2/p/r1"][0].Allow)
+	require.Equal(t, 4, *written["repoV2/p/r2"][0].Allow)
+}
+
+// verifies that concurrent updates of the same token are sent with a single request
+func TestSetAccessControlEntriesBatched_CoalescesEntriesOfConcurrentWritersOfSameToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	securityClient := azdosdkmocks.NewMockSecurityClient(ctrl)
+	batcher := newACLBatcher()
+	clients := &AggregatedClient{SecurityClient: securityClient, Ctx: context.Background(), aclBatcher: batcher}
+
+	namespaceID := uuid.New()
+	token := "repoV2/p/r1"
+
+	release := make(chan struct{})
+	var descriptors []string
+	gomock.InOrder(
+		securityClient.EXPECT().
+			SetAccessControlEntries(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, args security.SetAccessControlEntriesArgs) (*[]security.AccessControlEntry, error) {
+				<-release
+				return nil, nil
+			}),
+		securityClient.EXPECT().
+			SetAccessControlEntries(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, args security.SetAccessControlEntriesArgs) (*[]security.AccessControlEntry, error) {
+				for _, ace := range writtenEntries(t, args).AccessControlEntries {
+					descriptors = append(descriptors, *ace.Descriptor)
+				}
+				return nil, nil
+			}),
+	)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		require.Nil(t, clients.SetAccessControlEntriesBatched(namespaceID, token, []security.AccessControlEntry{newTestACE("first", 1)}))
+	}()
+	waitForWrite(t, batcher, namespaceID)
+
+	for _, descriptor := range []string{"second", "third"} {
+		wg.Add(1)
+		go func(descriptor string) {
+			defer wg.Done()
+			require.Nil(t, clients.SetAccessControlEntriesBatched(namespaceID, token, []security.AccessControlEntry{newTestACE(descriptor, 1)}))
+		}(descriptor)
+	}
+	require.Eventually(t, func() bool {
+		batcher.lock.Lock()
+		defer batcher.lock.Unlock()
+		queue := batcher.namespaces[namespaceID]
+		return queue.pending != nil && len(queue.pending.aces[token]) == 2
+	}, 5*time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.ElementsMatch(t, []string{"second", "third"}, descriptors)
+}
+
+func TestSetAccessControlEntriesBatched_ReturnsErrorToWaitersOfFailingToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	securityClient := azdosdkmocks.NewMockSecurityClient(ctrl)
+	batcher := newACLBatcher()
+	clients := &AggregatedClient{SecurityClient: securityClient, Ctx: context.Background(), aclBatcher: batcher}
+
+	namespaceID := uuid.New()
+	release := make(chan struct{})
+	securityClient.EXPECT().
+		SetAccessControlEntries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args security.SetAccessControlEntriesArgs) (*[]security.AccessControlEntry, error) {
+			switch writtenEntries(t, args).Token {
+			case "repoV2/p/r0":
+				<-release
+			case "repoV2/p/r1":
+				return nil, errors.New("SetAccessControlEntries() Failed")
+			}
+			return nil, nil
+		}).
+		Times(3)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		require.Nil(t, clients.SetAccessControlEntriesBatched(namespaceID, "repoV2/p/r0", []security.AccessControlEntry{newTestACE("principal", 1)}))
+	}()
+	waitForWrite(t, batcher, namespaceID)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		err := clients.SetAccessControlEntriesBatched(namespaceID, "repoV2/p/r1", []security.AccessControlEntry{newTestACE("principal", 1)})
+		require.EqualError(t, err, "SetAccessControlEntries() Failed")
+	}()
+	go func() {
+		defer wg.Done()
+		require.Nil(t, clients.SetAccessControlEntriesBatched(namespaceID, "repoV2/p/r2", []security.AccessControlEntry{newTestACE("principal", 1)}))
+	}()
+	waitForPendingTokens(t, batcher, namespaceID, 2)
+	close(release)
+	wg.Wait()
+}
+
+func TestSetAccessControlEntriesBatched_WritesRightAwayWithoutBatcher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	securityClient := azdosdkmocks.NewMockSecurityClient(ctrl)
+	clients := &AggregatedClient{SecurityClient: securityClient, Ctx: context.Background()}
+
+	namespaceID := uuid.New()
+	token := "repoV2/p/r1"
+	securityClient.EXPECT().
+		SetAccessControlEntries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args security.SetAccessControlEntriesArgs) (*[]security.AccessControlEntry, error) {
+			written := writtenEntries(t, args)
+			require.Equal(t, token, written.Token)
+			require.Len(t, written.AccessControlEntries, 1)
+			require.Equal(t, 8, *written.AccessControlEntries[0].Allow)
+			return nil, nil
+		}).
+		Times(1)
+
+	require.Nil(t, clients.SetAccessControlEntriesBatched(namespaceID, token, []security.AccessControlEntry{newTestACE("principal", 8)}))
+}
+
+func TestLatestAccessControlEntries_KeepsLastEntryOfDescriptor(t *testing.T) {
+	aces := latestAccessControlEntries([]security.AccessControlEntry{
+		newTestACE("first", 1),
+		newTestACE("second", 2),
+		newTestACE("first", 4),
+	})
+	require.Len(t, aces, 2)
+	require.Equal(t, "first", *aces[0].Descriptor)
+	require.Equal(t, 4, *aces[0].Allow)
+	require.Equal(t, "second", *aces[1].Descriptor)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
@@ -16,6 +18,7 @@ import (
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/graph"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/identity"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/location"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/memberentitlementmanagement"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/operations"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/pipelinepermissions"
@@ -28,9 +31,13 @@ import (
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/servicehooks"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/workitemtracking"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/workitemtrackingprocess"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/organizationpolicy"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/pipelineschecksextras"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/sdk"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/securityroles"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/taskagentextras"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/tokens"
 	"github.com/microsoft/terraform-provider-azuredevops/version"
 )
 
@@ -57,19 +64,72 @@ type AggregatedClient struct {
 	ReleaseClient                 release.Client
 	ServiceEndpointClient         serviceendpoint.Client
 	TaskAgentClient               taskagent.Client
+	TaskAgentClientExtras         taskagentextras.Client
 	MemberEntitleManagementClient memberentitlementmanagement.Client
 	FeatureManagementClient       featuremanagement.Client
 	FeedClient                    feed.Client
 	SecurityClient                security.Client
 	IdentityClient                identity.Client
+	LocationClient                location.Client
 	WorkItemTrackingClient        workitemtracking.Client
+	WorkItemTrackingProcessClient workitemtrackingprocess.Client
 	ServiceHooksClient            servicehooks.Client
 	Ctx                           context.Context
 	SecurityRolesClient           securityroles.Client
+	TokensClient                  tokens.Client
+	OrganizationPolicyClient      organizationpolicy.Client
+	// DefaultProjectID is the project used by resources for which no project is configured
+	DefaultProjectID string
+	// organizations holds the clients of the organizations configured through aliases
+	organizations *organizationClients
+	// cache memoizes identity and descriptor lookups, lookups are not cached when it is nil
+	cache *identityCache
+	// aclBatcher coalesces ACL updates of permission resources, updates are sent right away when it is nil
+	aclBatcher *aclBatcher
+	// tokenScopes holds the scopes of the token when token scope validation is enabled
+	tokenScopes *tokenScopeValidation
+	// projects remembers the existing projects when project reference validation is enabled
+	projects *projectValidation
+	// readAfterWrite configures the retries of reads of new resources, reads are not retried when it is nil
+	readAfterWrite *readAfterWrite
+}
+
+// ClientOptions configures how requests are sent to the Azure DevOps API
+type ClientOptions struct {
+	// MaxRetries is the maximum number of times a throttled or unavailable request is retried
+	MaxRetries int
+	// RetryBaseDelay is the delay before the first retry, when not dictated by the service
+	RetryBaseDelay time.Duration
+	// AdaptiveThrottling slows down requests when the rate limit of the caller is nearly exhausted
+	AdaptiveThrottling bool
+	// MaxConcurrentRequests is the maximum number of requests sent at the same time, zero means no limit
+	MaxConcurrentRequests int
+	// MaxConcurrentIdentityRequests is the maximum number of requests of the Graph and Member Entitlement
+	// Management services sent at the same time, zero means no limit
+	MaxConcurrentIdentityRequests int
+	// OrganizationAliases maps the aliases of additional organizations to their URL
+	OrganizationAliases map[string]string
+	// Transport sends the requests to Azure DevOps, defaults to http.DefaultTransport
+	Transport http.RoundTripper
+	// DebugLogging logs every request and response, with secrets redacted
+	DebugLogging bool
+	// ValidateTokenScopes verifies the token against the organization when the clients are created, and
+	// checks the scopes required by resources against the scopes of the token
+	ValidateTokenScopes bool
+	// PersonalAccessTokenScopes are the scopes granted to the personal access token of the provider, which
+	// cannot be read from the token. nil when they are not known.
+	PersonalAccessTokenScopes []string
+	// ValidateProjectReferences verifies the projects referenced by resources exist when they are planned
+	ValidateProjectReferences bool
+	// ReadAfterWriteTimeout is how long the read of a resource which was just created is retried while the
+	// resource is not found, reads are not retried when it is zero
+	ReadAfterWriteTimeout time.Duration
+	// ReadAfterWriteDelay is the delay before the first retry of a read after write, doubled for every retry
+	ReadAfterWriteDelay time.Duration
 }
 
 // GetAzdoClient builds and provides a connection to the Azure DevOps API
-func GetAzdoClient(azdoTokenProvider func() (string, error), organizationURL string, tfVersion string) (*AggregatedClient, error) {
+func GetAzdoClient(azdoTokenProvider func() (string, error), organizationURL string, tfVersion string, options *ClientOptions) (*AggregatedClient, error) {
 	ctx := context.Background()
 
 	if strings.EqualFold(organizationURL, "") {
@@ -82,134 +142,212 @@ func GetAzdoClient(azdoTokenProvider func() (string, error), organizationURL str
 	}
 	setUserAgent(connection, tfVersion)
 
-	coreClient, err := core.NewClient(ctx, connection)
+	// All clients share a single http.Client which retries throttled requests and requests a
+	// fresh authorization header for every attempt, so that expiring access tokens are renewed
+	// during long running applies. The errors of failed requests carry the activity ID Azure
+	// DevOps correlates them with. Requests waiting for a retry do not count against the concurrency
+	// limits.
+	transport := options.Transport
+	if options.DebugLogging {
+		transport = &sdk.LoggingTransport{Next: transport}
+	}
+	transport = &sdk.AuthorizationTransport{
+		Next:          transport,
+		TokenProvider: azdoTokenProvider,
+	}
+	if options.AdaptiveThrottling {
+		transport = &sdk.ThrottleTransport{Next: transport}
+	}
+	transport = &sdk.CorrelationTransport{Next: transport}
+	if options.MaxConcurrentRequests > 0 || options.MaxConcurrentIdentityRequests > 0 {
+		transport = sdk.NewConcurrencyTransport(transport, options.MaxConcurrentRequests, options.MaxConcurrentIdentityRequests)
+	}
+	httpClient := &http.Client{
+		Transport: &sdk.RetryTransport{
+			Next:       transport,
+			MaxRetries: options.MaxRetries,
+			BaseDelay:  options.RetryBaseDelay,
+		},
+	}
+
+	clientFactory, err := sdk.NewClientFactory(ctx, connection, httpClient)
+	if err != nil {
+		log.Printf("getAzdoClient(): sdk.NewClientFactory failed.")
+		return nil, err
+	}
+
+	coreClient, err := clientFactory.ClientByResourceAreaId(core.ResourceAreaId)
 	if err != nil {
 		log.Printf("getAzdoClient(): core.NewClient failed.")
 		return nil, err
 	}
 
-	buildClient, err := build.NewClient(ctx, connection)
+	buildClient, err := clientFactory.ClientByResourceAreaId(build.ResourceAreaId)
 	if err != nil {
 		log.Printf("getAzdoClient(): build.NewClient failed.")
 		return nil, err
 	}
 
-	operationsClient := operations.NewClient(ctx, connection)
+	operationsClient := clientFactory.ClientByUrl(connection.BaseUrl)
 
-	elasticClient := elastic.NewClient(ctx, connection)
+	elasticClient := clientFactory.ClientByUrl(connection.BaseUrl)
 
-	serviceEndpointClient, err := serviceendpoint.NewClient(ctx, connection)
+	serviceEndpointClient, err := clientFactory.ClientByResourceAreaId(serviceendpoint.ResourceAreaId)
 	if err != nil {
 		log.Printf("getAzdoClient(): serviceendpoint.NewClient failed.")
 		return nil, err
 	}
 
-	taskagentClient, err := taskagent.NewClient(ctx, connection)
+	taskagentClient, err := clientFactory.ClientByResourceAreaId(taskagent.ResourceAreaId)
 	if err != nil {
 		log.Printf("getAzdoClient(): taskagent.NewClient failed.")
 		return nil, err
 	}
 
-	gitReposClient, err := git.NewClient(ctx, connection)
+	gitReposClient, err := clientFactory.ClientByResourceAreaId(git.ResourceAreaId)
 	if err != nil {
 		log.Printf("getAzdoClient(): git.NewClient failed.")
 		return nil, err
 	}
 
-	graphClient, err := graph.NewClient(ctx, connection)
+	graphClient, err := clientFactory.ClientByResourceAreaId(graph.ResourceAreaId)
 	if err != nil {
 		log.Printf("getAzdoClient(): graph.NewClient failed.")
 		return nil, err
 	}
 
-	memberentitlementmanagementClient, err := memberentitlementmanagement.NewClient(ctx, connection)
+	memberentitlementmanagementClient, err := clientFactory.ClientByResourceAreaId(memberentitlementmanagement.ResourceAreaId)
 	if err != nil {
 		log.Printf("getAzdoClient(): memberentitlementmanagement.NewClient failed.")
 		return nil, err
 	}
 
-	policyClient, err := policy.NewClient(ctx, connection)
+	policyClient, err := clientFactory.ClientByResourceAreaId(policy.ResourceAreaId)
 	if err != nil {
 		log.Printf("getAzdoClient(): policy.NewClient failed.")
 		return nil, err
 	}
 
-	releaseClient, err := release.NewClient(ctx, connection)
+	releaseClient, err := clientFactory.ClientByResourceAreaId(release.ResourceAreaId)
 	if err != nil {
 		log.Printf("getAzdoClient(): release.NewClient failed.")
 		return nil, err
 	}
 
-	securityClient := security.NewClient(ctx, connection)
-	identityClient, err := identity.NewClient(ctx, connection)
+	securityClient := clientFactory.ClientByUrl(connection.BaseUrl)
+	identityClient, err := clientFactory.ClientByResourceAreaId(identity.ResourceAreaId)
 	if err != nil {
 		log.Printf("getAzdoClient(): identity.NewClient failed.")
 		return nil, err
 	}
 
-	featuremanagementClient := featuremanagement.NewClient(ctx, connection)
+	featuremanagementClient := clientFactory.ClientByUrl(connection.BaseUrl)
 
-	feedClient, err := feed.NewClient(ctx, connection)
+	feedClient, err := clientFactory.ClientByResourceAreaId(feed.ResourceAreaId)
 	if err != nil {
 		log.Printf("getAzdoClient(): feed.NewClient failed.")
 		return nil, err
 	}
 
-	workitemtrackingClient, err := workitemtracking.NewClient(ctx, connection)
+	workitemtrackingClient, err := clientFactory.ClientByResourceAreaId(workitemtracking.ResourceAreaId)
 	if err != nil {
 		log.Printf("getAzdoClient(): workitemtracking.NewClient failed.")
 		return nil, err
 	}
 
-	pipelines := pipelines.NewClient(ctx, connection)
+	pipelinesClient := clientFactory.ClientByUrl(connection.BaseUrl)
 
-	pipelinesChecksClient, err := pipelineschecks.NewClient(ctx, connection)
+	pipelinesChecksClient, err := clientFactory.ClientByResourceAreaId(pipelineschecks.ResourceAreaId)
 	if err != nil {
 		log.Printf("getAzdoClient(): pipelineschecks.NewClient failed.")
 		return nil, err
 	}
 
-	pipelinepermissionsClient, err := pipelinepermissions.NewClient(ctx, connection)
+	pipelinepermissionsClient, err := clientFactory.ClientByResourceAreaId(pipelinepermissions.ResourceAreaId)
 	if err != nil {
-		log.Printf("getAzdoClient(): pipelineschecks.NewClient failed.")
+		log.Printf("getAzdoClient(): pipelinepermissions.NewClient failed.")
 		return nil, err
 	}
 
-	pipelinesChecksClientExtras, err := pipelineschecksextras.NewClient(ctx, connection)
+	pipelinesChecksClientExtras, err := clientFactory.ClientByResourceAreaId(pipelineschecksextras.ResourceAreaId)
 	if err != nil {
 		log.Printf("getAzdoClient(): pipelineschecksextras.NewClient failed.")
 		return nil, err
 	}
 
-	serviceHooksClient := servicehooks.NewClient(ctx, connection)
+	serviceHooksClient := clientFactory.ClientByUrl(connection.BaseUrl)
 
-	securityRolesClient := securityroles.NewClient(ctx, connection)
+	securityRolesClient := clientFactory.ClientByUrl(connection.BaseUrl)
+
+	// personal access tokens are managed by the same service as the graph
+	tokensUrl, err := clientFactory.UrlByResourceAreaId(graph.ResourceAreaId)
+	if err != nil {
+		log.Printf("getAzdoClient(): tokens.NewClient failed.")
+		return nil, err
+	}
+	tokensClient := clientFactory.ClientByUrl(tokensUrl)
+
+	locationClient := clientFactory.ClientByUrl(connection.BaseUrl)
+
+	organizationPolicyClient := clientFactory.ClientByUrl(connection.BaseUrl)
 
 	aggregatedClient := &AggregatedClient{
 		OrganizationURL:               organizationURL,
-		CoreClient:                    coreClient,
-		BuildClient:                   buildClient,
-		ElasticClient:                 elasticClient,
-		GitReposClient:                gitReposClient,
-		GraphClient:                   graphClient,
-		OperationsClient:              operationsClient,
-		PipelinesClient:               pipelines,
-		PipelinesChecksClient:         pipelinesChecksClient,
-		PipelinePermissionsClient:     pipelinepermissionsClient,
-		PipelinesChecksClientExtras:   pipelinesChecksClientExtras,
-		PolicyClient:                  policyClient,
-		ReleaseClient:                 releaseClient,
-		ServiceEndpointClient:         serviceEndpointClient,
-		TaskAgentClient:               taskagentClient,
-		MemberEntitleManagementClient: memberentitlementmanagementClient,
-		FeatureManagementClient:       featuremanagementClient,
-		FeedClient:                    feedClient,
-		SecurityClient:                securityClient,
-		IdentityClient:                identityClient,
-		WorkItemTrackingClient:        workitemtrackingClient,
-		ServiceHooksClient:            serviceHooksClient,
-		SecurityRolesClient:           securityRolesClient,
+		CoreClient:                    &core.ClientImpl{Client: *coreClient},
+		BuildClient:                   &build.ClientImpl{Client: *buildClient},
+		ElasticClient:                 &elastic.ClientImpl{Client: *elasticClient},
+		GitReposClient:                &git.ClientImpl{Client: *gitReposClient},
+		GraphClient:                   &graph.ClientImpl{Client: *graphClient},
+		OperationsClient:              &operations.ClientImpl{Client: *operationsClient},
+		PipelinesClient:               &pipelines.ClientImpl{Client: *pipelinesClient},
+		PipelinesChecksClient:         &pipelineschecks.ClientImpl{Client: *pipelinesChecksClient},
+		PipelinePermissionsClient:     &pipelinepermissions.ClientImpl{Client: *pipelinepermissionsClient},
+		PipelinesChecksClientExtras:   &pipelineschecksextras.ClientImpl{Client: *pipelinesChecksClientExtras},
+		PolicyClient:                  &policy.ClientImpl{Client: *policyClient},
+		ReleaseClient:                 &release.ClientImpl{Client: *releaseClient},
+		ServiceEndpointClient:         &serviceendpoint.ClientImpl{Client: *serviceEndpointClient},
+		TaskAgentClient:               &taskagent.ClientImpl{Client: *taskagentClient},
+		TaskAgentClientExtras:         &taskagentextras.ClientImpl{Client: *taskagentClient},
+		MemberEntitleManagementClient: &memberentitlementmanagement.ClientImpl{Client: *memberentitlementmanagementClient},
+		FeatureManagementClient:       &featuremanagement.ClientImpl{Client: *featuremanagementClient},
+		FeedClient:                    &feed.ClientImpl{Client: *feedClient},
+		SecurityClient:                &security.ClientImpl{Client: *securityClient},
+		IdentityClient:                &identity.ClientImpl{Client: *identityClient},
+		LocationClient:                &location.ClientImpl{Client: *locationClient},
+		WorkItemTrackingClient:        &workitemtracking.ClientImpl{Client: *workitemtrackingClient},
+		WorkItemTrackingProcessClient: &workitemtrackingprocess.ClientImpl{Client: *workitemtrackingClient},
+		ServiceHooksClient:            &servicehooks.ClientImpl{Client: *serviceHooksClient},
+		SecurityRolesClient:           &securityroles.ClientImpl{Client: *securityRolesClient},
+		TokensClient:                  &tokens.ClientImpl{Client: *tokensClient, BaseUrl: tokensUrl},
+		OrganizationPolicyClient:      &organizationpolicy.ClientImpl{Client: *organizationPolicyClient, BaseUrl: connection.BaseUrl},
 		Ctx:                           ctx,
+		cache:                         newIdentityCache(),
+		aclBatcher:                    newACLBatcher(),
+	}
+
+	if options.ValidateProjectReferences {
+		aggregatedClient.projects = newProjectValidation()
+	}
+
+	if options.ReadAfterWriteTimeout > 0 {
+		aggregatedClient.WithReadAfterWrite(options.ReadAfterWriteTimeout, options.ReadAfterWriteDelay)
+	}
+
+	if options.ValidateTokenScopes {
+		aggregatedClient.tokenScopes, err = newTokenScopeValidation(ctx, aggregatedClient.LocationClient, organizationURL, azdoTokenProvider, options.PersonalAccessTokenScopes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(options.OrganizationAliases) > 0 {
+		// the clients of the other organizations authenticate the same way, they are only
+		// created once a resource references them to avoid needless resource area lookups
+		organizationOptions := *options
+		organizationOptions.OrganizationAliases = nil
+		aggregatedClient.organizations = newOrganizationClients(options.OrganizationAliases, func(url string) (*AggregatedClient, error) {
+			return GetAzdoClient(azdoTokenProvider, url, tfVersion, &organizationOptions)
+		})
 	}
 
 	log.Printf("getAzdoClient(): Created core, build, operations, and serviceendpoint clients successfully!")
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+)
+
+// maxReadAfterWriteDelay is the longest delay between two reads of a resource which was just written
+const maxReadAfterWriteDelay = 30 * time.Second
+
+// ErrResourceNotFound is returned by the reads passed to ReadAfterWrite when Azure DevOps answers a read of a
+// resource which does not exist yet without an error, e.g. with an empty response
+var ErrResourceNotFound = errors.New(" resource not found")
+
+// readAfterWrite configures the retries of reads of resources which were just written. Many services of Azure
+// DevOps are eventually consistent, so a resource may not be found right after it was created.
+type readAfterWrite struct {
+	timeout time.Duration
+	delay   time.Duration
+}
+
+// ReadAfterWrite calls read until it no longer fails because the resource is not found or because of a transient
+// error, see utils.IsRetryableError. The delay between the attempts starts at the read after write delay and
+// doubles after every attempt, until the read after write timeout is reached. read is only called once when read
+// after write retries are disabled.
+func (c *AggregatedClient) ReadAfterWrite(read func() error) error {
+	return c.retryRead(read, func(err error) bool {
+		return isResourceNotFound(err) || utils.IsRetryableError(err)
+	})
+}
+
+// ReadResource calls read with the retries of ReadAfterWrite while the resource of d is being created, so that
+// the read which completes the creation does not remove the new resource from the state. Reads of existing
+// resources are only retried after transient errors, as a resource which is not found has been deleted outside
+// of Terraform.
+func (c *AggregatedClient) ReadResource(d *schema.ResourceData, read func() error) error {
+	if !d.IsNewResource() {
+		return c.retryRead(read, utils.IsRetryableError)
+	}
+	return c.ReadAfterWrite(read)
+}
+
+// retryRead calls read until it no longer fails with an error which is retryable, with the delays and the
+// timeout of the read after write retries
+func (c *AggregatedClient) retryRead(read func() error, retryable func(error) bool) error {
+	if c.readAfterWrite == nil {
+		return read()
+	}
+
+	deadline := time.Now().Add(c.readAfterWrite.timeout)
+	delay := c.readAfterWrite.delay
+	for {
+		err := read()
+		if err == nil || !retryable(err) || time.Now().Add(delay).After(deadline) {
+			return err
+		}
+
+		log.Printf("[DEBUG] Reading the resource failed, reading it again in %s: %v", delay, err)
+		select {
+		case <-c.Ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxReadAfterWriteDelay {
+			delay = maxReadAfterWriteDelay
+		}
+	}
+}
+
+// WithReadAfterWrite enables read after write retries, mostly for unit testing purposes
+func (c *AggregatedClient) WithReadAfterWrite(timeout time.Duration, delay time.Duration) *AggregatedClient {
+	c.readAfterWrite = &readAfterWrite{timeout: timeout, delay: delay}
+	return c
+}
+
+func isResourceNotFound(err error) bool {
+	return err != nil && (utils.ResponseWasNotFound(err) || errors.Is(err, ErrResourceNotFound))
+}
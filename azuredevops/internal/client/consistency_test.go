@@ -0,0 +1,125 @@
+//go:build (all || client) && !exclude_client
+// +build all client
+// +build !exclude_client
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/stretchr/testify/require"
+)
+
+func notFoundError() error {
+	return statusError(http.StatusNotFound)
+}
+
+func statusError(statusCode int) error {
+	message := http.StatusText(statusCode)
+	return azuredevops.WrappedError{StatusCode: &statusCode, Message: &message}
+}
+
+// readAttempts returns a read which fails with the errors in order, and succeeds once they are used up
+func readAttempts(calls *int, errs ...error) func() error {
+	return func() error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+		return nil
+	}
+}
+
+func TestReadAfterWrite_RetriesUntilFound(t *testing.T) {
+	clients := (&AggregatedClient{Ctx: context.Background()}).WithReadAfterWrite(time.Second, time.Millisecond)
+
+	calls := 0
+	err := clients.ReadAfterWrite(readAttempts(&calls, notFoundError(), ErrResourceNotFound))
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+}
+
+func TestReadAfterWrite_GivesUpAfterTimeout(t *testing.T) {
+	clients := (&AggregatedClient{Ctx: context.Background()}).WithReadAfterWrite(50*time.Millisecond, 10*time.Millisecond)
+
+	calls := 0
+	err := clients.ReadAfterWrite(func() error {
+		calls++
+		return notFoundError()
+	})
+	require.Error(t, err)
+	require.GreaterOrEqual(t, calls, 2)
+	require.LessOrEqual(t, calls, 4)
+}
+
+func TestReadAfterWrite_DoesNotRetryOtherErrors(t *testing.T) {
+	clients := (&AggregatedClient{Ctx: context.Background()}).WithReadAfterWrite(time.Second, time.Millisecond)
+
+	calls := 0
+	err := clients.ReadAfterWrite(readAttempts(&calls, errors.New("forbidden")))
+	require.EqualError(t, err, "forbidden")
+	require.Equal(t, 1, calls)
+}
+
+func TestReadAfterWrite_DoesNotRetryWhenDisabled(t *testing.T) {
+	clients := &AggregatedClient{Ctx: context.Background()}
+
+	calls := 0
+	err := clients.ReadAfterWrite(readAttempts(&calls, notFoundError()))
+	require.Error(t, err)
+	require.Equal(t, 1, calls)
+}
+
+func TestReadAfterWrite_StopsWhenContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	clients := (&AggregatedClient{Ctx: ctx}).WithReadAfterWrite(time.Minute, time.Second)
+
+	calls := 0
+	err := clients.ReadAfterWrite(readAttempts(&calls, notFoundError(), notFoundError()))
+	require.Error(t, err)
+	require.Equal(t, 1, calls)
+}
+
+func TestReadAfterWrite_RetriesTransientErrors(t *testing.T) {
+	clients := (&AggregatedClient{Ctx: context.Background()}).WithReadAfterWrite(time.Second, time.Millisecond)
+
+	calls := 0
+	err := clients.ReadAfterWrite(readAttempts(&calls, statusError(http.StatusBadGateway), notFoundError()))
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+}
+
+func TestReadResource_RetriesTransientErrorsOfExistingResources(t *testing.T) {
+	clients := (&AggregatedClient{Ctx: context.Background()}).WithReadAfterWrite(time.Second, time.Millisecond)
+	d := (&schema.Resource{Schema: map[string]*schema.Schema{}}).TestResourceData()
+
+	calls := 0
+	require.NoError(t, clients.ReadResource(d, readAttempts(&calls, statusError(http.StatusServiceUnavailable))))
+	require.Equal(t, 2, calls)
+
+	calls = 0
+	require.Error(t, clients.ReadResource(d, readAttempts(&calls, statusError(http.StatusForbidden))))
+	require.Equal(t, 1, calls)
+}
+
+func TestReadResource_RetriesOnlyNewResources(t *testing.T) {
+	clients := (&AggregatedClient{Ctx: context.Background()}).WithReadAfterWrite(time.Second, time.Millisecond)
+	r := &schema.Resource{Schema: map[string]*schema.Schema{}}
+
+	d := r.TestResourceData()
+	calls := 0
+	require.Error(t, clients.ReadResource(d, readAttempts(&calls, notFoundError())))
+	require.Equal(t, 1, calls)
+
+	d.MarkNewResource()
+	calls = 0
+	require.NoError(t, clients.ReadResource(d, readAttempts(&calls, notFoundError())))
+	require.Equal(t, 2, calls)
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/graph"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/identity"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/security"
+)
+
+// identityCache memoizes lookups whose results never change for a given key, for the lifetime of the
+// provider, i.e. a single plan or apply. Configurations with many permission or membership resources
+// otherwise resolve the same groups and users over and over again.
+//
+// Only successful lookups are cached. Concurrent lookups of the same key may both reach the service.
+type identityCache struct {
+	lock        sync.Mutex
+	descriptors map[uuid.UUID]string
+	storageKeys map[string]uuid.UUID
+	identities  map[string]identity.Identity
+	namespaces  map[uuid.UUID][]security.SecurityNamespaceDescription
+}
+
+func newIdentityCache() *identityCache {
+	return &identityCache{
+		descriptors: map[uuid.UUID]string{},
+		storageKeys: map[string]uuid.UUID{},
+		identities:  map[string]identity.Identity{},
+		namespaces:  map[uuid.UUID][]security.SecurityNamespaceDescription{},
+	}
+}
+
+// LookupDescriptor returns the subject descriptor of a graph subject or scope given its storage key
+func (c *AggregatedClient) LookupDescriptor(storageKey uuid.UUID) (string, error) {
+	if c.cache != nil {
+		c.cache.lock.Lock()
+		descriptor, ok := c.cache.descriptors[storageKey]
+		c.cache.lock.Unlock()
+		if ok {
+			return descriptor, nil
+		}
+	}
+
+	result, err := c.GraphClient.GetDescriptor(c.Ctx, graph.GetDescriptorArgs{StorageKey: &storageKey})
+	if err != nil {
+		return "", err
+	}
+
+	descriptor := ""
+	if result != nil && result.Value != nil {
+		descriptor = *result.Value
+	}
+	if c.cache != nil && descriptor != "" {
+		c.cache.lock.Lock()
+		c.cache.descriptors[storageKey] = descriptor
+		c.cache.storageKeys[descriptor] = storageKey
+		c.cache.lock.Unlock()
+	}
+	return descriptor, nil
+}
+
+// LookupStorageKey returns the storage key of a graph subject given its subject descriptor
+func (c *AggregatedClient) LookupStorageKey(descriptor string) (uuid.UUID, error) {
+	if c.cache != nil {
+		c.cache.lock.Lock()
+		storageKey, ok := c.cache.storageKeys[descriptor]
+		c.cache.lock.Unlock()
+		if ok {
+			return storageKey, nil
+		}
+	}
+
+	result, err := c.GraphClient.GetStorageKey(c.Ctx, graph.GetStorageKeyArgs{SubjectDescriptor: &descriptor})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	storageKey := uuid.Nil
+	if result != nil && result.Value != nil {
+		storageKey = *result.Value
+	}
+	if c.cache != nil && storageKey != uuid.Nil {
+		c.cache.lock.Lock()
+		c.cache.storageKeys[descriptor] = storageKey
+		c.cache.descriptors[storageKey] = descriptor
+		c.cache.lock.Unlock()
+	}
+	return storageKey, nil
+}
+
+// LookupIdentitiesBySubjectDescriptors returns the identities of the subject descriptors. Only the
+// identities which are not cached yet are read, with a single request.
+func (c *AggregatedClient) LookupIdentitiesBySubjectDescriptors(descriptors []string) (*[]identity.Identity, error) {
+	if c.cache == nil {
+		return c.IdentityClient.ReadIdentities(c.Ctx, identity.ReadIdentitiesArgs{
+			SubjectDescriptors: joinDescriptors(descriptors),
+		})
+	}
+
+	var missing []string
+	c.cache.lock.Lock()
+	for _, descriptor := range descriptors {
+		if _, ok := c.cache.identities[descriptor]; !ok {
+			missing = append(missing, descriptor)
+		}
+	}
+	c.cache.lock.Unlock()
+
+	if len(missing) > 0 {
+		identities, err := c.IdentityClient.ReadIdentities(c.Ctx, identity.ReadIdentitiesArgs{
+			SubjectDescriptors: joinDescriptors(missing),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if identities != nil {
+			c.cache.lock.Lock()
+			for _, id := range *identities {
+				if id.SubjectDescriptor != nil {
+					c.cache.identities[*id.SubjectDescriptor] = id
+				}
+			}
+			c.cache.lock.Unlock()
+		}
+	}
+
+	// identities which could not be read are left out, like the service does
+	result := make([]identity.Identity, 0, len(descriptors))
+	c.cache.lock.Lock()
+	defer c.cache.lock.Unlock()
+	for _, descriptor := range descriptors {
+		if id, ok := c.cache.identities[descriptor]; ok {
+			result = append(result, id)
+		}
+	}
+	return &result, nil
+}
+
+// LookupSecurityNamespace returns the description of a security namespace, including its actions
+func (c *AggregatedClient) LookupSecurityNamespace(namespaceID uuid.UUID) (*[]security.SecurityNamespaceDescription, error) {
+	if c.cache != nil {
+		c.cache.lock.Lock()
+		namespaces, ok := c.cache.namespaces[namespaceID]
+		c.cache.lock.Unlock()
+		if ok {
+			return &namespaces, nil
+		}
+	}
+
+	namespaces, err := c.SecurityClient.QuerySecurityNamespaces(c.Ctx, security.QuerySecurityNamespacesArgs{
+		SecurityNamespaceId: &namespaceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if c.cache != nil && namespaces != nil && len(*namespaces) > 0 {
+		c.cache.lock.Lock()
+		c.cache.namespaces[namespaceID] = *namespaces
+		c.cache.lock.Unlock()
+	}
+	return namespaces, nil
+}
+
+func joinDescriptors(descriptors []string) *string {
+	joined := strings.Join(descriptors, ",")
+	return &joined
+}
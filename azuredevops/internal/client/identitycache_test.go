@@ -0,0 +1,125 @@
+//go:build (all || client) && !exclude_client
+// +build all client
+// +build !exclude_client
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/graph"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/identity"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupDescriptor_CachesDescriptorAndStorageKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &AggregatedClient{GraphClient: graphClient, Ctx: context.Background(), cache: newIdentityCache()}
+
+	storageKey := uuid.New()
+	descriptor := "scp.descriptor"
+	graphClient.EXPECT().
+		GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{StorageKey: &storageKey}).
+		Return(&graph.GraphDescriptorResult{Value: &descriptor}, nil).
+		Times(1)
+
+	for i := 0; i < 3; i++ {
+		result, err := clients.LookupDescriptor(storageKey)
+		require.NoError(t, err)
+		require.Equal(t, descriptor, result)
+	}
+
+	// the reverse lookup is answered from the cache as well
+	result, err := clients.LookupStorageKey(descriptor)
+	require.NoError(t, err)
+	require.Equal(t, storageKey, result)
+}
+
+func TestLookupStorageKey_DoesNotCacheErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &AggregatedClient{GraphClient: graphClient, Ctx: context.Background(), cache: newIdentityCache()}
+
+	descriptor := "aad.descriptor"
+	storageKey := uuid.New()
+	gomock.InOrder(
+		graphClient.EXPECT().
+			GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{SubjectDescriptor: &descriptor}).
+			Return(nil, errors.New("GetStorageKey() Failed")),
+		graphClient.EXPECT().
+			GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{SubjectDescriptor: &descriptor}).
+			Return(&graph.GraphStorageKeyResult{Value: &storageKey}, nil),
+	)
+
+	_, err := clients.LookupStorageKey(descriptor)
+	require.Error(t, err)
+
+	result, err := clients.LookupStorageKey(descriptor)
+	require.NoError(t, err)
+	require.Equal(t, storageKey, result)
+}
+
+func TestLookupIdentitiesBySubjectDescriptors_OnlyReadsMissingIdentities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	identityClient := azdosdkmocks.NewMockIdentityClient(ctrl)
+	clients := &AggregatedClient{IdentityClient: identityClient, Ctx: context.Background(), cache: newIdentityCache()}
+
+	newIdentity := func(descriptor string) identity.Identity {
+		id := uuid.New()
+		return identity.Identity{Id: &id, SubjectDescriptor: &descriptor}
+	}
+	first, second := newIdentity("vssgp.first"), newIdentity("vssgp.second")
+
+	gomock.InOrder(
+		identityClient.EXPECT().
+			ReadIdentities(clients.Ctx, identity.ReadIdentitiesArgs{SubjectDescriptors: joinDescriptors([]string{"vssgp.first"})}).
+			Return(&[]identity.Identity{first}, nil),
+		identityClient.EXPECT().
+			ReadIdentities(clients.Ctx, identity.ReadIdentitiesArgs{SubjectDescriptors: joinDescriptors([]string{"vssgp.second"})}).
+			Return(&[]identity.Identity{second}, nil),
+	)
+
+	identities, err := clients.LookupIdentitiesBySubjectDescriptors([]string{"vssgp.first"})
+	require.NoError(t, err)
+	require.Equal(t, []identity.Identity{first}, *identities)
+
+	identities, err = clients.LookupIdentitiesBySubjectDescriptors([]string{"vssgp.second", "vssgp.first"})
+	require.NoError(t, err)
+	require.Equal(t, []identity.Identity{second, first}, *identities)
+
+	identities, err = clients.LookupIdentitiesBySubjectDescriptors([]string{"vssgp.first", "vssgp.second"})
+	require.NoError(t, err)
+	require.Equal(t, []identity.Identity{first, second}, *identities)
+}
+
+func TestLookupDescriptor_WithoutCacheAlwaysReads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	storageKey := uuid.New()
+	descriptor := "scp.descriptor"
+	graphClient.EXPECT().
+		GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{StorageKey: &storageKey}).
+		Return(&graph.GraphDescriptorResult{Value: &descriptor}, nil).
+		Times(2)
+
+	for i := 0; i < 2; i++ {
+		_, err := clients.LookupDescriptor(storageKey)
+		require.NoError(t, err)
+	}
+}
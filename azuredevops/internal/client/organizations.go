@@ -0,0 +1,80 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// organizationClients lazily creates and caches the clients of the organizations configured through aliases
+type organizationClients struct {
+	mu      sync.Mutex
+	urls    map[string]string
+	clients map[string]*AggregatedClient
+	create  func(organizationURL string) (*AggregatedClient, error)
+}
+
+func newOrganizationClients(urls map[string]string, create func(organizationURL string) (*AggregatedClient, error)) *organizationClients {
+	return &organizationClients{
+		urls:    urls,
+		clients: map[string]*AggregatedClient{},
+		create:  create,
+	}
+}
+
+func (o *organizationClients) get(alias string) (*AggregatedClient, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if clients, ok := o.clients[alias]; ok {
+		return clients, nil
+	}
+
+	url, ok := o.urls[alias]
+	if !ok {
+		return nil, unknownOrganizationError(alias, o.urls)
+	}
+
+	clients, err := o.create(url)
+	if err != nil {
+		return nil, fmt.Errorf(" creating the clients of organization %s (%s): %+v", alias, url, err)
+	}
+	o.clients[alias] = clients
+	return clients, nil
+}
+
+// ForOrganization returns the clients of the organization configured under the alias in the provider
+// configuration. An empty alias refers to the organization of the clients themselves.
+func (c *AggregatedClient) ForOrganization(alias string) (*AggregatedClient, error) {
+	if alias == "" {
+		return c, nil
+	}
+	if c.organizations == nil {
+		return nil, unknownOrganizationError(alias, nil)
+	}
+	return c.organizations.get(alias)
+}
+
+// WithOrganizationClients configures the clients returned by ForOrganization for every alias, mostly for
+// unit testing purposes
+func (c *AggregatedClient) WithOrganizationClients(clients map[string]*AggregatedClient) *AggregatedClient {
+	urls := make(map[string]string, len(clients))
+	for alias, aliasClients := range clients {
+		urls[alias] = aliasClients.OrganizationURL
+	}
+	c.organizations = newOrganizationClients(urls, nil)
+	for alias, aliasClients := range clients {
+		c.organizations.clients[alias] = aliasClients
+	}
+	return c
+}
+
+func unknownOrganizationError(alias string, urls map[string]string) error {
+	aliases := make([]string, 0, len(urls))
+	for a := range urls {
+		aliases = append(aliases, a)
+	}
+	sort.Strings(aliases)
+	return fmt.Errorf(" organization alias %q is not configured in `organization_aliases` of the provider, configured aliases: [%s]", alias, strings.Join(aliases, ", "))
+}
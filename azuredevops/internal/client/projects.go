@@ -0,0 +1,54 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+)
+
+// projectValidation remembers the projects which are known to exist, so that every project referenced by
+// the resources of a plan is only looked up once
+type projectValidation struct {
+	lock     sync.Mutex
+	existing map[string]bool
+}
+
+func newProjectValidation() *projectValidation {
+	return &projectValidation{existing: map[string]bool{}}
+}
+
+// ValidateProjectReference returns an error when the project referenced by a resource does not exist or cannot
+// be accessed. Nothing is checked unless project reference validation is enabled.
+func (c *AggregatedClient) ValidateProjectReference(projectNameOrID string) error {
+	if c.projects == nil || projectNameOrID == "" {
+		return nil
+	}
+
+	c.projects.lock.Lock()
+	exists := c.projects.existing[projectNameOrID]
+	c.projects.lock.Unlock()
+	if exists {
+		return nil
+	}
+
+	_, err := c.CoreClient.GetProject(c.Ctx, core.GetProjectArgs{ProjectId: &projectNameOrID})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			return fmt.Errorf(" project %s does not exist or the credentials of the provider cannot access it", projectNameOrID)
+		}
+		return fmt.Errorf(" validating project %s: %+v", projectNameOrID, err)
+	}
+
+	c.projects.lock.Lock()
+	c.projects.existing[projectNameOrID] = true
+	c.projects.lock.Unlock()
+	return nil
+}
+
+// WithProjectValidation enables project reference validation, mostly for unit testing purposes
+func (c *AggregatedClient) WithProjectValidation() *AggregatedClient {
+	c.projects = newProjectValidation()
+	return c
+}
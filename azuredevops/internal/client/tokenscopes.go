@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/location"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/sdk"
+)
+
+// tokenScopeValidation holds the scopes granted to the token of the provider, once the token has been
+// verified against the organization
+type tokenScopeValidation struct {
+	// scopes are nil when they are not known, like the scopes of PATs which were not declared
+	scopes []string
+}
+
+// newTokenScopeValidation verifies the organization accepts the token through the connection data API, which
+// does not require any scope, and reads the scopes granted to the token. Azure DevOps does not expose the
+// scopes of PATs, neither in the token nor through an API accepting PATs, the declared scopes are used instead.
+func newTokenScopeValidation(ctx context.Context, locationClient location.Client, organizationURL string, azdoTokenProvider func() (string, error), patScopes []string) (*tokenScopeValidation, error) {
+	if _, err := locationClient.GetConnectionData(ctx, location.GetConnectionDataArgs{}); err != nil {
+		if utils.ResponseWasStatusCode(err, http.StatusUnauthorized) {
+			return nil, fmt.Errorf(" the credentials of the provider are not authorized to access %s, the token may be expired or revoked: %+v", organizationURL, err)
+		}
+		return nil, fmt.Errorf(" validating the credentials of the provider against %s: %+v", organizationURL, err)
+	}
+
+	authorization, err := azdoTokenProvider()
+	if err != nil {
+		return nil, err
+	}
+	if scopes := sdk.TokenScopes(authorization); scopes != nil {
+		return &tokenScopeValidation{scopes: scopes}, nil
+	}
+	if strings.HasPrefix(authorization, "Basic ") {
+		if patScopes == nil {
+			log.Printf("[WARN] The scopes of the personal access token are not known, set personal_access_token_scopes to check them when the resources are planned")
+		}
+		return &tokenScopeValidation{scopes: patScopes}, nil
+	}
+	return &tokenScopeValidation{}, nil
+}
+
+// CheckTokenScope returns an error when the token of the provider is known to lack the scope required by a
+// resource. Nothing is checked unless token scope validation is enabled and the scopes of the token are known.
+func (c *AggregatedClient) CheckTokenScope(scope string, resourceType string) error {
+	if c.tokenScopes == nil || c.tokenScopes.scopes == nil || scope == "" {
+		return nil
+	}
+	if !sdk.HasTokenScope(c.tokenScopes.scopes, scope) {
+		return fmt.Errorf(" the token of the provider is missing the %s scope required by %s", scope, resourceType)
+	}
+	return nil
+}
+
+// TokenScopeError explains authorization errors of a resource with the scope it requires, the token may lack
+// the scope when its scopes cannot be checked up front. Other errors are returned as is.
+func (c *AggregatedClient) TokenScopeError(err error, scope string, resourceType string) error {
+	if err == nil || c.tokenScopes == nil || scope == "" {
+		return err
+	}
+	if isAuthorizationError(err) {
+		return fmt.Errorf(" %s was denied access, make sure the token of the provider has the %s scope: %+v", resourceType, scope, err)
+	}
+	return err
+}
+
+// isAuthorizationError reports whether the service denied a request, resources usually wrap the errors of the
+// SDK in their own errors which only keep the message
+func isAuthorizationError(err error) bool {
+	if utils.ResponseWasStatusCode(err, http.StatusUnauthorized) || utils.ResponseWasStatusCode(err, http.StatusForbidden) {
+		return true
+	}
+	message := err.Error()
+	for _, marker := range []string{"status code 401", "status code 403", "TF400813"} {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithTokenScopes enables token scope validation with the scopes granted to the token, mostly for unit
+// testing purposes. nil scopes are unknown scopes, like the scopes of PATs.
+func (c *AggregatedClient) WithTokenScopes(scopes []string) *AggregatedClient {
+	c.tokenScopes = &tokenScopeValidation{scopes: scopes}
+	return c
+}
@@ -0,0 +1,56 @@
+//go:build (all || client) && !exclude_client
+// +build all client
+// +build !exclude_client
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/location"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/stretchr/testify/require"
+)
+
+func patAuthorization() (string, error) {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(":pat")), nil
+}
+
+func TestTokenScopeValidation_ChecksDeclaredScopesOfPATs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	locationClient := azdosdkmocks.NewMockLocationClient(ctrl)
+	locationClient.EXPECT().
+		GetConnectionData(gomock.Any(), location.GetConnectionDataArgs{}).
+		Return(&location.ConnectionData{}, nil).
+		Times(1)
+
+	validation, err := newTokenScopeValidation(context.Background(), locationClient, "https://dev.azure.com/org", patAuthorization, []string{"vso.code_write"})
+	require.NoError(t, err)
+
+	clients := &AggregatedClient{tokenScopes: validation}
+	require.NoError(t, clients.CheckTokenScope("vso.code", "azuredevops_git_repositories"))
+	require.EqualError(t, clients.CheckTokenScope("vso.packaging_manage", "azuredevops_feed"),
+		" the token of the provider is missing the vso.packaging_manage scope required by azuredevops_feed")
+}
+
+func TestTokenScopeValidation_SkipsUndeclaredScopesOfPATs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	locationClient := azdosdkmocks.NewMockLocationClient(ctrl)
+	locationClient.EXPECT().
+		GetConnectionData(gomock.Any(), gomock.Any()).
+		Return(&location.ConnectionData{}, nil).
+		Times(1)
+
+	validation, err := newTokenScopeValidation(context.Background(), locationClient, "https://dev.azure.com/org", patAuthorization, nil)
+	require.NoError(t, err)
+
+	clients := &AggregatedClient{tokenScopes: validation}
+	require.NoError(t, clients.CheckTokenScope("vso.packaging_manage", "azuredevops_feed"))
+}
@@ -1,11 +1,13 @@
 package approvalsandchecks
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
@@ -19,6 +21,10 @@ import (
 // so it doesn't seem to work and the website UI doesn't have it available
 var targetResourceTypes = []string{"endpoint", "environment", "queue", "repository", "securefile", "variablegroup"}
 
+// repositoryTargetType is the type of protected repositories, their resource IDs are qualified with the ID of
+// the project, i.e. <project ID>.<repository ID>
+const repositoryTargetType = "repository"
+
 type flatFunc func(d *schema.ResourceData, check *pipelineschecksextras.CheckConfiguration, projectID string) error
 type expandFunc func(d *schema.ResourceData) (*pipelineschecksextras.CheckConfiguration, string, error)
 
@@ -57,10 +63,12 @@ var approvalAndCheckType = approvalAndCheckTypes{
 // that all checks require.
 func genBaseCheckResource(f flatFunc, e expandFunc) *schema.Resource {
 	return &schema.Resource{
-		Create: genCheckCreateFunc(f, e),
-		Read:   genCheckReadFunc(f),
-		Update: genCheckUpdateFunc(f, e),
-		Delete: genCheckDeleteFunc(),
+		Create:        genCheckCreateFunc(f, e),
+		Read:          genCheckReadFunc(f),
+		Update:        genCheckUpdateFunc(f, e),
+		Delete:        genCheckDeleteFunc(),
+		Importer:      tfhelper.ImportProjectQualifiedResourceInteger(),
+		CustomizeDiff: validateCheckTarget,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(2 * time.Minute),
 			Read:   schema.DefaultTimeout(1 * time.Minute),
@@ -102,7 +110,7 @@ func doBaseExpansion(d *schema.ResourceData, checkType *pipelineschecksextras.Ch
 		Type:     checkType,
 		Settings: settings,
 		Resource: &pipelineschecksextras.Resource{
-			Id:   converter.String(d.Get("target_resource_id").(string)),
+			Id:   converter.String(expandCheckTargetID(projectID, d.Get("target_resource_type").(string), d.Get("target_resource_id").(string))),
 			Type: converter.String(d.Get("target_resource_type").(string)),
 		},
 		Version: converter.Int(d.Get("version").(int)),
@@ -133,13 +141,66 @@ func doBaseFlattening(d *schema.ResourceData, check *pipelineschecksextras.Check
 		return fmt.Errorf("Resource nil")
 	}
 
-	d.Set("target_resource_id", check.Resource.Id)
+	d.Set("target_resource_id", flattenCheckTargetID(d, projectID, check.Resource))
 	d.Set("target_resource_type", check.Resource.Type)
 	d.Set("version", check.Version)
 
 	return nil
 }
 
+// expandCheckTargetID qualifies the IDs of protected repositories with the ID of the project, the IDs of the
+// other resource types are used as is
+func expandCheckTargetID(projectID string, targetType string, targetID string) string {
+	if strings.EqualFold(targetType, repositoryTargetType) && !strings.Contains(targetID, ".") {
+		return projectID + "." + targetID
+	}
+	return targetID
+}
+
+// flattenCheckTargetID returns the ID of the protected resource in the form it is configured in, protected
+// repositories can be configured with or without the ID of the project
+func flattenCheckTargetID(d *schema.ResourceData, projectID string, resource *pipelineschecksextras.Resource) string {
+	targetID := converter.ToString(resource.Id, "")
+	if !strings.EqualFold(converter.ToString(resource.Type, ""), repositoryTargetType) {
+		return targetID
+	}
+	if configured := d.Get("target_resource_id").(string); configured != "" && !strings.Contains(configured, ".") {
+		return strings.TrimPrefix(targetID, projectID+".")
+	}
+	return targetID
+}
+
+// validateCheckTarget verifies the ID of the protected resource has the form its type requires, once the ID
+// is known. Agent queues, environments and variable groups have numeric IDs, service connections and secure
+// files have UUIDs and repositories have a UUID which may be qualified with the ID of the project.
+func validateCheckTarget(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("target_resource_id") || !d.NewValueKnown("target_resource_type") {
+		return nil
+	}
+	targetID := d.Get("target_resource_id").(string)
+	targetType := d.Get("target_resource_type").(string)
+
+	switch targetType {
+	case "queue", "environment", "variablegroup":
+		if _, err := strconv.Atoi(targetID); err != nil {
+			return fmt.Errorf(" target_resource_id must be the numeric ID of the %s, got %q", targetType, targetID)
+		}
+	case "endpoint", "securefile":
+		if _, err := uuid.Parse(targetID); err != nil {
+			return fmt.Errorf(" target_resource_id must be the UUID of the %s, got %q", targetType, targetID)
+		}
+	case repositoryTargetType:
+		repositoryID := targetID
+		if i := strings.LastIndex(targetID, "."); i >= 0 {
+			repositoryID = targetID[i+1:]
+		}
+		if _, err := uuid.Parse(repositoryID); err != nil {
+			return fmt.Errorf(" target_resource_id must be the UUID of the repository, optionally qualified with the ID of the project as <project ID>.<repository ID>, got %q", targetID)
+		}
+	}
+	return nil
+}
+
 func genCheckCreateFunc(flatFunc flatFunc, expandFunc expandFunc) func(d *schema.ResourceData, m interface{}) error {
 	return func(d *schema.ResourceData, m interface{}) error {
 		clients := m.(*client.AggregatedClient)
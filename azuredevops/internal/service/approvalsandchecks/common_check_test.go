@@ -0,0 +1,78 @@
+//go:build (all || common_check) && !exclude_approvalsandchecks
+// +build all common_check
+// +build !exclude_approvalsandchecks
+
+package approvalsandchecks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/pipelineschecksextras"
+	"github.com/stretchr/testify/require"
+)
+
+func planCheckTarget(targetType string, targetID string) error {
+	_, err := ResourceCheckExclusiveLock().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":           uuid.New().String(),
+		"target_resource_type": targetType,
+		"target_resource_id":   targetID,
+	}), nil)
+	return err
+}
+
+func TestCheck_ValidateTarget_AcceptsIDsOfAllTargetTypes(t *testing.T) {
+	projectID := uuid.New().String()
+	repositoryID := uuid.New().String()
+	for targetType, targetID := range map[string]string{
+		"endpoint":      uuid.New().String(),
+		"securefile":    uuid.New().String(),
+		"environment":   "12",
+		"queue":         "13",
+		"variablegroup": "14",
+		"repository":    repositoryID,
+	} {
+		require.NoError(t, planCheckTarget(targetType, targetID), targetType)
+	}
+	require.NoError(t, planCheckTarget("repository", projectID+"."+repositoryID))
+}
+
+func TestCheck_ValidateTarget_RejectsIDsOfOtherTypes(t *testing.T) {
+	err := planCheckTarget("queue", uuid.New().String())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "numeric ID of the queue")
+
+	err = planCheckTarget("securefile", "15")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "UUID of the securefile")
+
+	err = planCheckTarget("repository", "my-repository")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "UUID of the repository")
+}
+
+func TestCheck_TargetID_QualifiesRepositoriesWithProject(t *testing.T) {
+	projectID := uuid.New().String()
+	repositoryID := uuid.New().String()
+
+	require.Equal(t, projectID+"."+repositoryID, expandCheckTargetID(projectID, "repository", repositoryID))
+	require.Equal(t, projectID+"."+repositoryID, expandCheckTargetID(projectID, "repository", projectID+"."+repositoryID))
+	require.Equal(t, "12", expandCheckTargetID(projectID, "variablegroup", "12"))
+
+	resource := &pipelineschecksextras.Resource{
+		Id:   converter.String(projectID + "." + repositoryID),
+		Type: converter.String("repository"),
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceCheckExclusiveLock().Schema, map[string]interface{}{
+		"target_resource_id": repositoryID,
+	})
+	require.Equal(t, repositoryID, flattenCheckTargetID(resourceData, projectID, resource))
+
+	resourceData = schema.TestResourceDataRaw(t, ResourceCheckExclusiveLock().Schema, nil)
+	require.Equal(t, projectID+"."+repositoryID, flattenCheckTargetID(resourceData, projectID, resource))
+}
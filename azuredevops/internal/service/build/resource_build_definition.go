@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/pipelinepermissions"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/pipelines"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/model"
@@ -28,6 +29,11 @@ const (
 	bdSecretVariableValue   = "secret_value"
 	bdVariableIsSecret      = "is_secret"
 	bdVariableAllowOverride = "allow_override"
+	bdSecretVariable        = "secret_variable"
+	bdVariableValueWO       = "value_wo"
+	bdVariableValueWOVer    = "value_wo_version"
+	bdPipelineResource      = "pipeline_resource"
+	bdContainerResource     = "container_resource"
 )
 
 // ResourceBuildDefinition schema and implementation for build definition resource
@@ -138,6 +144,36 @@ func ResourceBuildDefinition() *schema.Resource {
 					},
 				},
 			},
+			bdSecretVariable: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						bdVariableName: {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						bdVariableValueWO: {
+							Type:        schema.TypeString,
+							Required:    true,
+							WriteOnly:   true,
+							Sensitive:   true,
+							Description: "The value of the secret variable, which is never persisted in the plan or the state.",
+						},
+						bdVariableValueWOVer: {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "The version of `value_wo`, changing it sends the current value of `value_wo` to Azure DevOps.",
+						},
+						bdVariableAllowOverride: {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
 			"agent_pool_name": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -344,6 +380,54 @@ func ResourceBuildDefinition() *schema.Resource {
 					},
 				},
 			},
+			bdPipelineResource: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"alias": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"source_pipeline_id": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"source_project_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+						"branch_filter": branchFilter,
+						"requires_successful_build": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
+			bdContainerResource: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"alias": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"service_connection_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+					},
+				},
+			},
 			"features": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -375,7 +459,7 @@ func resourceBuildDefinitionCreate(ctx context.Context, d *schema.ResourceData,
 	clients := m.(*client.AggregatedClient)
 	err := validateServiceConnectionIDExistsIfNeeded(d)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.ErrorDiagnostics(err)
 	}
 	buildDefinition, projectID, err := expandBuildDefinition(d)
 	if err != nil {
@@ -424,6 +508,10 @@ func resourceBuildDefinitionCreate(ctx context.Context, d *schema.ResourceData,
 	}
 	d.SetId(strconv.Itoa(*createdBuildDefinition.Id))
 
+	if err := authorizeContainerResources(clients, d, projectID, *createdBuildDefinition.Id); err != nil {
+		return utils.ErrorDiagnostics(err)
+	}
+
 	readDiag := resourceBuildDefinitionRead(ctx, d, m)
 
 	if readDiag != nil {
@@ -438,12 +526,16 @@ func resourceBuildDefinitionRead(ctx context.Context, d *schema.ResourceData, m
 	projectID, buildDefinitionID, err := tfhelper.ParseProjectIDAndResourceID(d)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.ErrorDiagnostics(err)
 	}
 
-	buildDefinition, err := clients.BuildClient.GetDefinition(clients.Ctx, build.GetDefinitionArgs{
-		Project:      &projectID,
-		DefinitionId: &buildDefinitionID,
+	var buildDefinition *build.BuildDefinition
+	err = clients.ReadResource(d, func() (err error) {
+		buildDefinition, err = clients.BuildClient.GetDefinition(clients.Ctx, build.GetDefinitionArgs{
+			Project:      &projectID,
+			DefinitionId: &buildDefinitionID,
+		})
+		return err
 	})
 
 	if err != nil {
@@ -451,7 +543,7 @@ func resourceBuildDefinitionRead(ctx context.Context, d *schema.ResourceData, m
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return utils.ErrorDiagnostics(err)
 	}
 
 	flattenBuildDefinition(d, buildDefinition, projectID)
@@ -462,11 +554,11 @@ func resourceBuildDefinitionUpdate(ctx context.Context, d *schema.ResourceData,
 	clients := m.(*client.AggregatedClient)
 	err := validateServiceConnectionIDExistsIfNeeded(d)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.ErrorDiagnostics(err)
 	}
 	buildDefinition, projectID, err := expandBuildDefinition(d)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.ErrorDiagnostics(err)
 	}
 
 	updatedBuildDefinition, err := clients.BuildClient.UpdateDefinition(m.(*client.AggregatedClient).Ctx, build.UpdateDefinitionArgs{
@@ -476,7 +568,13 @@ func resourceBuildDefinitionUpdate(ctx context.Context, d *schema.ResourceData,
 	})
 
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.ErrorDiagnostics(err)
+	}
+
+	if d.HasChange(bdContainerResource) {
+		if err := authorizeContainerResources(clients, d, projectID, *updatedBuildDefinition.Id); err != nil {
+			return utils.ErrorDiagnostics(err)
+		}
 	}
 
 	flattenBuildDefinition(d, updatedBuildDefinition, projectID)
@@ -491,14 +589,14 @@ func resourceBuildDefinitionDelete(ctx context.Context, d *schema.ResourceData,
 	clients := m.(*client.AggregatedClient)
 	projectID, buildDefinitionID, err := tfhelper.ParseProjectIDAndResourceID(d)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.ErrorDiagnostics(err)
 	}
 
 	err = clients.BuildClient.DeleteDefinition(m.(*client.AggregatedClient).Ctx, build.DeleteDefinitionArgs{
 		Project:      &projectID,
 		DefinitionId: &buildDefinitionID,
 	})
-	return diag.FromErr(err)
+	return utils.ErrorDiagnostics(err)
 }
 
 func flattenBuildDefinition(d *schema.ResourceData, buildDefinition *build.BuildDefinition, projectID string) {
@@ -515,12 +613,16 @@ func flattenBuildDefinition(d *schema.ResourceData, buildDefinition *build.Build
 
 	d.Set("variable_groups", flattenVariableGroups(buildDefinition))
 	d.Set(bdVariable, flattenBuildVariables(d, buildDefinition))
+	d.Set(bdSecretVariable, flattenBuildSecretVariables(d, buildDefinition))
 
 	if buildDefinition.Triggers != nil {
 		triggers := flattenTriggers(buildDefinition.Triggers)
 
 		if triggers[build.DefinitionTriggerTypeValues.ContinuousIntegration] != nil {
 			d.Set("ci_trigger", triggers[build.DefinitionTriggerTypeValues.ContinuousIntegration])
+		} else if len(d.Get("ci_trigger").([]interface{})) > 0 {
+			// the trigger was removed outside of Terraform, which disables continuous integration
+			d.Set("ci_trigger", []interface{}{map[string]interface{}{"use_yaml": false}})
 		}
 
 		if triggers[build.DefinitionTriggerTypeValues.PullRequest] != nil {
@@ -530,6 +632,8 @@ func flattenBuildDefinition(d *schema.ResourceData, buildDefinition *build.Build
 		if triggers[build.DefinitionTriggerTypeValues.Schedule] != nil {
 			d.Set("schedules", triggers[build.DefinitionTriggerTypeValues.Schedule])
 		}
+
+		d.Set(bdPipelineResource, flattenPipelineResources(d, triggers[build.DefinitionTriggerTypeValues.BuildCompletion], projectID))
 	}
 
 	revision := 0
@@ -539,7 +643,9 @@ func flattenBuildDefinition(d *schema.ResourceData, buildDefinition *build.Build
 
 	d.Set("revision", revision)
 
-	d.Set("queue_status", *buildDefinition.QueueStatus)
+	if buildDefinition.QueueStatus != nil {
+		d.Set("queue_status", *buildDefinition.QueueStatus)
+	}
 }
 
 func createBuildDefinition(clients *client.AggregatedClient, buildDefinition *build.BuildDefinition, project string) (*build.BuildDefinition, error) {
@@ -558,10 +664,18 @@ func flattenBuildVariables(d *schema.ResourceData, buildDefinition *build.BuildD
 	if buildDefinition.Variables == nil {
 		return nil
 	}
-	variables := make([]map[string]interface{}, len(*buildDefinition.Variables))
+	secretVariableNames := map[string]bool{}
+	for _, secretVariable := range d.Get(bdSecretVariable).([]interface{}) {
+		secretVariableNames[secretVariable.(map[string]interface{})[bdVariableName].(string)] = true
+	}
 
-	index := 0
+	variables := make([]map[string]interface{}, 0, len(*buildDefinition.Variables))
 	for varName, varVal := range *buildDefinition.Variables {
+		// secret variables with write-only values are tracked in the secret_variable block
+		if secretVariableNames[varName] {
+			continue
+		}
+
 		var variable map[string]interface{}
 
 		isSecret := converter.ToBool(varVal.IsSecret, false)
@@ -578,13 +692,34 @@ func flattenBuildVariables(d *schema.ResourceData, buildDefinition *build.BuildD
 				variable = stateVal
 			}
 		}
-		variables[index] = variable
-		index = index + 1
+		variables = append(variables, variable)
 	}
 
 	return variables
 }
 
+// Secret variables with write-only values are kept from the state as long as they exist in the definition,
+// the values are never returned by the service nor stored in the state.
+func flattenBuildSecretVariables(d *schema.ResourceData, buildDefinition *build.BuildDefinition) interface{} {
+	if buildDefinition.Variables == nil {
+		return nil
+	}
+
+	secretVariables := []interface{}{}
+	for _, secretVariable := range d.Get(bdSecretVariable).([]interface{}) {
+		secretVariableMap := secretVariable.(map[string]interface{})
+		varName := secretVariableMap[bdVariableName].(string)
+		if varVal, ok := (*buildDefinition.Variables)[varName]; ok {
+			secretVariables = append(secretVariables, map[string]interface{}{
+				bdVariableName:          varName,
+				bdVariableValueWOVer:    secretVariableMap[bdVariableValueWOVer],
+				bdVariableAllowOverride: converter.ToBool(varVal.AllowOverride, false),
+			})
+		}
+	}
+	return secretVariables
+}
+
 func flattenVariableGroups(buildDefinition *build.BuildDefinition) []int {
 	if buildDefinition.VariableGroups == nil {
 		return nil
@@ -651,7 +786,8 @@ func flattenBuildDefinitionBranchOrPathFilter(m []interface{}) []interface{} {
 		if v2, ok := v.(string); ok {
 			if strings.HasPrefix(v2, "-") {
 				exclude = append(exclude, strings.TrimPrefix(v2, "-"))
-			} else if strings.HasPrefix(v2, "+") {
+			} else {
+				// filters without a prefix are includes
 				include = append(include, strings.TrimPrefix(v2, "+"))
 			}
 		}
@@ -671,13 +807,16 @@ func flattenBuildDefinitionContinuousIntegrationTrigger(m interface{}, isYaml bo
 			"use_yaml": isYaml,
 		}
 		if !isYaml {
+			// filters are omitted by the service when there are none
+			branchFilters, _ := ms["branchFilters"].([]interface{})
+			pathFilters, _ := ms["pathFilters"].([]interface{})
 			f["override"] = []map[string]interface{}{{
 				"batch":                            ms["batchChanges"],
-				"branch_filter":                    flattenBuildDefinitionBranchOrPathFilter(ms["branchFilters"].([]interface{})),
+				"branch_filter":                    flattenBuildDefinitionBranchOrPathFilter(branchFilters),
 				"max_concurrent_builds_per_branch": ms["maxConcurrentBuildsPerBranch"],
 				"polling_interval":                 ms["pollingInterval"],
 				"polling_job_id":                   ms["pollingJobId"],
-				"path_filter":                      flattenBuildDefinitionBranchOrPathFilter(ms["pathFilters"].([]interface{})),
+				"path_filter":                      flattenBuildDefinitionBranchOrPathFilter(pathFilters),
 			}}
 		}
 		return f
@@ -777,10 +916,63 @@ func flattenTriggers(m *[]interface{}) map[build.DefinitionTriggerType][]interfa
 		if strings.EqualFold(triggerType, string(build.DefinitionTriggerTypeValues.Schedule)) {
 			buildTriggers[build.DefinitionTriggerTypeValues.Schedule] = flattenBuildDefinitionScheduleTrigger(trigger)
 		}
+		if strings.EqualFold(triggerType, string(build.DefinitionTriggerTypeValues.BuildCompletion)) {
+			buildTriggers[build.DefinitionTriggerTypeValues.BuildCompletion] =
+				append(buildTriggers[build.DefinitionTriggerTypeValues.BuildCompletion], trigger)
+		}
 	}
 	return buildTriggers
 }
 
+// flattenPipelineResources returns the pipeline resources of the build completion triggers of a definition.
+// The aliases and the stage and tag filters are not part of the triggers, they are matched by the ID of the
+// source pipeline.
+func flattenPipelineResources(d *schema.ResourceData, triggers []interface{}, projectID string) []interface{} {
+	// the data source shares the flattening, but does not know pipeline resources
+	configuredResources, _ := d.Get(bdPipelineResource).([]interface{})
+	configured := map[int]map[string]interface{}{}
+	for _, r := range configuredResources {
+		if resource, ok := r.(map[string]interface{}); ok {
+			configured[resource["source_pipeline_id"].(int)] = resource
+		}
+	}
+
+	resources := make([]interface{}, 0, len(triggers))
+	for _, t := range triggers {
+		trigger := t.(map[string]interface{})
+		definition, ok := trigger["definition"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, ok := definition["id"].(float64)
+		if !ok {
+			continue
+		}
+
+		sourceProjectID := projectID
+		if project, ok := definition["project"].(map[string]interface{}); ok {
+			if v, ok := project["id"].(string); ok && v != "" {
+				sourceProjectID = v
+			}
+		}
+
+		branchFilters, _ := trigger["branchFilters"].([]interface{})
+		requiresSuccessfulBuild, _ := trigger["requiresSuccessfulBuild"].(bool)
+		resource := map[string]interface{}{
+			"alias":                     "",
+			"source_pipeline_id":        int(id),
+			"source_project_id":         sourceProjectID,
+			"branch_filter":             flattenBuildDefinitionBranchOrPathFilter(branchFilters),
+			"requires_successful_build": requiresSuccessfulBuild,
+		}
+		if c, ok := configured[int(id)]; ok {
+			resource["alias"] = c["alias"]
+		}
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
 func expandBuildDefinitionBranchOrPathFilter(d map[string]interface{}) []interface{} {
 	include := tfhelper.ExpandStringSet(d["include"].(*schema.Set))
 	exclude := tfhelper.ExpandStringSet(d["exclude"].(*schema.Set))
@@ -870,6 +1062,8 @@ func expandBuildDefinitionManualContinuousIntegrationTrigger(d map[string]interf
 		"pathFilters":                  expandBuildDefinitionBranchOrPathFilterSet(d["path_filter"].(*schema.Set)),
 		"triggerType":                  string(build.DefinitionTriggerTypeValues.ContinuousIntegration),
 		"pollingInterval":              d["polling_interval"].(int),
+		// overrides the trigger of the YAML file
+		"settingsSourceType": float64(1),
 	}
 }
 func expandBuildDefinitionManualContinuousIntegrationTriggerList(d []interface{}) []map[string]interface{} {
@@ -903,7 +1097,11 @@ func expandBuildDefinitionTrigger(d map[string]interface{}, t build.DefinitionTr
 				"settingsSourceType":           float64(2),
 			}
 		}
-		return expandBuildDefinitionManualContinuousIntegrationTriggerListFirstOrNil(d["override"].([]interface{}))
+		if override := expandBuildDefinitionManualContinuousIntegrationTriggerListFirstOrNil(d["override"].([]interface{})); override != nil {
+			return override
+		}
+		// neither using the YAML file nor overriding it disables continuous integration
+		return nil
 	case build.DefinitionTriggerTypeValues.PullRequest:
 		isYaml := d["use_yaml"].(bool)
 		commentRequired := d["comment_required"].(string)
@@ -945,7 +1143,9 @@ func expandBuildDefinitionTriggerList(d []interface{}, t build.DefinitionTrigger
 	for _, v := range d {
 		val, ok := v.(map[string]interface{})
 		if ok {
-			vs = append(vs, expandBuildDefinitionTrigger(val, t))
+			if trigger := expandBuildDefinitionTrigger(val, t); trigger != nil {
+				vs = append(vs, trigger)
+			}
 		}
 	}
 	return vs
@@ -969,7 +1169,8 @@ func expandVariables(d *schema.ResourceData) (*map[string]build.BuildDefinitionV
 	}
 
 	variablesList := variables.(*schema.Set).List()
-	if len(variablesList) == 0 {
+	secretVariablesList := d.Get(bdSecretVariable).([]interface{})
+	if len(variablesList) == 0 && len(secretVariablesList) == 0 {
 		return nil, nil
 	}
 
@@ -997,6 +1198,22 @@ func expandVariables(d *schema.ResourceData) (*map[string]build.BuildDefinitionV
 		}
 	}
 
+	// values of secret variables are write-only and have to be read from the configuration
+	for i, secretVariable := range secretVariablesList {
+		varAsMap := secretVariable.(map[string]interface{})
+		varName := varAsMap[bdVariableName].(string)
+
+		if _, ok := expandedVars[varName]; ok {
+			return nil, fmt.Errorf("Unexpectedly found duplicate variable with name %s", varName)
+		}
+
+		expandedVars[varName] = build.BuildDefinitionVariable{
+			AllowOverride: converter.Bool(varAsMap[bdVariableAllowOverride].(bool)),
+			IsSecret:      converter.Bool(true),
+			Value:         converter.String(tfhelper.WriteOnlyString(d, fmt.Sprintf("%s.%d.%s", bdSecretVariable, i, bdVariableValueWO))),
+		}
+	}
+
 	return &expandedVars, nil
 }
 
@@ -1053,6 +1270,8 @@ func expandBuildDefinition(d *schema.ResourceData) (*build.BuildDefinition, stri
 		buildTriggers = append(buildTriggers, scheduleTriggers)
 	}
 
+	buildTriggers = append(buildTriggers, expandPipelineResources(d.Get(bdPipelineResource).([]interface{}), projectID)...)
+
 	// Look for the ID. This may not exist if we are within the context of a "create" operation,
 	// so it is OK if it is missing.
 	buildDefinitionID, err := strconv.Atoi(d.Id())
@@ -1110,6 +1329,76 @@ func expandBuildDefinition(d *schema.ResourceData) (*build.BuildDefinition, stri
 	return &buildDefinition, projectID, nil
 }
 
+// expandPipelineResources returns the build completion triggers of the pipeline resources, which queue the
+// definition when a run of the source pipeline completes
+func expandPipelineResources(resources []interface{}, projectID string) []interface{} {
+	triggers := make([]interface{}, 0, len(resources))
+	for _, r := range resources {
+		resource, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		sourceProjectID := projectID
+		if v := resource["source_project_id"].(string); v != "" {
+			sourceProjectID = v
+		}
+
+		branchFilters := expandBuildDefinitionBranchOrPathFilterSet(resource["branch_filter"].(*schema.Set))
+		if branchFilters == nil {
+			branchFilters = []interface{}{}
+		}
+		triggers = append(triggers, map[string]interface{}{
+			"triggerType": string(build.DefinitionTriggerTypeValues.BuildCompletion),
+			"definition": map[string]interface{}{
+				"id": resource["source_pipeline_id"].(int),
+				"project": map[string]interface{}{
+					"id": sourceProjectID,
+				},
+			},
+			"branchFilters":           branchFilters,
+			"requiresSuccessfulBuild": resource["requires_successful_build"].(bool),
+		})
+	}
+	return triggers
+}
+
+// authorizeContainerResources authorizes the definition to use the service connections of its container
+// resources, so runs are not blocked waiting for a permit. The authorization of service connections which are
+// not used by container resources anymore is revoked.
+func authorizeContainerResources(clients *client.AggregatedClient, d *schema.ResourceData, projectID string, definitionID int) error {
+	oldResources, newResources := d.GetChange(bdContainerResource)
+	authorizations := map[string]bool{}
+	for _, r := range oldResources.([]interface{}) {
+		if resource, ok := r.(map[string]interface{}); ok {
+			authorizations[resource["service_connection_id"].(string)] = false
+		}
+	}
+	for _, r := range newResources.([]interface{}) {
+		if resource, ok := r.(map[string]interface{}); ok {
+			authorizations[resource["service_connection_id"].(string)] = true
+		}
+	}
+
+	for serviceConnectionID, authorized := range authorizations {
+		_, err := clients.PipelinePermissionsClient.UpdatePipelinePermisionsForResource(clients.Ctx, pipelinepermissions.UpdatePipelinePermisionsForResourceArgs{
+			Project:      converter.String(projectID),
+			ResourceType: converter.String("endpoint"),
+			ResourceId:   converter.String(serviceConnectionID),
+			ResourceAuthorization: &pipelinepermissions.ResourcePipelinePermissions{
+				Pipelines: &[]pipelinepermissions.PipelinePermission{{
+					Authorized: converter.Bool(authorized),
+					Id:         converter.Int(definitionID),
+				}},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf(" updating the authorization of service connection %s of the container resources: %+v", serviceConnectionID, err)
+		}
+	}
+	return nil
+}
+
 /**
  * certain types of build definitions require a service connection to run. This function
  * returns an error if a service connection was needed but not provided
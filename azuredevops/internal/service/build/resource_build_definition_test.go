@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/pipelinepermissions"
 	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
@@ -38,6 +39,7 @@ var manualCiTrigger = map[string]interface{}{
 	"batchChanges":                 true,
 	"maxConcurrentBuildsPerBranch": 1,
 	"pollingInterval":              0,
+	"settingsSourceType":           float64(1),
 	"triggerType":                  "continuousIntegration",
 }
 
@@ -569,3 +571,244 @@ func sortBuildDefinition(b build.BuildDefinition) build.BuildDefinition {
 	}
 	return b
 }
+
+func TestBuildDefinition_ExpandPipelineResources_CreatesBuildCompletionTriggers(t *testing.T) {
+	sourceProjectID := uuid.New().String()
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, map[string]interface{}{
+		bdPipelineResource: []interface{}{
+			map[string]interface{}{
+				"alias":              "upstream",
+				"source_pipeline_id": 12,
+				"branch_filter": []interface{}{map[string]interface{}{
+					"include": []interface{}{"refs/heads/main"},
+				}},
+			},
+			map[string]interface{}{
+				"alias":                     "tools",
+				"source_pipeline_id":        13,
+				"source_project_id":         sourceProjectID,
+				"requires_successful_build": false,
+			},
+		},
+	})
+
+	triggers := expandPipelineResources(resourceData.Get(bdPipelineResource).([]interface{}), testProjectID)
+	require.Len(t, triggers, 2)
+
+	upstream := triggers[0].(map[string]interface{})
+	require.Equal(t, string(build.DefinitionTriggerTypeValues.BuildCompletion), upstream["triggerType"])
+	require.Equal(t, 12, upstream["definition"].(map[string]interface{})["id"])
+	require.Equal(t, testProjectID, upstream["definition"].(map[string]interface{})["project"].(map[string]interface{})["id"])
+	require.Equal(t, []interface{}{"+refs/heads/main"}, upstream["branchFilters"])
+	require.True(t, upstream["requiresSuccessfulBuild"].(bool))
+
+	tools := triggers[1].(map[string]interface{})
+	require.Equal(t, sourceProjectID, tools["definition"].(map[string]interface{})["project"].(map[string]interface{})["id"])
+	require.Equal(t, []interface{}{}, tools["branchFilters"])
+	require.False(t, tools["requiresSuccessfulBuild"].(bool))
+}
+
+func TestBuildDefinition_FlattenPipelineResources_MatchesAliasesBySourcePipeline(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, map[string]interface{}{
+		bdPipelineResource: []interface{}{
+			map[string]interface{}{
+				"alias":              "upstream",
+				"source_pipeline_id": 12,
+			},
+		},
+	})
+
+	definition := testBuildDefinition
+	definition.Triggers = &[]interface{}{
+		map[string]interface{}{
+			"triggerType": "buildCompletion",
+			"definition": map[string]interface{}{
+				"id":      float64(12),
+				"project": map[string]interface{}{"id": testProjectID},
+			},
+			"branchFilters":           []interface{}{"+refs/heads/main", "-refs/heads/wip"},
+			"requiresSuccessfulBuild": true,
+		},
+	}
+	flattenBuildDefinition(resourceData, &definition, testProjectID)
+
+	resources := resourceData.Get(bdPipelineResource).([]interface{})
+	require.Len(t, resources, 1)
+	resource := resources[0].(map[string]interface{})
+	require.Equal(t, "upstream", resource["alias"])
+	require.Equal(t, 12, resource["source_pipeline_id"])
+	require.Equal(t, testProjectID, resource["source_project_id"])
+	require.True(t, resource["requires_successful_build"].(bool))
+	branchFilter := resource["branch_filter"].(*schema.Set).List()[0].(map[string]interface{})
+	require.Equal(t, []interface{}{"refs/heads/main"}, branchFilter["include"].(*schema.Set).List())
+	require.Equal(t, []interface{}{"refs/heads/wip"}, branchFilter["exclude"].(*schema.Set).List())
+}
+
+func TestBuildDefinition_AuthorizeContainerResources_AuthorizesServiceConnections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pipelinePermissionsClient := azdosdkmocks.NewMockPipelinepermissionsClient(ctrl)
+	clients := &client.AggregatedClient{PipelinePermissionsClient: pipelinePermissionsClient, Ctx: context.Background()}
+
+	serviceConnectionID := uuid.New().String()
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, map[string]interface{}{
+		bdContainerResource: []interface{}{
+			map[string]interface{}{
+				"alias":                 "build",
+				"service_connection_id": serviceConnectionID,
+			},
+		},
+	})
+
+	pipelinePermissionsClient.
+		EXPECT().
+		UpdatePipelinePermisionsForResource(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args pipelinepermissions.UpdatePipelinePermisionsForResourceArgs) (*pipelinepermissions.ResourcePipelinePermissions, error) {
+			require.Equal(t, testProjectID, *args.Project)
+			require.Equal(t, "endpoint", *args.ResourceType)
+			require.Equal(t, serviceConnectionID, *args.ResourceId)
+			require.Equal(t, 100, *(*args.ResourceAuthorization.Pipelines)[0].Id)
+			require.True(t, *(*args.ResourceAuthorization.Pipelines)[0].Authorized)
+			return args.ResourceAuthorization, nil
+		}).
+		Times(1)
+
+	require.NoError(t, authorizeContainerResources(clients, resourceData, testProjectID, 100))
+}
+
+func TestBuildDefinition_AuthorizeContainerResources_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pipelinePermissionsClient := azdosdkmocks.NewMockPipelinepermissionsClient(ctrl)
+	clients := &client.AggregatedClient{PipelinePermissionsClient: pipelinePermissionsClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, map[string]interface{}{
+		bdContainerResource: []interface{}{
+			map[string]interface{}{
+				"alias":                 "build",
+				"service_connection_id": uuid.New().String(),
+			},
+		},
+	})
+
+	pipelinePermissionsClient.
+		EXPECT().
+		UpdatePipelinePermisionsForResource(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("UpdatePipelinePermisionsForResource() Failed")).
+		Times(1)
+
+	err := authorizeContainerResources(clients, resourceData, testProjectID, 100)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "UpdatePipelinePermisionsForResource() Failed")
+}
+
+// verifies that paused and disabled build definitions keep their queue status through a round trip
+func TestBuildDefinition_ExpandFlatten_QueueStatus(t *testing.T) {
+	for _, queueStatus := range []build.DefinitionQueueStatus{
+		build.DefinitionQueueStatusValues.Paused,
+		build.DefinitionQueueStatusValues.Disabled,
+	} {
+		resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, nil)
+		definition := testBuildDefinition
+		definition.QueueStatus = &queueStatus
+		flattenBuildDefinition(resourceData, &definition, testProjectID)
+
+		expanded, _, err := expandBuildDefinition(resourceData)
+		require.Nil(t, err)
+		require.Equal(t, queueStatus, *expanded.QueueStatus)
+	}
+}
+
+// verifies that the queue status is kept when the service does not return it
+func TestBuildDefinition_Flatten_KeepsQueueStatusIfMissing(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, map[string]interface{}{
+		"queue_status": "paused",
+	})
+	definition := testBuildDefinition
+	definition.QueueStatus = nil
+
+	require.NotPanics(t, func() { flattenBuildDefinition(resourceData, &definition, testProjectID) })
+	require.Equal(t, "paused", resourceData.Get("queue_status"))
+}
+
+// verifies that filters omitted by the service or without a prefix are flattened
+func TestBuildDefinition_Flatten_CITriggerOverrideFilters(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, nil)
+	definition := testBuildDefinition
+	definition.Triggers = &[]interface{}{
+		map[string]interface{}{
+			"branchFilters":                []interface{}{"main", "-release/*"},
+			"batchChanges":                 false,
+			"maxConcurrentBuildsPerBranch": float64(2),
+			"settingsSourceType":           float64(1),
+			"triggerType":                  "continuousIntegration",
+		},
+	}
+
+	require.NotPanics(t, func() { flattenBuildDefinition(resourceData, &definition, testProjectID) })
+	require.False(t, resourceData.Get("ci_trigger.0.use_yaml").(bool))
+	require.False(t, resourceData.Get("ci_trigger.0.override.0.batch").(bool))
+	require.Equal(t, 2, resourceData.Get("ci_trigger.0.override.0.max_concurrent_builds_per_branch"))
+
+	branchFilter := resourceData.Get("ci_trigger.0.override.0.branch_filter").(*schema.Set).List()[0].(map[string]interface{})
+	require.Equal(t, []interface{}{"main"}, branchFilter["include"].(*schema.Set).List())
+	require.Equal(t, []interface{}{"release/*"}, branchFilter["exclude"].(*schema.Set).List())
+	pathFilter := resourceData.Get("ci_trigger.0.override.0.path_filter").(*schema.Set).List()[0].(map[string]interface{})
+	require.Equal(t, 0, pathFilter["include"].(*schema.Set).Len())
+	require.Equal(t, 0, pathFilter["exclude"].(*schema.Set).Len())
+}
+
+// verifies that a CI trigger overriding the YAML file is expanded with its filters and batching
+func TestBuildDefinition_Expand_CITriggerOverride(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, nil)
+	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	require.Nil(t, resourceData.Set("ci_trigger", []interface{}{
+		map[string]interface{}{
+			"override": []interface{}{
+				map[string]interface{}{
+					"batch":                            true,
+					"max_concurrent_builds_per_branch": 1,
+					"branch_filter": []interface{}{
+						map[string]interface{}{"include": []interface{}{"main"}, "exclude": []interface{}{}},
+					},
+					"path_filter": []interface{}{
+						map[string]interface{}{"include": []interface{}{"src/*"}, "exclude": []interface{}{"docs/*"}},
+					},
+				},
+			},
+		},
+	}))
+
+	definition, _, err := expandBuildDefinition(resourceData)
+	require.Nil(t, err)
+	require.Len(t, *definition.Triggers, 1)
+	trigger := (*definition.Triggers)[0].(map[string]interface{})
+	require.Equal(t, float64(1), trigger["settingsSourceType"])
+	require.Equal(t, true, trigger["batchChanges"])
+	require.Equal(t, []interface{}{"+main"}, trigger["branchFilters"])
+	require.ElementsMatch(t, []interface{}{"+src/*", "-docs/*"}, trigger["pathFilters"])
+}
+
+// verifies that a CI trigger neither using the YAML file nor overriding it is not sent to the service
+func TestBuildDefinition_Expand_CITriggerWithoutOverrideIsOmitted(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, nil)
+	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+	require.Nil(t, resourceData.Set("ci_trigger", []interface{}{map[string]interface{}{"use_yaml": false}}))
+
+	definition, _, err := expandBuildDefinition(resourceData)
+	require.Nil(t, err)
+	require.Empty(t, *definition.Triggers)
+}
+
+// verifies that a CI trigger removed outside of Terraform is detected
+func TestBuildDefinition_Flatten_DetectsRemovedCITrigger(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, map[string]interface{}{
+		"ci_trigger": []interface{}{map[string]interface{}{"use_yaml": true}},
+	})
+	flattenBuildDefinition(resourceData, &testBuildDefinition, testProjectID)
+
+	require.False(t, resourceData.Get("ci_trigger.0.use_yaml").(bool))
+	require.Empty(t, resourceData.Get("ci_trigger.0.override"))
+}
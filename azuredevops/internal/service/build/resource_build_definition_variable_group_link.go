@@ -0,0 +1,208 @@
+package build
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// definitionLocks serializes the changes to the variable groups of a build definition, as every link updates
+// the whole definition and concurrent updates of the same revision are rejected
+var definitionLocks sync.Map
+
+// ResourceBuildDefinitionVariableGroupLink schema and implementation for linking a variable group to a build
+// definition that is not managed by the same configuration
+func ResourceBuildDefinitionVariableGroupLink() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBuildDefinitionVariableGroupLinkCreate,
+		Read:   resourceBuildDefinitionVariableGroupLinkRead,
+		Delete: resourceBuildDefinitionVariableGroupLinkDelete,
+		Importer: &schema.ResourceImporter{
+			State: importBuildDefinitionVariableGroupLink,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"definition_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"variable_group_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		},
+	}
+}
+
+func resourceBuildDefinitionVariableGroupLinkCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	definitionID := d.Get("definition_id").(int)
+	variableGroupID := d.Get("variable_group_id").(int)
+
+	err := updateDefinitionVariableGroups(clients, projectID, definitionID, func(variableGroups []build.VariableGroup) ([]build.VariableGroup, bool) {
+		if hasVariableGroup(variableGroups, variableGroupID) {
+			return variableGroups, false
+		}
+		return append(variableGroups, *buildVariableGroup(variableGroupID)), true
+	})
+	if err != nil {
+		return fmt.Errorf(" linking variable group %d to build definition %d: %+v", variableGroupID, definitionID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%d/%d", definitionID, variableGroupID))
+	return resourceBuildDefinitionVariableGroupLinkRead(d, m)
+}
+
+func resourceBuildDefinitionVariableGroupLinkRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	definitionID, variableGroupID, err := parseBuildDefinitionVariableGroupLinkID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	definition, err := clients.BuildClient.GetDefinition(clients.Ctx, build.GetDefinitionArgs{
+		Project:      &projectID,
+		DefinitionId: &definitionID,
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(" reading build definition %d: %+v", definitionID, err)
+	}
+
+	if definition.VariableGroups == nil || !hasVariableGroup(*definition.VariableGroups, variableGroupID) {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("definition_id", definitionID)
+	d.Set("variable_group_id", variableGroupID)
+	return nil
+}
+
+func resourceBuildDefinitionVariableGroupLinkDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	definitionID, variableGroupID, err := parseBuildDefinitionVariableGroupLinkID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	err = updateDefinitionVariableGroups(clients, projectID, definitionID, func(variableGroups []build.VariableGroup) ([]build.VariableGroup, bool) {
+		linked := make([]build.VariableGroup, 0, len(variableGroups))
+		for _, variableGroup := range variableGroups {
+			if variableGroup.Id == nil || *variableGroup.Id != variableGroupID {
+				linked = append(linked, variableGroup)
+			}
+		}
+		return linked, len(linked) != len(variableGroups)
+	})
+	if err != nil && !utils.ResponseWasNotFound(err) {
+		return fmt.Errorf(" unlinking variable group %d from build definition %d: %+v", variableGroupID, definitionID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// updateDefinitionVariableGroups reads a build definition and updates it with the variable groups returned by
+// update, the definition is not updated when update reports no change
+func updateDefinitionVariableGroups(clients *client.AggregatedClient, projectID string, definitionID int,
+	update func([]build.VariableGroup) ([]build.VariableGroup, bool)) error {
+	lock, _ := definitionLocks.LoadOrStore(fmt.Sprintf("%s/%d", projectID, definitionID), &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	definition, err := clients.BuildClient.GetDefinition(clients.Ctx, build.GetDefinitionArgs{
+		Project:      &projectID,
+		DefinitionId: &definitionID,
+	})
+	if err != nil {
+		return err
+	}
+
+	variableGroups := []build.VariableGroup{}
+	if definition.VariableGroups != nil {
+		variableGroups = *definition.VariableGroups
+	}
+	variableGroups, changed := update(variableGroups)
+	if !changed {
+		return nil
+	}
+
+	definition.VariableGroups = &variableGroups
+	_, err = clients.BuildClient.UpdateDefinition(clients.Ctx, build.UpdateDefinitionArgs{
+		Definition:   definition,
+		Project:      &projectID,
+		DefinitionId: &definitionID,
+	})
+	return err
+}
+
+func hasVariableGroup(variableGroups []build.VariableGroup, variableGroupID int) bool {
+	for _, variableGroup := range variableGroups {
+		if variableGroup.Id != nil && *variableGroup.Id == variableGroupID {
+			return true
+		}
+	}
+	return false
+}
+
+func parseBuildDefinitionVariableGroupLinkID(id string) (int, int, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf(" unexpected format of ID (%s), expected definitionId/variableGroupId", id)
+	}
+	definitionID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf(" parsing the build definition ID of %s: %+v", id, err)
+	}
+	variableGroupID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf(" parsing the variable group ID of %s: %+v", id, err)
+	}
+	return definitionID, variableGroupID, nil
+}
+
+// importBuildDefinitionVariableGroupLink imports a link by an ID that looks like one of the following:
+//
+//	<project ID>/<definition ID>/<variable group ID>
+//	<project name>/<definition ID>/<variable group ID>
+func importBuildDefinitionVariableGroupLink(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	projectNameOrID, linkID, err := tfhelper.ParseImportedName(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf(" unexpected format of ID (%s), expected projectId/definitionId/variableGroupId", d.Id())
+	}
+	if _, _, err := parseBuildDefinitionVariableGroupLinkID(linkID); err != nil {
+		return nil, err
+	}
+
+	projectID, err := tfhelper.GetRealProjectId(projectNameOrID, m)
+	if err != nil {
+		return nil, err
+	}
+	d.Set("project_id", projectID)
+	d.SetId(linkID)
+	return []*schema.ResourceData{d}, nil
+}
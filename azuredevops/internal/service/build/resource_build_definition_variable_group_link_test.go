@@ -0,0 +1,154 @@
+//go:build (all || resource_build_definition_variable_group_link) && !exclude_resource_build_definition_variable_group_link
+// +build all resource_build_definition_variable_group_link
+// +build !exclude_resource_build_definition_variable_group_link
+
+package build
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var testLinkProjectID = uuid.New().String()
+
+func testLinkDefinition(variableGroupIDs ...int) *build.BuildDefinition {
+	variableGroups := []build.VariableGroup{}
+	for _, id := range variableGroupIDs {
+		variableGroups = append(variableGroups, *buildVariableGroup(id))
+	}
+	return &build.BuildDefinition{
+		Id:             converter.Int(10),
+		Name:           converter.String("pipeline"),
+		Revision:       converter.Int(3),
+		VariableGroups: &variableGroups,
+	}
+}
+
+// verifies that the variable group is added to the variable groups of the definition
+func TestBuildDefinitionVariableGroupLink_Create_AppendsVariableGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinitionVariableGroupLink().Schema, nil)
+	resourceData.Set("project_id", testLinkProjectID)
+	resourceData.Set("definition_id", 10)
+	resourceData.Set("variable_group_id", 7)
+
+	getArgs := build.GetDefinitionArgs{Project: &testLinkProjectID, DefinitionId: converter.Int(10)}
+	gomock.InOrder(
+		buildClient.EXPECT().GetDefinition(clients.Ctx, getArgs).Return(testLinkDefinition(5), nil).Times(1),
+		buildClient.EXPECT().
+			UpdateDefinition(clients.Ctx, build.UpdateDefinitionArgs{
+				Definition:   testLinkDefinition(5, 7),
+				Project:      &testLinkProjectID,
+				DefinitionId: converter.Int(10),
+			}).
+			Return(testLinkDefinition(5, 7), nil).
+			Times(1),
+		buildClient.EXPECT().GetDefinition(clients.Ctx, getArgs).Return(testLinkDefinition(5, 7), nil).Times(1),
+	)
+
+	err := resourceBuildDefinitionVariableGroupLinkCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "10/7", resourceData.Id())
+}
+
+// verifies that the definition is not updated when the variable group is linked already
+func TestBuildDefinitionVariableGroupLink_Create_SkipsLinkedVariableGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinitionVariableGroupLink().Schema, nil)
+	resourceData.Set("project_id", testLinkProjectID)
+	resourceData.Set("definition_id", 10)
+	resourceData.Set("variable_group_id", 7)
+
+	buildClient.EXPECT().GetDefinition(clients.Ctx, gomock.Any()).Return(testLinkDefinition(7), nil).Times(2)
+	buildClient.EXPECT().UpdateDefinition(gomock.Any(), gomock.Any()).Times(0)
+
+	err := resourceBuildDefinitionVariableGroupLinkCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "10/7", resourceData.Id())
+}
+
+// verifies that only the linked variable group is removed from the definition
+func TestBuildDefinitionVariableGroupLink_Delete_RemovesVariableGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinitionVariableGroupLink().Schema, nil)
+	resourceData.SetId("10/7")
+	resourceData.Set("project_id", testLinkProjectID)
+
+	buildClient.EXPECT().GetDefinition(clients.Ctx, gomock.Any()).Return(testLinkDefinition(5, 7), nil).Times(1)
+	buildClient.EXPECT().
+		UpdateDefinition(clients.Ctx, build.UpdateDefinitionArgs{
+			Definition:   testLinkDefinition(5),
+			Project:      &testLinkProjectID,
+			DefinitionId: converter.Int(10),
+		}).
+		Return(nil, errors.New("UpdateDefinition() Failed")).
+		Times(1)
+
+	err := resourceBuildDefinitionVariableGroupLinkDelete(resourceData, clients)
+	require.ErrorContains(t, err, "UpdateDefinition() Failed")
+}
+
+// verifies that the link is removed from the state when the variable group was unlinked outside of Terraform
+func TestBuildDefinitionVariableGroupLink_Read_RemovesUnlinkedVariableGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	for _, response := range []struct {
+		definition *build.BuildDefinition
+		err        error
+	}{
+		{definition: testLinkDefinition(5)},
+		{err: azuredevops.WrappedError{StatusCode: converter.Int(404)}},
+	} {
+		resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinitionVariableGroupLink().Schema, nil)
+		resourceData.SetId("10/7")
+		resourceData.Set("project_id", testLinkProjectID)
+
+		buildClient.EXPECT().GetDefinition(clients.Ctx, gomock.Any()).Return(response.definition, response.err).Times(1)
+
+		err := resourceBuildDefinitionVariableGroupLinkRead(resourceData, clients)
+		require.Nil(t, err)
+		require.Empty(t, resourceData.Id())
+	}
+}
+
+func TestBuildDefinitionVariableGroupLink_ParseID(t *testing.T) {
+	definitionID, variableGroupID, err := parseBuildDefinitionVariableGroupLinkID("10/7")
+	require.Nil(t, err)
+	require.Equal(t, 10, definitionID)
+	require.Equal(t, 7, variableGroupID)
+
+	for _, id := range []string{"10", "10/7/1", "a/7", "10/b"} {
+		_, _, err := parseBuildDefinitionVariableGroupLinkID(id)
+		require.Error(t, err, id)
+	}
+}
@@ -1,6 +1,7 @@
 package build
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -66,12 +67,24 @@ func resourceBuildFolderRead(d *schema.ResourceData, m interface{}) error {
 	projectID := d.Get("project_id").(string)
 	path := d.Id()
 
-	buildFolders, err := clients.BuildClient.GetFolders(clients.Ctx, build.GetFoldersArgs{
-		Project: &projectID,
-		Path:    &path,
+	var buildFolders *[]build.Folder
+	err := clients.ReadResource(d, func() (err error) {
+		buildFolders, err = clients.BuildClient.GetFolders(clients.Ctx, build.GetFoldersArgs{
+			Project: &projectID,
+			Path:    &path,
+		})
+		if err == nil && (buildFolders == nil || len(*buildFolders) == 0) {
+			return client.ErrResourceNotFound
+		}
+		return err
 	})
 
 	if err != nil {
+		if errors.Is(err, client.ErrResourceNotFound) {
+			d.SetId("")
+			log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Folder [%s] not found. Removing from state.", path)
+			return nil
+		}
 		if utils.ResponseWasNotFound(err) {
 			d.SetId("")
 			return nil
@@ -79,12 +92,6 @@ func resourceBuildFolderRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	if len(*buildFolders) == 0 {
-		d.SetId("")
-		log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Folder [%s] not found. Removing from state.", path)
-		return nil
-	}
-
 	buildFolder := (*buildFolders)[0]
 
 	flattenBuildFolder(d, &buildFolder, projectID)
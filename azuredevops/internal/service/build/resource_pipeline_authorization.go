@@ -2,6 +2,8 @@ package build
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/pipelinepermissions"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
 )
 
 func ResourcePipelineAuthorization() *schema.Resource {
@@ -18,6 +21,9 @@ func ResourcePipelineAuthorization() *schema.Resource {
 		Create: resourcePipelineAuthorizationCreateUpdate,
 		Read:   resourcePipelineAuthorizationRead,
 		Delete: resourcePipelineAuthorizationDelete,
+		Importer: &schema.ResourceImporter{
+			State: importPipelineAuthorization,
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(2 * time.Minute),
 			Read:   schema.DefaultTimeout(1 * time.Minute),
@@ -219,6 +225,49 @@ func resourcePipelineAuthorizationDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
+// importPipelineAuthorization imports the authorization of a resource by an ID that looks like one of the following:
+//
+//	<project name or ID>/<type>/<resource ID>
+//	<project name or ID>/<type>/<resource ID>/<pipeline ID>
+func importPipelineAuthorization(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	projectID, resourceType, resourceID, pipelineID, err := parseAuthorizationImportID(d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("project_id", projectID)
+	d.Set("type", resourceType)
+	d.Set("resource_id", resourceID)
+	if pipelineID != 0 {
+		d.Set("pipeline_id", pipelineID)
+	}
+	d.SetId(resourceID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseAuthorizationImportID returns the project ID, the type and the ID of the resource and the ID of the pipeline
+// of an imported authorization. The ID of the pipeline is 0 when the resource is authorized for all pipelines.
+func parseAuthorizationImportID(id string, m interface{}) (string, string, string, int, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) < 3 || len(parts) > 4 || slices.Contains(parts, "") {
+		return "", "", "", 0, fmt.Errorf(" unexpected format of ID (%s), expected <project name or ID>/<type>/<resource ID> or <project name or ID>/<type>/<resource ID>/<pipeline ID>", id)
+	}
+
+	pipelineID := 0
+	if len(parts) == 4 {
+		var err error
+		if pipelineID, err = strconv.Atoi(parts[3]); err != nil {
+			return "", "", "", 0, fmt.Errorf(" pipeline ID was expected to be integer, but was not: %w", err)
+		}
+	}
+
+	projectID, err := tfhelper.GetRealProjectId(parts[0], m)
+	if err != nil {
+		return "", "", "", 0, err
+	}
+	return projectID, parts[1], parts[2], pipelineID, nil
+}
+
 func checkPipelineAuthorization(clients *client.AggregatedClient, d *schema.ResourceData, params pipelinepermissions.UpdatePipelinePermisionsForResourceArgs) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		projectId := d.Get("project_id").(string)
@@ -26,6 +26,9 @@ func ResourceResourceAuthorization() *schema.Resource {
 		Read:   resourceResourceAuthorizationRead,
 		Update: resourceResourceAuthorizationUpdate,
 		Delete: resourceResourceAuthorizationDelete,
+		Importer: &schema.ResourceImporter{
+			State: importResourceAuthorization,
+		},
 
 		DeprecationMessage: "This resource will be deprecated and removed in the future. Please use `azuredevops_pipeline_authorization` instead.",
 
@@ -169,6 +172,25 @@ func resourceResourceAuthorizationUpdate(d *schema.ResourceData, m interface{})
 	return resourceResourceAuthorizationRead(d, m)
 }
 
+// importResourceAuthorization imports the authorization of a resource by an ID that looks like one of the following:
+//
+//	<project name or ID>/<type>/<resource ID>
+//	<project name or ID>/<type>/<resource ID>/<definition ID>
+func importResourceAuthorization(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	projectID, resourceType, resourceID, definitionID, err := parseAuthorizationImportID(d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("project_id", projectID)
+	d.Set("type", resourceType)
+	d.Set("resource_id", resourceID)
+	d.Set("definition_id", definitionID)
+	d.Set("authorized", true)
+	d.SetId(resourceID)
+	return []*schema.ResourceData{d}, nil
+}
+
 func flattenAuthorizedResource(d *schema.ResourceData, authorizedResource *build.DefinitionResourceReference, projectID string, definitionID int) {
 	d.SetId(*authorizedResource.Id)
 	d.Set("resource_id", authorizedResource.Id)
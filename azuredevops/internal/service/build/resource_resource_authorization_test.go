@@ -182,3 +182,32 @@ func TestResourceAuthorization_DoesNotSwallowError(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceAuthorization_Import_ParsesID(t *testing.T) {
+	importedProjectID := uuid.New().String()
+	resourceData := schema.TestResourceDataRaw(t, ResourceResourceAuthorization().Schema, nil)
+	resourceData.SetId(importedProjectID + "/endpoint/" + endpointId.String() + "/666")
+
+	imported, err := importResourceAuthorization(resourceData, &client.AggregatedClient{Ctx: context.Background()})
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	require.Equal(t, importedProjectID, imported[0].Get("project_id"))
+	require.Equal(t, "endpoint", imported[0].Get("type"))
+	require.Equal(t, endpointId.String(), imported[0].Get("resource_id"))
+	require.Equal(t, definitionID, imported[0].Get("definition_id"))
+	require.True(t, imported[0].Get("authorized").(bool))
+}
+
+func TestResourceAuthorization_Import_RejectsMalformedID(t *testing.T) {
+	for _, id := range []string{
+		"project/endpoint",
+		"project/endpoint/" + endpointId.String() + "/definition",
+		"project//" + endpointId.String(),
+	} {
+		resourceData := schema.TestResourceDataRaw(t, ResourceResourceAuthorization().Schema, nil)
+		resourceData.SetId(id)
+
+		_, err := importResourceAuthorization(resourceData, &client.AggregatedClient{Ctx: context.Background()})
+		require.Error(t, err, id)
+	}
+}
@@ -68,7 +68,7 @@ func dataProjectRead(ctx context.Context, d *schema.ResourceData, m interface{})
 	id := d.Get("project_id").(string)
 
 	if name == "" && id == "" {
-		return diag.FromErr(fmt.Errorf("Either project_id or name must be set "))
+		return utils.ErrorDiagnostics(fmt.Errorf("Either project_id or name must be set "))
 	}
 
 	identifier := id
@@ -84,15 +84,15 @@ func dataProjectRead(ctx context.Context, d *schema.ResourceData, m interface{})
 
 	if err != nil {
 		if utils.ResponseWasNotFound(err) {
-			return diag.FromErr(fmt.Errorf("Project with name %s or ID %s does not exist ", name, id))
+			return utils.ErrorDiagnostics(fmt.Errorf("Project with name %s or ID %s does not exist ", name, id))
 		}
-		return diag.FromErr(fmt.Errorf("Error looking up project with Name %s or ID %s, %+v ", name, id, err))
+		return utils.ErrorDiagnostics(fmt.Errorf("Error looking up project with Name %s or ID %s, %+v ", name, id, err))
 	}
 
 	err = flattenProject(clients, d, project)
 	d.Set("project_id", project.Id.String())
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("Error flattening project: %v", err))
+		return utils.ErrorDiagnostics(fmt.Errorf("Error flattening project: %v", err))
 	}
 	return nil
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/datahelper"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
@@ -48,6 +49,8 @@ func DataProjects() *schema.Resource {
 				}, true),
 			},
 
+			"features": datahelper.FeaturesSchema(),
+
 			"projects": {
 				Type:     schema.TypeSet,
 				Computed: true,
@@ -86,9 +89,9 @@ func dataSourceProjectsRead(ctx context.Context, d *schema.ResourceData, m inter
 	state := d.Get("state").(string)
 	name := d.Get("name").(string)
 
-	projects, err := getProjectsForStateAndName(clients, state, name)
+	projects, err := getProjectsForStateAndName(clients, state, name, datahelper.GetConcurrentWorkers(d))
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("Error finding projects with state %s. Error: %v", state, err))
+		return utils.ErrorDiagnostics(fmt.Errorf("Error finding projects with state %s. Error: %v", state, err))
 	}
 	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] projects from current organization", len(projects))
 
@@ -96,19 +99,19 @@ func dataSourceProjectsRead(ctx context.Context, d *schema.ResourceData, m inter
 
 	projectNames, err := datahelper.GetAttributeValues(results, "name")
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("Failed to get list of project names: %v", err))
+		return utils.ErrorDiagnostics(fmt.Errorf("Failed to get list of project names: %v", err))
 	}
 	if len(projectNames) <= 0 && name != "" {
 		projectNames = append(projectNames, name)
 	}
 	h := sha1.New()
 	if _, err := h.Write([]byte(state + strings.Join(projectNames, "-"))); err != nil {
-		return diag.FromErr(fmt.Errorf("Unable to compute hash for project names: %v", err))
+		return utils.ErrorDiagnostics(fmt.Errorf("Unable to compute hash for project names: %v", err))
 	}
 	d.SetId("projects#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
 	err = d.Set("projects", results)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.ErrorDiagnostics(err)
 	}
 	return nil
 }
@@ -144,7 +147,13 @@ func flattenProjectReferences(input *[]core.TeamProjectReference) []interface{}
 	return results
 }
 
-func getProjectsForStateAndName(clients *client.AggregatedClient, projectState string, projectName string) ([]core.TeamProjectReference, error) {
+// getProjectsForStateAndName follows the continuation tokens of the projects one page after another, more than one
+// worker reads pages of projectsPageSize projects concurrently instead
+func getProjectsForStateAndName(clients *client.AggregatedClient, projectState string, projectName string, workers int) ([]core.TeamProjectReference, error) {
+	if workers > 1 {
+		return getProjectsConcurrently(clients, projectState, projectName, workers)
+	}
+
 	var projects []core.TeamProjectReference
 	var currentToken string
 
@@ -194,3 +203,57 @@ func getProjectsWithContinuationToken(clients *client.AggregatedClient, projectS
 
 	return response.Value, response.ContinuationToken, nil
 }
+
+// projectsPageSize is the number of projects read with each request
+const projectsPageSize = 100
+
+func getProjectsConcurrently(clients *client.AggregatedClient, projectState string, projectName string, workers int) ([]core.TeamProjectReference, error) {
+	findProject := func(projects []core.TeamProjectReference) *core.TeamProjectReference {
+		for _, project := range projects {
+			if strings.EqualFold(*project.Name, projectName) {
+				return &project
+			}
+		}
+		return nil
+	}
+
+	var done func([]core.TeamProjectReference) bool
+	if projectName != "" {
+		log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Searching for project name [%s]", projectName)
+		done = func(projects []core.TeamProjectReference) bool {
+			return findProject(projects) != nil
+		}
+	}
+
+	projects, err := datahelper.FetchPagesConcurrently(projectsPageSize, workers, func(skip int, top int) ([]core.TeamProjectReference, error) {
+		return getProjectsPage(clients, projectState, skip, top)
+	}, done)
+	if err != nil {
+		return nil, err
+	}
+
+	if projectName != "" {
+		if project := findProject(projects); project != nil {
+			log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Found project [%s] in current project list", projectName)
+			return []core.TeamProjectReference{*project}, nil
+		}
+		return nil, nil
+	}
+	return projects, nil
+}
+
+func getProjectsPage(clients *client.AggregatedClient, projectState string, skip int, top int) ([]core.TeamProjectReference, error) {
+	state := core.ProjectState(projectState)
+	args := core.GetProjectsArgs{
+		StateFilter: &state,
+		Top:         &top,
+		Skip:        &skip,
+	}
+
+	response, err := clients.CoreClient.GetProjects(clients.Ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Received [%d] projects (skip: %d, top: %d)", len(response.Value), skip, top)
+	return response.Value, nil
+}
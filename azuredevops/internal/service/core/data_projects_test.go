@@ -10,6 +10,7 @@ package core
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -87,6 +88,13 @@ var prjListDoubleID = []core.TeamProjectReference{
 	},
 }
 
+// singleWorkerFeatures reads the projects one page after another following the continuation tokens
+var singleWorkerFeatures = map[string]interface{}{
+	"features": []interface{}{
+		map[string]interface{}{"concurrent_workers": 1},
+	},
+}
+
 func TestDataSourceProjects_Read_TestFindProjectByName(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -110,7 +118,7 @@ func TestDataSourceProjects_Read_TestFindProjectByName(t *testing.T) {
 		}, nil).
 		Times(1)
 
-	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, nil)
+	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, singleWorkerFeatures)
 	resourceData.Set("name", "vsteam-0178")
 	resourceData.Set("state", "wellFormed")
 	err := dataSourceProjectsRead(clients.Ctx, resourceData, clients)
@@ -150,7 +158,7 @@ func TestDataSourceProjects_Read_TestEmptyProjectList(t *testing.T) {
 		}, nil).
 		Times(1)
 
-	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, nil)
+	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, singleWorkerFeatures)
 	err := dataSourceProjectsRead(clients.Ctx, resourceData, clients)
 	require.Nil(t, err)
 	require.Equal(t, "all", resourceData.Get("state").(string))
@@ -183,7 +191,7 @@ func TestDataSourceProjects_Read_TestFindAllProjects(t *testing.T) {
 		}, nil).
 		Times(1)
 
-	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, nil)
+	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, singleWorkerFeatures)
 	err := dataSourceProjectsRead(clients.Ctx, resourceData, clients)
 	require.Nil(t, err)
 	require.Equal(t, "all", resourceData.Get("state").(string))
@@ -216,7 +224,7 @@ func TestDataSourceProjects_Read_TestDuplicateProjectId(t *testing.T) {
 		}, nil).
 		Times(1)
 
-	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, nil)
+	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, singleWorkerFeatures)
 	err := dataSourceProjectsRead(clients.Ctx, resourceData, clients)
 	require.Nil(t, err)
 	require.Equal(t, "all", resourceData.Get("state").(string))
@@ -249,7 +257,7 @@ func TestDataSourceProjects_Read_TestFindProjectsWithState(t *testing.T) {
 		}, nil).
 		Times(1)
 
-	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, nil)
+	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, singleWorkerFeatures)
 	resourceData.Set("state", "wellFormed")
 	err := dataSourceProjectsRead(clients.Ctx, resourceData, clients)
 	require.Nil(t, err)
@@ -280,7 +288,7 @@ func TestDataSourceProjects_Read_TestHandleError(t *testing.T) {
 		Return(nil, errors.New("GetProjects() Failed")).
 		Times(1)
 
-	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, nil)
+	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, singleWorkerFeatures)
 	err := dataSourceProjectsRead(clients.Ctx, resourceData, clients)
 	require.Equal(t, err.HasError(), true)
 	require.Contains(t, err[0].Summary, "GetProjects() Failed")
@@ -322,7 +330,7 @@ func TestDataSourceProjects_Read_TestContinuationToken(t *testing.T) {
 
 	gomock.InOrder(calls...)
 
-	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, nil)
+	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, singleWorkerFeatures)
 	err := dataSourceProjectsRead(clients.Ctx, resourceData, clients)
 	require.Nil(t, err)
 	require.Equal(t, "all", resourceData.Get("state").(string))
@@ -331,3 +339,82 @@ func TestDataSourceProjects_Read_TestContinuationToken(t *testing.T) {
 	require.NotNil(t, projectSet)
 	require.Equal(t, 6, projectSet.Len())
 }
+
+func TestDataSourceProjects_Read_TestConcurrentPaging(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	clients := &client.AggregatedClient{
+		CoreClient: coreClient,
+		Ctx:        context.Background(),
+	}
+
+	firstPage := make([]core.TeamProjectReference, projectsPageSize)
+	for i := range firstPage {
+		firstPage[i] = core.TeamProjectReference{
+			Name:  converter.String(fmt.Sprintf("project-%d", i)),
+			Id:    testhelper.CreateUUID(),
+			State: &core.ProjectStateValues.WellFormed,
+		}
+	}
+
+	coreClient.
+		EXPECT().
+		GetProjects(clients.Ctx, core.GetProjectsArgs{
+			StateFilter: &core.ProjectStateValues.All,
+			Top:         converter.Int(projectsPageSize),
+			Skip:        converter.Int(0),
+		}).
+		Return(&core.GetProjectsResponseValue{Value: firstPage}, nil).
+		Times(1)
+	coreClient.
+		EXPECT().
+		GetProjects(clients.Ctx, core.GetProjectsArgs{
+			StateFilter: &core.ProjectStateValues.All,
+			Top:         converter.Int(projectsPageSize),
+			Skip:        converter.Int(projectsPageSize),
+		}).
+		Return(&core.GetProjectsResponseValue{Value: prjListStateWellFormed2}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, map[string]interface{}{
+		"features": []interface{}{map[string]interface{}{"concurrent_workers": 2}},
+	})
+	err := dataSourceProjectsRead(clients.Ctx, resourceData, clients)
+	require.Nil(t, err)
+	projectSet := resourceData.Get("projects").(*schema.Set)
+	require.NotNil(t, projectSet)
+	require.Equal(t, projectsPageSize+len(prjListStateWellFormed2), projectSet.Len())
+}
+
+func TestDataSourceProjects_Read_TestConcurrentPagingFindsProjectByName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	clients := &client.AggregatedClient{
+		CoreClient: coreClient,
+		Ctx:        context.Background(),
+	}
+
+	coreClient.
+		EXPECT().
+		GetProjects(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args core.GetProjectsArgs) (*core.GetProjectsResponseValue, error) {
+			if *args.Skip == 0 {
+				return &core.GetProjectsResponseValue{Value: prjListStateWellFormed}, nil
+			}
+			return &core.GetProjectsResponseValue{Value: []core.TeamProjectReference{}}, nil
+		}).
+		Times(3)
+
+	resourceData := schema.TestResourceDataRaw(t, DataProjects().Schema, map[string]interface{}{
+		"features": []interface{}{map[string]interface{}{"concurrent_workers": 3}},
+	})
+	resourceData.Set("name", "vsteam-0178")
+	err := dataSourceProjectsRead(clients.Ctx, resourceData, clients)
+	require.Nil(t, err)
+	projectSet := resourceData.Get("projects").(*schema.Set)
+	require.Equal(t, 1, projectSet.Len())
+}
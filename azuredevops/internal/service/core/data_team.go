@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
-	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/graph"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
 )
@@ -78,9 +77,7 @@ func dataTeamRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	descriptor, err := clients.GraphClient.GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{
-		StorageKey: team.Id,
-	})
+	descriptor, err := clients.LookupDescriptor(*team.Id)
 	if err != nil {
 		return fmt.Errorf(" get team descriptor. Error: %+v", err)
 	}
@@ -90,7 +87,7 @@ func dataTeamRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("description", team.Description)
 	d.Set("administrators", administrators)
 	d.Set("members", members)
-	d.Set("descriptor", descriptor.Value)
+	d.Set("descriptor", descriptor)
 
 	return nil
 }
@@ -81,7 +81,8 @@ func dataTeamsRead(d *schema.ResourceData, m interface{}) error {
 			data.(string),
 		}
 	} else {
-		projectList, err := getProjectsForStateAndName(clients, string(core.ProjectStateValues.All), "")
+		// the teams of the projects are read one after another, so are the pages of projects
+		projectList, err := getProjectsForStateAndName(clients, string(core.ProjectStateValues.All), "", 1)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,146 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/organizationpolicy"
+)
+
+// the organization policy which turns the logging of audit events on or off
+const logAuditEventsPolicy = "Policy.LogAuditEvents"
+
+// ResourceOrganizationAuditing schema and implementation for the auditing setting of the organization
+func ResourceOrganizationAuditing() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOrganizationAuditingCreateUpdate,
+		ReadContext:   resourceOrganizationAuditingRead,
+		UpdateContext: resourceOrganizationAuditingCreateUpdate,
+		DeleteContext: resourceOrganizationAuditingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Description: "Log audit events of the organization",
+				Type:        schema.TypeBool,
+				Required:    true,
+			},
+			"enforced": {
+				Description: "The auditing setting is enforced for the organization and cannot be changed",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceOrganizationAuditingCreateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clients := m.(*client.AggregatedClient)
+
+	policy, err := getLogAuditEventsPolicy(ctx, clients)
+	if err != nil {
+		return utils.ErrorDiagnostics(err)
+	}
+
+	enabled := d.Get("enabled").(bool)
+	if current, _ := policyBoolValue(policy); current != enabled {
+		if policy.Enforce != nil && *policy.Enforce {
+			return utils.ErrorDiagnostics(fmt.Errorf(" the auditing setting of the organization is enforced and cannot be changed"))
+		}
+		err = clients.OrganizationPolicyClient.UpdatePolicy(ctx, organizationpolicy.UpdatePolicyArgs{
+			PolicyName: converter.String(logAuditEventsPolicy),
+			Value:      strconv.FormatBool(enabled),
+		})
+		if err != nil {
+			return utils.ErrorDiagnostics(fmt.Errorf(" updating the auditing setting of the organization: %+v", err))
+		}
+	}
+
+	d.SetId(clients.OrganizationURL)
+	return resourceOrganizationAuditingRead(ctx, d, m)
+}
+
+func resourceOrganizationAuditingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clients := m.(*client.AggregatedClient)
+
+	policy, err := getLogAuditEventsPolicy(ctx, clients)
+	if err != nil {
+		return utils.ErrorDiagnostics(err)
+	}
+
+	enabled, err := policyBoolValue(policy)
+	if err != nil {
+		return utils.ErrorDiagnostics(err)
+	}
+	d.Set("enabled", enabled)
+	d.Set("enforced", policy.Enforce != nil && *policy.Enforce)
+	return nil
+}
+
+func resourceOrganizationAuditingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clients := m.(*client.AggregatedClient)
+
+	policy, err := getLogAuditEventsPolicy(ctx, clients)
+	if err != nil {
+		return utils.ErrorDiagnostics(err)
+	}
+
+	// auditing is off unless it is turned on, an enforced setting is owned by the parent policy.
+	if enabled, _ := policyBoolValue(policy); enabled && (policy.Enforce == nil || !*policy.Enforce) {
+		err = clients.OrganizationPolicyClient.UpdatePolicy(ctx, organizationpolicy.UpdatePolicyArgs{
+			PolicyName: converter.String(logAuditEventsPolicy),
+			Value:      strconv.FormatBool(false),
+		})
+		if err != nil {
+			return utils.ErrorDiagnostics(fmt.Errorf(" turning off the auditing of the organization: %w", err))
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// getLogAuditEventsPolicy reads the organization policy which turns auditing on or off
+func getLogAuditEventsPolicy(ctx context.Context, clients *client.AggregatedClient) (*organizationpolicy.Policy, error) {
+	policy, err := clients.OrganizationPolicyClient.GetPolicy(ctx, organizationpolicy.GetPolicyArgs{
+		PolicyName: converter.String(logAuditEventsPolicy),
+	})
+	if err != nil {
+		return nil, fmt.Errorf(" reading the auditing setting of the organization: %+v", err)
+	}
+	if policy == nil {
+		return nil, fmt.Errorf(" reading the auditing setting of the organization: the organization policy %s was not returned", logAuditEventsPolicy)
+	}
+	return policy, nil
+}
+
+// policyBoolValue returns the value of a boolean policy, which is the effective value when the organization has not set one.
+// The service returns the values either as booleans or as strings.
+func policyBoolValue(policy *organizationpolicy.Policy) (bool, error) {
+	value := policy.Value
+	if value == nil || (policy.IsValueUndefined != nil && *policy.IsValueUndefined) {
+		value = policy.EffectiveValue
+	}
+	switch v := value.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, fmt.Errorf(" parsing the value %q of the organization policy %s: %+v", v, converter.ToString(policy.Name, ""), err)
+		}
+		return b, nil
+	default:
+		return false, fmt.Errorf(" unexpected value %v of the organization policy %s", v, converter.ToString(policy.Name, ""))
+	}
+}
@@ -0,0 +1,222 @@
+//go:build (all || core || resource_organization_auditing) && !exclude_resource_organization_auditing
+// +build all core resource_organization_auditing
+// +build !exclude_resource_organization_auditing
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/organizationpolicy"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationAuditing_Create_EnablesAuditing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policyClient := azdosdkmocks.NewMockOrganizationpolicyClient(ctrl)
+	clients := &client.AggregatedClient{
+		OrganizationPolicyClient: policyClient,
+		OrganizationURL:          "https://dev.azure.com/myorg",
+		Ctx:                      context.Background(),
+	}
+
+	gomock.InOrder(
+		policyClient.EXPECT().
+			GetPolicy(clients.Ctx, gomock.Any()).
+			Return(&organizationpolicy.Policy{
+				Name:             converter.String(logAuditEventsPolicy),
+				EffectiveValue:   false,
+				IsValueUndefined: converter.Bool(true),
+			}, nil).
+			Times(1),
+		policyClient.EXPECT().
+			UpdatePolicy(clients.Ctx, organizationpolicy.UpdatePolicyArgs{
+				PolicyName: converter.String(logAuditEventsPolicy),
+				Value:      "true",
+			}).
+			Return(nil).
+			Times(1),
+		policyClient.EXPECT().
+			GetPolicy(clients.Ctx, gomock.Any()).
+			Return(&organizationpolicy.Policy{
+				Name:  converter.String(logAuditEventsPolicy),
+				Value: "true",
+			}, nil).
+			Times(1),
+	)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceOrganizationAuditing().Schema, map[string]interface{}{
+		"enabled": true,
+	})
+	diags := resourceOrganizationAuditingCreateUpdate(clients.Ctx, resourceData, clients)
+	require.False(t, diags.HasError())
+	require.Equal(t, "https://dev.azure.com/myorg", resourceData.Id())
+	require.True(t, resourceData.Get("enabled").(bool))
+}
+
+func TestOrganizationAuditing_Update_SkipsUnchangedSetting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policyClient := azdosdkmocks.NewMockOrganizationpolicyClient(ctrl)
+	clients := &client.AggregatedClient{
+		OrganizationPolicyClient: policyClient,
+		OrganizationURL:          "https://dev.azure.com/myorg",
+		Ctx:                      context.Background(),
+	}
+
+	policyClient.EXPECT().
+		GetPolicy(clients.Ctx, gomock.Any()).
+		Return(&organizationpolicy.Policy{Value: true}, nil).
+		Times(2)
+	policyClient.EXPECT().UpdatePolicy(gomock.Any(), gomock.Any()).Times(0)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceOrganizationAuditing().Schema, map[string]interface{}{
+		"enabled": true,
+	})
+	diags := resourceOrganizationAuditingCreateUpdate(clients.Ctx, resourceData, clients)
+	require.False(t, diags.HasError())
+}
+
+func TestOrganizationAuditing_Update_FailsWhenEnforced(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policyClient := azdosdkmocks.NewMockOrganizationpolicyClient(ctrl)
+	clients := &client.AggregatedClient{
+		OrganizationPolicyClient: policyClient,
+		Ctx:                      context.Background(),
+	}
+
+	policyClient.EXPECT().
+		GetPolicy(clients.Ctx, gomock.Any()).
+		Return(&organizationpolicy.Policy{Value: "true", Enforce: converter.Bool(true)}, nil).
+		Times(1)
+	policyClient.EXPECT().UpdatePolicy(gomock.Any(), gomock.Any()).Times(0)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceOrganizationAuditing().Schema, map[string]interface{}{
+		"enabled": false,
+	})
+	diags := resourceOrganizationAuditingCreateUpdate(clients.Ctx, resourceData, clients)
+	require.True(t, diags.HasError())
+	require.Contains(t, diags[0].Summary, "enforced")
+}
+
+func TestOrganizationAuditing_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policyClient := azdosdkmocks.NewMockOrganizationpolicyClient(ctrl)
+	clients := &client.AggregatedClient{
+		OrganizationPolicyClient: policyClient,
+		Ctx:                      context.Background(),
+	}
+
+	policyClient.EXPECT().
+		GetPolicy(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetPolicy() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceOrganizationAuditing().Schema, nil)
+	resourceData.SetId("https://dev.azure.com/myorg")
+	diags := resourceOrganizationAuditingRead(clients.Ctx, resourceData, clients)
+	require.True(t, diags.HasError())
+	require.Contains(t, diags[0].Summary, "GetPolicy() Failed")
+}
+
+func TestOrganizationAuditing_FailsWithoutPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policyClient := azdosdkmocks.NewMockOrganizationpolicyClient(ctrl)
+	clients := &client.AggregatedClient{
+		OrganizationPolicyClient: policyClient,
+		Ctx:                      context.Background(),
+	}
+
+	policyClient.EXPECT().
+		GetPolicy(clients.Ctx, gomock.Any()).
+		Return(nil, nil).
+		Times(3)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceOrganizationAuditing().Schema, map[string]interface{}{"enabled": true})
+	resourceData.SetId("https://dev.azure.com/myorg")
+	for _, f := range []schema.CreateContextFunc{resourceOrganizationAuditingCreateUpdate, resourceOrganizationAuditingRead, resourceOrganizationAuditingDelete} {
+		diags := f(clients.Ctx, resourceData, clients)
+		require.True(t, diags.HasError())
+		require.Contains(t, diags[0].Summary, "was not returned")
+	}
+}
+
+func TestOrganizationAuditing_PolicyBoolValue(t *testing.T) {
+	value, err := policyBoolValue(&organizationpolicy.Policy{Value: "false", EffectiveValue: true})
+	require.NoError(t, err)
+	require.False(t, value)
+
+	value, err = policyBoolValue(&organizationpolicy.Policy{EffectiveValue: true})
+	require.NoError(t, err)
+	require.True(t, value)
+
+	_, err = policyBoolValue(&organizationpolicy.Policy{Value: "on"})
+	require.Error(t, err)
+}
+
+func TestOrganizationAuditing_Delete_DisablesAuditing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policyClient := azdosdkmocks.NewMockOrganizationpolicyClient(ctrl)
+	clients := &client.AggregatedClient{
+		OrganizationPolicyClient: policyClient,
+		Ctx:                      context.Background(),
+	}
+
+	policyClient.EXPECT().
+		GetPolicy(clients.Ctx, gomock.Any()).
+		Return(&organizationpolicy.Policy{Value: "true"}, nil).
+		Times(1)
+	policyClient.EXPECT().
+		UpdatePolicy(clients.Ctx, organizationpolicy.UpdatePolicyArgs{
+			PolicyName: converter.String(logAuditEventsPolicy),
+			Value:      "false",
+		}).
+		Return(nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceOrganizationAuditing().Schema, nil)
+	resourceData.SetId("https://dev.azure.com/myorg")
+	diags := resourceOrganizationAuditingDelete(clients.Ctx, resourceData, clients)
+	require.False(t, diags.HasError())
+	require.Empty(t, resourceData.Id())
+}
+
+func TestOrganizationAuditing_Delete_LeavesEnforcedSetting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policyClient := azdosdkmocks.NewMockOrganizationpolicyClient(ctrl)
+	clients := &client.AggregatedClient{
+		OrganizationPolicyClient: policyClient,
+		Ctx:                      context.Background(),
+	}
+
+	policyClient.EXPECT().
+		GetPolicy(clients.Ctx, gomock.Any()).
+		Return(&organizationpolicy.Policy{Value: "true", Enforce: converter.Bool(true)}, nil).
+		Times(1)
+	policyClient.EXPECT().UpdatePolicy(gomock.Any(), gomock.Any()).Times(0)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceOrganizationAuditing().Schema, nil)
+	resourceData.SetId("https://dev.azure.com/myorg")
+	diags := resourceOrganizationAuditingDelete(clients.Ctx, resourceData, clients)
+	require.False(t, diags.HasError())
+}
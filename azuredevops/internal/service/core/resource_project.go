@@ -21,10 +21,6 @@ import (
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
 )
 
-// timeout used to wait for operations on projects to finish before executing an update or delete
-var projectBusyTimeoutDuration time.Duration = 6
-var projectRetryTimeoutDuration time.Duration = 3
-
 // ResourceProject schema and implementation for project resource
 func ResourceProject() *schema.Resource {
 	return &schema.Resource{
@@ -101,19 +97,19 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 	clients := m.(*client.AggregatedClient)
 	project, err := expandProject(clients, d, true)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("Error converting terraform data model to Azure DevOps project reference: %+v", err))
+		return utils.ErrorDiagnostics(fmt.Errorf("Error converting terraform data model to Azure DevOps project reference: %+v", err))
 	}
 
 	err = createProject(clients, project, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return diag.FromErr(fmt.Errorf(" creating project: %v", err))
+		return utils.ErrorDiagnostics(fmt.Errorf(" creating project: %v", err))
 	}
 
 	featureStates, ok := d.GetOk("features")
 	if ok {
-		err = configureProjectFeatures(clients, "", *project.Name, &featureStates, d.Timeout(schema.TimeoutDelete))
+		err = configureProjectFeatures(clients, "", *project.Name, &featureStates, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.ErrorDiagnostics(err)
 		}
 	}
 
@@ -157,7 +153,7 @@ func configureProjectFeatures(clients *client.AggregatedClient, projectID string
 		return nil
 	}
 	featureStateMap := (*featureStates).(map[string]interface{})
-	project, err := projectRead(clients, projectID, projectName)
+	project, err := projectRead(clients, projectID, projectName, timeout)
 	if err != nil {
 		return err
 	}
@@ -196,23 +192,27 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 	id := d.Id()
 	name := d.Get("name").(string)
 
-	project, err := projectRead(clients, id, name)
+	var project *core.TeamProject
+	err := clients.ReadResource(d, func() (err error) {
+		project, err = projectRead(clients, id, name, d.Timeout(schema.TimeoutRead))
+		return err
+	})
 	if err != nil {
 		if utils.ResponseWasNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(fmt.Errorf(" looking up project with (ID: %s or Name: %s). Error: %+v", id, name, err))
+		return utils.ErrorDiagnostics(fmt.Errorf(" looking up project with (ID: %s or Name: %s). Error: %+v", id, name, err))
 	}
 
 	err = flattenProject(clients, d, project)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf(" flattening project: %v", err))
+		return utils.ErrorDiagnostics(fmt.Errorf(" flattening project: %v", err))
 	}
 	return nil
 }
 
-func projectRead(clients *client.AggregatedClient, projectID string, projectName string) (*core.TeamProject, error) {
+func projectRead(clients *client.AggregatedClient, projectID string, projectName string, timeout time.Duration) (*core.TeamProject, error) {
 	identifier := projectID
 	if identifier == "" {
 		identifier = projectName
@@ -239,7 +239,7 @@ func projectRead(clients *client.AggregatedClient, projectID string, projectName
 			}
 			return project, "success", nil
 		},
-		Timeout: projectRetryTimeoutDuration * time.Minute,
+		Timeout: timeout,
 	}
 
 	if _, err := stateConf.WaitForStateContext(clients.Ctx); err != nil {
@@ -256,7 +256,7 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	clients := m.(*client.AggregatedClient)
 	project, err := expandProject(clients, d, false)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf(" converting terraform data model to AzDO project reference: %+v", err))
+		return utils.ErrorDiagnostics(fmt.Errorf(" converting terraform data model to AzDO project reference: %+v", err))
 	}
 
 	requiresUpdate := false
@@ -280,7 +280,7 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		log.Printf("[TRACE] resourceProjectUpdate: updating project")
 		err = updateProject(clients, project, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("Error updating project: %v", err))
+			return utils.ErrorDiagnostics(fmt.Errorf("Error updating project: %v", err))
 		}
 	}
 
@@ -304,7 +304,7 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 
 		err := updateProjectFeatureStates(clients.Ctx, clients.FeatureManagementClient, project.Id.String(), &featureStates)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.ErrorDiagnostics(err)
 		}
 	}
 
@@ -314,9 +314,9 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 func updateProject(clients *client.AggregatedClient, project *core.TeamProject, timeoutSeconds time.Duration) error {
 	var operationRef *operations.OperationReference
 
-	// project updates may fail if there is activity going on in the project. A retry can be employed
-	// to gracefully handle errors encountered for updates, up until a timeout is reached
-	err := resource.RetryContext(clients.Ctx, projectBusyTimeoutDuration*time.Minute, func() *resource.RetryError {
+	// project updates may fail if there is activity going on in the project. Those errors are retried
+	// up until a timeout is reached, all other errors are returned right away
+	err := resource.RetryContext(clients.Ctx, timeoutSeconds, func() *resource.RetryError {
 		var updateErr error
 		operationRef, updateErr = clients.CoreClient.UpdateProject(
 			clients.Ctx,
@@ -325,7 +325,7 @@ func updateProject(clients *client.AggregatedClient, project *core.TeamProject,
 				ProjectId:     project.Id,
 			})
 		if updateErr != nil {
-			return resource.RetryableError(updateErr)
+			return utils.RetryError(updateErr)
 		}
 		return nil
 	})
@@ -363,7 +363,7 @@ func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interf
 
 	err := deleteProject(clients, id, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
-		return diag.FromErr(fmt.Errorf(" deleting project: %v", err))
+		return utils.ErrorDiagnostics(fmt.Errorf(" deleting project: %v", err))
 	}
 
 	return nil
@@ -377,16 +377,16 @@ func deleteProject(clients *client.AggregatedClient, id string, timeoutSeconds t
 
 	var operationRef *operations.OperationReference
 
-	// project deletes may fail if there is activity going on in the project. A retry can be employed
-	// to gracefully handle errors encountered for deletes, up until a timeout is reached
-	err = resource.RetryContext(clients.Ctx, projectBusyTimeoutDuration*time.Minute, func() *resource.RetryError {
+	// project deletes may fail if there is activity going on in the project. Those errors are retried
+	// up until a timeout is reached, all other errors are returned right away
+	err = resource.RetryContext(clients.Ctx, timeoutSeconds, func() *resource.RetryError {
 		var deleteErr error
 		operationRef, deleteErr = clients.CoreClient.QueueDeleteProject(clients.Ctx, core.QueueDeleteProjectArgs{
 			ProjectId: &uuid,
 		})
 
 		if deleteErr != nil {
-			return resource.RetryableError(deleteErr)
+			return utils.RetryError(deleteErr)
 		}
 		return nil
 	})
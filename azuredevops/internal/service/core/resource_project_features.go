@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/featuremanagement"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
 )
 
@@ -87,7 +88,7 @@ func resourceProjectFeaturesCreateUpdate(ctx context.Context, d *schema.Resource
 
 	err := updateProjectFeatureStates(ctx, clients.FeatureManagementClient, projectID, &featureStates)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.ErrorDiagnostics(err)
 	}
 
 	d.SetId(projectID)
@@ -101,11 +102,11 @@ func resourceProjectFeaturesRead(ctx context.Context, d *schema.ResourceData, m
 	featureStates := d.Get("features").(map[string]interface{})
 	currentFeatureStates, err := getConfiguredProjectFeatureStates(ctx, clients.FeatureManagementClient, &featureStates, projectID)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.ErrorDiagnostics(err)
 	}
 	if currentFeatureStates == nil {
 		d.SetId("")
-		return diag.FromErr(fmt.Errorf(" failed to retrieve current feature states for project: %s", projectID))
+		return utils.ErrorDiagnostics(fmt.Errorf(" failed to retrieve current feature states for project: %s", projectID))
 	}
 	d.Set("features", currentFeatureStates)
 	return nil
@@ -121,7 +122,7 @@ func resourceProjectFeaturesDelete(ctx context.Context, d *schema.ResourceData,
 	}
 	err := updateProjectFeatureStates(ctx, clients.FeatureManagementClient, projectID, &featureStates)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.ErrorDiagnostics(err)
 	}
 
 	d.SetId("")
@@ -75,7 +75,7 @@ func resourceProjectPipelineSettingsCreateUpdate(ctx context.Context, d *schema.
 
 	err := configureProjectPipelineGeneralSettings(clients, projectID, d)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf(" creating/updating project build general settings: %v", err))
+		return utils.ErrorDiagnostics(fmt.Errorf(" creating/updating project build general settings: %v", err))
 	}
 	d.SetId(projectID)
 	return resourceProjectPipelineSettingsRead(ctx, d, m)
@@ -95,7 +95,7 @@ func resourceProjectPipelineSettingsRead(ctx context.Context, d *schema.Resource
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(fmt.Errorf("Error reading project build general settings: %v", err))
+		return utils.ErrorDiagnostics(fmt.Errorf("Error reading project build general settings: %v", err))
 	}
 
 	d.Set("project_id", projectId)
@@ -0,0 +1,55 @@
+//go:build (all || core || resource_project) && !exclude_resource_project
+// +build all core resource_project
+// +build !exclude_resource_project
+
+package core
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/testhelper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProject_Update_DoesNotRetryTerminalErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	clients := &client.AggregatedClient{CoreClient: coreClient, Ctx: context.Background()}
+
+	coreClient.
+		EXPECT().
+		UpdateProject(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(http.StatusBadRequest), Message: converter.String("invalid project")}).
+		Times(1)
+
+	err := updateProject(clients, &core.TeamProject{Id: testhelper.CreateUUID()}, time.Minute)
+	require.ErrorContains(t, err, "invalid project")
+}
+
+func TestProject_Delete_DoesNotRetryTerminalErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	clients := &client.AggregatedClient{CoreClient: coreClient, Ctx: context.Background()}
+
+	coreClient.
+		EXPECT().
+		QueueDeleteProject(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(http.StatusForbidden), Message: converter.String("access denied")}).
+		Times(1)
+
+	err := deleteProject(clients, testhelper.CreateUUID().String(), time.Minute)
+	require.ErrorContains(t, err, "access denied")
+}
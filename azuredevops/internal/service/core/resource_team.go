@@ -10,7 +10,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
-	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/graph"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/identity"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	securityhelper "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/permissions/utils"
@@ -150,10 +149,14 @@ func resourceTeamRead(d *schema.ResourceData, m interface{}) error {
 	projectID := d.Get("project_id").(string)
 	teamID := d.Id()
 
-	team, err := clients.CoreClient.GetTeam(clients.Ctx, core.GetTeamArgs{
-		ProjectId:      converter.String(projectID),
-		TeamId:         converter.String(teamID),
-		ExpandIdentity: converter.Bool(false),
+	var team *core.WebApiTeam
+	err := clients.ReadResource(d, func() (err error) {
+		team, err = clients.CoreClient.GetTeam(clients.Ctx, core.GetTeamArgs{
+			ProjectId:      converter.String(projectID),
+			TeamId:         converter.String(teamID),
+			ExpandIdentity: converter.Bool(false),
+		})
+		return err
 	})
 
 	if err != nil {
@@ -176,14 +179,12 @@ func resourceTeamRead(d *schema.ResourceData, m interface{}) error {
 
 	flattenTeam(d, team, members, administrators)
 
-	descriptor, err := clients.GraphClient.GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{
-		StorageKey: team.Id,
-	})
+	descriptor, err := clients.LookupDescriptor(*team.Id)
 	if err != nil {
 		return fmt.Errorf(" get team descriptor. Error: %+v", err)
 	}
 
-	d.Set("descriptor", descriptor.Value)
+	d.Set("descriptor", descriptor)
 	return nil
 }
 
@@ -353,6 +354,31 @@ func flattenTeam(d *schema.ResourceData, team *core.WebApiTeam, members *schema.
 	d.Set("members", members)
 }
 
+// getImportedTeam returns the team of an imported ID like <project name or ID>/<team name or ID>
+func getImportedTeam(d *schema.ResourceData, m interface{}) (*core.WebApiTeam, error) {
+	clients := m.(*client.AggregatedClient)
+
+	projectNameOrID, teamNameOrID, err := tfhelper.ParseImportedName(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the resource ID from the Terraform resource data: %v", err)
+	}
+
+	projectID, err := tfhelper.GetRealProjectId(projectNameOrID, m)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := clients.CoreClient.GetTeam(clients.Ctx, core.GetTeamArgs{
+		ProjectId:      converter.String(projectID),
+		TeamId:         converter.String(teamNameOrID),
+		ExpandIdentity: converter.Bool(false),
+	})
+	if err != nil {
+		return nil, fmt.Errorf(" reading team %s of project %s: %w", teamNameOrID, projectNameOrID, err)
+	}
+	return team, nil
+}
+
 func readTeamMembers(clients *client.AggregatedClient, team *core.WebApiTeam) (*schema.Set, error) {
 	members, err := clients.IdentityClient.ReadMembers(clients.Ctx, identity.ReadMembersArgs{
 		ContainerId: converter.String(team.Id.String()),
@@ -400,23 +426,9 @@ func getIdentitiesFromSubjects(clients *client.AggregatedClient, query linq.Quer
 		return &[]identity.Identity{}, nil
 	}
 
-	discriptors := query.
-		Aggregate(func(r interface{}, i interface{}) interface{} {
-			if r.(string) == "" {
-				return i
-			}
-			return r.(string) + "," + i.(string)
-		}).(string)
-
-	idlist, err := clients.IdentityClient.ReadIdentities(clients.Ctx, identity.ReadIdentitiesArgs{
-		SubjectDescriptors: converter.String(discriptors),
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	return idlist, err
+	var descriptors []string
+	query.ToSlice(&descriptors)
+	return clients.LookupIdentitiesBySubjectDescriptors(descriptors)
 }
 
 func removeTeamMembers(clients *client.AggregatedClient, team *core.WebApiTeam, query linq.Query) error {
@@ -24,6 +24,9 @@ func ResourceTeamAdministrators() *schema.Resource {
 		Read:   resourceTeamAdministratorsRead,
 		Update: resourceTeamAdministratorsUpdate,
 		Delete: resourceTeamAdministratorsDelete,
+		Importer: &schema.ResourceImporter{
+			State: importTeamAdministrators,
+		},
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:         schema.TypeString,
@@ -220,3 +223,26 @@ func resourceTeamAdministratorsDelete(d *schema.ResourceData, m interface{}) err
 	d.SetId("")
 	return nil
 }
+
+// importTeamAdministrators imports the administrators of a team by an ID like <project name or ID>/<team name or ID>.
+// All current administrators of the team are imported.
+func importTeamAdministrators(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	clients := m.(*client.AggregatedClient)
+
+	team, err := getImportedTeam(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	administrators, err := readTeamAdministrators(d, clients, team)
+	if err != nil {
+		return nil, fmt.Errorf(" reading the administrators of team %s: %w", *team.Name, err)
+	}
+
+	d.Set("project_id", team.ProjectId.String())
+	d.Set("team_id", team.Id.String())
+	d.Set("mode", "add")
+	d.Set("administrators", administrators)
+	d.SetId(fmt.Sprintf("%d", rand.Int()))
+	return []*schema.ResourceData{d}, nil
+}
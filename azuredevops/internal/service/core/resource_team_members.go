@@ -25,6 +25,9 @@ func ResourceTeamMembers() *schema.Resource {
 		Read:   resourceTeamMembersRead,
 		Update: resourceTeamMembersUpdate,
 		Delete: resourceTeamMembersDelete,
+		Importer: &schema.ResourceImporter{
+			State: importTeamMembers,
+		},
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:         schema.TypeString,
@@ -316,3 +319,26 @@ func resourceTeamMembersDelete(d *schema.ResourceData, m interface{}) error {
 	d.SetId("")
 	return nil
 }
+
+// importTeamMembers imports the members of a team by an ID like <project name or ID>/<team name or ID>. All current
+// members of the team are imported.
+func importTeamMembers(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	clients := m.(*client.AggregatedClient)
+
+	team, err := getImportedTeam(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := readTeamMembers(clients, team)
+	if err != nil {
+		return nil, fmt.Errorf(" reading the members of team %s: %w", *team.Name, err)
+	}
+
+	d.Set("project_id", team.ProjectId.String())
+	d.Set("team_id", team.Id.String())
+	d.Set("mode", "add")
+	d.Set("members", members)
+	d.SetId(fmt.Sprintf("%d", rand.Int()))
+	return []*schema.ResourceData{d}, nil
+}
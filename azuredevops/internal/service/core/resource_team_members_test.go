@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
@@ -155,3 +156,43 @@ func TestTeamMembers_Delete_DontSwallowError(t *testing.T) {
 	require.NotNil(t, err)
 	require.Contains(t, err.Error(), errMsg)
 }
+
+func TestTeamMembers_Import_DontSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	clients := &client.AggregatedClient{
+		CoreClient: coreClient,
+		Ctx:        context.Background(),
+	}
+
+	testProjectID := uuid.New()
+	errMsg := "@@GetTeam@@failed@@"
+
+	coreClient.
+		EXPECT().
+		GetTeam(clients.Ctx, core.GetTeamArgs{
+			ProjectId:      converter.String(testProjectID.String()),
+			TeamId:         converter.String("Example Team"),
+			ExpandIdentity: converter.Bool(false),
+		}).
+		Return(nil, fmt.Errorf(errMsg)).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceTeamMembers().Schema, nil)
+	resourceData.SetId(testProjectID.String() + "/Example Team")
+	_, err := importTeamMembers(resourceData, clients)
+
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), errMsg)
+}
+
+func TestTeamMembers_Import_RejectsMalformedID(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceTeamMembers().Schema, nil)
+	resourceData.SetId(uuid.New().String())
+	_, err := importTeamMembers(resourceData, &client.AggregatedClient{Ctx: context.Background()})
+
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "unexpected format of ID")
+}
@@ -0,0 +1,65 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+//https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/state-migration
+
+func FeedSchemaV0ToV1() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"features": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"permanent_delete": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"restore": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// FeedStateUpgradeV0ToV1 copies the `features` block into the `permanent_delete` and `restore` arguments
+// which replace it. The block is kept, so that configurations still using it do not change.
+func FeedStateUpgradeV0ToV1() schema.StateUpgradeFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		rawState["permanent_delete"] = false
+		rawState["restore"] = false
+
+		if features, ok := rawState["features"].([]interface{}); ok && len(features) > 0 {
+			if feature, ok := features[0].(map[string]interface{}); ok {
+				for _, key := range []string{"permanent_delete", "restore"} {
+					if v, ok := feature[key].(bool); ok {
+						rawState[key] = v
+					}
+				}
+			}
+		}
+
+		return rawState, nil
+	}
+}
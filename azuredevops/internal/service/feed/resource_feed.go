@@ -2,14 +2,17 @@ package feed
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/feed"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/feed/migration"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
 )
 
 func ResourceFeed() *schema.Resource {
@@ -18,6 +21,17 @@ func ResourceFeed() *schema.Resource {
 		Read:   resourceFeedRead,
 		Update: resourceFeedUpdate,
 		Delete: resourceFeedDelete,
+		Importer: &schema.ResourceImporter{
+			State: importFeed,
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    migration.FeedSchemaV0ToV1().CoreConfigSchema().ImpliedType(),
+				Upgrade: migration.FeedStateUpgradeV0ToV1(),
+				Version: 0,
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -31,9 +45,24 @@ func ResourceFeed() *schema.Resource {
 				Optional:     true,
 				ForceNew:     true,
 			},
+			"permanent_delete": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				ConflictsWith:    []string{"features"},
+				DiffSuppressFunc: suppressDiffWithFeedFeatures,
+			},
+			"restore": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				ConflictsWith:    []string{"features"},
+				DiffSuppressFunc: suppressDiffWithFeedFeatures,
+			},
 			"features": {
-				Type:     schema.TypeList,
-				Optional: true,
+				Type:       schema.TypeList,
+				Optional:   true,
+				Deprecated: "`features` has been deprecated in favor of the `permanent_delete` and `restore` arguments",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"permanent_delete": {
@@ -90,9 +119,13 @@ func resourceFeedRead(d *schema.ResourceData, m interface{}) error {
 	name := d.Get("name").(string)
 	projectId := d.Get("project_id").(string)
 
-	getFeed, err := clients.FeedClient.GetFeed(clients.Ctx, feed.GetFeedArgs{
-		FeedId:  &name,
-		Project: &projectId,
+	var getFeed *feed.Feed
+	err := clients.ReadResource(d, func() (err error) {
+		getFeed, err = clients.FeedClient.GetFeed(clients.Ctx, feed.GetFeedArgs{
+			FeedId:  &name,
+			Project: &projectId,
+		})
+		return err
 	})
 
 	if err != nil {
@@ -197,10 +230,46 @@ func restoreFeed(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// feedFeatures returns the features of the deprecated `features` block when it is configured, the
+// `permanent_delete` and `restore` arguments otherwise
 func feedFeatures(d *schema.ResourceData) map[string]interface{} {
 	features := d.Get("features").([]interface{})
-	if len(features) != 0 {
+	if len(features) != 0 && features[0] != nil {
 		return features[0].(map[string]interface{})
 	}
-	return map[string]interface{}{}
+	return map[string]interface{}{
+		"permanent_delete": d.Get("permanent_delete").(bool),
+		"restore":          d.Get("restore").(bool),
+	}
+}
+
+// suppressDiffWithFeedFeatures suppresses the diff of the `permanent_delete` and `restore` arguments while the
+// deprecated `features` block is configured, as the upgraded state holds the values of the block
+func suppressDiffWithFeedFeatures(_, _, _ string, d *schema.ResourceData) bool {
+	features := d.Get("features").([]interface{})
+	return len(features) != 0 && features[0] != nil
+}
+
+// importFeed imports a feed by an ID that looks like one of the following:
+//
+//	<feed name or ID>
+//	<project name or ID>/<feed name or ID>
+func importFeed(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	projectNameOrID, feedNameOrID, projectScoped := strings.Cut(d.Id(), "/")
+	if !projectScoped {
+		projectNameOrID, feedNameOrID = "", projectNameOrID
+	}
+	if feedNameOrID == "" || (projectScoped && projectNameOrID == "") {
+		return nil, fmt.Errorf(" unexpected format of ID (%s), expected <feed name or ID> or <project name or ID>/<feed name or ID>", d.Id())
+	}
+
+	if projectScoped {
+		projectID, err := tfhelper.GetRealProjectId(projectNameOrID, m)
+		if err != nil {
+			return nil, err
+		}
+		d.Set("project_id", projectID)
+	}
+	d.Set("name", feedNameOrID)
+	return []*schema.ResourceData{d}, nil
 }
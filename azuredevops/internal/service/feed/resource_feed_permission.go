@@ -3,17 +3,19 @@ package feed
 import (
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/feed"
-	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/graph"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/identity"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
 )
 
 func ResourceFeedPermission() *schema.Resource {
@@ -22,6 +24,9 @@ func ResourceFeedPermission() *schema.Resource {
 		Read:   resourceFeedPermissionRead,
 		Update: resourceFeedPermissionUpdate,
 		Delete: resourceFeedPermissionDelete,
+		Importer: &schema.ResourceImporter{
+			State: importFeedPermission,
+		},
 		Schema: map[string]*schema.Schema{
 			"feed_id": {
 				Type:         schema.TypeString,
@@ -195,19 +200,18 @@ func resourceFeedPermissionDelete(d *schema.ResourceData, m interface{}) error {
 }
 
 func getIdentity(d *schema.ResourceData, m interface{}) (*identity.Identity, error) {
-	clients := m.(*client.AggregatedClient)
-	identityDescriptor := d.Get("identity_descriptor").(string)
-
-	storageKey, err := clients.GraphClient.GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{
-		SubjectDescriptor: &identityDescriptor,
-	})
+	return lookupIdentity(m.(*client.AggregatedClient), d.Get("identity_descriptor").(string))
+}
 
+// lookupIdentity looks up the identity of a subject descriptor
+func lookupIdentity(clients *client.AggregatedClient, identityDescriptor string) (*identity.Identity, error) {
+	storageKey, err := clients.LookupStorageKey(identityDescriptor)
 	if err != nil {
 		return nil, err
 	}
 
 	response, err := clients.IdentityClient.ReadIdentity(clients.Ctx, identity.ReadIdentityArgs{
-		IdentityId: converter.String((*storageKey.Value).String()),
+		IdentityId: converter.String(storageKey.String()),
 	})
 
 	if err != nil {
@@ -252,3 +256,56 @@ func getFeedPermission(d *schema.ResourceData, m interface{}) (*feed.FeedPermiss
 		Message:    &message,
 	}
 }
+
+// importFeedPermission imports the permission of an identity on a feed by an ID that looks like one of the following:
+//
+//	<feed name or ID>/<identity descriptor>
+//	<project name or ID>/<feed name or ID>/<identity descriptor>
+func importFeedPermission(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	projectID, feedID, identityDescriptor, err := parseFeedPermissionImportID(d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("project_id", projectID)
+	d.Set("feed_id", feedID)
+	d.Set("identity_descriptor", identityDescriptor)
+
+	id, _ := uuid.NewUUID()
+	d.SetId(fmt.Sprintf("fp-%s", id.String()))
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseFeedPermissionImportID returns the project ID, the feed ID and the identity descriptor of the imported
+// permissions of an identity on a feed. The project ID is empty for organization scoped feeds.
+func parseFeedPermissionImportID(importID string, m interface{}) (string, string, string, error) {
+	clients := m.(*client.AggregatedClient)
+
+	parts := strings.Split(importID, "/")
+	if len(parts) < 2 || len(parts) > 3 || slices.Contains(parts, "") {
+		return "", "", "", fmt.Errorf(" unexpected format of ID (%s), expected <feed name or ID>/<identity descriptor> or <project name or ID>/<feed name or ID>/<identity descriptor>", importID)
+	}
+
+	projectID := ""
+	if len(parts) == 3 {
+		var err error
+		if projectID, err = tfhelper.GetRealProjectId(parts[0], m); err != nil {
+			return "", "", "", err
+		}
+		parts = parts[1:]
+	}
+
+	// feeds are referenced by their ID
+	feedNameOrID := parts[0]
+	if _, err := uuid.Parse(feedNameOrID); err != nil {
+		existingFeed, err := clients.FeedClient.GetFeed(clients.Ctx, feed.GetFeedArgs{
+			FeedId:  &feedNameOrID,
+			Project: &projectID,
+		})
+		if err != nil {
+			return "", "", "", fmt.Errorf(" reading feed %s: %w", feedNameOrID, err)
+		}
+		feedNameOrID = existingFeed.Id.String()
+	}
+	return projectID, feedNameOrID, parts[1], nil
+}
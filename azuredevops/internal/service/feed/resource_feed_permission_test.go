@@ -335,3 +335,60 @@ func TestFeedPermission_Delete_DoesNotSwallowError(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "Something unexpected happened")
 }
+
+func TestFeedPermission_Import_ResolvesFeedName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	feedClient := azdosdkmocks.NewMockFeedClient(ctrl)
+	clients := &client.AggregatedClient{
+		FeedClient: feedClient,
+		Ctx:        context.Background(),
+	}
+
+	feedName := "some-feed"
+	feedID := uuid.MustParse(FeedId)
+	feedClient.
+		EXPECT().
+		GetFeed(clients.Ctx, feed.GetFeedArgs{
+			FeedId:  &feedName,
+			Project: &ProjectId,
+		}).
+		Return(&feed.Feed{Id: &feedID}, nil).
+		Times(1)
+
+	r := ResourceFeedPermission()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	resourceData.SetId(fmt.Sprintf("%s/%s/%s", ProjectId, feedName, IdentityDescriptor))
+
+	result, err := r.Importer.State(resourceData, clients)
+	require.Nil(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, FeedId, result[0].Get("feed_id"))
+	require.Equal(t, ProjectId, result[0].Get("project_id"))
+	require.Equal(t, IdentityDescriptor, result[0].Get("identity_descriptor"))
+	require.Regexp(t, "^fp-", result[0].Id())
+}
+
+func TestFeedPermission_Import_OrganizationFeedByID(t *testing.T) {
+	r := ResourceFeedPermission()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	resourceData.SetId(fmt.Sprintf("%s/%s", FeedId, IdentityDescriptor))
+
+	result, err := r.Importer.State(resourceData, &client.AggregatedClient{Ctx: context.Background()})
+	require.Nil(t, err)
+	require.Equal(t, FeedId, result[0].Get("feed_id"))
+	require.Equal(t, "", result[0].Get("project_id"))
+	require.Equal(t, IdentityDescriptor, result[0].Get("identity_descriptor"))
+}
+
+func TestFeedPermission_Import_InvalidID(t *testing.T) {
+	r := ResourceFeedPermission()
+	for _, id := range []string{FeedId, FeedId + "/", "a/b/c/d"} {
+		resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+		resourceData.SetId(id)
+
+		_, err := r.Importer.State(resourceData, &client.AggregatedClient{Ctx: context.Background()})
+		require.ErrorContains(t, err, "unexpected format of ID")
+	}
+}
@@ -0,0 +1,299 @@
+package feed
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/feed"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/identity"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// ResourceFeedPermissions schema and implementation for the roles of many identities on many feeds. The roles
+// of all identities are written to a feed with a single request.
+func ResourceFeedPermissions() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceFeedPermissionsCreateOrUpdate,
+		Read:   resourceFeedPermissionsRead,
+		Update: resourceFeedPermissionsCreateOrUpdate,
+		Delete: resourceFeedPermissionsDelete,
+		Importer: &schema.ResourceImporter{
+			State: importFeedPermissions,
+		},
+		Schema: map[string]*schema.Schema{
+			"feed_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsUUID,
+				},
+			},
+			"project_id": {
+				Type:         schema.TypeString,
+				ValidateFunc: validation.IsUUID,
+				Optional:     true,
+				ForceNew:     true,
+			},
+			"permission": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identity_descriptor": {
+							Type:         schema.TypeString,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Required:     true,
+						},
+						"role": {
+							Type: schema.TypeString,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(feed.FeedRoleValues.Reader),
+								string(feed.FeedRoleValues.Contributor),
+								string(feed.FeedRoleValues.Administrator),
+								string(feed.FeedRoleValues.Collaborator),
+							}, false),
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceFeedPermissionsCreateOrUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	roles := expandFeedPermissionRoles(d.Get("permission").(*schema.Set))
+	// identities which are no longer configured lose their role on the feeds they remain on
+	oldPermissions, _ := d.GetChange("permission")
+	for descriptor := range expandFeedPermissionRoles(oldPermissions.(*schema.Set)) {
+		if _, ok := roles[descriptor]; !ok {
+			roles[descriptor] = feed.FeedRoleValues.None
+		}
+	}
+	identities, err := lookupFeedIdentities(clients, roles)
+	if err != nil {
+		return err
+	}
+
+	oldFeedIDs, newFeedIDs := d.GetChange("feed_ids")
+	for _, feedID := range tfhelper.ExpandStringSet(oldFeedIDs.(*schema.Set).Difference(newFeedIDs.(*schema.Set))) {
+		if err := setFeedPermissions(clients, projectID, feedID, identities, noFeedRoles(roles)); err != nil {
+			return err
+		}
+	}
+	for _, feedID := range tfhelper.ExpandStringSet(newFeedIDs.(*schema.Set)) {
+		if err := setFeedPermissions(clients, projectID, feedID, identities, roles); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() == "" {
+		id, _ := uuid.NewUUID()
+		d.SetId(fmt.Sprintf("fps-%s", id.String()))
+	}
+	return resourceFeedPermissionsRead(d, m)
+}
+
+func resourceFeedPermissionsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	configured := expandFeedPermissionRoles(d.Get("permission").(*schema.Set))
+	identities, err := lookupFeedIdentities(clients, configured)
+	if err != nil {
+		return err
+	}
+
+	roles := expandFeedPermissionRoles(d.Get("permission").(*schema.Set))
+	feedIDs := []interface{}{}
+	for _, feedID := range tfhelper.ExpandStringSet(d.Get("feed_ids").(*schema.Set)) {
+		permissions, err := clients.FeedClient.GetFeedPermissions(clients.Ctx, feed.GetFeedPermissionsArgs{
+			FeedId:  &feedID,
+			Project: &projectID,
+		})
+		if err != nil {
+			if utils.ResponseWasNotFound(err) {
+				log.Printf("[INFO] plugin.terraform-provider-azuredevops: Feed %s of feed permissions %s was not found, removing it from the state", feedID, d.Id())
+				continue
+			}
+			return fmt.Errorf(" reading permissions of feed %s: %+v", feedID, err)
+		}
+		feedIDs = append(feedIDs, feedID)
+
+		current := map[string]feed.FeedRole{}
+		if permissions != nil {
+			for _, permission := range *permissions {
+				if permission.IdentityDescriptor != nil && permission.Role != nil {
+					current[*permission.IdentityDescriptor] = *permission.Role
+				}
+			}
+		}
+		// a role which differs on any feed is reported, so that the next apply sets it again
+		for descriptor, role := range configured {
+			currentRole, ok := current[*identities[descriptor].Descriptor]
+			if !ok {
+				delete(roles, descriptor)
+			} else if currentRole != role {
+				roles[descriptor] = currentRole
+			}
+		}
+	}
+
+	if len(feedIDs) == 0 {
+		d.SetId("")
+		return nil
+	}
+	d.Set("feed_ids", feedIDs)
+	d.Set("permission", flattenFeedPermissionRoles(roles))
+	return nil
+}
+
+func resourceFeedPermissionsDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	roles := noFeedRoles(expandFeedPermissionRoles(d.Get("permission").(*schema.Set)))
+	identities, err := lookupFeedIdentities(clients, roles)
+	if err != nil {
+		return err
+	}
+
+	for _, feedID := range tfhelper.ExpandStringSet(d.Get("feed_ids").(*schema.Set)) {
+		err := setFeedPermissions(clients, projectID, feedID, identities, roles)
+		if err != nil && !utils.ResponseWasNotFound(err) {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// importFeedPermissions imports the role of an identity on a feed by an ID that looks like one of the following:
+//
+//	<feed name or ID>/<identity descriptor>
+//	<project name or ID>/<feed name or ID>/<identity descriptor>
+func importFeedPermissions(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	clients := m.(*client.AggregatedClient)
+
+	projectID, feedID, identityDescriptor, err := parseFeedPermissionImportID(d.Id(), m)
+	if err != nil {
+		return nil, err
+	}
+
+	identities, err := lookupFeedIdentities(clients, map[string]feed.FeedRole{identityDescriptor: feed.FeedRoleValues.None})
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := clients.FeedClient.GetFeedPermissions(clients.Ctx, feed.GetFeedPermissionsArgs{
+		FeedId:  &feedID,
+		Project: &projectID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(" reading permissions of feed %s: %w", feedID, err)
+	}
+
+	var role *feed.FeedRole
+	if permissions != nil {
+		for _, permission := range *permissions {
+			if permission.IdentityDescriptor != nil && *permission.IdentityDescriptor == *identities[identityDescriptor].Descriptor {
+				role = permission.Role
+			}
+		}
+	}
+	if role == nil {
+		return nil, fmt.Errorf(" identity %s has no role on feed %s", identityDescriptor, feedID)
+	}
+
+	d.Set("project_id", projectID)
+	d.Set("feed_ids", []interface{}{feedID})
+	d.Set("permission", flattenFeedPermissionRoles(map[string]feed.FeedRole{identityDescriptor: *role}))
+
+	id, _ := uuid.NewUUID()
+	d.SetId(fmt.Sprintf("fps-%s", id.String()))
+	return []*schema.ResourceData{d}, nil
+}
+
+// setFeedPermissions writes the roles of all identities to a feed with a single request
+func setFeedPermissions(clients *client.AggregatedClient, projectID string, feedID string, identities map[string]*identity.Identity, roles map[string]feed.FeedRole) error {
+	permissions := make([]feed.FeedPermission, 0, len(roles))
+	for descriptor, role := range roles {
+		role := role
+		permission := feed.FeedPermission{
+			IdentityDescriptor: identities[descriptor].Descriptor,
+			Role:               &role,
+		}
+		if role != feed.FeedRoleValues.None {
+			permission.IdentityId = identities[descriptor].Id
+		}
+		permissions = append(permissions, permission)
+	}
+
+	_, err := clients.FeedClient.SetFeedPermissions(clients.Ctx, feed.SetFeedPermissionsArgs{
+		FeedId:         &feedID,
+		Project:        &projectID,
+		FeedPermission: &permissions,
+	})
+	if err != nil {
+		return fmt.Errorf(" setting permissions of feed %s: %+v", feedID, err)
+	}
+	return nil
+}
+
+// lookupFeedIdentities looks up the identities of the subject descriptors, feeds reference identities by
+// their legacy descriptors
+func lookupFeedIdentities(clients *client.AggregatedClient, roles map[string]feed.FeedRole) (map[string]*identity.Identity, error) {
+	identities := make(map[string]*identity.Identity, len(roles))
+	for descriptor := range roles {
+		identityResponse, err := lookupIdentity(clients, descriptor)
+		if err != nil {
+			return nil, fmt.Errorf(" looking up identity %s: %+v", descriptor, err)
+		}
+		if identityResponse == nil || identityResponse.Descriptor == nil {
+			return nil, fmt.Errorf(" identity %s was not found", descriptor)
+		}
+		identities[descriptor] = identityResponse
+	}
+	return identities, nil
+}
+
+func expandFeedPermissionRoles(permissions *schema.Set) map[string]feed.FeedRole {
+	roles := make(map[string]feed.FeedRole, permissions.Len())
+	for _, item := range permissions.List() {
+		permission := item.(map[string]interface{})
+		roles[permission["identity_descriptor"].(string)] = feed.FeedRole(permission["role"].(string))
+	}
+	return roles
+}
+
+func flattenFeedPermissionRoles(roles map[string]feed.FeedRole) []interface{} {
+	permissions := make([]interface{}, 0, len(roles))
+	for descriptor, role := range roles {
+		permissions = append(permissions, map[string]interface{}{
+			"identity_descriptor": descriptor,
+			"role":                string(role),
+		})
+	}
+	return permissions
+}
+
+// noFeedRoles returns the roles which remove the identities from a feed
+func noFeedRoles(roles map[string]feed.FeedRole) map[string]feed.FeedRole {
+	none := make(map[string]feed.FeedRole, len(roles))
+	for descriptor := range roles {
+		none[descriptor] = feed.FeedRoleValues.None
+	}
+	return none
+}
@@ -0,0 +1,190 @@
+//go:build (all || resource_feed) && !exclude_feed
+// +build all resource_feed
+// +build !exclude_feed
+
+package feed
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/feed"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/graph"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/identity"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/stretchr/testify/require"
+)
+
+var otherFeedID = uuid.New().String()
+
+func feedPermissionsConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"feed_ids":   []interface{}{FeedId, otherFeedID},
+		"project_id": ProjectId,
+		"permission": []interface{}{
+			map[string]interface{}{"identity_descriptor": IdentityDescriptor, "role": Role},
+		},
+	}
+}
+
+func mockFeedPermissionsClients(ctrl *gomock.Controller) (*client.AggregatedClient, *azdosdkmocks.MockFeedClient) {
+	feedClient := azdosdkmocks.NewMockFeedClient(ctrl)
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	identityClient := azdosdkmocks.NewMockIdentityClient(ctrl)
+	clients := &client.AggregatedClient{
+		FeedClient:     feedClient,
+		IdentityClient: identityClient,
+		GraphClient:    graphClient,
+		Ctx:            context.Background(),
+	}
+
+	graphClient.EXPECT().
+		GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{SubjectDescriptor: &IdentityDescriptor}).
+		Return(&graph.GraphStorageKeyResult{Value: &IdentityId}, nil).
+		AnyTimes()
+	identityClient.EXPECT().
+		ReadIdentity(clients.Ctx, gomock.Any()).
+		Return(&identity.Identity{Id: &IdentityId, Descriptor: &IdentityLegacyDescriptor}, nil).
+		AnyTimes()
+	return clients, feedClient
+}
+
+func TestFeedPermissions_Create_SetsRolesOfFeedsWithOneRequestEach(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, feedClient := mockFeedPermissionsClients(ctrl)
+	d := schema.TestResourceDataRaw(t, ResourceFeedPermissions().Schema, feedPermissionsConfig())
+
+	reader := feed.FeedRoleValues.Reader
+	for _, feedID := range []string{FeedId, otherFeedID} {
+		feedID := feedID
+		feedClient.EXPECT().
+			SetFeedPermissions(clients.Ctx, feed.SetFeedPermissionsArgs{
+				FeedId:  &feedID,
+				Project: &ProjectId,
+				FeedPermission: &[]feed.FeedPermission{
+					{IdentityDescriptor: &IdentityLegacyDescriptor, IdentityId: &IdentityId, Role: &reader},
+				},
+			}).
+			Return(nil, nil).
+			Times(1)
+		feedClient.EXPECT().
+			GetFeedPermissions(clients.Ctx, feed.GetFeedPermissionsArgs{FeedId: &feedID, Project: &ProjectId}).
+			Return(&[]feed.FeedPermission{{IdentityDescriptor: &IdentityLegacyDescriptor, Role: &reader}}, nil).
+			Times(1)
+	}
+
+	require.Nil(t, resourceFeedPermissionsCreateOrUpdate(d, clients))
+	require.NotEmpty(t, d.Id())
+	require.Equal(t, 2, d.Get("feed_ids").(*schema.Set).Len())
+}
+
+func TestFeedPermissions_Read_ReportsRolesWhichDifferOnAnyFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, feedClient := mockFeedPermissionsClients(ctrl)
+	d := schema.TestResourceDataRaw(t, ResourceFeedPermissions().Schema, feedPermissionsConfig())
+	d.SetId("fps-id")
+
+	reader := feed.FeedRoleValues.Reader
+	contributor := feed.FeedRoleValues.Contributor
+	feedClient.EXPECT().
+		GetFeedPermissions(clients.Ctx, feed.GetFeedPermissionsArgs{FeedId: &FeedId, Project: &ProjectId}).
+		Return(&[]feed.FeedPermission{{IdentityDescriptor: &IdentityLegacyDescriptor, Role: &reader}}, nil).
+		Times(1)
+	feedClient.EXPECT().
+		GetFeedPermissions(clients.Ctx, feed.GetFeedPermissionsArgs{FeedId: &otherFeedID, Project: &ProjectId}).
+		Return(&[]feed.FeedPermission{{IdentityDescriptor: &IdentityLegacyDescriptor, Role: &contributor}}, nil).
+		Times(1)
+
+	require.Nil(t, resourceFeedPermissionsRead(d, clients))
+	permissions := d.Get("permission").(*schema.Set).List()
+	require.Len(t, permissions, 1)
+	require.Equal(t, "contributor", permissions[0].(map[string]interface{})["role"])
+}
+
+func TestFeedPermissions_Delete_RemovesRolesFromAllFeeds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, feedClient := mockFeedPermissionsClients(ctrl)
+	d := schema.TestResourceDataRaw(t, ResourceFeedPermissions().Schema, feedPermissionsConfig())
+	d.SetId("fps-id")
+
+	feedClient.EXPECT().
+		SetFeedPermissions(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args feed.SetFeedPermissionsArgs) (*[]feed.FeedPermission, error) {
+			require.Len(t, *args.FeedPermission, 1)
+			require.Equal(t, feed.FeedRoleValues.None, *(*args.FeedPermission)[0].Role)
+			return nil, nil
+		}).
+		Times(2)
+
+	require.Nil(t, resourceFeedPermissionsDelete(d, clients))
+	require.Empty(t, d.Id())
+}
+
+func TestFeedPermissions_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, feedClient := mockFeedPermissionsClients(ctrl)
+	d := schema.TestResourceDataRaw(t, ResourceFeedPermissions().Schema, feedPermissionsConfig())
+
+	feedClient.EXPECT().
+		SetFeedPermissions(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("SetFeedPermissions() Failed")).
+		Times(1)
+
+	err := resourceFeedPermissionsCreateOrUpdate(d, clients)
+	require.Contains(t, err.Error(), "SetFeedPermissions() Failed")
+}
+
+func TestFeedPermissions_Import_ImportsRoleOfIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, feedClient := mockFeedPermissionsClients(ctrl)
+	d := schema.TestResourceDataRaw(t, ResourceFeedPermissions().Schema, nil)
+	d.SetId(ProjectId + "/" + FeedId + "/" + IdentityDescriptor)
+
+	contributor := feed.FeedRoleValues.Contributor
+	feedClient.EXPECT().
+		GetFeedPermissions(clients.Ctx, feed.GetFeedPermissionsArgs{FeedId: &FeedId, Project: &ProjectId}).
+		Return(&[]feed.FeedPermission{{IdentityDescriptor: &IdentityLegacyDescriptor, Role: &contributor}}, nil).
+		Times(1)
+
+	imported, err := importFeedPermissions(d, clients)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	require.Equal(t, ProjectId, imported[0].Get("project_id"))
+	require.Equal(t, []interface{}{FeedId}, imported[0].Get("feed_ids").(*schema.Set).List())
+	permissions := imported[0].Get("permission").(*schema.Set).List()
+	require.Len(t, permissions, 1)
+	require.Equal(t, IdentityDescriptor, permissions[0].(map[string]interface{})["identity_descriptor"])
+	require.Equal(t, "contributor", permissions[0].(map[string]interface{})["role"])
+}
+
+func TestFeedPermissions_Import_ErrorsWithoutRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, feedClient := mockFeedPermissionsClients(ctrl)
+	d := schema.TestResourceDataRaw(t, ResourceFeedPermissions().Schema, nil)
+	d.SetId(ProjectId + "/" + FeedId + "/" + IdentityDescriptor)
+
+	feedClient.EXPECT().
+		GetFeedPermissions(clients.Ctx, feed.GetFeedPermissionsArgs{FeedId: &FeedId, Project: &ProjectId}).
+		Return(&[]feed.FeedPermission{}, nil).
+		Times(1)
+
+	_, err := importFeedPermissions(d, clients)
+	require.ErrorContains(t, err, "has no role on feed")
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/feed"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/stretchr/testify/require"
@@ -114,3 +115,87 @@ func TestFeed_Delete_DoesNotSwallowError(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "Feed with given name not found")
 }
+
+func TestFeed_Import_OrganizationFeed(t *testing.T) {
+	r := ResourceFeed()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	resourceData.SetId(FeedName)
+
+	result, err := r.Importer.State(resourceData, &client.AggregatedClient{Ctx: context.Background()})
+	require.Nil(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, FeedName, result[0].Get("name"))
+	require.Equal(t, "", result[0].Get("project_id"))
+}
+
+func TestFeed_Import_ProjectFeed(t *testing.T) {
+	r := ResourceFeed()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	resourceData.SetId(FeedProjectId + "/" + FeedName)
+
+	result, err := r.Importer.State(resourceData, &client.AggregatedClient{Ctx: context.Background()})
+	require.Nil(t, err)
+	require.Equal(t, FeedName, result[0].Get("name"))
+	require.Equal(t, FeedProjectId, result[0].Get("project_id"))
+}
+
+func TestFeed_Import_InvalidID(t *testing.T) {
+	r := ResourceFeed()
+	for _, id := range []string{"/" + FeedName, FeedProjectId + "/"} {
+		resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+		resourceData.SetId(id)
+
+		_, err := r.Importer.State(resourceData, &client.AggregatedClient{Ctx: context.Background()})
+		require.ErrorContains(t, err, "unexpected format of ID")
+	}
+}
+
+func TestFeed_StateUpgradeV0ToV1(t *testing.T) {
+	r := ResourceFeed()
+	require.Equal(t, 1, r.SchemaVersion)
+	upgrade := r.StateUpgraders[0].Upgrade
+
+	state, err := upgrade(context.Background(), map[string]interface{}{
+		"name": FeedName,
+		"features": []interface{}{
+			map[string]interface{}{"permanent_delete": true, "restore": false},
+		},
+	}, nil)
+	require.Nil(t, err)
+	require.Equal(t, true, state["permanent_delete"])
+	require.Equal(t, false, state["restore"])
+	require.Len(t, state["features"], 1)
+
+	state, err = upgrade(context.Background(), map[string]interface{}{"name": FeedName}, nil)
+	require.Nil(t, err)
+	require.Equal(t, false, state["permanent_delete"])
+	require.Equal(t, false, state["restore"])
+}
+
+func TestFeed_Diff_UnsetsPermanentDeleteAndRestore(t *testing.T) {
+	ctx := context.Background()
+	feedID := uuid.New().String()
+	state := &terraform.InstanceState{
+		ID: feedID,
+		Attributes: map[string]string{
+			"id":               feedID,
+			"name":             FeedName,
+			"permanent_delete": "true",
+			"restore":          "true",
+		},
+	}
+
+	diff, err := ResourceFeed().Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{"name": FeedName}), nil)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	require.Equal(t, "false", diff.Attributes["permanent_delete"].New)
+	require.Equal(t, "false", diff.Attributes["restore"].New)
+
+	diff, err = ResourceFeed().Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     FeedName,
+		"features": []interface{}{map[string]interface{}{"permanent_delete": true, "restore": true}},
+	}), nil)
+	require.NoError(t, err)
+	require.Nil(t, diff.Attributes["permanent_delete"])
+	require.Nil(t, diff.Attributes["restore"])
+}
@@ -5,10 +5,12 @@ import (
 	"encoding/base64"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
@@ -39,6 +41,7 @@ func DataGitRepositories() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"features": datahelper.FeaturesSchema(),
 			"repositories": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -98,7 +101,7 @@ func dataSourceGitRepositoriesRead(d *schema.ResourceData, m interface{}) error
 	projectID := d.Get("project_id").(string)
 	includeHidden := d.Get("include_hidden").(bool)
 
-	projectRepos, err := getGitRepositoriesByNameAndProject(clients, name, projectID, includeHidden)
+	projectRepos, err := getGitRepositoriesByNameAndProject(clients, name, projectID, includeHidden, datahelper.GetConcurrentWorkers(d))
 	if err != nil {
 		if utils.ResponseWasNotFound(err) {
 			d.SetId("")
@@ -206,7 +209,10 @@ func flattenGitRepositories(repos *[]git.GitRepository) ([]interface{}, error) {
 	return results, nil
 }
 
-func getGitRepositoriesByNameAndProject(clients *client.AggregatedClient, name string, projectID string, includeHidden bool) (*[]git.GitRepository, error) {
+// getGitRepositoriesByNameAndProject lists the repositories of the project. Without a project more than one worker
+// lists the repositories of every project of the organization concurrently, a single worker lists them all with one
+// request.
+func getGitRepositoriesByNameAndProject(clients *client.AggregatedClient, name string, projectID string, includeHidden bool, workers int) (*[]git.GitRepository, error) {
 	var repos *[]git.GitRepository
 	var err error
 
@@ -217,10 +223,11 @@ func getGitRepositoriesByNameAndProject(clients *client.AggregatedClient, name s
 		}
 		repos = &[]git.GitRepository{*repo}
 	} else {
-		repos, err = clients.GitReposClient.GetRepositories(clients.Ctx, git.GetRepositoriesArgs{
-			Project:       converter.String(projectID),
-			IncludeHidden: converter.Bool(includeHidden),
-		})
+		if projectID == "" && workers > 1 {
+			repos, err = getGitRepositoriesOfAllProjects(clients, includeHidden, workers)
+		} else {
+			repos, err = getGitRepositoriesOfProject(clients, projectID, includeHidden)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -235,3 +242,69 @@ func getGitRepositoriesByNameAndProject(clients *client.AggregatedClient, name s
 	}
 	return repos, nil
 }
+
+// getGitRepositoriesOfProject lists the repositories of the project, or of the organization without a project. The
+// repositories are not paged, they are returned by a single request.
+func getGitRepositoriesOfProject(clients *client.AggregatedClient, projectID string, includeHidden bool) (*[]git.GitRepository, error) {
+	return clients.GitReposClient.GetRepositories(clients.Ctx, git.GetRepositoriesArgs{
+		Project:       converter.String(projectID),
+		IncludeHidden: converter.Bool(includeHidden),
+	})
+}
+
+func getGitRepositoriesOfAllProjects(clients *client.AggregatedClient, includeHidden bool, workers int) (*[]git.GitRepository, error) {
+	projectIDs, err := getAllProjectIDs(clients)
+	if err != nil {
+		return nil, err
+	}
+
+	projectRepos := make([][]git.GitRepository, len(projectIDs))
+	err = datahelper.ForEachConcurrently(len(projectIDs), workers, func(i int) error {
+		repos, err := getGitRepositoriesOfProject(clients, projectIDs[i], includeHidden)
+		if err != nil {
+			if utils.ResponseWasNotFound(err) {
+				// the project has been deleted since it was listed
+				return nil
+			}
+			return err
+		}
+		if repos != nil {
+			projectRepos[i] = *repos
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	repos := []git.GitRepository{}
+	for _, r := range projectRepos {
+		repos = append(repos, r...)
+	}
+	return &repos, nil
+}
+
+// getAllProjectIDs follows the continuation tokens of the projects of the organization
+func getAllProjectIDs(clients *client.AggregatedClient) ([]string, error) {
+	var projectIDs []string
+	args := core.GetProjectsArgs{}
+	for {
+		response, err := clients.CoreClient.GetProjects(clients.Ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range response.Value {
+			if project.Id != nil {
+				projectIDs = append(projectIDs, project.Id.String())
+			}
+		}
+		if response.ContinuationToken == "" {
+			return projectIDs, nil
+		}
+		token, err := strconv.Atoi(response.ContinuationToken)
+		if err != nil {
+			return nil, err
+		}
+		args.ContinuationToken = &token
+	}
+}
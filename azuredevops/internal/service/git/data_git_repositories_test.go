@@ -82,6 +82,13 @@ var gitRepoList = []git.GitRepository{
 	},
 }
 
+// singleWorkerFeatures lists all repositories of the organization with a single request
+var singleWorkerFeatures = map[string]interface{}{
+	"features": []interface{}{
+		map[string]interface{}{"concurrent_workers": 1},
+	},
+}
+
 func TestGitRepositoriesDataSource_Read_TestHandleError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -106,7 +113,7 @@ func TestGitRepositoriesDataSource_Read_TestHandleError(t *testing.T) {
 		Return(nil, errors.New("GetRepositories() Failed")).
 		Times(1)
 
-	resourceData := schema.TestResourceDataRaw(t, DataGitRepositories().Schema, nil)
+	resourceData := schema.TestResourceDataRaw(t, DataGitRepositories().Schema, singleWorkerFeatures)
 
 	err := dataSourceGitRepositoriesRead(resourceData, clients)
 	require.NotNil(t, err)
@@ -175,7 +182,7 @@ func TestGitRepositoriesDataSource_Read_NoRepositories(t *testing.T) {
 		Return(&[]git.GitRepository{}, nil).
 		Times(1)
 
-	resourceData := schema.TestResourceDataRaw(t, DataGitRepositories().Schema, nil)
+	resourceData := schema.TestResourceDataRaw(t, DataGitRepositories().Schema, singleWorkerFeatures)
 
 	err := dataSourceGitRepositoriesRead(resourceData, clients)
 	require.Nil(t, err)
@@ -188,12 +195,68 @@ func TestGitRepositoriesDataSource_Read_AllRepositories(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	repoClient := azdosdkmocks.NewMockGitClient(ctrl)
+
+	clients := &client.AggregatedClient{
+		CoreClient:     coreClient,
+		GitReposClient: repoClient,
+		Ctx:            context.Background(),
+	}
+
+	project02 := gitRepoList[2].Project
+	coreClient.
+		EXPECT().
+		GetProjects(clients.Ctx, core.GetProjectsArgs{}).
+		Return(&core.GetProjectsResponseValue{
+			Value:             []core.TeamProjectReference{*azProjectRef},
+			ContinuationToken: "1",
+		}, nil).
+		Times(1)
+	coreClient.
+		EXPECT().
+		GetProjects(clients.Ctx, core.GetProjectsArgs{ContinuationToken: converter.Int(1)}).
+		Return(&core.GetProjectsResponseValue{
+			Value: []core.TeamProjectReference{*project02},
+		}, nil).
+		Times(1)
+
+	repoClient.
+		EXPECT().
+		GetRepositories(clients.Ctx, git.GetRepositoriesArgs{
+			Project:       converter.String(azProjectRef.Id.String()),
+			IncludeHidden: converter.Bool(false),
+		}).
+		Return(&[]git.GitRepository{gitRepoList[0], gitRepoList[1]}, nil).
+		Times(1)
+	repoClient.
+		EXPECT().
+		GetRepositories(clients.Ctx, git.GetRepositoriesArgs{
+			Project:       converter.String(project02.Id.String()),
+			IncludeHidden: converter.Bool(false),
+		}).
+		Return(&[]git.GitRepository{gitRepoList[2]}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataGitRepositories().Schema, nil)
+
+	err := dataSourceGitRepositoriesRead(resourceData, clients)
+	require.Nil(t, err)
+	repos := resourceData.Get("repositories").([]interface{})
+	require.NotNil(t, repos)
+	require.Equal(t, len(repos), 3)
+	require.Equal(t, "repo-01", repos[0].(map[string]interface{})["name"])
+	require.Equal(t, "repo-03", repos[2].(map[string]interface{})["name"])
+}
+
+func TestGitRepositoriesDataSource_Read_AllRepositoriesWithSingleWorker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
 	repoClient := azdosdkmocks.NewMockGitClient(ctrl)
 
 	clients := &client.AggregatedClient{
 		GitReposClient: repoClient,
-		GraphClient:    graphClient,
 		Ctx:            context.Background(),
 	}
 
@@ -208,7 +271,7 @@ func TestGitRepositoriesDataSource_Read_AllRepositories(t *testing.T) {
 		Return(&gitRepoList, nil).
 		Times(1)
 
-	resourceData := schema.TestResourceDataRaw(t, DataGitRepositories().Schema, nil)
+	resourceData := schema.TestResourceDataRaw(t, DataGitRepositories().Schema, singleWorkerFeatures)
 
 	err := dataSourceGitRepositoriesRead(resourceData, clients)
 	require.Nil(t, err)
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/datahelper"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
 )
 
@@ -69,7 +70,7 @@ func dataSourceGitRepositoryRead(d *schema.ResourceData, m interface{}) error {
 	name := d.Get("name").(string)
 	projectID := d.Get("project_id").(string)
 
-	projectRepos, err := getGitRepositoriesByNameAndProject(clients, name, projectID, true)
+	projectRepos, err := getGitRepositoriesByNameAndProject(clients, name, projectID, true, datahelper.DefaultConcurrentWorkers)
 	if err != nil {
 		if utils.ResponseWasNotFound(err) {
 			return fmt.Errorf("Repository with name %s does not exist in project %s", name, projectID)
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -40,11 +41,15 @@ var RepoInitTypeValues = repoInitTypeValuesType{
 // ResourceGitRepository schema and implementation for git repo resource
 func ResourceGitRepository() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceGitRepositoryCreate,
-		Read:     resourceGitRepositoryRead,
-		Update:   resourceGitRepositoryUpdate,
-		Delete:   resourceGitRepositoryDelete,
-		Importer: tfhelper.ImportProjectQualifiedResource(),
+		Create:        resourceGitRepositoryCreate,
+		Read:          resourceGitRepositoryRead,
+		Update:        resourceGitRepositoryUpdate,
+		Delete:        resourceGitRepositoryDelete,
+		Importer:      tfhelper.ImportProjectQualifiedResource(),
+		CustomizeDiff: validateGitRepositoryInitialization,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:             schema.TypeString,
@@ -142,6 +147,24 @@ func ResourceGitRepository() *schema.Resource {
 							},
 							Default: "",
 						},
+						"gitignore_template": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.StringIsNotWhiteSpace,
+							DiffSuppressFunc: suppressDiffAfterCreation,
+						},
+						"license_content": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.StringIsNotEmpty,
+							DiffSuppressFunc: suppressDiffAfterCreation,
+						},
+						"readme_content": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.StringIsNotEmpty,
+							DiffSuppressFunc: suppressDiffAfterCreation,
+						},
 					},
 				},
 			},
@@ -155,6 +178,36 @@ type repoInitializationMeta struct {
 	sourceType          string
 	sourceURL           string
 	serviceConnectionID string
+	gitignoreTemplate   string
+	licenseContent      string
+	readmeContent       string
+}
+
+// hasInitialFiles reports whether the initial commit of the repository is seeded with configured files
+func (meta *repoInitializationMeta) hasInitialFiles() bool {
+	return meta.gitignoreTemplate != "" || meta.licenseContent != "" || meta.readmeContent != ""
+}
+
+// validateGitRepositoryInitialization rejects initial files for repositories which are not initialized by the provider
+func validateGitRepositoryInitialization(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("initialization.0.init_type") {
+		return nil
+	}
+	initType := d.Get("initialization.0.init_type").(string)
+	if strings.EqualFold(initType, string(RepoInitTypeValues.Clean)) {
+		return nil
+	}
+	for _, key := range []string{"gitignore_template", "license_content", "readme_content"} {
+		if d.Get("initialization.0."+key).(string) != "" {
+			return fmt.Errorf(" Repository 'initialization.init_type = %s', 'gitignore_template', 'license_content' and 'readme_content' can only be set for 'Clean' repositories.", initType)
+		}
+	}
+	return nil
+}
+
+// suppressDiffAfterCreation suppresses the diff of the initial files, which only seed the initial commit of new repositories
+func suppressDiffAfterCreation(_, _, _ string, d *schema.ResourceData) bool {
+	return d.Id() != ""
 }
 
 func resourceGitRepositoryCreate(d *schema.ResourceData, m interface{}) error {
@@ -209,15 +262,20 @@ func resourceGitRepositoryCreate(d *schema.ResourceData, m interface{}) error {
 				importRequest.Parameters.DeleteServiceEndpointAfterImportIsDone = converter.Bool(false)
 			}
 
-			_, importErr := createImportRequest(clients, importRequest, projectID.String(), *createdRepo.Name)
+			createdImport, importErr := createImportRequest(clients, importRequest, projectID.String(), *createdRepo.Name)
 			if importErr != nil {
 				return fmt.Errorf("Error import repository in Azure DevOps: %+v ", importErr)
 			}
+
+			importErr = waitForImportRequest(clients, createdImport, projectID.String(), createdRepo.Id.String(), d.Timeout(schema.TimeoutCreate))
+			if importErr != nil {
+				return importErr
+			}
 		}
 
 		if strings.EqualFold(initialization.initType, string(RepoInitTypeValues.Clean)) ||
 			strings.EqualFold(initialization.initType, string(RepoInitTypeValues.Fork)) {
-			err = initializeGitRepository(clients, createdRepo, repo.DefaultBranch)
+			err = initializeGitRepository(clients, createdRepo, repo.DefaultBranch, initialization)
 			if err != nil {
 				return fmt.Errorf(" initializing repository in Azure DevOps: %+v ", err)
 			}
@@ -225,7 +283,7 @@ func resourceGitRepositoryCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if !strings.EqualFold(initialization.initType, string(RepoInitTypeValues.Uninitialized)) || parentRepoRef != nil {
-		err := waitForBranch(clients, repo.Name, projectID)
+		err := waitForBranch(clients, repo.Name, projectID, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
 			return err
 		}
@@ -249,7 +307,11 @@ func resourceGitRepositoryRead(d *schema.ResourceData, m interface{}) error {
 	projectID := d.Get("project_id").(string)
 
 	clients := m.(*client.AggregatedClient)
-	repo, err := gitRepositoryRead(clients, repoID, repoName, projectID)
+	var repo *git.GitRepository
+	err := clients.ReadResource(d, func() (err error) {
+		repo, err = gitRepositoryRead(clients, repoID, repoName, projectID)
+		return err
+	})
 	if err != nil {
 		if utils.ResponseWasNotFound(err) {
 			d.SetId("")
@@ -292,7 +354,7 @@ func resourceGitRepositoryDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func waitForBranch(clients *client.AggregatedClient, repoName *string, projectID fmt.Stringer) error {
+func waitForBranch(clients *client.AggregatedClient, repoName *string, projectID fmt.Stringer, timeout time.Duration) error {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"Waiting"},
 		Target:  []string{"Synched"},
@@ -309,7 +371,7 @@ func waitForBranch(clients *client.AggregatedClient, repoName *string, projectID
 
 			return state, state, nil
 		},
-		Timeout:                   60 * time.Second,
+		Timeout:                   timeout,
 		MinTimeout:                2 * time.Second,
 		Delay:                     1 * time.Second,
 		ContinuousTargetOccurence: 1,
@@ -330,6 +392,53 @@ func createImportRequest(clients *client.AggregatedClient, gitImportRequest git.
 	return clients.GitReposClient.CreateImportRequest(clients.Ctx, args)
 }
 
+// waitForImportRequest polls an import request until it completes, fails or the timeout elapses
+func waitForImportRequest(clients *client.AggregatedClient, importRequest *git.GitImportRequest, project string, repositoryID string, timeout time.Duration) error {
+	if importRequest == nil || importRequest.ImportRequestId == nil {
+		return nil
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			string(git.GitAsyncOperationStatusValues.Queued),
+			string(git.GitAsyncOperationStatusValues.InProgress),
+		},
+		Target: []string{
+			string(git.GitAsyncOperationStatusValues.Completed),
+		},
+		Refresh: func() (interface{}, string, error) {
+			ret, err := clients.GitReposClient.GetImportRequest(clients.Ctx, git.GetImportRequestArgs{
+				Project:         &project,
+				RepositoryId:    &repositoryID,
+				ImportRequestId: importRequest.ImportRequestId,
+			})
+			if err != nil {
+				return nil, "", fmt.Errorf(" reading import request: %+v", err)
+			}
+			if ret.Status == nil {
+				return ret, string(git.GitAsyncOperationStatusValues.Queued), nil
+			}
+
+			status := *ret.Status
+			if status == git.GitAsyncOperationStatusValues.Failed || status == git.GitAsyncOperationStatusValues.Abandoned {
+				message := ""
+				if ret.DetailedStatus != nil {
+					message = converter.ToString(ret.DetailedStatus.ErrorMessage, "")
+				}
+				return nil, "", fmt.Errorf(" import request %d %s: %s", *importRequest.ImportRequestId, status, message)
+			}
+			return ret, string(status), nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 2 * time.Second,
+		Delay:      1 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil { //nolint:staticcheck
+		return fmt.Errorf("Error waiting for repository import to finish: %+v", err)
+	}
+	return nil
+}
+
 func createGitRepository(clients *client.AggregatedClient, repoName *string, projectID *uuid.UUID, parentRepo *git.GitRepositoryRef) (*git.GitRepository, error) {
 	args := git.CreateRepositoryArgs{
 		GitRepositoryToCreate: &git.GitRepositoryCreateOptions{
@@ -348,7 +457,9 @@ func createGitRepository(clients *client.AggregatedClient, repoName *string, pro
 	return createdRepository, nil
 }
 
-func initializeGitRepository(clients *client.AggregatedClient, repo *git.GitRepository, defaultBranch *string) error {
+// initializeGitRepository pushes the initial commit to the default branch of a repository, which contains the
+// configured .gitignore template, LICENSE and README or a README with the name of the project otherwise
+func initializeGitRepository(clients *client.AggregatedClient, repo *git.GitRepository, defaultBranch *string, initialization *repoInitializationMeta) error {
 	branchName := converter.ToString(defaultBranch, "")
 	if strings.EqualFold(branchName, "") {
 		branchName = "refs/heads/master"
@@ -366,18 +477,7 @@ func initializeGitRepository(clients *client.AggregatedClient, repo *git.GitRepo
 			Commits: &[]git.GitCommitRef{
 				{
 					Comment: converter.String("Initial commit."),
-					Changes: &[]interface{}{
-						git.Change{
-							ChangeType: &git.VersionControlChangeTypeValues.Add,
-							Item: git.GitItem{
-								Path: converter.String("/README.md"),
-							},
-							NewContent: &git.ItemContent{
-								ContentType: &git.ItemContentTypeValues.RawText,
-								Content:     repo.Project.Name,
-							},
-						},
-					},
+					Changes: initialCommitChanges(repo, initialization),
 				},
 			},
 		},
@@ -388,6 +488,68 @@ func initializeGitRepository(clients *client.AggregatedClient, repo *git.GitRepo
 	return err
 }
 
+// initialCommitChanges returns the files added by the initial commit of a repository. The .gitignore file is
+// generated by the service from one of its templates.
+func initialCommitChanges(repo *git.GitRepository, initialization *repoInitializationMeta) *[]interface{} {
+	if initialization == nil || !initialization.hasInitialFiles() {
+		return &[]interface{}{
+			git.Change{
+				ChangeType: &git.VersionControlChangeTypeValues.Add,
+				Item: git.GitItem{
+					Path: converter.String("/README.md"),
+				},
+				NewContent: &git.ItemContent{
+					ContentType: &git.ItemContentTypeValues.RawText,
+					Content:     repo.Project.Name,
+				},
+			},
+		}
+	}
+
+	changes := []interface{}{}
+	if initialization.readmeContent != "" {
+		changes = append(changes, git.Change{
+			ChangeType: &git.VersionControlChangeTypeValues.Add,
+			Item: git.GitItem{
+				Path: converter.String("/README.md"),
+			},
+			NewContent: &git.ItemContent{
+				ContentType: &git.ItemContentTypeValues.RawText,
+				Content:     converter.String(initialization.readmeContent),
+			},
+		})
+	}
+	if initialization.licenseContent != "" {
+		changes = append(changes, git.Change{
+			ChangeType: &git.VersionControlChangeTypeValues.Add,
+			Item: git.GitItem{
+				Path: converter.String("/LICENSE"),
+			},
+			NewContent: &git.ItemContent{
+				ContentType: &git.ItemContentTypeValues.RawText,
+				Content:     converter.String(initialization.licenseContent),
+			},
+		})
+	}
+	if initialization.gitignoreTemplate != "" {
+		templateName := initialization.gitignoreTemplate
+		if !strings.HasSuffix(strings.ToLower(templateName), ".gitignore") {
+			templateName += ".gitignore"
+		}
+		changes = append(changes, git.GitChange{
+			ChangeType: &git.VersionControlChangeTypeValues.Add,
+			Item: git.GitItem{
+				Path: converter.String("/.gitignore"),
+			},
+			NewContentTemplate: &git.GitTemplate{
+				Name: converter.String(templateName),
+				Type: converter.String("gitignore"),
+			},
+		})
+	}
+	return &changes
+}
+
 func updateGitRepository(clients *client.AggregatedClient, repository *git.GitRepository, project fmt.Stringer) (*git.GitRepository, error) {
 	if nil == project {
 		return nil, fmt.Errorf("updateGitRepository: ID of project cannot be nil")
@@ -504,6 +666,9 @@ func expandGitRepository(d *schema.ResourceData) (*git.GitRepository, *repoIniti
 			sourceType:          initValues["source_type"].(string),
 			sourceURL:           initValues["source_url"].(string),
 			serviceConnectionID: initValues["service_connection_id"].(string),
+			gitignoreTemplate:   initValues["gitignore_template"].(string),
+			licenseContent:      initValues["license_content"].(string),
+			readmeContent:       initValues["readme_content"].(string),
 		}
 
 		if strings.EqualFold(initialization.initType, "clean") {
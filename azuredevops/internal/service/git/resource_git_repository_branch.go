@@ -29,6 +29,9 @@ func ResourceGitRepositoryBranch() *schema.Resource {
 		CreateContext: resourceGitRepositoryBranchCreate,
 		ReadContext:   resourceGitRepositoryBranchRead,
 		DeleteContext: resourceGitRepositoryBranchDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -102,21 +105,21 @@ func resourceGitRepositoryBranchCreate(ctx context.Context, d *schema.ResourceDa
 			PeelTags:     converter.Bool(true),
 		})
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("Error getting refs matching %q: %w", filter, err))
+			return utils.ErrorDiagnostics(fmt.Errorf("Error getting refs matching %q: %w", filter, err))
 		}
 
 		if len(gotRefs.Value) == 0 {
-			return diag.FromErr(fmt.Errorf("No refs found that match ref %q.", rs))
+			return utils.ErrorDiagnostics(fmt.Errorf("No refs found that match ref %q.", rs))
 		}
 
 		gotRef := gotRefs.Value[0]
 		if gotRef.Name == nil {
-			return diag.FromErr(fmt.Errorf("Got unexpected GetRefs response, a ref without a name was returned."))
+			return utils.ErrorDiagnostics(fmt.Errorf("Got unexpected GetRefs response, a ref without a name was returned."))
 		}
 
 		// Check for complete match. Sometimes refs exist that match prefix with Ref, but do not match completely.
 		if *gotRef.Name != rs {
-			return diag.FromErr(fmt.Errorf("Ref %q not found, closest match is %q.", filter, *gotRef.Name))
+			return utils.ErrorDiagnostics(fmt.Errorf("Ref %q not found, closest match is %q.", filter, *gotRef.Name))
 		}
 
 		if gotRef.PeeledObjectId != nil {
@@ -124,7 +127,7 @@ func resourceGitRepositoryBranchCreate(ctx context.Context, d *schema.ResourceDa
 		} else if gotRef.ObjectId != nil {
 			newObjectId = *gotRef.ObjectId
 		} else {
-			return diag.FromErr(fmt.Errorf("GetRefs response doesn't have a valid commit id."))
+			return utils.ErrorDiagnostics(fmt.Errorf("GetRefs response doesn't have a valid commit id."))
 		}
 	}
 
@@ -137,7 +140,7 @@ func resourceGitRepositoryBranchCreate(ctx context.Context, d *schema.ResourceDa
 		RepositoryId: converter.String(repoId),
 	})
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("Error creating branch %q: %w", shortBranchName, err))
+		return utils.ErrorDiagnostics(fmt.Errorf("Error creating branch %q: %w", shortBranchName, err))
 	}
 
 	d.SetId(fmt.Sprintf("%s:%s", repoId, shortBranchName))
@@ -150,7 +153,7 @@ func resourceGitRepositoryBranchRead(ctx context.Context, d *schema.ResourceData
 
 	repoId, name, err := tfhelper.ParseGitRepoBranchID(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.ErrorDiagnostics(err)
 	}
 
 	shortBranchName := withoutPrefix(REF_BRANCH_PREFIX, name)
@@ -175,7 +178,7 @@ func resourceGitRepositoryBranchRead(ctx context.Context, d *schema.ResourceData
 				return nil
 			}
 		}
-		return diag.FromErr(fmt.Errorf("Error reading branch %q: %w", name, err))
+		return utils.ErrorDiagnostics(fmt.Errorf("Error reading branch %q: %w", name, err))
 	}
 
 	d.SetId(fmt.Sprintf("%s:%s", repoId, shortBranchName))
@@ -191,7 +194,7 @@ func resourceGitRepositoryBranchDelete(ctx context.Context, d *schema.ResourceDa
 
 	repoId, name, err := tfhelper.ParseGitRepoBranchID(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.ErrorDiagnostics(err)
 	}
 
 	shortBranchName := withoutPrefix(REF_BRANCH_PREFIX, name)
@@ -205,7 +208,7 @@ func resourceGitRepositoryBranchDelete(ctx context.Context, d *schema.ResourceDa
 		Name:         converter.String(shortBranchName),
 	})
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("Error getting latest commit of %q: %w", name, err))
+		return utils.ErrorDiagnostics(fmt.Errorf("Error getting latest commit of %q: %w", name, err))
 	}
 
 	_, err = updateRefs(clients, git.UpdateRefsArgs{
@@ -217,7 +220,7 @@ func resourceGitRepositoryBranchDelete(ctx context.Context, d *schema.ResourceDa
 		RepositoryId: converter.String(repoId),
 	})
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("Error deleting branch %q: %w", name, err))
+		return utils.ErrorDiagnostics(fmt.Errorf("Error deleting branch %q: %w", name, err))
 	}
 
 	return nil
@@ -145,7 +145,7 @@ func resourceGitRepositoryFileCreate(d *schema.ResourceData, m interface{}) erro
 
 		_, err = clients.GitReposClient.CreatePush(ctx, *args)
 		if err != nil {
-			if utils.ResponseContainsStatusMessage(err, "has already been updated by another client") {
+			if utils.ResponseContainsStatusMessage(err, "has already been updated by another client") || utils.IsRetryableError(err) {
 				return resource.RetryableError(err)
 			}
 			return resource.NonRetryableError(err)
@@ -246,7 +246,7 @@ func resourceGitRepositoryFileUpdate(d *schema.ResourceData, m interface{}) erro
 
 		_, err = clients.GitReposClient.CreatePush(ctx, *args)
 		if err != nil {
-			if utils.ResponseContainsStatusMessage(err, "has already been updated by another client") {
+			if utils.ResponseContainsStatusMessage(err, "has already been updated by another client") || utils.IsRetryableError(err) {
 				return resource.RetryableError(err)
 			}
 			return resource.NonRetryableError(err)
@@ -299,7 +299,7 @@ func resourceGitRepositoryFileDelete(d *schema.ResourceData, m interface{}) erro
 			},
 		})
 		if err != nil {
-			if utils.ResponseContainsStatusMessage(err, "has already been updated by another client") {
+			if utils.ResponseContainsStatusMessage(err, "has already been updated by another client") || utils.IsRetryableError(err) {
 				return resource.RetryableError(err)
 			}
 			return resource.NonRetryableError(err)
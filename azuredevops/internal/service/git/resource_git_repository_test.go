@@ -13,6 +13,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
 	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
@@ -217,10 +218,79 @@ func TestGitRepo_Initialize_UsesTheDefaultBranch(t *testing.T) {
 		Return(nil, nil).
 		Times(1)
 
-	err := initializeGitRepository(clients, &repo, &defaultBranch)
+	err := initializeGitRepository(clients, &repo, &defaultBranch, nil)
 	require.Nil(t, err, fmt.Sprintf("Error was %v", err))
 }
 
+// verifies that 'Clean' repo initialization seeds the configured files in the initial commit
+func TestGitRepo_Initialize_SeedsConfiguredFiles(t *testing.T) {
+	repo := git.GitRepository{
+		Name:    converter.String("test-repository"),
+		Project: &core.TeamProjectReference{Name: converter.String("test-project")},
+	}
+
+	changes := *initialCommitChanges(&repo, &repoInitializationMeta{
+		initType:          string(RepoInitTypeValues.Clean),
+		gitignoreTemplate: "Go",
+		licenseContent:    "MIT License",
+		readmeContent:     "# Scaffold",
+	})
+
+	require.Len(t, changes, 3)
+	require.Equal(t, "/README.md", *changes[0].(git.Change).Item.(git.GitItem).Path)
+	require.Equal(t, "# Scaffold", *changes[0].(git.Change).NewContent.Content)
+	require.Equal(t, "/LICENSE", *changes[1].(git.Change).Item.(git.GitItem).Path)
+	require.Equal(t, "MIT License", *changes[1].(git.Change).NewContent.Content)
+	gitignore := changes[2].(git.GitChange)
+	require.Equal(t, "/.gitignore", *gitignore.Item.(git.GitItem).Path)
+	require.Equal(t, &git.GitTemplate{Name: converter.String("Go.gitignore"), Type: converter.String("gitignore")}, gitignore.NewContentTemplate)
+}
+
+// verifies that initial files are rejected for repositories which are not initialized by the provider
+func TestGitRepo_Diff_RejectsInitialFilesOfImportedRepositories(t *testing.T) {
+	_, err := ResourceGitRepository().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id": testRepoProjectID.String(),
+		"name":       "RepoName",
+		"initialization": []interface{}{map[string]interface{}{
+			"init_type":      string(RepoInitTypeValues.Import),
+			"source_type":    "Git",
+			"source_url":     "https://github.com/microsoft/terraform-provider-azuredevops.git",
+			"readme_content": "# Scaffold",
+		}},
+	}), nil)
+	require.ErrorContains(t, err, "can only be set for 'Clean' repositories")
+}
+
+// verifies that changes of the initial files do not change existing repositories
+func TestGitRepo_Diff_IgnoresInitialFilesOfExistingRepositories(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: testRepoID.String(),
+		Attributes: map[string]string{
+			"id":                                     testRepoID.String(),
+			"project_id":                             testRepoProjectID.String(),
+			"name":                                   "RepoName",
+			"default_branch":                         "refs/heads/main",
+			"initialization.#":                       "1",
+			"initialization.0.init_type":             string(RepoInitTypeValues.Clean),
+			"initialization.0.source_url":            "",
+			"initialization.0.service_connection_id": "",
+			"initialization.0.readme_content":        "# Scaffold",
+		},
+	}
+
+	diff, err := ResourceGitRepository().Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id": testRepoProjectID.String(),
+		"name":       "RepoName",
+		"initialization": []interface{}{map[string]interface{}{
+			"init_type":          string(RepoInitTypeValues.Clean),
+			"gitignore_template": "Go",
+			"readme_content":     "# Changed",
+		}},
+	}), nil)
+	require.NoError(t, err)
+	require.True(t, diff.Empty(), fmt.Sprintf("Diff was %v", diff))
+}
+
 // verifies that the resource ID is used for reads if the ID is set
 func TestGitRepo_Read_UsesIdIfSet(t *testing.T) {
 	ctrl := gomock.NewController(t)
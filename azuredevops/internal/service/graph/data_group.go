@@ -19,31 +19,41 @@ func DataGroup() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringIsNotWhiteSpace,
+				ExactlyOneOf: []string{"name", "origin_id", "descriptor"},
 			},
 			"project_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				ConflictsWith: []string{"origin_id", "descriptor"},
+			},
+			"descriptor": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.StringIsNotWhiteSpace,
-			},
-			"descriptor": {
-				Type:     schema.TypeString,
-				Computed: true,
+				ExactlyOneOf: []string{"name", "origin_id", "descriptor"},
 			},
 			"origin": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 			"origin_id": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				ExactlyOneOf: []string{"name", "origin_id", "descriptor"},
 			},
 		},
 	}
 }
 
-// Performs a lookup of a project group. This involves the following actions:
+// Performs a lookup of a group. Groups with a known descriptor or origin ID are looked up directly, otherwise
+// the lookup by name involves the following actions:
 //
 //	(1) Identify AzDO graph descriptor for the project in which the group exists
 //	(2) Query for all AzDO groups that exist within the project. This leverages the AzDO graph descriptor for the project.
@@ -51,6 +61,29 @@ func DataGroup() *schema.Resource {
 //	(3) Select group that has the name identified by the schema
 func dataSourceGroupRead(d *schema.ResourceData, m interface{}) error {
 	clients := m.(*client.AggregatedClient)
+
+	if descriptor := d.Get("descriptor").(string); descriptor != "" {
+		group, err := clients.GraphClient.GetGroup(clients.Ctx, graph.GetGroupArgs{GroupDescriptor: &descriptor})
+		if err != nil {
+			if utils.ResponseWasNotFound(err) {
+				return fmt.Errorf("Could not find group with descriptor %s", descriptor)
+			}
+			return fmt.Errorf("Error finding group with descriptor %s. Error: %v", descriptor, err)
+		}
+		return flattenGroupDataSource(d, group)
+	}
+
+	if originID := d.Get("origin_id").(string); originID != "" {
+		group, err := getGroupByOriginID(clients, originID)
+		if err != nil {
+			return fmt.Errorf("Error finding group with origin ID %s. Error: %v", originID, err)
+		}
+		if group == nil {
+			return fmt.Errorf("Could not find group with origin ID %s", originID)
+		}
+		return flattenGroupDataSource(d, group)
+	}
+
 	groupName, projectID := d.Get("name").(string), d.Get("project_id").(string)
 
 	projectDescriptor, err := getProjectDescriptor(clients, projectID)
@@ -78,14 +111,54 @@ func dataSourceGroupRead(d *schema.ResourceData, m interface{}) error {
 		}
 		return fmt.Errorf(errMsg)
 	}
+	return flattenGroupDataSource(d, targetGroup)
+}
+
+func flattenGroupDataSource(d *schema.ResourceData, group *graph.GraphGroup) error {
+	if group == nil || group.Descriptor == nil {
+		return fmt.Errorf("The service did not return the descriptor of the group")
+	}
 
-	d.SetId(*targetGroup.Descriptor)
-	d.Set("descriptor", targetGroup.Descriptor)
-	d.Set("origin", targetGroup.Origin)
-	d.Set("origin_id", targetGroup.OriginId)
+	d.SetId(*group.Descriptor)
+	d.Set("name", group.DisplayName)
+	d.Set("descriptor", group.Descriptor)
+	d.Set("origin", group.Origin)
+	d.Set("origin_id", group.OriginId)
 	return nil
 }
 
+// getGroupByOriginID looks up a group by the ID of the group in its system of origin, e.g. the object ID of an
+// AAD group. The subject query matches the origin ID, the graph group is read by the descriptor of the subject.
+func getGroupByOriginID(clients *client.AggregatedClient, originID string) (*graph.GraphGroup, error) {
+	subjects, err := clients.GraphClient.QuerySubjects(clients.Ctx, graph.QuerySubjectsArgs{
+		SubjectQuery: &graph.GraphSubjectQuery{
+			Query:       &originID,
+			SubjectKind: &[]string{"Group"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if subjects == nil {
+		return nil, nil
+	}
+
+	for _, subject := range *subjects {
+		if subject.OriginId == nil || subject.Descriptor == nil || !strings.EqualFold(*subject.OriginId, originID) {
+			continue
+		}
+		group, err := clients.GraphClient.GetGroup(clients.Ctx, graph.GetGroupArgs{GroupDescriptor: subject.Descriptor})
+		if err != nil {
+			if utils.ResponseWasNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return group, nil
+	}
+	return nil, nil
+}
+
 func getProjectDescriptor(clients *client.AggregatedClient, projectID string) (string, error) {
 	if projectID == "" {
 		return "", nil
@@ -96,12 +169,7 @@ func getProjectDescriptor(clients *client.AggregatedClient, projectID string) (s
 		return "", err
 	}
 
-	descriptor, err := clients.GraphClient.GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{StorageKey: &projectUUID})
-	if err != nil {
-		return "", err
-	}
-
-	return *descriptor.Value, nil
+	return clients.LookupDescriptor(projectUUID)
 }
 
 func getGroupsForDescriptor(clients *client.AggregatedClient, projectDescriptor string) (*[]graph.GraphGroup, error) {
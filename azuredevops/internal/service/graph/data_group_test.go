@@ -202,6 +202,106 @@ func testGroupDataSource_HandlesCollectionGroups(t *testing.T, resourceData *sch
 	return dataSourceGroupRead(resourceData, clients)
 }
 
+func TestGroupDataSource_LooksUpGroupByDescriptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, DataGroup().Schema, nil)
+	resourceData.Set("descriptor", "descriptor1")
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	group := (*createGroupsWithDescriptors(groupMeta{name: "name1", descriptor: "descriptor1", origin: "aad", originId: "origin1"}))[0]
+	graphClient.
+		EXPECT().
+		GetGroup(clients.Ctx, graph.GetGroupArgs{GroupDescriptor: converter.String("descriptor1")}).
+		Return(&group, nil)
+
+	err := dataSourceGroupRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "descriptor1", resourceData.Id())
+	require.Equal(t, "name1", resourceData.Get("name"))
+	require.Equal(t, "origin1", resourceData.Get("origin_id"))
+}
+
+func TestGroupDataSource_LooksUpGroupByDescriptor_ReturnsErrorWhenNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, DataGroup().Schema, nil)
+	resourceData.Set("descriptor", "descriptor1")
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	graphClient.
+		EXPECT().
+		GetGroup(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(404)})
+
+	err := dataSourceGroupRead(resourceData, clients)
+	require.ErrorContains(t, err, "Could not find group with descriptor descriptor1")
+}
+
+// verifies that only the subject with the exact origin ID is selected from the results of the subject query
+func TestGroupDataSource_LooksUpGroupByOriginID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	originID := uuid.New().String()
+	resourceData := schema.TestResourceDataRaw(t, DataGroup().Schema, nil)
+	resourceData.Set("origin_id", originID)
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	graphClient.
+		EXPECT().
+		QuerySubjects(clients.Ctx, graph.QuerySubjectsArgs{
+			SubjectQuery: &graph.GraphSubjectQuery{
+				Query:       &originID,
+				SubjectKind: &[]string{"Group"},
+			},
+		}).
+		Return(&[]graph.GraphSubject{
+			{Descriptor: converter.String("descriptor1"), OriginId: converter.String(uuid.New().String())},
+			{Descriptor: converter.String("descriptor2"), OriginId: &originID},
+		}, nil)
+
+	group := (*createGroupsWithDescriptors(groupMeta{name: "name2", descriptor: "descriptor2", origin: "aad", originId: originID}))[0]
+	graphClient.
+		EXPECT().
+		GetGroup(clients.Ctx, graph.GetGroupArgs{GroupDescriptor: converter.String("descriptor2")}).
+		Return(&group, nil)
+
+	err := dataSourceGroupRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "descriptor2", resourceData.Id())
+	require.Equal(t, "name2", resourceData.Get("name"))
+	require.Equal(t, "aad", resourceData.Get("origin"))
+}
+
+func TestGroupDataSource_LooksUpGroupByOriginID_ReturnsErrorWhenNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	originID := uuid.New().String()
+	resourceData := schema.TestResourceDataRaw(t, DataGroup().Schema, nil)
+	resourceData.Set("origin_id", originID)
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	graphClient.
+		EXPECT().
+		QuerySubjects(clients.Ctx, gomock.Any()).
+		Return(&[]graph.GraphSubject{}, nil)
+
+	err := dataSourceGroupRead(resourceData, clients)
+	require.ErrorContains(t, err, "Could not find group with origin ID "+originID)
+}
+
 func createPaginatedResponse(continuationToken string, groups ...groupMeta) *graph.PagedGraphGroups {
 	continuationTokenList := []string{continuationToken}
 	return &graph.PagedGraphGroups{
@@ -9,7 +9,7 @@ import (
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/graph"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
-	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/datahelper"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
 )
 
@@ -23,6 +23,7 @@ func DataGroups() *schema.Resource {
 				Optional:     true,
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
+			"features": datahelper.FeaturesSchema(),
 			"groups": {
 				Type:     schema.TypeSet,
 				Computed: true,
@@ -116,16 +117,18 @@ func dataSourceGroupsRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Error flatten groups. Error: %w", err)
 	}
 
-	for _, group := range fgroups {
-		grp := group.(map[string]interface{})
-
-		storageKey, err := clients.GraphClient.GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{
-			SubjectDescriptor: converter.String(grp["descriptor"].(string)),
-		})
+	// the continuation tokens of the groups are opaque, only the storage keys can be read concurrently
+	err = datahelper.ForEachConcurrently(len(fgroups), datahelper.GetConcurrentWorkers(d), func(i int) error {
+		grp := fgroups[i].(map[string]interface{})
+		storageKey, err := clients.LookupStorageKey(grp["descriptor"].(string))
 		if err != nil {
 			return err
 		}
-		grp["id"] = storageKey.Value.String()
+		grp["id"] = storageKey.String()
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	d.SetId("groups-" + uuid.New().String())
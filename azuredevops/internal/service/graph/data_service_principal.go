@@ -0,0 +1,150 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/graph"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+)
+
+// DataServicePrincipal schema and implementation for service principal data source
+func DataServicePrincipal() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceServicePrincipalRead,
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsUUID,
+				ExactlyOneOf: []string{"application_id", "object_id"},
+			},
+			"object_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsUUID,
+				ExactlyOneOf: []string{"application_id", "object_id"},
+			},
+			"descriptor": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"origin": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Performs a lookup of a service principal of the organization. Service principals with a known object ID are
+// looked up directly through a subject query, otherwise the service principals of the organization are searched
+// for the application ID, which involves querying a paginated API.
+func dataSourceServicePrincipalRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	if objectID := d.Get("object_id").(string); objectID != "" {
+		servicePrincipal, err := getServicePrincipalByOriginID(clients, objectID)
+		if err != nil {
+			return fmt.Errorf(" finding service principal with object ID %s: %w", objectID, err)
+		}
+		if servicePrincipal == nil {
+			return fmt.Errorf(" could not find service principal with object ID %s. The service principal must be added to the organization first", objectID)
+		}
+		return flattenServicePrincipalDataSource(d, servicePrincipal)
+	}
+
+	applicationID := d.Get("application_id").(string)
+	servicePrincipal, err := getServicePrincipalByApplicationID(clients, applicationID)
+	if err != nil {
+		return fmt.Errorf(" finding service principal with application ID %s: %w", applicationID, err)
+	}
+	if servicePrincipal == nil {
+		return fmt.Errorf(" could not find service principal with application ID %s. The service principal must be added to the organization first", applicationID)
+	}
+	return flattenServicePrincipalDataSource(d, servicePrincipal)
+}
+
+func flattenServicePrincipalDataSource(d *schema.ResourceData, servicePrincipal *graph.GraphServicePrincipal) error {
+	if servicePrincipal.Descriptor == nil {
+		return fmt.Errorf(" the service did not return the descriptor of the service principal")
+	}
+
+	d.SetId(*servicePrincipal.Descriptor)
+	d.Set("descriptor", servicePrincipal.Descriptor)
+	d.Set("display_name", servicePrincipal.DisplayName)
+	d.Set("origin", servicePrincipal.Origin)
+	d.Set("object_id", servicePrincipal.OriginId)
+	d.Set("application_id", servicePrincipal.ApplicationId)
+	return nil
+}
+
+// getServicePrincipalByOriginID looks up a service principal by its object ID in Entra ID. The subject query matches
+// the origin ID, the service principal is read by the descriptor of the subject.
+func getServicePrincipalByOriginID(clients *client.AggregatedClient, originID string) (*graph.GraphServicePrincipal, error) {
+	subjects, err := clients.GraphClient.QuerySubjects(clients.Ctx, graph.QuerySubjectsArgs{
+		SubjectQuery: &graph.GraphSubjectQuery{
+			Query:       &originID,
+			SubjectKind: &[]string{"ServicePrincipal"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if subjects == nil {
+		return nil, nil
+	}
+
+	for _, subject := range *subjects {
+		if subject.OriginId == nil || subject.Descriptor == nil || !strings.EqualFold(*subject.OriginId, originID) {
+			continue
+		}
+		servicePrincipal, err := clients.GraphClient.GetServicePrincipal(clients.Ctx, graph.GetServicePrincipalArgs{
+			ServicePrincipalDescriptor: subject.Descriptor,
+		})
+		if err != nil {
+			if utils.ResponseWasNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return servicePrincipal, nil
+	}
+	return nil, nil
+}
+
+// getServicePrincipalByApplicationID searches the service principals of the organization for the one of an application
+func getServicePrincipalByApplicationID(clients *client.AggregatedClient, applicationID string) (*graph.GraphServicePrincipal, error) {
+	args := graph.ListServicePrincipalsArgs{}
+	for {
+		response, err := clients.GraphClient.ListServicePrincipals(clients.Ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		if response == nil {
+			return nil, nil
+		}
+
+		if response.GraphServicePrincipals != nil {
+			for _, servicePrincipal := range *response.GraphServicePrincipals {
+				if servicePrincipal.ApplicationId != nil && strings.EqualFold(*servicePrincipal.ApplicationId, applicationID) {
+					return &servicePrincipal, nil
+				}
+			}
+		}
+
+		if response.ContinuationToken == nil || len(*response.ContinuationToken) == 0 || (*response.ContinuationToken)[0] == "" {
+			return nil, nil
+		}
+		args.ContinuationToken = &(*response.ContinuationToken)[0]
+	}
+}
@@ -0,0 +1,141 @@
+//go:build (all || core || data_sources || data_service_principal) && (!exclude_data_sources || !exclude_data_service_principal)
+// +build all core data_sources data_service_principal
+// +build !exclude_data_sources !exclude_data_service_principal
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/graph"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServicePrincipalDataSource_LooksUpServicePrincipalByObjectID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	objectID := uuid.New().String()
+	applicationID := uuid.New().String()
+	resourceData := schema.TestResourceDataRaw(t, DataServicePrincipal().Schema, map[string]interface{}{"object_id": objectID})
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	graphClient.
+		EXPECT().
+		QuerySubjects(clients.Ctx, graph.QuerySubjectsArgs{
+			SubjectQuery: &graph.GraphSubjectQuery{
+				Query:       &objectID,
+				SubjectKind: &[]string{"ServicePrincipal"},
+			},
+		}).
+		Return(&[]graph.GraphSubject{
+			{Descriptor: converter.String("aadsp.other"), OriginId: converter.String(uuid.New().String())},
+			{Descriptor: converter.String("aadsp.descriptor"), OriginId: &objectID},
+		}, nil).
+		Times(1)
+	graphClient.
+		EXPECT().
+		GetServicePrincipal(clients.Ctx, graph.GetServicePrincipalArgs{ServicePrincipalDescriptor: converter.String("aadsp.descriptor")}).
+		Return(&graph.GraphServicePrincipal{
+			Descriptor:    converter.String("aadsp.descriptor"),
+			DisplayName:   converter.String("deployment"),
+			Origin:        converter.String("aad"),
+			OriginId:      &objectID,
+			ApplicationId: &applicationID,
+		}, nil).
+		Times(1)
+
+	err := dataSourceServicePrincipalRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "aadsp.descriptor", resourceData.Id())
+	require.Equal(t, "aadsp.descriptor", resourceData.Get("descriptor"))
+	require.Equal(t, "deployment", resourceData.Get("display_name"))
+	require.Equal(t, "aad", resourceData.Get("origin"))
+	require.Equal(t, applicationID, resourceData.Get("application_id"))
+}
+
+func TestServicePrincipalDataSource_LooksUpServicePrincipalByApplicationIDOnAllPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	objectID := uuid.New().String()
+	applicationID := uuid.New().String()
+	resourceData := schema.TestResourceDataRaw(t, DataServicePrincipal().Schema, map[string]interface{}{"application_id": applicationID})
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	gomock.InOrder(
+		graphClient.
+			EXPECT().
+			ListServicePrincipals(clients.Ctx, graph.ListServicePrincipalsArgs{}).
+			Return(&graph.PagedGraphServicePrincipals{
+				ContinuationToken: &[]string{"next"},
+				GraphServicePrincipals: &[]graph.GraphServicePrincipal{
+					{Descriptor: converter.String("aadsp.other"), ApplicationId: converter.String(uuid.New().String())},
+				},
+			}, nil),
+		graphClient.
+			EXPECT().
+			ListServicePrincipals(clients.Ctx, graph.ListServicePrincipalsArgs{ContinuationToken: converter.String("next")}).
+			Return(&graph.PagedGraphServicePrincipals{
+				GraphServicePrincipals: &[]graph.GraphServicePrincipal{
+					{Descriptor: converter.String("aadsp.descriptor"), OriginId: &objectID, ApplicationId: &applicationID},
+				},
+			}, nil),
+	)
+
+	err := dataSourceServicePrincipalRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "aadsp.descriptor", resourceData.Id())
+	require.Equal(t, objectID, resourceData.Get("object_id"))
+}
+
+func TestServicePrincipalDataSource_ReturnsErrorWhenNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	applicationID := uuid.New().String()
+	resourceData := schema.TestResourceDataRaw(t, DataServicePrincipal().Schema, map[string]interface{}{"application_id": applicationID})
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	graphClient.
+		EXPECT().
+		ListServicePrincipals(clients.Ctx, gomock.Any()).
+		Return(&graph.PagedGraphServicePrincipals{GraphServicePrincipals: &[]graph.GraphServicePrincipal{}}, nil).
+		Times(1)
+
+	err := dataSourceServicePrincipalRead(resourceData, clients)
+	require.ErrorContains(t, err, "could not find service principal with application ID "+applicationID)
+}
+
+func TestServicePrincipalDataSource_ReturnsErrorWhenLookupFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, DataServicePrincipal().Schema, map[string]interface{}{"object_id": uuid.New().String()})
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	graphClient.
+		EXPECT().
+		QuerySubjects(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("QuerySubjects() Failed")).
+		Times(1)
+
+	err := dataSourceServicePrincipalRead(resourceData, clients)
+	require.ErrorContains(t, err, "QuerySubjects() Failed")
+}
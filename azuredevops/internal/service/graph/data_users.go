@@ -5,14 +5,13 @@ import (
 	"encoding/base64"
 	"fmt"
 	"strings"
-	"sync"
 
 	"github.com/ahmetb/go-linq"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/graph"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
-	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/datahelper"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
 )
 
@@ -49,20 +48,7 @@ func DataUsers() *schema.Resource {
 				ValidateFunc:  validation.StringIsNotWhiteSpace,
 				ConflictsWith: []string{"principal_name"},
 			},
-			"features": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"concurrent_workers": {
-							Type:         schema.TypeInt,
-							Optional:     true,
-							ValidateFunc: validation.IntAtLeast(1),
-						},
-					},
-				},
-			},
+			"features": datahelper.FeaturesSchema(),
 			"users": {
 				Type:     schema.TypeSet,
 				Computed: true,
@@ -118,15 +104,27 @@ func dataUsersRead(d *schema.ResourceData, m interface{}) error {
 	origin := d.Get("origin").(string)
 	originID := d.Get("origin_id").(string)
 
-	var currentToken string
-	for hasMore := true; hasMore; {
-		newUsers, latestToken, err := getUsersWithContinuationToken(clients, &subjectTypes, currentToken)
-		currentToken = latestToken
-		hasMore = currentToken != ""
-		if err != nil {
-			return err
+	workers := datahelper.GetConcurrentWorkers(d)
+
+	// the continuation tokens chain the pages of a list, so only the lists of different subject types are read
+	// concurrently
+	subjectTypeLists := [][]string{subjectTypes}
+	if workers > 1 && len(subjectTypes) > 1 {
+		subjectTypeLists = make([][]string, len(subjectTypes))
+		for i, subjectType := range subjectTypes {
+			subjectTypeLists[i] = []string{subjectType}
 		}
+	}
+	userLists := make([][]graph.GraphUser, len(subjectTypeLists))
+	err := datahelper.ForEachConcurrently(len(subjectTypeLists), workers, func(i int) (err error) {
+		userLists[i], err = getAllUsers(clients, &subjectTypeLists[i])
+		return err
+	})
+	if err != nil {
+		return err
+	}
 
+	for _, newUsers := range userLists {
 		linq.From(newUsers).
 			WhereT(func(x interface{}) bool {
 				usr := x.(graph.GraphUser)
@@ -150,14 +148,7 @@ func dataUsersRead(d *schema.ResourceData, m interface{}) error {
 		users = append(users, fusers...)
 	}
 
-	features := d.Get("features").(*schema.Set)
-	numWorkers := 1
-	if features.Len() > 0 {
-		if v, ok := features.List()[0].(map[string]interface{})["concurrent_workers"]; ok {
-			numWorkers = v.(int)
-		}
-	}
-	err := addStorageKeyAsId(clients, users, numWorkers)
+	err = addStorageKeyAsId(clients, users, workers)
 	if err != nil {
 		return err
 	}
@@ -228,6 +219,27 @@ func flattenUser(user *graph.GraphUser) (map[string]interface{}, error) {
 	return s, nil
 }
 
+// getAllUsers follows the continuation tokens of the users of the subject types
+func getAllUsers(clients *client.AggregatedClient, subjectTypes *[]string) ([]graph.GraphUser, error) {
+	var users []graph.GraphUser
+	var currentToken string
+	for hasMore := true; hasMore; {
+		newUsers, latestToken, err := getUsersWithContinuationToken(clients, subjectTypes, currentToken)
+		if err != nil {
+			return nil, err
+		}
+		// most lists have a single page, which is used as is
+		if users == nil {
+			users = newUsers
+		} else {
+			users = append(users, newUsers...)
+		}
+		currentToken = latestToken
+		hasMore = currentToken != ""
+	}
+	return users, nil
+}
+
 func getUsersWithContinuationToken(clients *client.AggregatedClient, subjectTypes *[]string, continuationToken string) ([]graph.GraphUser, string, error) {
 	args := graph.ListUsersArgs{
 		SubjectTypes: subjectTypes,
@@ -249,44 +261,13 @@ func getUsersWithContinuationToken(clients *client.AggregatedClient, subjectType
 }
 
 func addStorageKeyAsId(clients *client.AggregatedClient, users []interface{}, numWorkers int) error {
-	userQueue := make(chan map[string]interface{}, len(users))
-	errChan := make(chan error)
-
-	var wg sync.WaitGroup
-
-	for i := 0; i < numWorkers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for user := range userQueue {
-				storageKey, err := clients.GraphClient.GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{
-					SubjectDescriptor: converter.String(user["descriptor"].(string)),
-				})
-				if err != nil {
-					errChan <- err
-					return
-				}
-				user["id"] = storageKey.Value.String()
-			}
-		}()
-	}
-
-	for _, user := range users {
-		userQueue <- user.(map[string]interface{})
-	}
-	close(userQueue)
-
-	done := make(chan bool)
-	go func() {
-		wg.Wait()
-		close(done)
-	}()
-
-	select {
-	case err := <-errChan:
-		return err
-	case <-done:
-	}
-
-	return nil
+	return datahelper.ForEachConcurrently(len(users), numWorkers, func(i int) error {
+		user := users[i].(map[string]interface{})
+		storageKey, err := clients.LookupStorageKey(user["descriptor"].(string))
+		if err != nil {
+			return err
+		}
+		user["id"] = storageKey.String()
+		return nil
+	})
 }
@@ -469,3 +469,41 @@ func TestDataSourceUser_Read_TestFilterBySubjectType(t *testing.T) {
 	}
 	require.Equal(t, len(usrList), iFound)
 }
+
+func TestDataSourceUser_Read_ListsSubjectTypesConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{
+		GraphClient: graphClient,
+		Ctx:         context.Background(),
+	}
+
+	for _, subjectType := range []string{"aad", "msa"} {
+		graphClient.
+			EXPECT().
+			ListUsers(clients.Ctx, graph.ListUsersArgs{SubjectTypes: &[]string{subjectType}}).
+			Return(&graph.PagedGraphUsers{
+				GraphUsers: &[]graph.GraphUser{{
+					Descriptor:    converter.String(subjectType + ".descriptor"),
+					PrincipalName: converter.String(subjectType + "@example.com"),
+					Origin:        converter.String(subjectType),
+				}},
+			}, nil).
+			Times(1)
+	}
+	graphClient.
+		EXPECT().
+		GetStorageKey(clients.Ctx, gomock.Any()).
+		Return(&graph.GraphStorageKeyResult{Value: &id}, nil).
+		Times(2)
+
+	resourceData := schema.TestResourceDataRaw(t, DataUsers().Schema, map[string]interface{}{
+		"subject_types": []interface{}{"aad", "msa"},
+		"features":      []interface{}{map[string]interface{}{"concurrent_workers": 2}},
+	})
+	err := dataUsersRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, 2, resourceData.Get("users").(*schema.Set).Len())
+}
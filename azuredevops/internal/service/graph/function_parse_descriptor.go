@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &parseDescriptorFunction{}
+
+var descriptorAttributeTypes = map[string]attr.Type{
+	"subject_type": types.StringType,
+	"identifier":   types.StringType,
+}
+
+// NewParseDescriptorFunction splits a graph descriptor into its subject type and its decoded identifier
+func NewParseDescriptorFunction() function.Function {
+	return &parseDescriptorFunction{}
+}
+
+type parseDescriptorFunction struct{}
+
+func (f *parseDescriptorFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_descriptor"
+}
+
+func (f *parseDescriptorFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parses a graph descriptor",
+		Description: "Splits a graph descriptor, like `vssgp.Uy0xLTktMTU1MTM3NDI0NS0x`, into its subject type and its decoded identifier, e.g. the SID of a group or the origin ID of a user.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "descriptor",
+				Description: "The descriptor of a user, a group or another graph subject.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: descriptorAttributeTypes,
+		},
+	}
+}
+
+func (f *parseDescriptorFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var descriptor string
+	resp.Error = req.Arguments.Get(ctx, &descriptor)
+	if resp.Error != nil {
+		return
+	}
+
+	subjectType, identifier, err := parseDescriptor(descriptor)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(descriptorAttributeTypes, map[string]attr.Value{
+		"subject_type": types.StringValue(subjectType),
+		"identifier":   types.StringValue(identifier),
+	})
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// parseDescriptor splits a descriptor at the first dot into the subject type and the identifier, which is
+// encoded with unpadded URL safe base64
+func parseDescriptor(descriptor string) (string, string, error) {
+	subjectType, encoded, ok := strings.Cut(descriptor, ".")
+	if !ok || subjectType == "" || encoded == "" {
+		return "", "", fmt.Errorf("%q is not a descriptor, descriptors have the format <subject type>.<identifier>", descriptor)
+	}
+	identifier, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return "", "", fmt.Errorf("the identifier of descriptor %q cannot be decoded: %+v", descriptor, err)
+	}
+	return subjectType, string(identifier), nil
+}
@@ -0,0 +1,41 @@
+//go:build (all || core || data_sources || data_group) && (!exclude_data_sources || !exclude_data_group)
+// +build all core data_sources data_group
+// +build !exclude_data_sources !exclude_data_group
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func runParseDescriptorFunction(descriptor string) *function.RunResponse {
+	resp := &function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(descriptorAttributeTypes))}
+	NewParseDescriptorFunction().Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(descriptor)}),
+	}, resp)
+	return resp
+}
+
+func TestParseDescriptorFunction_DecodesIdentifier(t *testing.T) {
+	resp := runParseDescriptorFunction("vssgp.Uy0xLTktMTU1MTM3NDI0NS0xMjA0NDAwOTY5")
+	require.Nil(t, resp.Error)
+
+	expected := types.ObjectValueMust(descriptorAttributeTypes, map[string]attr.Value{
+		"subject_type": types.StringValue("vssgp"),
+		"identifier":   types.StringValue("S-1-9-1551374245-1204400969"),
+	})
+	require.Equal(t, expected, resp.Result.Value())
+}
+
+func TestParseDescriptorFunction_RejectsInvalidDescriptors(t *testing.T) {
+	for _, descriptor := range []string{"", "vssgp", "vssgp.", "vssgp.!!!"} {
+		resp := runParseDescriptorFunction(descriptor)
+		require.NotNil(t, resp.Error, descriptor)
+	}
+}
@@ -116,13 +116,11 @@ func resourceGroupCreate(d *schema.ResourceData, m interface{}) error {
 	var scopeDescriptor *string
 	if val, ok := d.GetOk("scope"); ok {
 		scopeUid, _ := uuid.Parse(val.(string))
-		desc, err := clients.GraphClient.GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{
-			StorageKey: &scopeUid,
-		})
+		desc, err := clients.LookupDescriptor(scopeUid)
 		if err != nil {
 			return err
 		}
-		scopeDescriptor = desc.Value
+		scopeDescriptor = &desc
 	}
 
 	var group *graph.GraphGroup
@@ -182,9 +180,13 @@ func resourceGroupCreate(d *schema.ResourceData, m interface{}) error {
 func resourceGroupRead(d *schema.ResourceData, m interface{}) error {
 	clients := m.(*client.AggregatedClient)
 
-	group, err := clients.GraphClient.GetGroup(
-		clients.Ctx,
-		graph.GetGroupArgs{GroupDescriptor: converter.String(d.Id())})
+	var group *graph.GraphGroup
+	err := clients.ReadResource(d, func() (err error) {
+		group, err = clients.GraphClient.GetGroup(
+			clients.Ctx,
+			graph.GetGroupArgs{GroupDescriptor: converter.String(d.Id())})
+		return err
+	})
 
 	if err != nil {
 		if utils.ResponseWasNotFound(err) {
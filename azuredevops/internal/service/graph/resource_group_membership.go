@@ -23,6 +23,9 @@ func ResourceGroupMembership() *schema.Resource {
 		Read:   resourceGroupMembershipRead,
 		Update: resourceGroupMembershipUpdate,
 		Delete: resourceGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			State: importGroupMembership,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"group": {
@@ -283,6 +286,29 @@ func resourceGroupMembershipRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// importGroupMembership imports the memberships of a group by the descriptor of the group. All current members of
+// the group are imported.
+func importGroupMembership(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	clients := m.(*client.AggregatedClient)
+	group := d.Id()
+
+	memberships, err := getGroupMemberships(clients, group)
+	if err != nil {
+		return nil, fmt.Errorf(" reading the memberships of group %s: %w", group, err)
+	}
+
+	members := make([]string, 0, len(*memberships))
+	for _, membership := range *memberships {
+		members = append(members, *membership.MemberDescriptor)
+	}
+
+	d.Set("group", group)
+	d.Set("mode", "add")
+	d.Set("members", members)
+	d.SetId(fmt.Sprintf("%d", rand.Int()))
+	return []*schema.ResourceData{d}, nil
+}
+
 func getGroupMemberships(clients *client.AggregatedClient, groupDescriptor string) (*[]graph.GraphMembership, error) {
 	return clients.GraphClient.ListMemberships(clients.Ctx, graph.ListMembershipsArgs{
 		SubjectDescriptor: &groupDescriptor,
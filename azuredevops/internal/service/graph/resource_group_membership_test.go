@@ -82,6 +82,37 @@ func TestGroupMembership_Read_DoesNotSwallowErrors(t *testing.T) {
 	require.Contains(t, err.Error(), "ListMemberships() Failed")
 }
 
+func TestGroupMembership_Import_ImportsAllMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	expectedArgs := graph.ListMembershipsArgs{
+		SubjectDescriptor: converter.String("TEST_GROUP"),
+		Direction:         &graph.GraphTraversalDirectionValues.Down,
+		Depth:             converter.Int(1),
+	}
+	graphClient.
+		EXPECT().
+		ListMemberships(clients.Ctx, expectedArgs).
+		Return(&[]graph.GraphMembership{
+			*buildMembership("TEST_GROUP", "TEST_MEMBER_1"),
+			*buildMembership("TEST_GROUP", "TEST_MEMBER_2"),
+		}, nil)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceGroupMembership().Schema, nil)
+	resourceData.SetId("TEST_GROUP")
+	imported, err := importGroupMembership(resourceData, clients)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	require.Equal(t, "TEST_GROUP", imported[0].Get("group"))
+	require.Equal(t, "add", imported[0].Get("mode"))
+	require.ElementsMatch(t, []interface{}{"TEST_MEMBER_1", "TEST_MEMBER_2"}, imported[0].Get("members").(*schema.Set).List())
+	require.NotEqual(t, "TEST_GROUP", imported[0].Id())
+}
+
 func getGroupMembershipResourceData(t *testing.T, group string, members ...string) *schema.ResourceData {
 	d := schema.TestResourceDataRaw(t, ResourceGroupMembership().Schema, nil)
 	d.Set("group", group)
@@ -1,12 +1,15 @@
 package memberentitlementmanagement
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/ahmetb/go-linq"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
@@ -36,6 +39,9 @@ func ResourceGroupEntitlement() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: importGroupEntitlement,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"principal_name": {
 				Type:     schema.TypeString,
@@ -113,6 +119,37 @@ func ResourceGroupEntitlement() *schema.Resource {
 				}, true),
 				DiffSuppressFunc: suppress.CaseDifference,
 			},
+			"project_entitlement": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+						"group_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(memberentitlementmanagement.GroupTypeValues.ProjectStakeholder),
+								string(memberentitlementmanagement.GroupTypeValues.ProjectReader),
+								string(memberentitlementmanagement.GroupTypeValues.ProjectContributor),
+								string(memberentitlementmanagement.GroupTypeValues.ProjectAdministrator),
+							}, false),
+						},
+						"team_ids": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.IsUUID,
+							},
+						},
+					},
+				},
+			},
 			"descriptor": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -144,8 +181,15 @@ func resourceGroupEntitlementRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return fmt.Errorf("Error parsing GroupEntitlementID: %s. %v", groupEntitlementID, err)
 	}
-	groupEntitlement, err := clients.MemberEntitleManagementClient.GetGroupEntitlement(clients.Ctx, memberentitlementmanagement.GetGroupEntitlementArgs{
-		GroupId: &id,
+	var groupEntitlement *memberentitlementmanagement.GroupEntitlement
+	err = clients.ReadResource(d, func() (err error) {
+		groupEntitlement, err = clients.MemberEntitleManagementClient.GetGroupEntitlement(clients.Ctx, memberentitlementmanagement.GetGroupEntitlementArgs{
+			GroupId: &id,
+		})
+		if err == nil && (groupEntitlement == nil || groupEntitlement.Id == nil) {
+			return client.ErrResourceNotFound
+		}
+		return err
 	})
 
 	if err != nil {
@@ -153,15 +197,14 @@ func resourceGroupEntitlementRead(d *schema.ResourceData, m interface{}) error {
 			d.SetId("")
 			return nil
 		}
+		if errors.Is(err, client.ErrResourceNotFound) {
+			log.Println(" Group has been deleted")
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf(" reading group entitlement: %v", err)
 	}
 
-	if groupEntitlement == nil || groupEntitlement.Id == nil {
-		log.Println(" Group has been deleted")
-		d.SetId("")
-		return nil
-	}
-
 	flattenGroupEntitlement(d, groupEntitlement)
 	return nil
 }
@@ -187,6 +230,32 @@ func resourceGroupEntitlementDelete(d *schema.ResourceData, m interface{}) error
 		return fmt.Errorf("Deleting group entitlement: %v", err)
 	}
 
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Waiting"},
+		Target:  []string{"Deleted"},
+		Refresh: func() (interface{}, string, error) {
+			groupEntitlement, err := clients.MemberEntitleManagementClient.GetGroupEntitlement(clients.Ctx, memberentitlementmanagement.GetGroupEntitlementArgs{
+				GroupId: &id,
+			})
+			if err != nil {
+				if utils.ResponseWasNotFound(err) {
+					return "Deleted", "Deleted", nil
+				}
+				return nil, "", fmt.Errorf(" reading group entitlement: %v", err)
+			}
+			if groupEntitlement == nil || groupEntitlement.Id == nil {
+				return "Deleted", "Deleted", nil
+			}
+			return "Waiting", "Waiting", nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: 2 * time.Second,
+		Delay:      1 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil { //nolint:staticcheck
+		return fmt.Errorf(" waiting for group entitlement %s to be deleted: %v", groupEntitlementID, err)
+	}
+
 	// Also delete the org wise group if the group is Azure DevOps local, meaning
 	// most likely the local group was created by this resource
 	origin := d.Get("origin")
@@ -221,23 +290,29 @@ func resourceGroupEntitlementUpdate(d *schema.ResourceData, m interface{}) error
 
 	clients := m.(*client.AggregatedClient)
 
+	operations := []webapi.JsonPatchOperation{
+		{
+			Op:   &webapi.OperationValues.Replace,
+			From: nil,
+			Path: converter.String("/accessLevel"),
+			Value: struct {
+				AccountLicenseType string `json:"accountLicenseType"`
+				LicensingSource    string `json:"licensingSource"`
+			}{
+				string(*accountLicenseType),
+				licensingSource.(string),
+			},
+		},
+	}
+	if d.HasChange("project_entitlement") {
+		oldRules, newRules := d.GetChange("project_entitlement")
+		operations = append(operations, projectEntitlementOperations(oldRules.(*schema.Set), newRules.(*schema.Set))...)
+	}
+
 	patchResponse, err := clients.MemberEntitleManagementClient.UpdateGroupEntitlement(clients.Ctx,
 		memberentitlementmanagement.UpdateGroupEntitlementArgs{
-			GroupId: &id,
-			Document: &[]webapi.JsonPatchOperation{
-				{
-					Op:   &webapi.OperationValues.Replace,
-					From: nil,
-					Path: converter.String("/accessLevel"),
-					Value: struct {
-						AccountLicenseType string `json:"accountLicenseType"`
-						LicensingSource    string `json:"licensingSource"`
-					}{
-						string(*accountLicenseType),
-						licensingSource.(string),
-					},
-				},
-			},
+			GroupId:  &id,
+			Document: &operations,
 		})
 
 	if err != nil {
@@ -269,6 +344,8 @@ func importGroupEntitlement(d *schema.ResourceData, m interface{}) ([]*schema.Re
 	}
 
 	d.SetId((*result).Id.String())
+	// the import takes over the group rules of all projects
+	d.Set("project_entitlement", flattenProjectEntitlements(result.ProjectEntitlements, nil))
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -284,6 +361,93 @@ func flattenGroupEntitlement(d *schema.ResourceData, groupEntitlement *memberent
 	d.Set("display_name", *groupEntitlement.Group.DisplayName)
 	d.Set("account_license_type", string(*groupEntitlement.LicenseRule.AccountLicenseType))
 	d.Set("licensing_source", *groupEntitlement.LicenseRule.LicensingSource)
+	d.Set("project_entitlement", flattenProjectEntitlements(groupEntitlement.ProjectEntitlements, managedProjectEntitlements(d)))
+}
+
+// managedProjectEntitlements returns the IDs of the projects whose group rules are managed by the resource, the
+// group rules of other projects are left alone
+func managedProjectEntitlements(d *schema.ResourceData) map[string]bool {
+	projectIDs := map[string]bool{}
+	for _, rule := range d.Get("project_entitlement").(*schema.Set).List() {
+		projectIDs[strings.ToLower(rule.(map[string]interface{})["project_id"].(string))] = true
+	}
+	return projectIDs
+}
+
+// projectEntitlementOperations returns the patch operations which turn the group rules of the old projects
+// into the group rules of the new projects. A changed rule of a project is removed and added again.
+func projectEntitlementOperations(oldRules *schema.Set, newRules *schema.Set) []webapi.JsonPatchOperation {
+	operations := []webapi.JsonPatchOperation{}
+	for _, rule := range oldRules.Difference(newRules).List() {
+		projectID := rule.(map[string]interface{})["project_id"].(string)
+		operations = append(operations, webapi.JsonPatchOperation{
+			Op:   &webapi.OperationValues.Remove,
+			Path: converter.String("/projectEntitlements/" + projectID),
+		})
+	}
+	for _, entitlement := range *expandProjectEntitlements(newRules.Difference(oldRules)) {
+		operations = append(operations, webapi.JsonPatchOperation{
+			Op:    &webapi.OperationValues.Add,
+			Path:  converter.String("/projectEntitlements"),
+			Value: entitlement,
+		})
+	}
+	return operations
+}
+
+func expandProjectEntitlements(rules *schema.Set) *[]memberentitlementmanagement.ProjectEntitlement {
+	entitlements := make([]memberentitlementmanagement.ProjectEntitlement, 0, rules.Len())
+	for _, item := range rules.List() {
+		rule := item.(map[string]interface{})
+		projectID := uuid.MustParse(rule["project_id"].(string))
+		groupType := memberentitlementmanagement.GroupType(rule["group_type"].(string))
+
+		entitlement := memberentitlementmanagement.ProjectEntitlement{
+			Group:      &memberentitlementmanagement.Group{GroupType: &groupType},
+			ProjectRef: &memberentitlementmanagement.ProjectRef{Id: &projectID},
+		}
+		if teamIDs := rule["team_ids"].(*schema.Set); teamIDs.Len() > 0 {
+			teams := make([]memberentitlementmanagement.TeamRef, 0, teamIDs.Len())
+			for _, teamID := range teamIDs.List() {
+				id := uuid.MustParse(teamID.(string))
+				teams = append(teams, memberentitlementmanagement.TeamRef{Id: &id})
+			}
+			entitlement.TeamRefs = &teams
+		}
+		entitlements = append(entitlements, entitlement)
+	}
+	return &entitlements
+}
+
+// flattenProjectEntitlements returns the group rules of the managed projects, or of all projects if projectIDs is nil
+func flattenProjectEntitlements(entitlements *[]memberentitlementmanagement.ProjectEntitlement, projectIDs map[string]bool) []interface{} {
+	if entitlements == nil {
+		return nil
+	}
+	rules := make([]interface{}, 0, len(*entitlements))
+	for _, entitlement := range *entitlements {
+		if entitlement.ProjectRef == nil || entitlement.ProjectRef.Id == nil ||
+			entitlement.Group == nil || entitlement.Group.GroupType == nil {
+			continue
+		}
+		if projectIDs != nil && !projectIDs[entitlement.ProjectRef.Id.String()] {
+			continue
+		}
+		teamIDs := []interface{}{}
+		if entitlement.TeamRefs != nil {
+			for _, team := range *entitlement.TeamRefs {
+				if team.Id != nil {
+					teamIDs = append(teamIDs, team.Id.String())
+				}
+			}
+		}
+		rules = append(rules, map[string]interface{}{
+			"project_id": entitlement.ProjectRef.Id.String(),
+			"group_type": string(*entitlement.Group.GroupType),
+			"team_ids":   schema.NewSet(schema.HashString, teamIDs),
+		})
+	}
+	return rules
 }
 
 func expandGroupEntitlement(d *schema.ResourceData) (*memberentitlementmanagement.GroupEntitlement, error) {
@@ -301,6 +465,7 @@ func expandGroupEntitlement(d *schema.ResourceData) (*memberentitlementmanagemen
 	}
 
 	return &memberentitlementmanagement.GroupEntitlement{
+		ProjectEntitlements: expandProjectEntitlements(d.Get("project_entitlement").(*schema.Set)),
 		LicenseRule: &licensing.AccessLevel{
 			AccountLicenseType: accountLicenseType,
 			LicensingSource:    licensingSource,
@@ -510,6 +510,131 @@ func TestGroupEntitlement_Update_TestEmptyErrors(t *testing.T) {
 	assert.Contains(t, err.Error(), "Unknown API error")
 }
 
+// verifies that the group rules of the projects are part of the new group entitlement
+func TestGroupEntitlement_Create_WithProjectEntitlements(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	memberEntitlementClient := azdosdkmocks.NewMockMemberentitlementmanagementClient(ctrl)
+	clients := &client.AggregatedClient{
+		MemberEntitleManagementClient: memberEntitlementClient,
+		Ctx:                           context.Background(),
+	}
+
+	id := uuid.New()
+	projectID := uuid.New()
+	teamID := uuid.New()
+	mockGroupEntitlement := getMockGroupEntitlement(&id, licensing.AccountLicenseTypeValues.Express, "aad", "originId", "[contoso]\\PrincipalName", "displayName", "baz")
+	mockGroupEntitlement.ProjectEntitlements = &[]memberentitlementmanagement.ProjectEntitlement{
+		{
+			Group:      &memberentitlementmanagement.Group{GroupType: &memberentitlementmanagement.GroupTypeValues.ProjectContributor},
+			ProjectRef: &memberentitlementmanagement.ProjectRef{Id: &projectID},
+			TeamRefs:   &[]memberentitlementmanagement.TeamRef{{Id: &teamID}},
+		},
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceGroupEntitlement().Schema, nil)
+	resourceData.Set("origin", "aad")
+	resourceData.Set("origin_id", "originId")
+	resourceData.Set("project_entitlement", []interface{}{
+		map[string]interface{}{
+			"project_id": projectID.String(),
+			"group_type": "projectContributor",
+			"team_ids":   []interface{}{teamID.String()},
+		},
+	})
+
+	memberEntitlementClient.
+		EXPECT().
+		AddGroupEntitlement(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, args memberentitlementmanagement.AddGroupEntitlementArgs) (*memberentitlementmanagement.GroupEntitlementOperationReference, error) {
+			require.Equal(t, mockGroupEntitlement.ProjectEntitlements, args.GroupEntitlement.ProjectEntitlements)
+			return &memberentitlementmanagement.GroupEntitlementOperationReference{
+				Results: &[]memberentitlementmanagement.GroupOperationResult{
+					{IsSuccess: converter.Bool(true), Result: mockGroupEntitlement},
+				},
+			}, nil
+		}).
+		Times(1)
+
+	memberEntitlementClient.
+		EXPECT().
+		GetGroupEntitlement(gomock.Any(), memberentitlementmanagement.GetGroupEntitlementArgs{GroupId: &id}).
+		Return(mockGroupEntitlement, nil).
+		Times(1)
+
+	err := resourceGroupEntitlementCreate(resourceData, clients)
+	require.Nil(t, err)
+
+	rules := resourceData.Get("project_entitlement").(*schema.Set).List()
+	require.Len(t, rules, 1)
+	rule := rules[0].(map[string]interface{})
+	require.Equal(t, projectID.String(), rule["project_id"])
+	require.Equal(t, "projectContributor", rule["group_type"])
+	require.ElementsMatch(t, []interface{}{teamID.String()}, rule["team_ids"].(*schema.Set).List())
+}
+
+// verifies that removed group rules are removed by project and changed group rules are removed and added again
+func TestGroupEntitlement_ProjectEntitlementOperations(t *testing.T) {
+	projectResource := ResourceGroupEntitlement().Schema["project_entitlement"].Elem.(*schema.Resource)
+	rule := func(projectID string, groupType string) interface{} {
+		return map[string]interface{}{
+			"project_id": projectID,
+			"group_type": groupType,
+			"team_ids":   schema.NewSet(schema.HashString, []interface{}{}),
+		}
+	}
+
+	unchanged, changed, removed, added := uuid.New().String(), uuid.New().String(), uuid.New().String(), uuid.New().String()
+	oldRules := schema.NewSet(schema.HashResource(projectResource), []interface{}{
+		rule(unchanged, "projectReader"),
+		rule(changed, "projectReader"),
+		rule(removed, "projectReader"),
+	})
+	newRules := schema.NewSet(schema.HashResource(projectResource), []interface{}{
+		rule(unchanged, "projectReader"),
+		rule(changed, "projectAdministrator"),
+		rule(added, "projectContributor"),
+	})
+
+	operations := projectEntitlementOperations(oldRules, newRules)
+	require.Len(t, operations, 4)
+
+	removedPaths := []string{}
+	addedProjects := map[string]memberentitlementmanagement.GroupType{}
+	for _, operation := range operations {
+		switch *operation.Op {
+		case webapi.OperationValues.Remove:
+			removedPaths = append(removedPaths, *operation.Path)
+		case webapi.OperationValues.Add:
+			require.Equal(t, "/projectEntitlements", *operation.Path)
+			entitlement := operation.Value.(memberentitlementmanagement.ProjectEntitlement)
+			addedProjects[entitlement.ProjectRef.Id.String()] = *entitlement.Group.GroupType
+			require.Nil(t, entitlement.TeamRefs)
+		}
+	}
+	require.ElementsMatch(t, []string{"/projectEntitlements/" + changed, "/projectEntitlements/" + removed}, removedPaths)
+	require.Equal(t, map[string]memberentitlementmanagement.GroupType{
+		changed: memberentitlementmanagement.GroupTypeValues.ProjectAdministrator,
+		added:   memberentitlementmanagement.GroupTypeValues.ProjectContributor,
+	}, addedProjects)
+}
+
+func TestGroupEntitlement_FlattenProjectEntitlements_IgnoresUnmanagedProjects(t *testing.T) {
+	managedProjectID, unmanagedProjectID := uuid.New(), uuid.New()
+	groupType := memberentitlementmanagement.GroupTypeValues.ProjectReader
+	entitlements := &[]memberentitlementmanagement.ProjectEntitlement{
+		{Group: &memberentitlementmanagement.Group{GroupType: &groupType}, ProjectRef: &memberentitlementmanagement.ProjectRef{Id: &managedProjectID}},
+		{Group: &memberentitlementmanagement.Group{GroupType: &groupType}, ProjectRef: &memberentitlementmanagement.ProjectRef{Id: &unmanagedProjectID}},
+	}
+
+	rules := flattenProjectEntitlements(entitlements, map[string]bool{managedProjectID.String(): true})
+	require.Len(t, rules, 1)
+	require.Equal(t, managedProjectID.String(), rules[0].(map[string]interface{})["project_id"])
+
+	require.Len(t, flattenProjectEntitlements(entitlements, nil), 2)
+}
+
 func getMockGroupEntitlement(id *uuid.UUID, accountLicenseType licensing.AccountLicenseType, origin string, originID string, principalName string, displayName string, descriptor string) *memberentitlementmanagement.GroupEntitlement {
 	subjectKind := "group"
 	licensingSource := licensing.LicensingSourceValues.Account
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/ahmetb/go-linq"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
@@ -40,6 +42,9 @@ func ResourceUserEntitlement() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: importUserEntitlement,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"principal_name": {
 				Type:             schema.TypeString,
@@ -146,7 +151,11 @@ func resourceUserEntitlementRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Error parsing UserEntitlementID: %s. %v", userEntitlementID, err)
 	}
 
-	userEntitlement, err := readUserEntitlement(clients, &id)
+	var userEntitlement *memberentitlementmanagement.UserEntitlement
+	err = clients.ReadResource(d, func() (err error) {
+		userEntitlement, err = readUserEntitlement(clients, &id)
+		return err
+	})
 
 	if err != nil {
 		if utils.ResponseWasNotFound(err) || isUserDeleted(userEntitlement) {
@@ -262,6 +271,30 @@ func resourceUserEntitlementDelete(d *schema.ResourceData, m interface{}) error
 		return fmt.Errorf("Deleting user entitlement: %v", err)
 	}
 
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Waiting"},
+		Target:  []string{"Deleted"},
+		Refresh: func() (interface{}, string, error) {
+			userEntitlement, err := readUserEntitlement(clients, &id)
+			if err != nil {
+				if utils.ResponseWasNotFound(err) {
+					return "Deleted", "Deleted", nil
+				}
+				return nil, "", fmt.Errorf(" reading user entitlement: %v", err)
+			}
+			if isUserDeleted(userEntitlement) {
+				return "Deleted", "Deleted", nil
+			}
+			return "Waiting", "Waiting", nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		MinTimeout: 2 * time.Second,
+		Delay:      1 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil { //nolint:staticcheck
+		return fmt.Errorf(" waiting for user entitlement %s to be deleted: %v", userEntitlementID, err)
+	}
+
 	return nil
 }
 
@@ -376,7 +409,7 @@ func getAPIErrorMessage(operationResults *[]memberentitlementmanagement.UserEnti
 }
 
 func isUserDeleted(userEntitlement *memberentitlementmanagement.UserEntitlement) bool {
-	if userEntitlement == nil {
+	if userEntitlement == nil || userEntitlement.AccessLevel == nil || userEntitlement.AccessLevel.Status == nil {
 		return true
 	}
 
@@ -0,0 +1,166 @@
+package permissions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/security"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+// DataSecurityNamespaces schema and implementation for the security namespaces data source
+func DataSecurityNamespaces() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSecurityNamespacesRead,
+		Schema: map[string]*schema.Schema{
+			"namespace_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.IsUUID,
+				ConflictsWith: []string{"name"},
+			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				ConflictsWith: []string{"namespace_id"},
+			},
+			"namespaces": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"separator": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"read_permission": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"write_permission": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"actions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"display_name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"bit": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"action_bits": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSecurityNamespacesRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	namespaceID := d.Get("namespace_id").(string)
+	name := d.Get("name").(string)
+
+	var namespaces *[]security.SecurityNamespaceDescription
+	var err error
+	if namespaceID != "" {
+		namespaces, err = clients.LookupSecurityNamespace(uuid.MustParse(namespaceID))
+	} else {
+		namespaces, err = clients.SecurityClient.QuerySecurityNamespaces(clients.Ctx, security.QuerySecurityNamespacesArgs{})
+	}
+	if err != nil {
+		return fmt.Errorf(" reading security namespaces: %+v", err)
+	}
+
+	results := []security.SecurityNamespaceDescription{}
+	if namespaces != nil {
+		for _, namespace := range *namespaces {
+			if name != "" && !strings.EqualFold(converter.ToString(namespace.Name, ""), name) {
+				continue
+			}
+			results = append(results, namespace)
+		}
+	}
+	if (namespaceID != "" || name != "") && len(results) == 0 {
+		return fmt.Errorf(" security namespace %s%s was not found", namespaceID, name)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return converter.ToString(results[i].Name, "") < converter.ToString(results[j].Name, "")
+	})
+
+	if err := d.Set("namespaces", flattenSecurityNamespaces(results)); err != nil {
+		return fmt.Errorf(" setting namespaces: %+v", err)
+	}
+	d.SetId(fmt.Sprintf("securitynamespaces-%s-%s", namespaceID, strings.ToLower(name)))
+	return nil
+}
+
+func flattenSecurityNamespaces(namespaces []security.SecurityNamespaceDescription) []interface{} {
+	results := make([]interface{}, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		result := map[string]interface{}{
+			"name":             converter.ToString(namespace.Name, ""),
+			"display_name":     converter.ToString(namespace.DisplayName, ""),
+			"separator":        converter.ToString(namespace.SeparatorValue, ""),
+			"read_permission":  converter.ToInt(namespace.ReadPermission, 0),
+			"write_permission": converter.ToInt(namespace.WritePermission, 0),
+		}
+		if namespace.NamespaceId != nil {
+			result["id"] = namespace.NamespaceId.String()
+		}
+
+		actions := []interface{}{}
+		actionBits := map[string]interface{}{}
+		if namespace.Actions != nil {
+			for _, action := range *namespace.Actions {
+				actionName := converter.ToString(action.Name, "")
+				bit := converter.ToInt(action.Bit, 0)
+				actions = append(actions, map[string]interface{}{
+					"name":         actionName,
+					"display_name": converter.ToString(action.DisplayName, ""),
+					"bit":          bit,
+				})
+				actionBits[actionName] = bit
+			}
+		}
+		result["actions"] = actions
+		result["action_bits"] = actionBits
+		results = append(results, result)
+	}
+	return results
+}
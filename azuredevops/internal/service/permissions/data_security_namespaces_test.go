@@ -0,0 +1,121 @@
+//go:build (all || data_sources || data_security_namespaces) && (!exclude_data_sources || !exclude_data_security_namespaces)
+// +build all data_sources data_security_namespaces
+// +build !exclude_data_sources !exclude_data_security_namespaces
+
+package permissions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/security"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var gitRepositoriesNamespaceID = uuid.MustParse("2e9eb7ed-3c0a-47d4-87c1-0ffdd275fd87")
+
+func securityNamespacesResponse() *[]security.SecurityNamespaceDescription {
+	return &[]security.SecurityNamespaceDescription{
+		{
+			NamespaceId:    &gitRepositoriesNamespaceID,
+			Name:           converter.String("Git Repositories"),
+			DisplayName:    converter.String("Git Repositories"),
+			SeparatorValue: converter.String("/"),
+			ReadPermission: converter.Int(2),
+			Actions: &[]security.ActionDefinition{
+				{Name: converter.String("Administer"), DisplayName: converter.String("Administer"), Bit: converter.Int(1)},
+				{Name: converter.String("GenericRead"), DisplayName: converter.String("Read"), Bit: converter.Int(2)},
+			},
+		},
+		{
+			NamespaceId: converter.UUID("33344d9c-fc72-4d6f-aba5-fa317101a7e9"),
+			Name:        converter.String("Build"),
+		},
+	}
+}
+
+func TestDataSourceSecurityNamespaces_Read_SortsNamespacesByName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	securityClient := azdosdkmocks.NewMockSecurityClient(ctrl)
+	clients := &client.AggregatedClient{SecurityClient: securityClient, Ctx: context.Background()}
+
+	securityClient.EXPECT().
+		QuerySecurityNamespaces(clients.Ctx, security.QuerySecurityNamespacesArgs{}).
+		Return(securityNamespacesResponse(), nil).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, DataSecurityNamespaces().Schema, nil)
+	require.Nil(t, dataSourceSecurityNamespacesRead(d, clients))
+
+	namespaces := d.Get("namespaces").([]interface{})
+	require.Len(t, namespaces, 2)
+	require.Equal(t, "Build", namespaces[0].(map[string]interface{})["name"])
+	require.Equal(t, "Git Repositories", namespaces[1].(map[string]interface{})["name"])
+}
+
+func TestDataSourceSecurityNamespaces_Read_FiltersByName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	securityClient := azdosdkmocks.NewMockSecurityClient(ctrl)
+	clients := &client.AggregatedClient{SecurityClient: securityClient, Ctx: context.Background()}
+
+	securityClient.EXPECT().
+		QuerySecurityNamespaces(clients.Ctx, gomock.Any()).
+		Return(securityNamespacesResponse(), nil).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, DataSecurityNamespaces().Schema, map[string]interface{}{"name": "git repositories"})
+	require.Nil(t, dataSourceSecurityNamespacesRead(d, clients))
+
+	namespaces := d.Get("namespaces").([]interface{})
+	require.Len(t, namespaces, 1)
+	namespace := namespaces[0].(map[string]interface{})
+	require.Equal(t, gitRepositoriesNamespaceID.String(), namespace["id"])
+	require.Equal(t, 2, namespace["read_permission"])
+	require.Equal(t, map[string]interface{}{"Administer": 1, "GenericRead": 2}, namespace["action_bits"])
+	require.Equal(t, map[string]interface{}{"name": "GenericRead", "display_name": "Read", "bit": 2}, namespace["actions"].([]interface{})[1])
+}
+
+func TestDataSourceSecurityNamespaces_Read_ErrorsWhenNamespaceIsMissing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	securityClient := azdosdkmocks.NewMockSecurityClient(ctrl)
+	clients := &client.AggregatedClient{SecurityClient: securityClient, Ctx: context.Background()}
+
+	securityClient.EXPECT().
+		QuerySecurityNamespaces(clients.Ctx, gomock.Any()).
+		Return(securityNamespacesResponse(), nil).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, DataSecurityNamespaces().Schema, map[string]interface{}{"name": "Unknown"})
+	err := dataSourceSecurityNamespacesRead(d, clients)
+	require.Contains(t, err.Error(), "was not found")
+}
+
+func TestDataSourceSecurityNamespaces_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	securityClient := azdosdkmocks.NewMockSecurityClient(ctrl)
+	clients := &client.AggregatedClient{SecurityClient: securityClient, Ctx: context.Background()}
+
+	securityClient.EXPECT().
+		QuerySecurityNamespaces(clients.Ctx, security.QuerySecurityNamespacesArgs{SecurityNamespaceId: &gitRepositoriesNamespaceID}).
+		Return(nil, errors.New("QuerySecurityNamespaces() Failed")).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, DataSecurityNamespaces().Schema, map[string]interface{}{"namespace_id": gitRepositoriesNamespaceID.String()})
+	err := dataSourceSecurityNamespacesRead(d, clients)
+	require.Contains(t, err.Error(), "QuerySecurityNamespaces() Failed")
+}
@@ -0,0 +1,62 @@
+package permissions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &gitTokenFunction{}
+
+// NewGitTokenFunction builds the security token of a project, a repository or a branch in the git
+// repositories security namespace
+func NewGitTokenFunction() function.Function {
+	return &gitTokenFunction{}
+}
+
+type gitTokenFunction struct{}
+
+func (f *gitTokenFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "git_token"
+}
+
+func (f *gitTokenFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Builds the security token of the git repositories security namespace",
+		Description: "Builds the security token of all repositories of a project, of a repository or of a branch, as used by the git repositories security namespace. Pass empty strings to omit the repository or the branch.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "project_id",
+				Description: "The ID of the project.",
+			},
+			function.StringParameter{
+				Name:        "repository_id",
+				Description: "The ID of the repository, or an empty string for all repositories of the project.",
+			},
+			function.StringParameter{
+				Name:        "branch_name",
+				Description: "The name of the branch, with or without the `refs/heads/` prefix, or an empty string for the whole repository.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *gitTokenFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var projectID, repositoryID, branchName string
+	resp.Error = req.Arguments.Get(ctx, &projectID, &repositoryID, &branchName)
+	if resp.Error != nil {
+		return
+	}
+
+	if projectID == "" {
+		resp.Error = function.NewArgumentFuncError(0, "project_id must not be empty")
+		return
+	}
+	token, err := gitToken(projectID, repositoryID, branchName)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, token)
+}
@@ -0,0 +1,51 @@
+//go:build (all || permissions || resource_git_permissions) && (!exclude_permissions || !exclude_resource_project_permissions)
+// +build all permissions resource_git_permissions
+// +build !exclude_permissions !exclude_resource_project_permissions
+
+package permissions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func runGitTokenFunction(projectID, repositoryID, branchName string) *function.RunResponse {
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	NewGitTokenFunction().Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue(projectID),
+			types.StringValue(repositoryID),
+			types.StringValue(branchName),
+		}),
+	}, resp)
+	return resp
+}
+
+func TestGitTokenFunction_BuildsTokens(t *testing.T) {
+	for _, tc := range []struct {
+		repositoryID string
+		branchName   string
+		expected     string
+	}{
+		{"", "", gitTokenProject},
+		{gitRepositoryID, "", gitTokenRepository},
+		{gitRepositoryID, gitBranchNameValid, gitTokenBranch},
+		{gitRepositoryID, "refs/heads/" + gitBranchNameValid, gitTokenBranch},
+		{gitRepositoryID, gitBranchNameValid + "/" + gitSubBranchNameValid, gitTokenSubBranch},
+	} {
+		resp := runGitTokenFunction(gitProjectID, tc.repositoryID, tc.branchName)
+		require.Nil(t, resp.Error)
+		require.Equal(t, types.StringValue(tc.expected), resp.Result.Value())
+	}
+}
+
+func TestGitTokenFunction_RequiresRepositoryForBranch(t *testing.T) {
+	resp := runGitTokenFunction(gitProjectID, "", gitBranchNameValid)
+	require.NotNil(t, resp.Error)
+	require.Equal(t, int64(2), *resp.Error.FunctionArgument)
+}
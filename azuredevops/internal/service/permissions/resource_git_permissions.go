@@ -8,9 +8,11 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	securityhelper "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/permissions/utils"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/datahelper"
 )
 
 // ResourceGitPermissions schema and implementation for Git repository permission resource
@@ -34,11 +36,24 @@ func ResourceGitPermissions() *schema.Resource {
 				ForceNew:     true,
 			},
 			"branch_name": {
-				Type:         schema.TypeString,
-				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Type:          schema.TypeString,
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"branch_pattern"},
+			},
+			"branch_pattern": {
+				Type:          schema.TypeString,
+				ValidateFunc:  validation.StringMatch(regexp.MustCompile(`\*`), "the pattern must contain the wildcard *"),
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"branch_name"},
+			},
+			"concurrent_workers": {
+				Type:         schema.TypeInt,
 				Optional:     true,
-				ForceNew:     true,
-				RequiredWith: []string{"repository_id"},
+				Default:      datahelper.DefaultConcurrentWorkers,
+				ValidateFunc: validation.IntAtLeast(1),
 			},
 		}),
 	}
@@ -47,10 +62,24 @@ func ResourceGitPermissions() *schema.Resource {
 func resourceGitPermissionsCreateOrUpdate(d *schema.ResourceData, m interface{}) error {
 	clients := m.(*client.AggregatedClient)
 
+	if targetsManyGitTokens(d) {
+		sns, err := newGitSecurityNamespaces(d, clients)
+		if err != nil {
+			return err
+		}
+		if err := securityhelper.SetPrincipalPermissionsOfTokens(d, sns, d.Get("concurrent_workers").(int), nil, false); err != nil {
+			return err
+		}
+		d.SetId(gitPermissionsWildcardID(d))
+		return resourceGitPermissionsRead(d, m)
+	}
+
 	sn, err := securityhelper.NewSecurityNamespace(d, clients, securityhelper.SecurityNamespaceIDValues.GitRepositories, createGitToken)
 	if err != nil {
 		return err
 	}
+	// the permissions of many repositories are usually applied at once, write them in bulk
+	sn.WithBatchedUpdates()
 
 	if err := securityhelper.SetPrincipalPermissions(d, sn, nil, false); err != nil {
 		return err
@@ -62,6 +91,10 @@ func resourceGitPermissionsCreateOrUpdate(d *schema.ResourceData, m interface{})
 func resourceGitPermissionsRead(d *schema.ResourceData, m interface{}) error {
 	clients := m.(*client.AggregatedClient)
 
+	if targetsManyGitTokens(d) {
+		return readGitPermissionsOfTokens(d, clients)
+	}
+
 	sn, err := securityhelper.NewSecurityNamespace(d, clients, securityhelper.SecurityNamespaceIDValues.GitRepositories, createGitToken)
 	if err != nil {
 		return err
@@ -84,10 +117,23 @@ func resourceGitPermissionsRead(d *schema.ResourceData, m interface{}) error {
 func resourceGitPermissionsDelete(d *schema.ResourceData, m interface{}) error {
 	clients := m.(*client.AggregatedClient)
 
+	if targetsManyGitTokens(d) {
+		sns, err := newGitSecurityNamespaces(d, clients)
+		if err != nil {
+			return err
+		}
+		if err := securityhelper.SetPrincipalPermissionsOfTokens(d, sns, d.Get("concurrent_workers").(int), &securityhelper.PermissionTypeValues.NotSet, true); err != nil {
+			return err
+		}
+		d.SetId("")
+		return nil
+	}
+
 	sn, err := securityhelper.NewSecurityNamespace(d, clients, securityhelper.SecurityNamespaceIDValues.GitRepositories, createGitToken)
 	if err != nil {
 		return err
 	}
+	sn.WithBatchedUpdates()
 
 	if err := securityhelper.SetPrincipalPermissions(d, sn, &securityhelper.PermissionTypeValues.NotSet, true); err != nil {
 		return err
@@ -102,7 +148,12 @@ func createGitToken(d *schema.ResourceData, clients *client.AggregatedClient) (s
 	if !ok {
 		return "", fmt.Errorf("Failed to get 'project_id' from schema")
 	}
+	return gitToken(projectID.(string), d.Get("repository_id").(string), d.Get("branch_name").(string))
+}
 
+// gitToken builds the security token of the git repositories namespace for a project, a repository or
+// a branch. The repository and the branch are optional, a branch requires a repository.
+func gitToken(projectID string, repositoryID string, branchName string) (string, error) {
 	/*
 	 * Token format
 	 * ACL for ALL Git repositories in a project:                 repoV2/#ProjectID#
@@ -110,19 +161,17 @@ func createGitToken(d *schema.ResourceData, clients *client.AggregatedClient) (s
 	 * ACL for all branches inside a Git repository in a project: repoV2/#ProjectID#/#RepositoryID#/refs/heads
 	 * ACL for a branch inside a Git repository in a project:     repoV2/#ProjectID#/#RepositoryID#/refs/heads/#BranchID#
 	 */
-	aclToken := "repoV2/" + projectID.(string)
-	repositoryID, repoOk := d.GetOk("repository_id")
-	if repoOk {
-		aclToken += "/" + repositoryID.(string)
-	}
-	branchName, branchOk := d.GetOk("branch_name")
-	if branchOk {
-		if !repoOk {
+	aclToken := "repoV2/" + projectID
+	if repositoryID != "" {
+		aclToken += "/" + repositoryID
+	}
+	if branchName != "" {
+		if repositoryID == "" {
 			return "", fmt.Errorf("Unable to create ACL token for branch %s, because no repository is specified", branchName)
 		}
 
 		re := regexp.MustCompile(`(/?refs/heads/)?(.*)+`)
-		branchPath := re.FindStringSubmatch(branchName.(string))
+		branchPath := re.FindStringSubmatch(branchName)
 
 		paths := strings.Split(branchPath[len(branchPath)-1], "/")
 		encodedPaths := make([]string, len(paths))
@@ -138,3 +187,176 @@ func createGitToken(d *schema.ResourceData, clients *client.AggregatedClient) (s
 	}
 	return aclToken, nil
 }
+
+// targetsManyGitTokens reports whether the permissions apply to branches of several repositories or to the
+// branches matching a pattern, which have a token each
+func targetsManyGitTokens(d *schema.ResourceData) bool {
+	if d.Get("branch_pattern").(string) != "" {
+		return true
+	}
+	return d.Get("branch_name").(string) != "" && d.Get("repository_id").(string) == ""
+}
+
+// gitPermissionsWildcardID is the ID of permissions which apply to many tokens, as the tokens change when
+// branches and repositories are created
+func gitPermissionsWildcardID(d *schema.ResourceData) string {
+	repositoryID := d.Get("repository_id").(string)
+	if repositoryID == "" {
+		repositoryID = "*"
+	}
+	branch := d.Get("branch_pattern").(string)
+	if branch == "" {
+		branch = d.Get("branch_name").(string)
+	}
+	return fmt.Sprintf("repoV2/%s/%s/refs/heads/%s/%s", d.Get("project_id").(string), repositoryID, strings.TrimPrefix(strings.TrimPrefix(branch, "/"), "refs/heads/"), d.Get("principal").(string))
+}
+
+// newGitSecurityNamespaces returns a security namespace for every token the permissions currently apply to
+func newGitSecurityNamespaces(d *schema.ResourceData, clients *client.AggregatedClient) ([]*securityhelper.SecurityNamespace, error) {
+	tokens, err := gitPermissionTokens(d, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	sns := make([]*securityhelper.SecurityNamespace, 0, len(tokens))
+	for _, token := range tokens {
+		token := token
+		sn, err := securityhelper.NewSecurityNamespace(d, clients, securityhelper.SecurityNamespaceIDValues.GitRepositories, func(*schema.ResourceData, *client.AggregatedClient) (string, error) {
+			return token, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sns = append(sns, sn.WithBatchedUpdates())
+	}
+	return sns, nil
+}
+
+// readGitPermissionsOfTokens reads the permissions of the principal on all tokens. A permission which differs
+// from the configuration on any token is reported, so that branches created since the last apply are updated.
+func readGitPermissionsOfTokens(d *schema.ResourceData, clients *client.AggregatedClient) error {
+	sns, err := newGitSecurityNamespaces(d, clients)
+	if err != nil {
+		return err
+	}
+
+	configured := d.Get("permissions").(map[string]interface{})
+	permissions := make(map[string]interface{}, len(configured))
+	for action, permission := range configured {
+		permissions[action] = permission
+	}
+	for _, sn := range sns {
+		principalPermissions, err := securityhelper.GetPrincipalPermissions(d, sn)
+		if err != nil {
+			return err
+		}
+		for action, permission := range configured {
+			current := securityhelper.PermissionTypeValues.NotSet
+			if principalPermissions != nil {
+				if value, ok := principalPermissions.Permissions[securityhelper.ActionName(action)]; ok {
+					current = value
+				}
+			}
+			if !strings.EqualFold(string(current), permission.(string)) {
+				permissions[action] = string(current)
+			}
+		}
+	}
+
+	d.Set("permissions", permissions)
+	return nil
+}
+
+// gitPermissionTokens returns the tokens of the branches the permissions apply to. A branch name without a
+// repository applies to the branch of every repository of the project, a pattern applies to the existing
+// branches matching it.
+func gitPermissionTokens(d *schema.ResourceData, clients *client.AggregatedClient) ([]string, error) {
+	projectID := d.Get("project_id").(string)
+	repositoryIDs := []string{}
+	if repositoryID := d.Get("repository_id").(string); repositoryID != "" {
+		repositoryIDs = append(repositoryIDs, repositoryID)
+	} else {
+		repositories, err := clients.GitReposClient.GetRepositories(clients.Ctx, git.GetRepositoriesArgs{
+			Project: &projectID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf(" listing the repositories of project %s: %+v", projectID, err)
+		}
+		if repositories != nil {
+			for _, repository := range *repositories {
+				if repository.Id == nil || converter.ToBool(repository.IsDisabled, false) {
+					continue
+				}
+				repositoryIDs = append(repositoryIDs, repository.Id.String())
+			}
+		}
+	}
+
+	// the branches of the repositories are listed by up to concurrent_workers at the same time
+	repositoryTokens := make([][]string, len(repositoryIDs))
+	pattern := d.Get("branch_pattern").(string)
+	branchName := d.Get("branch_name").(string)
+	err := datahelper.ForEachConcurrently(len(repositoryIDs), d.Get("concurrent_workers").(int), func(i int) error {
+		branchNames := []string{branchName}
+		if pattern != "" {
+			var err error
+			branchNames, err = matchingGitBranches(clients, projectID, repositoryIDs[i], pattern)
+			if err != nil {
+				return err
+			}
+		}
+		for _, branchName := range branchNames {
+			token, err := gitToken(projectID, repositoryIDs[i], branchName)
+			if err != nil {
+				return err
+			}
+			repositoryTokens[i] = append(repositoryTokens[i], token)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []string
+	for _, t := range repositoryTokens {
+		tokens = append(tokens, t...)
+	}
+	return tokens, nil
+}
+
+// matchingGitBranches lists the names of the branches of a repository matching a pattern, the wildcard *
+// matches any characters including slashes. Like the prefix filter of the refs the pattern is case-sensitive.
+func matchingGitBranches(clients *client.AggregatedClient, projectID string, repositoryID string, pattern string) ([]string, error) {
+	pattern = strings.TrimPrefix(strings.TrimPrefix(pattern, "/"), "refs/heads/")
+	matcher, err := regexp.Compile("^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$")
+	if err != nil {
+		return nil, err
+	}
+
+	args := git.GetRefsArgs{
+		RepositoryId: &repositoryID,
+		Project:      &projectID,
+		Filter:       converter.String("heads/" + pattern[:strings.Index(pattern, "*")]),
+	}
+	var branchNames []string
+	for {
+		refs, err := clients.GitReposClient.GetRefs(clients.Ctx, args)
+		if err != nil {
+			return nil, fmt.Errorf(" listing the branches of repository %s: %+v", repositoryID, err)
+		}
+		if refs == nil {
+			return branchNames, nil
+		}
+		for _, ref := range refs.Value {
+			branchName := strings.TrimPrefix(converter.ToString(ref.Name, ""), "refs/heads/")
+			if matcher.MatchString(branchName) {
+				branchNames = append(branchNames, branchName)
+			}
+		}
+		if refs.ContinuationToken == "" || len(refs.Value) == 0 {
+			return branchNames, nil
+		}
+		args.ContinuationToken = converter.String(refs.ContinuationToken)
+	}
+}
@@ -13,7 +13,10 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
 	"github.com/stretchr/testify/assert"
@@ -99,6 +102,123 @@ func TestGitPermissions_CreateGitTokenWithBranch(t *testing.T) {
 	assert.Equal(t, gitTokenSubBranch, token)
 }
 
+func TestGitPermissions_TargetsManyGitTokens(t *testing.T) {
+	assert.False(t, targetsManyGitTokens(getGitPermissionsResource(t, gitProjectID, "", "")))
+	assert.False(t, targetsManyGitTokens(getGitPermissionsResource(t, gitProjectID, gitRepositoryID, gitBranchNameValid)))
+	assert.True(t, targetsManyGitTokens(getGitPermissionsResource(t, gitProjectID, "", gitBranchNameValid)))
+
+	d := getGitPermissionsResource(t, gitProjectID, gitRepositoryID, "")
+	d.Set("branch_pattern", "release/*")
+	assert.True(t, targetsManyGitTokens(d))
+}
+
+func TestGitPermissions_GitPermissionTokens_BranchOfAllRepositories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	otherRepositoryID := uuid.New()
+	disabledRepositoryID := uuid.New()
+	reposClient.EXPECT().
+		GetRepositories(clients.Ctx, git.GetRepositoriesArgs{Project: &gitProjectID}).
+		Return(&[]git.GitRepository{
+			{Id: converter.UUID(gitRepositoryID)},
+			{Id: &otherRepositoryID},
+			{Id: &disabledRepositoryID, IsDisabled: converter.Bool(true)},
+		}, nil).
+		Times(1)
+
+	tokens, err := gitPermissionTokens(getGitPermissionsResource(t, gitProjectID, "", gitBranchNameValid), clients)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		gitTokenBranch,
+		fmt.Sprintf("%s/%s/refs/heads/%s", gitTokenProject, otherRepositoryID, encodeBranchName(gitBranchNameValid)),
+	}, tokens)
+}
+
+func TestGitPermissions_GitPermissionTokens_BranchesMatchingPattern(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	args := git.GetRefsArgs{
+		RepositoryId: &gitRepositoryID,
+		Project:      &gitProjectID,
+		Filter:       converter.String("heads/" + gitBranchNameValid + "/"),
+	}
+	nextArgs := args
+	nextArgs.ContinuationToken = converter.String("next")
+	gomock.InOrder(
+		reposClient.EXPECT().
+			GetRefs(clients.Ctx, args).
+			Return(&git.GetRefsResponseValue{
+				Value:             []git.GitRef{{Name: converter.String("refs/heads/master/1.0.0")}, {Name: converter.String("refs/heads/master/1.0.0-hotfix")}},
+				ContinuationToken: "next",
+			}, nil).
+			Times(1),
+		reposClient.EXPECT().
+			GetRefs(clients.Ctx, nextArgs).
+			Return(&git.GetRefsResponseValue{
+				Value: []git.GitRef{{Name: converter.String("refs/heads/master/2.0.0")}},
+			}, nil).
+			Times(1),
+	)
+
+	d := getGitPermissionsResource(t, gitProjectID, gitRepositoryID, "")
+	d.Set("branch_pattern", "refs/heads/master/*.0.0")
+	tokens, err := gitPermissionTokens(d, clients)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		gitTokenSubBranch,
+		fmt.Sprintf("%s/refs/heads/%s/%s", gitTokenRepository, encodeBranchName(gitBranchNameValid), encodeBranchName("2.0.0")),
+	}, tokens)
+}
+
+func TestGitPermissions_GitPermissionTokens_PatternIsCaseSensitive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	reposClient.EXPECT().
+		GetRefs(clients.Ctx, git.GetRefsArgs{
+			RepositoryId: &gitRepositoryID,
+			Project:      &gitProjectID,
+			Filter:       converter.String("heads/release/"),
+		}).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{{Name: converter.String("refs/heads/release/1.0")}, {Name: converter.String("refs/heads/release/1.0-RC")}},
+		}, nil).
+		Times(1)
+
+	d := getGitPermissionsResource(t, gitProjectID, gitRepositoryID, "")
+	d.Set("branch_pattern", "release/*-rc")
+	tokens, err := gitPermissionTokens(d, clients)
+	assert.Nil(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestGitPermissions_GitPermissionTokens_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	reposClient.EXPECT().
+		GetRepositories(clients.Ctx, gomock.Any()).
+		Return(nil, fmt.Errorf("GetRepositories() Failed")).
+		Times(1)
+
+	_, err := gitPermissionTokens(getGitPermissionsResource(t, gitProjectID, "", gitBranchNameValid), clients)
+	assert.Contains(t, err.Error(), "GetRepositories() Failed")
+}
+
 func encodeBranchName(branchName string) string {
 	ret, _ := converter.EncodeUtf16HexString(branchName)
 	return ret
@@ -0,0 +1,113 @@
+package permissions
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/workitemtrackingprocess"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	securityhelper "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/permissions/utils"
+)
+
+// ResourceProcessPermissions schema and implementation for process permission resource
+func ResourceProcessPermissions() *schema.Resource {
+	resourceSchema := securityhelper.CreatePermissionResourceSchema(map[string]*schema.Schema{
+		"process_id": {
+			Type:         schema.TypeString,
+			ValidateFunc: validation.IsUUID,
+			Required:     true,
+			ForceNew:     true,
+		},
+	})
+	resourceSchema["permissions"].ValidateDiagFunc = validation.MapKeyMatch(
+		regexp.MustCompile("^(Edit|Delete|Create|AdministerProcessPermissions|ReadProcessPermissions)$"),
+		"the process permissions are Edit, Delete, Create, AdministerProcessPermissions, ReadProcessPermissions")
+
+	return &schema.Resource{
+		Create: resourceProcessPermissionsCreateOrUpdate,
+		Read:   resourceProcessPermissionsRead,
+		Update: resourceProcessPermissionsCreateOrUpdate,
+		Delete: resourceProcessPermissionsDelete,
+		Schema: resourceSchema,
+	}
+}
+
+func resourceProcessPermissionsCreateOrUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	sn, err := securityhelper.NewSecurityNamespace(d, clients, securityhelper.SecurityNamespaceIDValues.Process, createProcessToken)
+	if err != nil {
+		return err
+	}
+
+	if err := securityhelper.SetPrincipalPermissions(d, sn, nil, false); err != nil {
+		return err
+	}
+
+	return resourceProcessPermissionsRead(d, m)
+}
+
+func resourceProcessPermissionsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	sn, err := securityhelper.NewSecurityNamespace(d, clients, securityhelper.SecurityNamespaceIDValues.Process, createProcessToken)
+	if err != nil {
+		return err
+	}
+
+	principalPermissions, err := securityhelper.GetPrincipalPermissions(d, sn)
+	if err != nil {
+		return err
+	}
+	if principalPermissions == nil {
+		d.SetId("")
+		log.Printf("[INFO] Permissions for ACL token %q not found. Removing from state", sn.GetToken())
+		return nil
+	}
+
+	d.Set("permissions", principalPermissions.Permissions)
+	return nil
+}
+
+func resourceProcessPermissionsDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	sn, err := securityhelper.NewSecurityNamespace(d, clients, securityhelper.SecurityNamespaceIDValues.Process, createProcessToken)
+	if err != nil {
+		return err
+	}
+
+	if err := securityhelper.SetPrincipalPermissions(d, sn, &securityhelper.PermissionTypeValues.NotSet, true); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+// createProcessToken creates the token of a process, which contains the parent (system) process of an inherited process
+func createProcessToken(d *schema.ResourceData, clients *client.AggregatedClient) (string, error) {
+	processID, ok := d.GetOk("process_id")
+	if !ok {
+		return "", fmt.Errorf("Failed to get 'process_id' from schema")
+	}
+	processTypeID, err := uuid.Parse(processID.(string))
+	if err != nil {
+		return "", fmt.Errorf(" parsing process ID %s: %+v", processID.(string), err)
+	}
+
+	process, err := clients.WorkItemTrackingProcessClient.GetProcessByItsId(clients.Ctx, workitemtrackingprocess.GetProcessByItsIdArgs{
+		ProcessTypeId: &processTypeID,
+	})
+	if err != nil {
+		return "", fmt.Errorf(" reading process %s: %+v", processTypeID, err)
+	}
+
+	if process.ParentProcessTypeId == nil || *process.ParentProcessTypeId == uuid.Nil {
+		return fmt.Sprintf("$PROCESS:%s:", processTypeID), nil
+	}
+	return fmt.Sprintf("$PROCESS:%s:%s:", process.ParentProcessTypeId.String(), processTypeID), nil
+}
@@ -0,0 +1,93 @@
+//go:build (all || permissions || resource_process_permissions) && (!exclude_permissions || !resource_process_permissions)
+// +build all permissions resource_process_permissions
+// +build !exclude_permissions !resource_process_permissions
+
+package permissions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/workitemtrackingprocess"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var agileProcessID = uuid.MustParse("adcc42ab-9882-485e-a3ed-7678f01f66bc")
+var inheritedProcessID = uuid.MustParse("9c8d2d3a-0a0e-4d6b-9b0e-2a1f5a2f6f1b")
+
+func TestProcessPermissions_CreateProcessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	processClient := azdosdkmocks.NewMockWorkitemtrackingprocessClient(ctrl)
+	clients := &client.AggregatedClient{
+		WorkItemTrackingProcessClient: processClient,
+		Ctx:                           context.Background(),
+	}
+
+	processClient.EXPECT().
+		GetProcessByItsId(clients.Ctx, workitemtrackingprocess.GetProcessByItsIdArgs{ProcessTypeId: &inheritedProcessID}).
+		Return(&workitemtrackingprocess.ProcessInfo{TypeId: &inheritedProcessID, ParentProcessTypeId: &agileProcessID}, nil).
+		Times(1)
+	processClient.EXPECT().
+		GetProcessByItsId(clients.Ctx, workitemtrackingprocess.GetProcessByItsIdArgs{ProcessTypeId: &agileProcessID}).
+		Return(&workitemtrackingprocess.ProcessInfo{TypeId: &agileProcessID, ParentProcessTypeId: &uuid.Nil}, nil).
+		Times(1)
+
+	token, err := createProcessToken(getProcessPermissionsResource(t, inheritedProcessID.String()), clients)
+	assert.Nil(t, err)
+	assert.Equal(t, "$PROCESS:"+agileProcessID.String()+":"+inheritedProcessID.String()+":", token)
+
+	token, err = createProcessToken(getProcessPermissionsResource(t, agileProcessID.String()), clients)
+	assert.Nil(t, err)
+	assert.Equal(t, "$PROCESS:"+agileProcessID.String()+":", token)
+
+	token, err = createProcessToken(getProcessPermissionsResource(t, ""), clients)
+	assert.Empty(t, token)
+	assert.NotNil(t, err)
+}
+
+func TestProcessPermissions_CreateProcessToken_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	processClient := azdosdkmocks.NewMockWorkitemtrackingprocessClient(ctrl)
+	clients := &client.AggregatedClient{
+		WorkItemTrackingProcessClient: processClient,
+		Ctx:                           context.Background(),
+	}
+
+	processClient.EXPECT().
+		GetProcessByItsId(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetProcessByItsId() Failed")).
+		Times(1)
+
+	_, err := createProcessToken(getProcessPermissionsResource(t, inheritedProcessID.String()), clients)
+	require.ErrorContains(t, err, "GetProcessByItsId() Failed")
+}
+
+func TestProcessPermissions_ValidatePermissionNames(t *testing.T) {
+	validate := ResourceProcessPermissions().Schema["permissions"].ValidateDiagFunc
+
+	diags := validate(map[string]interface{}{"Edit": "Deny", "Delete": "Deny", "Create": "Allow"}, cty.GetAttrPath("permissions"))
+	require.False(t, diags.HasError())
+
+	diags = validate(map[string]interface{}{"CREATE_PROJECTS": "Allow"}, cty.GetAttrPath("permissions"))
+	require.True(t, diags.HasError())
+}
+
+func getProcessPermissionsResource(t *testing.T, processID string) *schema.ResourceData {
+	d := schema.TestResourceDataRaw(t, ResourceProcessPermissions().Schema, nil)
+	if processID != "" {
+		d.Set("process_id", processID)
+	}
+	return d
+}
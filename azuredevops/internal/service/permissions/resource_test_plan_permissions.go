@@ -0,0 +1,164 @@
+package permissions
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	securityhelper "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/permissions/utils"
+)
+
+// the test plan permissions of the CSS namespace, which are granted per area
+var areaTestPlanActions = []securityhelper.ActionName{
+	"MANAGE_TEST_PLANS",
+	"MANAGE_TEST_SUITES",
+}
+
+// the test permissions of the project namespace, test runs are not bound to an area
+var projectTestPlanActions = []securityhelper.ActionName{
+	"PUBLISH_TEST_RESULTS",
+	"DELETE_TEST_RESULTS",
+	"VIEW_TEST_RESULTS",
+	"MANAGE_TEST_ENVIRONMENTS",
+	"MANAGE_TEST_CONFIGURATIONS",
+}
+
+// ResourceTestPlanPermissions schema and implementation for the test plan permission resource, which manages
+// the test permissions of an area and of the project with a single set of permissions
+func ResourceTestPlanPermissions() *schema.Resource {
+	actions := make([]string, 0, len(areaTestPlanActions)+len(projectTestPlanActions))
+	for _, action := range append(append([]securityhelper.ActionName{}, areaTestPlanActions...), projectTestPlanActions...) {
+		actions = append(actions, string(action))
+	}
+
+	resourceSchema := securityhelper.CreatePermissionResourceSchema(map[string]*schema.Schema{
+		"project_id": {
+			Type:         schema.TypeString,
+			ValidateFunc: validation.IsUUID,
+			Required:     true,
+			ForceNew:     true,
+		},
+		"path": {
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+			ForceNew:     true,
+			Optional:     true,
+		},
+	})
+	resourceSchema["permissions"].ValidateDiagFunc = validation.MapKeyMatch(
+		regexp.MustCompile(fmt.Sprintf("^(%s)$", strings.Join(actions, "|"))),
+		fmt.Sprintf("the test plan permissions are %s", strings.Join(actions, ", ")))
+
+	return &schema.Resource{
+		Create:        resourceTestPlanPermissionsCreateOrUpdate,
+		Read:          resourceTestPlanPermissionsRead,
+		Update:        resourceTestPlanPermissionsCreateOrUpdate,
+		Delete:        resourceTestPlanPermissionsDelete,
+		CustomizeDiff: validateTestPlanPermissionsScope,
+		Schema:        resourceSchema,
+	}
+}
+
+func resourceTestPlanPermissionsCreateOrUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	areaNamespace, projectNamespace, err := newTestPlanSecurityNamespaces(d, clients)
+	if err != nil {
+		return err
+	}
+
+	if err := securityhelper.SetPrincipalPermissionsOfActions(d, areaNamespace, areaTestPlanActions, nil, false); err != nil {
+		return err
+	}
+	if err := securityhelper.SetPrincipalPermissionsOfActions(d, projectNamespace, projectTestPlanActions, nil, false); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", areaNamespace.GetToken(), d.Get("principal").(string)))
+	return resourceTestPlanPermissionsRead(d, m)
+}
+
+func resourceTestPlanPermissionsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	areaNamespace, projectNamespace, err := newTestPlanSecurityNamespaces(d, clients)
+	if err != nil {
+		return err
+	}
+
+	permissions := map[securityhelper.ActionName]securityhelper.PermissionType{}
+	found := false
+	for _, sn := range []*securityhelper.SecurityNamespace{areaNamespace, projectNamespace} {
+		principalPermissions, err := securityhelper.GetPrincipalPermissions(d, sn)
+		if err != nil {
+			return err
+		}
+		if principalPermissions == nil {
+			continue
+		}
+		found = true
+		for action, permission := range principalPermissions.Permissions {
+			permissions[action] = permission
+		}
+	}
+	if !found {
+		d.SetId("")
+		log.Printf("[INFO] Permissions for ACL tokens %q and %q not found. Removing from state", areaNamespace.GetToken(), projectNamespace.GetToken())
+		return nil
+	}
+
+	d.Set("permissions", permissions)
+	return nil
+}
+
+func resourceTestPlanPermissionsDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	areaNamespace, projectNamespace, err := newTestPlanSecurityNamespaces(d, clients)
+	if err != nil {
+		return err
+	}
+
+	if err := securityhelper.SetPrincipalPermissionsOfActions(d, areaNamespace, areaTestPlanActions, &securityhelper.PermissionTypeValues.NotSet, true); err != nil {
+		return err
+	}
+	if err := securityhelper.SetPrincipalPermissionsOfActions(d, projectNamespace, projectTestPlanActions, &securityhelper.PermissionTypeValues.NotSet, true); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// newTestPlanSecurityNamespaces returns the CSS namespace of the area and the project namespace
+func newTestPlanSecurityNamespaces(d *schema.ResourceData, clients *client.AggregatedClient) (*securityhelper.SecurityNamespace, *securityhelper.SecurityNamespace, error) {
+	areaNamespace, err := securityhelper.NewSecurityNamespace(d, clients, securityhelper.SecurityNamespaceIDValues.CSS, createAreaToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	projectNamespace, err := securityhelper.NewSecurityNamespace(d, clients, securityhelper.SecurityNamespaceIDValues.Project, createProjectToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	return areaNamespace, projectNamespace, nil
+}
+
+// validateTestPlanPermissionsScope rejects the project-wide test permissions for an area, as they would apply to
+// all areas of the project
+func validateTestPlanPermissionsScope(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Get("path").(string) == "" || !d.NewValueKnown("permissions") {
+		return nil
+	}
+	permissions := d.Get("permissions").(map[string]interface{})
+	for _, action := range projectTestPlanActions {
+		if _, ok := permissions[string(action)]; ok {
+			return fmt.Errorf(" the %s permission applies to the whole project and cannot be set for the area %s, remove `path` to set it", action, d.Get("path").(string))
+		}
+	}
+	return nil
+}
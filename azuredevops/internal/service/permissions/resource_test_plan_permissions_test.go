@@ -0,0 +1,55 @@
+//go:build (all || permissions || resource_test_plan_permissions) && (!exclude_permissions || !resource_test_plan_permissions)
+// +build all permissions resource_test_plan_permissions
+// +build !exclude_permissions !resource_test_plan_permissions
+
+package permissions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func planTestPlanPermissions(path string, permissions map[string]interface{}) error {
+	config := map[string]interface{}{
+		"project_id":  projectID,
+		"principal":   "vssgp.Uy0xLTktMTU1MTM3NDI0NS0xMjA0NDAwOTY5",
+		"permissions": permissions,
+	}
+	if path != "" {
+		config["path"] = path
+	}
+	_, err := ResourceTestPlanPermissions().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	return err
+}
+
+// verifies that the project-wide test permissions can only be set without an area
+func TestTestPlanPermissions_ValidateScope(t *testing.T) {
+	require.NoError(t, planTestPlanPermissions(`\QA`, map[string]interface{}{
+		"MANAGE_TEST_PLANS":  "Allow",
+		"MANAGE_TEST_SUITES": "Deny",
+	}))
+	require.NoError(t, planTestPlanPermissions("", map[string]interface{}{
+		"MANAGE_TEST_PLANS":    "Allow",
+		"PUBLISH_TEST_RESULTS": "Allow",
+	}))
+
+	err := planTestPlanPermissions(`\QA`, map[string]interface{}{
+		"MANAGE_TEST_PLANS":    "Allow",
+		"PUBLISH_TEST_RESULTS": "Allow",
+	})
+	require.ErrorContains(t, err, "PUBLISH_TEST_RESULTS permission applies to the whole project")
+}
+
+func TestTestPlanPermissions_ValidatePermissionNames(t *testing.T) {
+	validate := ResourceTestPlanPermissions().Schema["permissions"].ValidateDiagFunc
+
+	diags := validate(map[string]interface{}{"MANAGE_TEST_PLANS": "Allow", "VIEW_TEST_RESULTS": "Allow"}, cty.GetAttrPath("permissions"))
+	require.False(t, diags.HasError())
+
+	diags = validate(map[string]interface{}{"GENERIC_WRITE": "Allow"}, cty.GetAttrPath("permissions"))
+	require.True(t, diags.HasError())
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/identity"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/security"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
-	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
 )
 
 // ActionName type for an permission actions
@@ -181,9 +180,10 @@ type SecurityNamespace struct {
 	namespaceID    uuid.UUID
 	context        context.Context
 	securityClient security.Client
-	identityClient identity.Client
+	clients        *client.AggregatedClient
 	actions        *map[string]security.ActionDefinition
 	token          string
+	batchUpdates   bool
 }
 
 // TokenCreatorFunc signature for creating namespace tokens
@@ -204,7 +204,7 @@ func NewSecurityNamespace(d *schema.ResourceData, clients *client.AggregatedClie
 	sn.context = clients.Ctx
 	sn.namespaceID = uuid.UUID(namespaceID)
 	sn.securityClient = clients.SecurityClient
-	sn.identityClient = clients.IdentityClient
+	sn.clients = clients
 	token, err := tokenCreator(d, clients)
 	if err != nil {
 		return nil, err
@@ -213,6 +213,13 @@ func NewSecurityNamespace(d *schema.ResourceData, clients *client.AggregatedClie
 	return sn, nil
 }
 
+// WithBatchedUpdates sends the permission updates of the namespace together with the updates of other
+// tokens of the namespace, applied at the same time, through the bulk ACL API
+func (sn *SecurityNamespace) WithBatchedUpdates() *SecurityNamespace {
+	sn.batchUpdates = true
+	return sn
+}
+
 // GetToken return namespace tokens
 func (sn *SecurityNamespace) GetToken() string {
 	return sn.token
@@ -220,9 +227,7 @@ func (sn *SecurityNamespace) GetToken() string {
 
 func (sn *SecurityNamespace) GetActionDefinitions() (*map[string]security.ActionDefinition, error) {
 	if sn.actions == nil {
-		secns, err := sn.securityClient.QuerySecurityNamespaces(sn.context, security.QuerySecurityNamespacesArgs{
-			SecurityNamespaceId: &sn.namespaceID,
-		})
+		secns, err := sn.clients.LookupSecurityNamespace(sn.namespaceID)
 		if err != nil {
 			return nil, err
 		}
@@ -277,23 +282,12 @@ func (sn *SecurityNamespace) getIdentitiesFromSubjects(principal *[]string) (*[]
 		return nil, fmt.Errorf("principal is nil or empty")
 	}
 
-	descriptors := linq.From(*principal).
-		Aggregate(func(r interface{}, i interface{}) interface{} {
-			if r.(string) == "" {
-				return i
-			}
-			return r.(string) + "," + i.(string)
-		}).(string)
-
-	idlist, err := sn.identityClient.ReadIdentities(sn.context, identity.ReadIdentitiesArgs{
-		SubjectDescriptors: converter.String(descriptors),
-	})
-
+	idlist, err := sn.clients.LookupIdentitiesBySubjectDescriptors(*principal)
 	if err != nil {
 		return nil, err
 	}
 	if idlist == nil || len(*idlist) != len(*principal) {
-		return nil, fmt.Errorf("Failed to load identity information for defined principals [%s]", descriptors)
+		return nil, fmt.Errorf("Failed to load identity information for defined principals [%s]", strings.Join(*principal, ","))
 	}
 	return idlist, nil
 }
@@ -354,6 +348,7 @@ func (sn *SecurityNamespace) SetPrincipalPermissions(permissionList *[]SetPrinci
 		return err
 	}
 
+	var replaceAces, mergeAces []security.AccessControlEntry
 	for subjectDescriptor, principalPermissions := range permissionMap {
 		desc, ok := idMap[subjectDescriptor]
 		if !ok {
@@ -401,27 +396,44 @@ func (sn *SecurityNamespace) SetPrincipalPermissions(permissionList *[]SetPrinci
 			}
 		}
 
-		bMerge := !principalPermissions.Replace
-		container := struct {
-			Token                *string                        `json:"token,omitempty"`
-			Merge                *bool                          `json:"merge,omitempty"`
-			AccessControlEntries *[]security.AccessControlEntry `json:"accessControlEntries,omitempty"`
-		}{
-			Token:                &sn.token,
-			Merge:                &bMerge,
-			AccessControlEntries: &[]security.AccessControlEntry{*aceItem},
+		if principalPermissions.Replace {
+			replaceAces = append(replaceAces, *aceItem)
+		} else {
+			mergeAces = append(mergeAces, *aceItem)
 		}
+	}
 
-		_, err = sn.securityClient.SetAccessControlEntries(sn.context, security.SetAccessControlEntriesArgs{
-			SecurityNamespaceId: &sn.namespaceID,
-			Container:           container,
-		})
-		if err != nil {
-			return err
-		}
+	if sn.batchUpdates {
+		// the entries are complete, they already contain the bits of the current entries of the principals
+		return sn.clients.SetAccessControlEntriesBatched(sn.namespaceID, sn.token, append(replaceAces, mergeAces...))
+	}
+	if err := sn.setAccessControlEntries(replaceAces, false); err != nil {
+		return err
 	}
+	return sn.setAccessControlEntries(mergeAces, true)
+}
 
-	return nil
+// setAccessControlEntries writes the access control entries of the token with a single request
+func (sn *SecurityNamespace) setAccessControlEntries(aces []security.AccessControlEntry, merge bool) error {
+	if len(aces) == 0 {
+		return nil
+	}
+
+	container := struct {
+		Token                *string                        `json:"token,omitempty"`
+		Merge                *bool                          `json:"merge,omitempty"`
+		AccessControlEntries *[]security.AccessControlEntry `json:"accessControlEntries,omitempty"`
+	}{
+		Token:                &sn.token,
+		Merge:                &merge,
+		AccessControlEntries: &aces,
+	}
+
+	_, err := sn.securityClient.SetAccessControlEntries(sn.context, security.SetAccessControlEntriesArgs{
+		SecurityNamespaceId: &sn.namespaceID,
+		Container:           container,
+	})
+	return err
 }
 
 // GetPrincipalPermissions returns an array of PrincipalPermission for a Security Namespace token an a list of principals
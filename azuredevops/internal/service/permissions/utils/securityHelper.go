@@ -8,18 +8,88 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/datahelper"
 )
 
 // SetPrincipalPermissions sets permissions for a specific security namespac
 func SetPrincipalPermissions(d *schema.ResourceData, sn *SecurityNamespace, forcePermission *PermissionType, forceReplace bool) error {
+	principal, setPermissions, err := expandPrincipalPermissions(d, forcePermission, forceReplace)
+	if err != nil {
+		return err
+	}
+
+	if err := sn.SetPrincipalPermissions(&setPermissions); err != nil {
+		return err
+	}
+	if err := waitForPrincipalPermissions([]*SecurityNamespace{sn}, principal, setPermissions[0].PrincipalPermission.Permissions); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", sn.token, principal))
+	return nil
+}
+
+// SetPrincipalPermissionsOfTokens sets the permissions of the principal on the tokens of several security
+// namespaces at once. The updates are sent by up to workers at the same time, so that namespaces with batched
+// updates write them together. Unlike SetPrincipalPermissions the ID of the resource is left to the caller.
+func SetPrincipalPermissionsOfTokens(d *schema.ResourceData, sns []*SecurityNamespace, workers int, forcePermission *PermissionType, forceReplace bool) error {
+	if len(sns) == 0 {
+		return nil
+	}
+	principal, setPermissions, err := expandPrincipalPermissions(d, forcePermission, forceReplace)
+	if err != nil {
+		return err
+	}
+
+	err = datahelper.ForEachConcurrently(len(sns), workers, func(i int) error {
+		if err := sns[i].SetPrincipalPermissions(&setPermissions); err != nil {
+			return fmt.Errorf(" setting permissions of token %s: %w", sns[i].token, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return waitForPrincipalPermissions(sns, principal, setPermissions[0].PrincipalPermission.Permissions)
+}
+
+// SetPrincipalPermissionsOfActions sets only the configured permissions named by actions on the token of the
+// security namespace, for resources whose permissions span several security namespaces. Nothing is sent when
+// none of the actions is configured. Unlike SetPrincipalPermissions the ID of the resource is left to the caller.
+func SetPrincipalPermissionsOfActions(d *schema.ResourceData, sn *SecurityNamespace, actions []ActionName, forcePermission *PermissionType, forceReplace bool) error {
+	principal, setPermissions, err := expandPrincipalPermissions(d, forcePermission, forceReplace)
+	if err != nil {
+		return err
+	}
+
+	permissionMap := map[ActionName]PermissionType{}
+	for _, action := range actions {
+		if permission, ok := setPermissions[0].PrincipalPermission.Permissions[action]; ok {
+			permissionMap[action] = permission
+		}
+	}
+	if len(permissionMap) == 0 {
+		return nil
+	}
+	setPermissions[0].PrincipalPermission.Permissions = permissionMap
+
+	if err := sn.SetPrincipalPermissions(&setPermissions); err != nil {
+		return err
+	}
+	return waitForPrincipalPermissions([]*SecurityNamespace{sn}, principal, permissionMap)
+}
+
+// expandPrincipalPermissions reads the principal and the permissions to set from the resource data
+func expandPrincipalPermissions(d *schema.ResourceData, forcePermission *PermissionType, forceReplace bool) (string, []SetPrincipalPermission, error) {
 	principal, ok := d.GetOk("principal")
 	if !ok {
-		return fmt.Errorf("Failed to get 'principal' from schema")
+		return "", nil, fmt.Errorf("Failed to get 'principal' from schema")
 	}
 
 	permissions, ok := d.GetOk("permissions")
 	if !ok {
-		return fmt.Errorf("Failed to get 'permissions' from schema")
+		return "", nil, fmt.Errorf("Failed to get 'permissions' from schema")
 	}
 
 	bReplace := d.Get("replace")
@@ -42,39 +112,39 @@ func SetPrincipalPermissions(d *schema.ResourceData, sn *SecurityNamespace, forc
 				Permissions:       permissionMap,
 			},
 		}}
+	return principal.(string), setPermissions, nil
+}
 
-	if err := sn.SetPrincipalPermissions(&setPermissions); err != nil {
-		return err
-	}
-
+// waitForPrincipalPermissions waits until the service returns the permissions of the principal for all tokens
+func waitForPrincipalPermissions(sns []*SecurityNamespace, principal string, permissionMap map[ActionName]PermissionType) error {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"Waiting"},
 		Target:  []string{"Synched"},
 		Refresh: func() (interface{}, string, error) {
-			state := "Waiting"
-			currentPermissions, err := sn.GetPrincipalPermissions(&[]string{
-				principal.(string),
-			})
-			if err != nil {
-				return nil, "", fmt.Errorf("Error reading permissions for principal %s: %+v", err, principal.(string))
-			}
-			if len(*currentPermissions) != 1 {
-				return nil, "", fmt.Errorf("Received multiple permission sets for principal [%s] from backend. Expected single value.", principal.(string))
-			}
+			for _, sn := range sns {
+				currentPermissions, err := sn.GetPrincipalPermissions(&[]string{
+					principal,
+				})
+				if err != nil {
+					return nil, "", fmt.Errorf("Error reading permissions for principal %s: %+v", err, principal)
+				}
+				if len(*currentPermissions) != 1 {
+					return nil, "", fmt.Errorf("Received multiple permission sets for principal [%s] from backend. Expected single value.", principal)
+				}
 
-			bInsnyc := false
-			for key := range permissionMap {
-				value, ok := ((*currentPermissions)[0]).Permissions[key]
-				bInsnyc = ok && strings.EqualFold(string(permissionMap[key]), string(value))
+				bInsnyc := false
+				for key := range permissionMap {
+					value, ok := ((*currentPermissions)[0]).Permissions[key]
+					bInsnyc = ok && strings.EqualFold(string(permissionMap[key]), string(value))
+					if !bInsnyc {
+						break
+					}
+				}
 				if !bInsnyc {
-					break
+					return "Waiting", "Waiting", nil
 				}
 			}
-			if bInsnyc {
-				state = "Synched"
-			}
-
-			return state, state, nil
+			return "Synched", "Synched", nil
 		},
 		Timeout:                   60 * time.Minute,
 		MinTimeout:                5 * time.Second,
@@ -85,8 +155,6 @@ func SetPrincipalPermissions(d *schema.ResourceData, sn *SecurityNamespace, forc
 	if _, err := stateConf.WaitForState(); err != nil { //nolint:staticcheck
 		return fmt.Errorf(" waiting for permission update. %v ", err)
 	}
-
-	d.SetId(fmt.Sprintf("%s/%s", sn.token, principal.(string)))
 	return nil
 }
 
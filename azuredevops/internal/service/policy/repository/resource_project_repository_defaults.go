@@ -0,0 +1,330 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+// gitRepositorySettingsPolicyName is the display name of the policy type holding the settings of new repositories,
+// like their default branch name. Unlike the other policy types its ID is not documented, so it is looked up by name.
+const gitRepositorySettingsPolicyName = "GitRepositorySettingsPolicyName"
+
+// projectRepositoryDefault is a setting of the "All Repositories" settings of a project, which the service
+// stores as a policy of the project without a repository scope. The zero value of a setting removes the policy.
+type projectRepositoryDefault struct {
+	policyType uuid.UUID
+	// policyTypeName is the display name of the policy type, for policy types which are looked up by name
+	policyTypeName string
+	expand         func(value interface{}) map[string]interface{}
+	flatten        func(settings map[string]interface{}) interface{}
+}
+
+var projectRepositoryDefaults = map[string]projectRepositoryDefault{
+	"default_branch_name": {
+		policyTypeName: gitRepositorySettingsPolicyName,
+		expand: func(value interface{}) map[string]interface{} {
+			return map[string]interface{}{"DefaultBranchName": value.(string)}
+		},
+		flatten: func(settings map[string]interface{}) interface{} {
+			name, _ := settings["DefaultBranchName"].(string)
+			return strings.TrimPrefix(name, "refs/heads/")
+		},
+	},
+	"case_enforcement": {
+		policyType: CaseEnforcement,
+		expand: func(value interface{}) map[string]interface{} {
+			return map[string]interface{}{"enforceConsistentCase": value.(bool)}
+		},
+		flatten: func(settings map[string]interface{}) interface{} {
+			enforced, _ := settings["enforceConsistentCase"].(bool)
+			return enforced
+		},
+	},
+	"max_path_length": {
+		policyType: PathLength,
+		expand: func(value interface{}) map[string]interface{} {
+			return map[string]interface{}{"maxPathLength": value.(int)}
+		},
+		flatten: func(settings map[string]interface{}) interface{} {
+			length, _ := settings["maxPathLength"].(float64)
+			return int(length)
+		},
+	},
+	"max_file_size": {
+		policyType: FileSize,
+		expand: func(value interface{}) map[string]interface{} {
+			return map[string]interface{}{
+				"maximumGitBlobSizeInBytes": value.(int) * UNIT,
+				"useUncompressedSize":       false,
+			}
+		},
+		flatten: func(settings map[string]interface{}) interface{} {
+			size, _ := settings["maximumGitBlobSizeInBytes"].(float64)
+			return int(size / UNIT)
+		},
+	},
+}
+
+// ResourceProjectRepositoryDefaults schema and implementation for the "All Repositories" settings of a project
+func ResourceProjectRepositoryDefaults() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectRepositoryDefaultsCreateUpdate,
+		ReadContext:   resourceProjectRepositoryDefaultsRead,
+		UpdateContext: resourceProjectRepositoryDefaultsCreateUpdate,
+		DeleteContext: resourceProjectRepositoryDefaultsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceProjectRepositoryDefaultsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"default_branch_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.All(
+					validation.StringIsNotWhiteSpace,
+					validation.StringDoesNotMatch(regexp.MustCompile(`^refs/`), "the name of the branch must not start with refs/"),
+				),
+			},
+			"case_enforcement": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"max_path_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 10000),
+			},
+			"max_file_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntInSlice([]int{1, 2, 5, 10, 100, 200}),
+			},
+		},
+	}
+}
+
+func resourceProjectRepositoryDefaultsCreateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	for attribute, setting := range projectRepositoryDefaults {
+		// settings which are not configured are left alone, a setting removed from the configuration is a change
+		// to its zero value and removes the policy
+		if d.IsNewResource() {
+			if !projectRepositoryDefaultIsManaged(d, attribute) {
+				continue
+			}
+		} else if !d.HasChange(attribute) {
+			continue
+		}
+		if err := updateProjectRepositoryDefault(clients, projectID, setting, d.Get(attribute)); err != nil {
+			return utils.ErrorDiagnostics(fmt.Errorf(" updating %s of the repositories of project %s: %+v", attribute, projectID, err))
+		}
+	}
+
+	d.SetId(projectID)
+	return resourceProjectRepositoryDefaultsRead(ctx, d, m)
+}
+
+func resourceProjectRepositoryDefaultsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return readProjectRepositoryDefaults(d, m.(*client.AggregatedClient), false)
+}
+
+func resourceProjectRepositoryDefaultsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Id()
+
+	for attribute, setting := range projectRepositoryDefaults {
+		if !projectRepositoryDefaultIsManaged(d, attribute) {
+			continue
+		}
+		if err := updateProjectRepositoryDefault(clients, projectID, setting, nil); err != nil {
+			return utils.ErrorDiagnostics(fmt.Errorf(" removing %s of the repositories of project %s: %+v", attribute, projectID, err))
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceProjectRepositoryDefaultsImport imports every setting the project has a policy for
+func resourceProjectRepositoryDefaultsImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	diags := readProjectRepositoryDefaults(d, m.(*client.AggregatedClient), true)
+	if diags.HasError() {
+		return nil, fmt.Errorf(" importing the repository settings of project %s: %s", d.Id(), diags[0].Summary)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf(" project %s not found", d.Get("project_id"))
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// readProjectRepositoryDefaults sets the managed settings from the project policies, so that policies created
+// outside of Terraform do not show up as a diff. The import takes over every setting the project has a policy for.
+func readProjectRepositoryDefaults(d *schema.ResourceData, clients *client.AggregatedClient, importing bool) diag.Diagnostics {
+	projectID := d.Id()
+
+	for attribute, setting := range projectRepositoryDefaults {
+		if !importing && !projectRepositoryDefaultIsManaged(d, attribute) {
+			continue
+		}
+		var policyConfig *policy.PolicyConfiguration
+		policyType, err := getProjectRepositoryDefaultPolicyType(clients, projectID, setting)
+		if err == nil {
+			policyConfig, err = getProjectRepositoryDefault(clients, projectID, policyType)
+		}
+		if err != nil {
+			if utils.ResponseWasNotFound(err) {
+				d.SetId("")
+				return nil
+			}
+			return utils.ErrorDiagnostics(fmt.Errorf(" reading %s of the repositories of project %s: %+v", attribute, projectID, err))
+		}
+
+		settings := map[string]interface{}{}
+		if policyConfig != nil && converter.ToBool(policyConfig.IsEnabled, false) {
+			if policySettings, ok := policyConfig.Settings.(map[string]interface{}); ok {
+				settings = policySettings
+			}
+		} else if importing {
+			continue
+		}
+		d.Set(attribute, setting.flatten(settings))
+	}
+	d.Set("project_id", projectID)
+	return nil
+}
+
+// projectRepositoryDefaultIsManaged returns whether a setting is part of the configuration, or of the state when
+// the configuration is not available
+func projectRepositoryDefaultIsManaged(d *schema.ResourceData, attribute string) bool {
+	if config := d.GetRawConfig(); !config.IsNull() {
+		return !config.GetAttr(attribute).IsNull()
+	}
+	if state := d.GetRawState(); !state.IsNull() {
+		return !state.GetAttr(attribute).IsNull()
+	}
+	return false
+}
+
+// getProjectRepositoryDefaultPolicyType returns the ID of the policy type of a setting, looking it up by its display
+// name if the setting has no fixed ID
+func getProjectRepositoryDefaultPolicyType(clients *client.AggregatedClient, projectID string, setting projectRepositoryDefault) (uuid.UUID, error) {
+	if setting.policyTypeName == "" {
+		return setting.policyType, nil
+	}
+
+	policyTypes, err := clients.PolicyClient.GetPolicyTypes(clients.Ctx, policy.GetPolicyTypesArgs{
+		Project: &projectID,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if policyTypes != nil {
+		for _, policyType := range *policyTypes {
+			if policyType.Id != nil && strings.EqualFold(converter.ToString(policyType.DisplayName, ""), setting.policyTypeName) {
+				return *policyType.Id, nil
+			}
+		}
+	}
+	return uuid.Nil, fmt.Errorf(" policy type %s not found", setting.policyTypeName)
+}
+
+// getProjectRepositoryDefault returns the policy of a type which applies to all repositories of a project, or nil
+func getProjectRepositoryDefault(clients *client.AggregatedClient, projectID string, policyType uuid.UUID) (*policy.PolicyConfiguration, error) {
+	var continuationToken *string
+	for {
+		policies, err := clients.GitReposClient.GetPolicyConfigurations(clients.Ctx, git.GetPolicyConfigurationsArgs{
+			Project:           &projectID,
+			PolicyType:        &policyType,
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if policies == nil {
+			return nil, nil
+		}
+		if policies.PolicyConfigurations != nil {
+			for _, policyConfig := range *policies.PolicyConfigurations {
+				if converter.ToBool(policyConfig.IsDeleted, false) {
+					continue
+				}
+				repoIds, err := flattenSettings(&policyConfig)
+				if err != nil {
+					return nil, err
+				}
+				if len(repoIds) == 0 {
+					return &policyConfig, nil
+				}
+			}
+		}
+		if policies.ContinuationToken == nil || *policies.ContinuationToken == "" {
+			return nil, nil
+		}
+		continuationToken = policies.ContinuationToken
+	}
+}
+
+// updateProjectRepositoryDefault creates, updates or deletes the project policy of a setting, a zero value
+// removes the policy so that the service defaults apply again
+func updateProjectRepositoryDefault(clients *client.AggregatedClient, projectID string, setting projectRepositoryDefault, value interface{}) error {
+	policyType, err := getProjectRepositoryDefaultPolicyType(clients, projectID, setting)
+	if err != nil {
+		return err
+	}
+	existing, err := getProjectRepositoryDefault(clients, projectID, policyType)
+	if err != nil {
+		return err
+	}
+
+	if value == nil || value == false || value == 0 || value == "" {
+		if existing == nil {
+			return nil
+		}
+		return clients.PolicyClient.DeletePolicyConfiguration(clients.Ctx, policy.DeletePolicyConfigurationArgs{
+			Project:         &projectID,
+			ConfigurationId: existing.Id,
+		})
+	}
+
+	settings := setting.expand(value)
+	settings["scope"] = []map[string]interface{}{{"repositoryId": nil}}
+	policyConfig := &policy.PolicyConfiguration{
+		IsEnabled:  converter.Bool(true),
+		IsBlocking: converter.Bool(true),
+		Type:       &policy.PolicyTypeRef{Id: &policyType},
+		Settings:   settings,
+	}
+
+	if existing == nil {
+		_, err = clients.PolicyClient.CreatePolicyConfiguration(clients.Ctx, policy.CreatePolicyConfigurationArgs{
+			Project:       &projectID,
+			Configuration: policyConfig,
+		})
+		return err
+	}
+	policyConfig.Id = existing.Id
+	_, err = clients.PolicyClient.UpdatePolicyConfiguration(clients.Ctx, policy.UpdatePolicyConfigurationArgs{
+		Project:         &projectID,
+		ConfigurationId: existing.Id,
+		Configuration:   policyConfig,
+	})
+	return err
+}
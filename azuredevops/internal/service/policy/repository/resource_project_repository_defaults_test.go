@@ -0,0 +1,381 @@
+//go:build (all || policy) && !exclude_policy
+// +build all policy
+// +build !exclude_policy
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/convert"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/policy"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var defaultsProjectID = uuid.New().String()
+
+// gitRepositorySettings is the ID of the policy type of the settings of new repositories
+var gitRepositorySettings = uuid.MustParse("0517f88d-4ec5-4343-9d26-9930ebd53069")
+
+// expectPolicyTypes expects the policy types of the project to be listed
+func expectPolicyTypes(policyClient *azdosdkmocks.MockPolicyClient) {
+	policyClient.EXPECT().
+		GetPolicyTypes(gomock.Any(), policy.GetPolicyTypesArgs{Project: &defaultsProjectID}).
+		Return(&[]policy.PolicyType{
+			{Id: converter.UUID("fa4e907d-c16b-4a4c-9dfa-4916e5d171ab"), DisplayName: converter.String("Require a merge strategy")},
+			{Id: &gitRepositorySettings, DisplayName: converter.String(gitRepositorySettingsPolicyName)},
+		}, nil).
+		AnyTimes()
+}
+
+func projectPolicy(id int, policyType uuid.UUID, settings map[string]interface{}) policy.PolicyConfiguration {
+	settings["scope"] = []interface{}{map[string]interface{}{"repositoryId": nil}}
+	return policy.PolicyConfiguration{
+		Id:        converter.Int(id),
+		IsEnabled: converter.Bool(true),
+		IsDeleted: converter.Bool(false),
+		Type:      &policy.PolicyTypeRef{Id: &policyType},
+		Settings:  settings,
+	}
+}
+
+// projectPolicyConfigurations returns the policies of the requested type
+func projectPolicyConfigurations(policies map[uuid.UUID]policy.PolicyConfiguration) func(context.Context, git.GetPolicyConfigurationsArgs) (*git.GitPolicyConfigurationResponse, error) {
+	return func(ctx context.Context, args git.GetPolicyConfigurationsArgs) (*git.GitPolicyConfigurationResponse, error) {
+		value := []policy.PolicyConfiguration{}
+		if policyConfig, ok := policies[*args.PolicyType]; ok {
+			value = append(value, policyConfig)
+		}
+		return &git.GitPolicyConfigurationResponse{PolicyConfigurations: &value}, nil
+	}
+}
+
+// projectRepositoryDefaultsData returns the resource data of a state which manages the given settings
+func projectRepositoryDefaultsData(t *testing.T, attributes map[string]string) *schema.ResourceData {
+	resource := ResourceProjectRepositoryDefaults()
+	attributes["project_id"] = defaultsProjectID
+	values := map[string]cty.Value{}
+	for name, attribute := range resource.CoreConfigSchema().Attributes {
+		values[name] = cty.NullVal(attribute.Type)
+		if value, ok := attributes[name]; ok {
+			converted, err := convert.Convert(cty.StringVal(value), attribute.Type)
+			require.Nil(t, err)
+			values[name] = converted
+		}
+	}
+	return resource.Data(&terraform.InstanceState{
+		ID:         defaultsProjectID,
+		Attributes: attributes,
+		RawState:   cty.ObjectVal(values),
+	})
+}
+
+func TestProjectRepositoryDefaults_GetDefault_IgnoresRepositoryPolicies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, PolicyClient: policyClient, Ctx: context.Background()}
+
+	repositoryPolicy := projectPolicy(1, PathLength, map[string]interface{}{"maxPathLength": float64(100)})
+	repositoryPolicy.Settings = map[string]interface{}{
+		"maxPathLength": float64(100),
+		"scope":         []interface{}{map[string]interface{}{"repositoryId": uuid.New().String()}},
+	}
+	gitClient.EXPECT().
+		GetPolicyConfigurations(clients.Ctx, git.GetPolicyConfigurationsArgs{Project: &defaultsProjectID, PolicyType: &PathLength}).
+		Return(&git.GitPolicyConfigurationResponse{PolicyConfigurations: &[]policy.PolicyConfiguration{
+			repositoryPolicy,
+			projectPolicy(2, PathLength, map[string]interface{}{"maxPathLength": float64(200)}),
+		}}, nil).
+		Times(1)
+
+	projectDefault, err := getProjectRepositoryDefault(clients, defaultsProjectID, PathLength)
+	require.Nil(t, err)
+	require.Equal(t, 2, *projectDefault.Id)
+}
+
+func TestProjectRepositoryDefaults_GetDefault_PagesPolicies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, Ctx: context.Background()}
+
+	repositoryPolicy := projectPolicy(1, FileSize, map[string]interface{}{})
+	repositoryPolicy.Settings = map[string]interface{}{
+		"scope": []interface{}{map[string]interface{}{"repositoryId": uuid.New().String()}},
+	}
+	continuationToken := "2"
+	gitClient.EXPECT().
+		GetPolicyConfigurations(clients.Ctx, git.GetPolicyConfigurationsArgs{Project: &defaultsProjectID, PolicyType: &FileSize}).
+		Return(&git.GitPolicyConfigurationResponse{
+			PolicyConfigurations: &[]policy.PolicyConfiguration{repositoryPolicy},
+			ContinuationToken:    &continuationToken,
+		}, nil).
+		Times(1)
+	gitClient.EXPECT().
+		GetPolicyConfigurations(clients.Ctx, git.GetPolicyConfigurationsArgs{Project: &defaultsProjectID, PolicyType: &FileSize, ContinuationToken: &continuationToken}).
+		Return(&git.GitPolicyConfigurationResponse{PolicyConfigurations: &[]policy.PolicyConfiguration{
+			projectPolicy(2, FileSize, map[string]interface{}{"maximumGitBlobSizeInBytes": float64(UNIT)}),
+		}}, nil).
+		Times(1)
+
+	projectDefault, err := getProjectRepositoryDefault(clients, defaultsProjectID, FileSize)
+	require.Nil(t, err)
+	require.Equal(t, 2, *projectDefault.Id)
+}
+
+func TestProjectRepositoryDefaults_UpdateDefault_CreatesProjectPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, PolicyClient: policyClient, Ctx: context.Background()}
+
+	gitClient.EXPECT().
+		GetPolicyConfigurations(clients.Ctx, gomock.Any()).
+		Return(&git.GitPolicyConfigurationResponse{}, nil).
+		Times(1)
+	policyClient.EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args policy.CreatePolicyConfigurationArgs) (*policy.PolicyConfiguration, error) {
+			require.Equal(t, defaultsProjectID, *args.Project)
+			require.Equal(t, FileSize, *args.Configuration.Type.Id)
+			settings := args.Configuration.Settings.(map[string]interface{})
+			require.Equal(t, 10*UNIT, settings["maximumGitBlobSizeInBytes"])
+			require.Equal(t, []map[string]interface{}{{"repositoryId": nil}}, settings["scope"])
+			return args.Configuration, nil
+		}).
+		Times(1)
+
+	err := updateProjectRepositoryDefault(clients, defaultsProjectID, projectRepositoryDefaults["max_file_size"], 10)
+	require.Nil(t, err)
+}
+
+func TestProjectRepositoryDefaults_UpdateDefault_SendsPolicyTypeOfSetting(t *testing.T) {
+	values := map[string]interface{}{
+		"default_branch_name": "main",
+		"case_enforcement":    true,
+		"max_path_length":     100,
+		"max_file_size":       10,
+	}
+	policyTypes := map[string]uuid.UUID{
+		"default_branch_name": gitRepositorySettings,
+		"case_enforcement":    CaseEnforcement,
+		"max_path_length":     PathLength,
+		"max_file_size":       FileSize,
+	}
+	require.Len(t, policyTypes, len(projectRepositoryDefaults))
+
+	for attribute, policyType := range policyTypes {
+		t.Run(attribute, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+			policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+			clients := &client.AggregatedClient{GitReposClient: gitClient, PolicyClient: policyClient, Ctx: context.Background()}
+
+			expectPolicyTypes(policyClient)
+			gitClient.EXPECT().
+				GetPolicyConfigurations(clients.Ctx, git.GetPolicyConfigurationsArgs{Project: &defaultsProjectID, PolicyType: &policyType}).
+				Return(&git.GitPolicyConfigurationResponse{}, nil).
+				Times(1)
+			policyClient.EXPECT().
+				CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, args policy.CreatePolicyConfigurationArgs) (*policy.PolicyConfiguration, error) {
+					require.Equal(t, policyType, *args.Configuration.Type.Id)
+					return args.Configuration, nil
+				}).
+				Times(1)
+
+			err := updateProjectRepositoryDefault(clients, defaultsProjectID, projectRepositoryDefaults[attribute], values[attribute])
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestProjectRepositoryDefaults_UpdateDefault_FailsWithoutPolicyType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &client.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	policyClient.EXPECT().
+		GetPolicyTypes(clients.Ctx, gomock.Any()).
+		Return(&[]policy.PolicyType{}, nil).
+		Times(1)
+
+	err := updateProjectRepositoryDefault(clients, defaultsProjectID, projectRepositoryDefaults["default_branch_name"], "main")
+	require.ErrorContains(t, err, gitRepositorySettingsPolicyName)
+}
+
+func TestProjectRepositoryDefaults_UpdateDefault_DeletesPolicyOfZeroValue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, PolicyClient: policyClient, Ctx: context.Background()}
+
+	gitClient.EXPECT().
+		GetPolicyConfigurations(clients.Ctx, gomock.Any()).
+		Return(&git.GitPolicyConfigurationResponse{PolicyConfigurations: &[]policy.PolicyConfiguration{
+			projectPolicy(3, CaseEnforcement, map[string]interface{}{"enforceConsistentCase": true}),
+		}}, nil).
+		Times(1)
+	policyClient.EXPECT().
+		DeletePolicyConfiguration(clients.Ctx, policy.DeletePolicyConfigurationArgs{
+			Project:         &defaultsProjectID,
+			ConfigurationId: converter.Int(3),
+		}).
+		Return(nil).
+		Times(1)
+
+	err := updateProjectRepositoryDefault(clients, defaultsProjectID, projectRepositoryDefaults["case_enforcement"], false)
+	require.Nil(t, err)
+}
+
+func TestProjectRepositoryDefaults_Read_FlattensProjectPolicies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, PolicyClient: policyClient, Ctx: context.Background()}
+
+	policies := map[uuid.UUID]policy.PolicyConfiguration{
+		gitRepositorySettings: projectPolicy(1, gitRepositorySettings, map[string]interface{}{"DefaultBranchName": "refs/heads/main"}),
+		CaseEnforcement:       projectPolicy(2, CaseEnforcement, map[string]interface{}{"enforceConsistentCase": true}),
+		FileSize:              projectPolicy(3, FileSize, map[string]interface{}{"maximumGitBlobSizeInBytes": float64(5 * UNIT)}),
+	}
+	expectPolicyTypes(policyClient)
+	gitClient.EXPECT().
+		GetPolicyConfigurations(clients.Ctx, gomock.Any()).
+		DoAndReturn(projectPolicyConfigurations(policies)).
+		Times(len(projectRepositoryDefaults))
+
+	d := projectRepositoryDefaultsData(t, map[string]string{
+		"default_branch_name": "develop",
+		"case_enforcement":    "false",
+		"max_path_length":     "100",
+		"max_file_size":       "5",
+	})
+	require.Nil(t, resourceProjectRepositoryDefaultsRead(context.Background(), d, clients))
+
+	require.Equal(t, defaultsProjectID, d.Get("project_id"))
+	require.Equal(t, "main", d.Get("default_branch_name"))
+	require.Equal(t, true, d.Get("case_enforcement"))
+	require.Equal(t, 0, d.Get("max_path_length"))
+	require.Equal(t, 5, d.Get("max_file_size"))
+}
+
+func TestProjectRepositoryDefaults_Read_IgnoresUnmanagedSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, Ctx: context.Background()}
+
+	gitClient.EXPECT().
+		GetPolicyConfigurations(clients.Ctx, git.GetPolicyConfigurationsArgs{Project: &defaultsProjectID, PolicyType: &FileSize}).
+		Return(&git.GitPolicyConfigurationResponse{PolicyConfigurations: &[]policy.PolicyConfiguration{
+			projectPolicy(3, FileSize, map[string]interface{}{"maximumGitBlobSizeInBytes": float64(10 * UNIT)}),
+		}}, nil).
+		Times(1)
+
+	d := projectRepositoryDefaultsData(t, map[string]string{"max_file_size": "5"})
+	require.Nil(t, resourceProjectRepositoryDefaultsRead(context.Background(), d, clients))
+
+	require.Equal(t, 10, d.Get("max_file_size"))
+	_, ok := d.GetOk("case_enforcement")
+	require.False(t, ok)
+}
+
+func TestProjectRepositoryDefaults_Delete_RemovesOnlyManagedSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, PolicyClient: policyClient, Ctx: context.Background()}
+
+	gitClient.EXPECT().
+		GetPolicyConfigurations(clients.Ctx, git.GetPolicyConfigurationsArgs{Project: &defaultsProjectID, PolicyType: &PathLength}).
+		Return(&git.GitPolicyConfigurationResponse{PolicyConfigurations: &[]policy.PolicyConfiguration{
+			projectPolicy(4, PathLength, map[string]interface{}{"maxPathLength": float64(100)}),
+		}}, nil).
+		Times(1)
+	policyClient.EXPECT().
+		DeletePolicyConfiguration(clients.Ctx, policy.DeletePolicyConfigurationArgs{
+			Project:         &defaultsProjectID,
+			ConfigurationId: converter.Int(4),
+		}).
+		Return(nil).
+		Times(1)
+
+	d := projectRepositoryDefaultsData(t, map[string]string{"max_path_length": "100"})
+	require.Nil(t, resourceProjectRepositoryDefaultsDelete(context.Background(), d, clients))
+}
+
+func TestProjectRepositoryDefaults_Import_TakesOverExistingPolicies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, PolicyClient: policyClient, Ctx: context.Background()}
+
+	expectPolicyTypes(policyClient)
+	policies := map[uuid.UUID]policy.PolicyConfiguration{
+		CaseEnforcement: projectPolicy(2, CaseEnforcement, map[string]interface{}{"enforceConsistentCase": true}),
+	}
+	gitClient.EXPECT().
+		GetPolicyConfigurations(clients.Ctx, gomock.Any()).
+		DoAndReturn(projectPolicyConfigurations(policies)).
+		Times(len(projectRepositoryDefaults))
+
+	d := schema.TestResourceDataRaw(t, ResourceProjectRepositoryDefaults().Schema, nil)
+	d.SetId(defaultsProjectID)
+	imported, err := resourceProjectRepositoryDefaultsImport(context.Background(), d, clients)
+	require.Nil(t, err)
+	require.Len(t, imported, 1)
+
+	require.Equal(t, true, d.Get("case_enforcement"))
+	_, ok := d.GetOk("max_path_length")
+	require.False(t, ok)
+}
+
+func TestProjectRepositoryDefaults_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, PolicyClient: policyClient, Ctx: context.Background()}
+
+	gitClient.EXPECT().
+		GetPolicyConfigurations(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetPolicyConfigurations() Failed")).
+		Times(1)
+
+	d := projectRepositoryDefaultsData(t, map[string]string{"max_path_length": "100"})
+	diags := resourceProjectRepositoryDefaultsRead(context.Background(), d, clients)
+	require.True(t, diags.HasError())
+	require.Contains(t, diags[0].Summary, "GetPolicyConfigurations() Failed")
+}
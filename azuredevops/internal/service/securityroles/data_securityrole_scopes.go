@@ -0,0 +1,224 @@
+package securityroles
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/securityroles"
+)
+
+// securityRoleScope describes a scope of security roles and the resources roles are assigned on within the scope
+type securityRoleScope struct {
+	id               string
+	description      string
+	resourceIDFormat string
+}
+
+// knownSecurityRoleScopes are the scopes of security roles used by Azure DevOps. The service does not list its
+// scopes, only the roles within a scope.
+var knownSecurityRoleScopes = []securityRoleScope{
+	{
+		id:               "distributedtask.agentpoolrole",
+		description:      "Agent pools of the organization",
+		resourceIDFormat: "{poolId}",
+	},
+	{
+		id:               "distributedtask.agentqueuerole",
+		description:      "Agent pools of a project",
+		resourceIDFormat: "{projectId}_{queueId}",
+	},
+	{
+		id:               "distributedtask.globalagentqueuerole",
+		description:      "All agent pools of a project",
+		resourceIDFormat: "{projectId}",
+	},
+	{
+		id:               "distributedtask.environmentreferencerole",
+		description:      "Environments of a project",
+		resourceIDFormat: "{projectId}_{environmentId}",
+	},
+	{
+		id:               "distributedtask.machinegrouprole",
+		description:      "Deployment groups of a project",
+		resourceIDFormat: "{projectId}_{deploymentGroupId}",
+	},
+	{
+		id:               "distributedtask.serviceendpointrole",
+		description:      "Service connections of a project",
+		resourceIDFormat: "{projectId}_{serviceEndpointId}",
+	},
+	{
+		id:               "distributedtask.library",
+		description:      "The library of a project, containing its variable groups and secure files",
+		resourceIDFormat: "{projectId}$0",
+	},
+	{
+		id:               "distributedtask.variablegroup",
+		description:      "Variable groups of a project",
+		resourceIDFormat: "{projectId}${variableGroupId}",
+	},
+	{
+		id:               "distributedtask.securefile",
+		description:      "Secure files of a project",
+		resourceIDFormat: "{projectId}${secureFileId}",
+	},
+}
+
+// DataSecurityRoleScopes schema and implementation for the security role scopes data source
+func DataSecurityRoleScopes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSecurityRoleScopesRead,
+		Schema: map[string]*schema.Schema{
+			"scope_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"scopes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_id_format": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"roles": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"display_name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"description": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"identifier": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"allow_permissions": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"deny_permissions": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSecurityRoleScopesRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	scopes := knownSecurityRoleScopes
+	requested := false
+	if v, ok := d.GetOk("scope_ids"); ok {
+		scopes = requestedSecurityRoleScopes(v.(*schema.Set).List())
+		requested = true
+	}
+
+	results := make([]interface{}, 0, len(scopes))
+	for _, scope := range scopes {
+		definitions, err := clients.SecurityRolesClient.ListSecurityRoleDefinitions(clients.Ctx, &securityroles.ListSecurityRoleDefinitionsArgs{
+			Scope: converter.String(scope.id),
+		})
+		if err != nil && !utils.ResponseWasNotFound(err) {
+			return fmt.Errorf(" finding security role definitions for scope: %s. Error: %w", scope.id, err)
+		}
+		if definitions == nil || len(*definitions) == 0 {
+			// the known scopes depend on the features of the organization, only requested scopes must exist
+			if requested {
+				return fmt.Errorf(" no role definition found at scope: %s", scope.id)
+			}
+			log.Printf("[DEBUG] No role definition found at scope %s, skipping the scope", scope.id)
+			continue
+		}
+		results = append(results, flattenSecurityRoleScope(scope, definitions))
+	}
+
+	if err := d.Set("scopes", results); err != nil {
+		return fmt.Errorf(" setting scopes: %w", err)
+	}
+	ids := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		ids = append(ids, scope.id)
+	}
+	d.SetId("secrolescopes-" + strconv.Itoa(tfhelper.HashString(strings.Join(ids, ","))))
+	return nil
+}
+
+// requestedSecurityRoleScopes returns the requested scopes ordered by their ID, with the details of the known ones
+func requestedSecurityRoleScopes(ids []interface{}) []securityRoleScope {
+	scopes := make([]securityRoleScope, 0, len(ids))
+	for _, id := range ids {
+		scope := securityRoleScope{id: id.(string)}
+		for _, known := range knownSecurityRoleScopes {
+			if strings.EqualFold(known.id, scope.id) {
+				scope.description = known.description
+				scope.resourceIDFormat = known.resourceIDFormat
+				break
+			}
+		}
+		scopes = append(scopes, scope)
+	}
+	sort.Slice(scopes, func(i, j int) bool {
+		return scopes[i].id < scopes[j].id
+	})
+	return scopes
+}
+
+func flattenSecurityRoleScope(scope securityRoleScope, definitions *[]securityroles.SecurityRoleDefinition) map[string]interface{} {
+	roles := make([]interface{}, 0, len(*definitions))
+	for _, definition := range *definitions {
+		roles = append(roles, map[string]interface{}{
+			"name":              converter.ToString(definition.Name, ""),
+			"display_name":      converter.ToString(definition.DisplayName, ""),
+			"description":       converter.ToString(definition.Description, ""),
+			"identifier":        converter.ToString(definition.Identifier, ""),
+			"allow_permissions": converter.ToInt(definition.AllowPermissions, 0),
+			"deny_permissions":  converter.ToInt(definition.DenyPermissions, 0),
+		})
+	}
+
+	return map[string]interface{}{
+		"id":                 scope.id,
+		"description":        scope.description,
+		"resource_id_format": scope.resourceIDFormat,
+		"roles":              roles,
+	}
+}
@@ -0,0 +1,158 @@
+//go:build (all || data_sources || data_securityrole_scopes) && (!exclude_data_sources || !exclude_data_securityrole_scopes)
+// +build all data_sources data_securityrole_scopes
+// +build !exclude_data_sources !exclude_data_securityrole_scopes
+
+package securityroles
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/securityroles"
+	"github.com/stretchr/testify/require"
+)
+
+func testRoleDefinitions(scope string) *[]securityroles.SecurityRoleDefinition {
+	return &[]securityroles.SecurityRoleDefinition{
+		{
+			Name:             converter.String("Reader"),
+			DisplayName:      converter.String("Reader"),
+			Description:      converter.String("Can view"),
+			Identifier:       converter.String(scope + ".Reader"),
+			AllowPermissions: converter.Int(1),
+			DenyPermissions:  converter.Int(0),
+			Scope:            converter.String(scope),
+		},
+		{
+			Name:             converter.String("Administrator"),
+			DisplayName:      converter.String("Administrator"),
+			Identifier:       converter.String(scope + ".Administrator"),
+			AllowPermissions: converter.Int(27),
+			Scope:            converter.String(scope),
+		},
+	}
+}
+
+func TestDataSecurityRoleScopes_Read_ListsKnownScopesWithRoles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	securityRolesClient := azdosdkmocks.NewMockSecurityrolesClient(ctrl)
+	clients := &client.AggregatedClient{SecurityRolesClient: securityRolesClient, Ctx: context.Background()}
+
+	notFound := 404
+	securityRolesClient.
+		EXPECT().
+		ListSecurityRoleDefinitions(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args *securityroles.ListSecurityRoleDefinitionsArgs) (*[]securityroles.SecurityRoleDefinition, error) {
+			switch *args.Scope {
+			case "distributedtask.environmentreferencerole":
+				return testRoleDefinitions(*args.Scope), nil
+			case "distributedtask.securefile":
+				return nil, azuredevops.WrappedError{StatusCode: &notFound}
+			default:
+				return &[]securityroles.SecurityRoleDefinition{}, nil
+			}
+		}).
+		Times(len(knownSecurityRoleScopes))
+
+	d := schema.TestResourceDataRaw(t, DataSecurityRoleScopes().Schema, nil)
+	require.Nil(t, dataSecurityRoleScopesRead(d, clients))
+
+	scopes := d.Get("scopes").([]interface{})
+	require.Len(t, scopes, 1)
+	scope := scopes[0].(map[string]interface{})
+	require.Equal(t, "distributedtask.environmentreferencerole", scope["id"])
+	require.Equal(t, "{projectId}_{environmentId}", scope["resource_id_format"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":              "Reader",
+			"display_name":      "Reader",
+			"description":       "Can view",
+			"identifier":        "distributedtask.environmentreferencerole.Reader",
+			"allow_permissions": 1,
+			"deny_permissions":  0,
+		},
+		map[string]interface{}{
+			"name":              "Administrator",
+			"display_name":      "Administrator",
+			"description":       "",
+			"identifier":        "distributedtask.environmentreferencerole.Administrator",
+			"allow_permissions": 27,
+			"deny_permissions":  0,
+		},
+	}, scope["roles"])
+}
+
+func TestDataSecurityRoleScopes_Read_ListsRequestedScopes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	securityRolesClient := azdosdkmocks.NewMockSecurityrolesClient(ctrl)
+	clients := &client.AggregatedClient{SecurityRolesClient: securityRolesClient, Ctx: context.Background()}
+
+	for _, scope := range []string{"distributedtask.variablegroup", "custom.scope"} {
+		securityRolesClient.
+			EXPECT().
+			ListSecurityRoleDefinitions(clients.Ctx, &securityroles.ListSecurityRoleDefinitionsArgs{Scope: converter.String(scope)}).
+			Return(testRoleDefinitions(scope), nil).
+			Times(1)
+	}
+
+	d := schema.TestResourceDataRaw(t, DataSecurityRoleScopes().Schema, map[string]interface{}{
+		"scope_ids": []interface{}{"distributedtask.variablegroup", "custom.scope"},
+	})
+	require.Nil(t, dataSecurityRoleScopesRead(d, clients))
+
+	scopes := d.Get("scopes").([]interface{})
+	require.Len(t, scopes, 2)
+	require.Equal(t, "custom.scope", scopes[0].(map[string]interface{})["id"])
+	require.Equal(t, "", scopes[0].(map[string]interface{})["resource_id_format"])
+	require.Equal(t, "distributedtask.variablegroup", scopes[1].(map[string]interface{})["id"])
+	require.Equal(t, "{projectId}${variableGroupId}", scopes[1].(map[string]interface{})["resource_id_format"])
+}
+
+func TestDataSecurityRoleScopes_Read_ReturnsErrorWhenRequestedScopeHasNoRoles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	securityRolesClient := azdosdkmocks.NewMockSecurityrolesClient(ctrl)
+	clients := &client.AggregatedClient{SecurityRolesClient: securityRolesClient, Ctx: context.Background()}
+
+	securityRolesClient.
+		EXPECT().
+		ListSecurityRoleDefinitions(clients.Ctx, gomock.Any()).
+		Return(&[]securityroles.SecurityRoleDefinition{}, nil).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, DataSecurityRoleScopes().Schema, map[string]interface{}{
+		"scope_ids": []interface{}{"unknown.scope"},
+	})
+	err := dataSecurityRoleScopesRead(d, clients)
+	require.ErrorContains(t, err, "no role definition found at scope: unknown.scope")
+}
+
+func TestDataSecurityRoleScopes_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	securityRolesClient := azdosdkmocks.NewMockSecurityrolesClient(ctrl)
+	clients := &client.AggregatedClient{SecurityRolesClient: securityRolesClient, Ctx: context.Background()}
+
+	securityRolesClient.
+		EXPECT().
+		ListSecurityRoleDefinitions(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("ListSecurityRoleDefinitions() Failed")).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, DataSecurityRoleScopes().Schema, nil)
+	err := dataSecurityRoleScopesRead(d, clients)
+	require.ErrorContains(t, err, "ListSecurityRoleDefinitions() Failed")
+}
@@ -2,6 +2,8 @@ package securityroles
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,6 +19,9 @@ func ResourceSecurityRoleAssignment() *schema.Resource {
 		Read:   resourceSecurityRoleAssignmentRead,
 		Update: resourceSecurityRoleAssignmentCreateOrUpdate,
 		Delete: resourceSecurityRoleAssignmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: importSecurityRoleAssignment,
+		},
 		Schema: map[string]*schema.Schema{
 			"scope": {
 				Type:         schema.TypeString,
@@ -129,3 +134,21 @@ func resourceSecurityRoleAssignmentDelete(d *schema.ResourceData, m interface{})
 	d.SetId("")
 	return nil
 }
+
+// importSecurityRoleAssignment imports the role assignment of an identity by an ID like
+// <scope>/<resource ID>/<identity ID>
+func importSecurityRoleAssignment(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || slices.Contains(parts, "") {
+		return nil, fmt.Errorf(" unexpected format of ID (%s), expected <scope>/<resource ID>/<identity ID>", d.Id())
+	}
+	if _, err := uuid.Parse(parts[2]); err != nil {
+		return nil, fmt.Errorf(" identity ID %s isn't a valid UUID", parts[2])
+	}
+
+	d.Set("scope", parts[0])
+	d.Set("resource_id", parts[1])
+	d.Set("identity_id", parts[2])
+	d.SetId("sra-" + uuid.New().String())
+	return []*schema.ResourceData{d}, nil
+}
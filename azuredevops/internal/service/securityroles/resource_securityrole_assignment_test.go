@@ -119,3 +119,25 @@ func TestSecurityRoleAssignment_Delete_DoesNotSwallowError(t *testing.T) {
 	err := r.Delete(resourceData, clients)
 	require.Contains(t, err.Error(), "invalid UUID length")
 }
+
+// verifies that the scope, the resource and the identity are imported from the ID
+
+func TestSecurityRoleAssignment_Import_ParsesID(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceSecurityRoleAssignment().Schema, nil)
+	resourceData.SetId(fmt.Sprintf("%s/%s/%s", SecurityRoleAssignmentScope, SecurityRoleAssignmentResourceID, SecurityRoleAssignmentIdentityID))
+
+	imported, err := importSecurityRoleAssignment(resourceData, &client.AggregatedClient{Ctx: context.Background()})
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	require.Equal(t, SecurityRoleAssignmentScope, imported[0].Get("scope"))
+	require.Equal(t, SecurityRoleAssignmentResourceID, imported[0].Get("resource_id"))
+	require.Equal(t, SecurityRoleAssignmentIdentityID.String(), imported[0].Get("identity_id"))
+}
+
+func TestSecurityRoleAssignment_Import_RejectsMalformedID(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceSecurityRoleAssignment().Schema, nil)
+	resourceData.SetId(fmt.Sprintf("%s/%s", SecurityRoleAssignmentScope, SecurityRoleAssignmentResourceID))
+
+	_, err := importSecurityRoleAssignment(resourceData, &client.AggregatedClient{Ctx: context.Background()})
+	require.ErrorContains(t, err, "unexpected format of ID")
+}
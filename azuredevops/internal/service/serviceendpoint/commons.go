@@ -1,6 +1,7 @@
 package serviceendpoint
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -140,26 +141,11 @@ func deleteServiceEndpoint(clients *client.AggregatedClient, projectID *uuid.UUI
 }
 
 func validateServiceEndpoint(clients *client.AggregatedClient, endpoint *serviceendpoint.ServiceEndpoint, serviceEndpointID *string, retryTimeout time.Duration) error {
-	reqArgs := serviceendpoint.ExecuteServiceEndpointRequestArgs{
-		ServiceEndpointRequest: &serviceendpoint.ServiceEndpointRequest{
-			DataSourceDetails: &serviceendpoint.DataSourceDetails{
-				DataSourceName: converter.String("TestConnection"),
-			},
-			ResultTransformationDetails: &serviceendpoint.ResultTransformationDetails{},
-			ServiceEndpointDetails: &serviceendpoint.ServiceEndpointDetails{
-				Data:          endpoint.Data,
-				Authorization: endpoint.Authorization,
-				Url:           endpoint.Url,
-				Type:          endpoint.Type,
-			},
-		},
-		Project:    converter.String((*endpoint.ServiceEndpointProjectReferences)[0].ProjectReference.Id.String()),
-		EndpointId: serviceEndpointID,
-	}
+	projectID := (*endpoint.ServiceEndpointProjectReferences)[0].ProjectReference.Id.String()
 
 	log.Printf(":: %s :: Initiating validation", *endpoint.Name)
 	err := resource.RetryContext(clients.Ctx, retryTimeout, func() *resource.RetryError {
-		reqResult, err := clients.ServiceEndpointClient.ExecuteServiceEndpointRequest(clients.Ctx, reqArgs)
+		reqResult, err := testServiceEndpointConnection(clients.Ctx, clients, endpoint, projectID, serviceEndpointID)
 		if err != nil {
 			log.Printf(":: %s :: error during endpoint validation request", *endpoint.Name)
 			return resource.NonRetryableError(err)
@@ -174,6 +160,26 @@ func validateServiceEndpoint(clients *client.AggregatedClient, endpoint *service
 	return err
 }
 
+// testServiceEndpointConnection asks the service to verify the connection of the service endpoint
+func testServiceEndpointConnection(ctx context.Context, clients *client.AggregatedClient, endpoint *serviceendpoint.ServiceEndpoint, projectID string, serviceEndpointID *string) (*serviceendpoint.ServiceEndpointRequestResult, error) {
+	return clients.ServiceEndpointClient.ExecuteServiceEndpointRequest(ctx, serviceendpoint.ExecuteServiceEndpointRequestArgs{
+		ServiceEndpointRequest: &serviceendpoint.ServiceEndpointRequest{
+			DataSourceDetails: &serviceendpoint.DataSourceDetails{
+				DataSourceName: converter.String("TestConnection"),
+			},
+			ResultTransformationDetails: &serviceendpoint.ResultTransformationDetails{},
+			ServiceEndpointDetails: &serviceendpoint.ServiceEndpointDetails{
+				Data:          endpoint.Data,
+				Authorization: endpoint.Authorization,
+				Url:           endpoint.Url,
+				Type:          endpoint.Type,
+			},
+		},
+		Project:    &projectID,
+		EndpointId: serviceEndpointID,
+	})
+}
+
 func serviceEndpointGetArgs(d *schema.ResourceData) (*serviceendpoint.GetServiceEndpointDetailsArgs, error) {
 	var serviceEndpointID *uuid.UUID
 	parsedServiceEndpointID, err := uuid.Parse(d.Id())
@@ -215,15 +221,23 @@ func checkServiceEndpointStatus(clients *client.AggregatedClient, projectID *uui
 	}
 }
 
-func getServiceEndpoint(client *client.AggregatedClient, serviceEndpointID *uuid.UUID, projectID *uuid.UUID) resource.StateRefreshFunc {
+func getServiceEndpoint(clients *client.AggregatedClient, serviceEndpointID *uuid.UUID, projectID *uuid.UUID) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		serviceEndpoint, err := client.ServiceEndpointClient.GetServiceEndpointDetails(
-			client.Ctx,
-			serviceendpoint.GetServiceEndpointDetailsArgs{
-				EndpointId: serviceEndpointID,
-				Project:    converter.String(projectID.String()),
-			},
-		)
+		// a new service endpoint may not be found right away
+		var serviceEndpoint *serviceendpoint.ServiceEndpoint
+		err := clients.ReadAfterWrite(func() (err error) {
+			serviceEndpoint, err = clients.ServiceEndpointClient.GetServiceEndpointDetails(
+				clients.Ctx,
+				serviceendpoint.GetServiceEndpointDetailsArgs{
+					EndpointId: serviceEndpointID,
+					Project:    converter.String(projectID.String()),
+				},
+			)
+			if err == nil && (serviceEndpoint == nil || serviceEndpoint.Id == nil) {
+				return client.ErrResourceNotFound
+			}
+			return err
+		})
 
 		if err != nil {
 			return nil, opState.Failed, fmt.Errorf(errMsgServiceCreate, serviceEndpointID, *projectID, err)
@@ -0,0 +1,119 @@
+package serviceendpoint
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/location"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+// the issuer of the OIDC tokens of service endpoints using workload identity federation, followed by the ID of
+// the organization
+const oidcIssuerPrefix = "https://vstoken.dev.azure.com/"
+
+// DataServiceEndpointOidcSubject schema and implementation for the data source computing the issuer and subject
+// claim of the OIDC tokens issued to a service endpoint using workload identity federation. They only depend on
+// the names of the organization, project and service endpoint, so the federated identity credential can be created
+// before the service endpoint.
+func DataServiceEndpointOidcSubject() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceServiceEndpointOidcSubjectRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsUUID,
+				ExactlyOneOf: []string{"project_id", "project_name"},
+			},
+			"project_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				ExactlyOneOf: []string{"project_id", "project_name"},
+			},
+			"service_endpoint_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"organization_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"issuer": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"subject": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceServiceEndpointOidcSubjectRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	organizationName, err := organizationNameFromURL(clients.OrganizationURL)
+	if err != nil {
+		return err
+	}
+
+	connectionData, err := clients.LocationClient.GetConnectionData(clients.Ctx, location.GetConnectionDataArgs{})
+	if err != nil {
+		return fmt.Errorf(" reading the connection data of organization %s: %+v", organizationName, err)
+	}
+	if connectionData.InstanceId == nil {
+		return fmt.Errorf(" the connection data of organization %s has no instance ID", organizationName)
+	}
+
+	projectName := d.Get("project_name").(string)
+	if projectID := d.Get("project_id").(string); projectID != "" {
+		project, err := clients.CoreClient.GetProject(clients.Ctx, core.GetProjectArgs{
+			ProjectId: converter.String(projectID),
+		})
+		if err != nil {
+			return fmt.Errorf(" reading project %s: %+v", projectID, err)
+		}
+		projectName = *project.Name
+	}
+
+	subject := fmt.Sprintf("sc://%s/%s/%s", organizationName, projectName, d.Get("service_endpoint_name").(string))
+	d.SetId(subject)
+	d.Set("project_name", projectName)
+	d.Set("organization_name", organizationName)
+	d.Set("organization_id", connectionData.InstanceId.String())
+	d.Set("issuer", oidcIssuerPrefix+connectionData.InstanceId.String())
+	d.Set("subject", subject)
+	return nil
+}
+
+// organizationNameFromURL returns the name of the organization of both the https://dev.azure.com/<organization>
+// and the legacy https://<organization>.visualstudio.com URLs
+func organizationNameFromURL(organizationURL string) (string, error) {
+	parsed, err := url.Parse(organizationURL)
+	if err != nil {
+		return "", fmt.Errorf(" parsing the organization URL %s: %+v", organizationURL, err)
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if name, ok := strings.CutSuffix(host, ".visualstudio.com"); ok && name != "" {
+		return name, nil
+	}
+	if segments := strings.Split(strings.Trim(parsed.Path, "/"), "/"); segments[0] != "" {
+		return segments[0], nil
+	}
+	return "", fmt.Errorf(" the organization URL %s does not contain the name of the organization", organizationURL)
+}
@@ -0,0 +1,101 @@
+//go:build (all || data_serviceendpoint_oidc_subject) && !exclude_serviceendpoints
+// +build all data_serviceendpoint_oidc_subject
+// +build !exclude_serviceendpoints
+
+package serviceendpoint
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/location"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataServiceEndpointOidcSubject_Read_ResolvesProjectName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	locationClient := azdosdkmocks.NewMockLocationClient(ctrl)
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	clients := &client.AggregatedClient{
+		OrganizationURL: "https://dev.azure.com/myorg",
+		LocationClient:  locationClient,
+		CoreClient:      coreClient,
+		Ctx:             context.Background(),
+	}
+
+	organizationID := uuid.New()
+	projectID := uuid.New().String()
+	locationClient.
+		EXPECT().
+		GetConnectionData(clients.Ctx, location.GetConnectionDataArgs{}).
+		Return(&location.ConnectionData{InstanceId: &organizationID}, nil).
+		Times(1)
+	coreClient.
+		EXPECT().
+		GetProject(clients.Ctx, core.GetProjectArgs{ProjectId: &projectID}).
+		Return(&core.TeamProject{Name: converter.String("My Project")}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataServiceEndpointOidcSubject().Schema, nil)
+	resourceData.Set("project_id", projectID)
+	resourceData.Set("service_endpoint_name", "my-connection")
+
+	err := dataSourceServiceEndpointOidcSubjectRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "sc://myorg/My Project/my-connection", resourceData.Get("subject"))
+	require.Equal(t, "https://vstoken.dev.azure.com/"+organizationID.String(), resourceData.Get("issuer"))
+	require.Equal(t, organizationID.String(), resourceData.Get("organization_id"))
+	require.Equal(t, "My Project", resourceData.Get("project_name"))
+}
+
+func TestDataServiceEndpointOidcSubject_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	locationClient := azdosdkmocks.NewMockLocationClient(ctrl)
+	clients := &client.AggregatedClient{
+		OrganizationURL: "https://dev.azure.com/myorg",
+		LocationClient:  locationClient,
+		Ctx:             context.Background(),
+	}
+
+	locationClient.
+		EXPECT().
+		GetConnectionData(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetConnectionData() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataServiceEndpointOidcSubject().Schema, nil)
+	resourceData.Set("project_name", "My Project")
+	resourceData.Set("service_endpoint_name", "my-connection")
+
+	err := dataSourceServiceEndpointOidcSubjectRead(resourceData, clients)
+	require.ErrorContains(t, err, "GetConnectionData() Failed")
+}
+
+func TestDataServiceEndpointOidcSubject_OrganizationNameFromURL(t *testing.T) {
+	for organizationURL, expected := range map[string]string{
+		"https://dev.azure.com/myorg":        "myorg",
+		"https://dev.azure.com/myorg/":       "myorg",
+		"https://myorg.visualstudio.com":     "myorg",
+		"https://myorg.visualstudio.com/":    "myorg",
+		"https://tfs.example.com/Collection": "Collection",
+	} {
+		name, err := organizationNameFromURL(organizationURL)
+		require.Nil(t, err)
+		require.Equal(t, expected, name, organizationURL)
+	}
+
+	_, err := organizationNameFromURL("https://dev.azure.com")
+	require.Error(t, err)
+}
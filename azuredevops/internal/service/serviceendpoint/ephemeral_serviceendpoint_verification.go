@@ -0,0 +1,130 @@
+package serviceendpoint
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/serviceendpoint"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &serviceEndpointVerificationEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &serviceEndpointVerificationEphemeralResource{}
+)
+
+// NewServiceEndpointVerificationEphemeralResource verifies the connection of an existing service endpoint
+func NewServiceEndpointVerificationEphemeralResource() ephemeral.EphemeralResource {
+	return &serviceEndpointVerificationEphemeralResource{}
+}
+
+type serviceEndpointVerificationEphemeralResource struct {
+	clients *client.AggregatedClient
+}
+
+type serviceEndpointVerificationModel struct {
+	ProjectId         types.String `tfsdk:"project_id"`
+	ServiceEndpointId types.String `tfsdk:"service_endpoint_id"`
+	IsValid           types.Bool   `tfsdk:"is_valid"`
+	StatusCode        types.String `tfsdk:"status_code"`
+	ErrorMessage      types.String `tfsdk:"error_message"`
+}
+
+func (r *serviceEndpointVerificationEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_serviceendpoint_verification"
+}
+
+func (r *serviceEndpointVerificationEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Verifies the connection of a service endpoint, the same way the `Verify` button of the Azure DevOps portal does.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the project the service endpoint is shared with.",
+			},
+			"service_endpoint_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the service endpoint to verify.",
+			},
+			"is_valid": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the service endpoint could connect to the remote service.",
+			},
+			"status_code": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status code returned by the verification.",
+			},
+			"error_message": schema.StringAttribute{
+				Computed:    true,
+				Description: "The error message returned by the verification, if any.",
+			},
+		},
+	}
+}
+
+func (r *serviceEndpointVerificationEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*client.AggregatedClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *client.AggregatedClient, got %T", req.ProviderData))
+		return
+	}
+	r.clients = clients
+}
+
+func (r *serviceEndpointVerificationEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model serviceEndpointVerificationModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := verifyServiceEndpoint(ctx, r.clients, model.ProjectId.ValueString(), model.ServiceEndpointId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Verifying service endpoint", err.Error())
+		return
+	}
+
+	statusCode := converter.ToString(result.StatusCode, "")
+	model.IsValid = types.BoolValue(strings.EqualFold(statusCode, "ok"))
+	model.StatusCode = types.StringValue(statusCode)
+	model.ErrorMessage = types.StringValue(converter.ToString(result.ErrorMessage, ""))
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
+
+func verifyServiceEndpoint(ctx context.Context, clients *client.AggregatedClient, projectID string, serviceEndpointID string) (*serviceendpoint.ServiceEndpointRequestResult, error) {
+	if clients == nil {
+		return nil, fmt.Errorf(" the provider has not been configured")
+	}
+
+	endpointID, err := uuid.Parse(serviceEndpointID)
+	if err != nil {
+		return nil, fmt.Errorf(" parsing service endpoint ID %s: %+v", serviceEndpointID, err)
+	}
+
+	endpoint, err := clients.ServiceEndpointClient.GetServiceEndpointDetails(ctx, serviceendpoint.GetServiceEndpointDetailsArgs{
+		Project:    &projectID,
+		EndpointId: &endpointID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(" reading service endpoint %s: %+v", serviceEndpointID, err)
+	}
+	if endpoint == nil || endpoint.Id == nil {
+		return nil, fmt.Errorf(" service endpoint %s does not exist in project %s", serviceEndpointID, projectID)
+	}
+
+	result, err := testServiceEndpointConnection(ctx, clients, endpoint, projectID, &serviceEndpointID)
+	if err != nil {
+		return nil, fmt.Errorf(" verifying service endpoint %s: %+v", serviceEndpointID, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,81 @@
+//go:build (all || ephemeral_serviceendpoint_verification) && !exclude_serviceendpoints
+// +build all ephemeral_serviceendpoint_verification
+// +build !exclude_serviceendpoints
+
+package serviceendpoint
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/serviceendpoint"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceEndpointVerification_DoesNotSwallowReadError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	seClient := azdosdkmocks.NewMockServiceendpointClient(ctrl)
+	clients := &client.AggregatedClient{ServiceEndpointClient: seClient, Ctx: context.Background()}
+
+	seClient.
+		EXPECT().
+		GetServiceEndpointDetails(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetServiceEndpointDetails() Failed")).
+		Times(1)
+
+	_, err := verifyServiceEndpoint(clients.Ctx, clients, uuid.New().String(), uuid.New().String())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "GetServiceEndpointDetails() Failed")
+}
+
+func TestServiceEndpointVerification_ReturnsTestConnectionResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	seClient := azdosdkmocks.NewMockServiceendpointClient(ctrl)
+	clients := &client.AggregatedClient{ServiceEndpointClient: seClient, Ctx: context.Background()}
+
+	projectID := uuid.New().String()
+	endpointID := uuid.New()
+	endpoint := &serviceendpoint.ServiceEndpoint{
+		Id:   &endpointID,
+		Type: converter.String("github"),
+		Url:  converter.String("https://github.com"),
+	}
+
+	seClient.
+		EXPECT().
+		GetServiceEndpointDetails(clients.Ctx, serviceendpoint.GetServiceEndpointDetailsArgs{
+			Project:    &projectID,
+			EndpointId: &endpointID,
+		}).
+		Return(endpoint, nil).
+		Times(1)
+
+	seClient.
+		EXPECT().
+		ExecuteServiceEndpointRequest(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args serviceendpoint.ExecuteServiceEndpointRequestArgs) (*serviceendpoint.ServiceEndpointRequestResult, error) {
+			require.Equal(t, projectID, *args.Project)
+			require.Equal(t, endpointID.String(), *args.EndpointId)
+			require.Equal(t, "TestConnection", *args.ServiceEndpointRequest.DataSourceDetails.DataSourceName)
+			return &serviceendpoint.ServiceEndpointRequestResult{
+				StatusCode:   converter.String("badRequest"),
+				ErrorMessage: converter.String("invalid credentials"),
+			}, nil
+		}).
+		Times(1)
+
+	result, err := verifyServiceEndpoint(clients.Ctx, clients, projectID, endpointID.String())
+	require.NoError(t, err)
+	require.Equal(t, "badRequest", *result.StatusCode)
+	require.Equal(t, "invalid credentials", *result.ErrorMessage)
+}
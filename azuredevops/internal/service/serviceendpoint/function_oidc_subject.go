@@ -0,0 +1,60 @@
+package serviceendpoint
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &oidcSubjectFunction{}
+
+// NewOidcSubjectFunction builds the subject claim of the OIDC tokens issued to a service endpoint using
+// workload identity federation
+func NewOidcSubjectFunction() function.Function {
+	return &oidcSubjectFunction{}
+}
+
+type oidcSubjectFunction struct{}
+
+func (f *oidcSubjectFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "serviceendpoint_oidc_subject"
+}
+
+func (f *oidcSubjectFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Builds the OIDC subject claim of a service endpoint",
+		Description: "Builds the subject claim of the OIDC tokens Azure DevOps issues to a service endpoint using workload identity federation, to be used in the federated identity credential of the identity the service endpoint signs in as.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "organization_name",
+				Description: "The name of the organization.",
+			},
+			function.StringParameter{
+				Name:        "project_name",
+				Description: "The name of the project the service endpoint is created in.",
+			},
+			function.StringParameter{
+				Name:        "service_endpoint_name",
+				Description: "The name of the service endpoint.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *oidcSubjectFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var organizationName, projectName, serviceEndpointName string
+	resp.Error = req.Arguments.Get(ctx, &organizationName, &projectName, &serviceEndpointName)
+	if resp.Error != nil {
+		return
+	}
+
+	for i, value := range []string{organizationName, projectName, serviceEndpointName} {
+		if value == "" {
+			resp.Error = function.NewArgumentFuncError(int64(i), "the argument must not be empty")
+			return
+		}
+	}
+	resp.Error = resp.Result.Set(ctx, fmt.Sprintf("sc://%s/%s/%s", organizationName, projectName, serviceEndpointName))
+}
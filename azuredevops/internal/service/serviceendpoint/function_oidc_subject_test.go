@@ -0,0 +1,37 @@
+//go:build (all || resource_serviceendpoint_azurerm) && !exclude_serviceendpoints
+// +build all resource_serviceendpoint_azurerm
+// +build !exclude_serviceendpoints
+
+package serviceendpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func runOidcSubjectFunction(arguments ...string) *function.RunResponse {
+	values := make([]attr.Value, len(arguments))
+	for i, argument := range arguments {
+		values[i] = types.StringValue(argument)
+	}
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	NewOidcSubjectFunction().Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(values)}, resp)
+	return resp
+}
+
+func TestOidcSubjectFunction_BuildsSubject(t *testing.T) {
+	resp := runOidcSubjectFunction("contoso", "Example Project", "Example AzureRM")
+	require.Nil(t, resp.Error)
+	require.Equal(t, types.StringValue("sc://contoso/Example Project/Example AzureRM"), resp.Result.Value())
+}
+
+func TestOidcSubjectFunction_RejectsEmptyArguments(t *testing.T) {
+	resp := runOidcSubjectFunction("contoso", "", "Example AzureRM")
+	require.NotNil(t, resp.Error)
+	require.Equal(t, int64(1), *resp.Error.FunctionArgument)
+}
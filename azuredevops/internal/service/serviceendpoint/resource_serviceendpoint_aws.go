@@ -68,6 +68,8 @@ func ResourceServiceEndpointAws() *schema.Resource {
 		DefaultFunc: schema.EnvDefaultFunc("AZDO_AWS_SERVICE_CONNECTION_EXTERNAL_ID", nil),
 		Description: "A unique identifier that is used by third parties when assuming roles in their customers' accounts, aka cross-account role access.",
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "secret_access_key", "session_token")
 	return r
 }
 
@@ -140,8 +142,8 @@ func expandServiceEndpointAws(d *schema.ResourceData) (*serviceendpoint.ServiceE
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 		Parameters: &map[string]string{
 			"username":        d.Get("access_key_id").(string),
-			"password":        d.Get("secret_access_key").(string),
-			"sessionToken":    d.Get("session_token").(string),
+			"password":        tfhelper.SecretValue(d, "secret_access_key"),
+			"sessionToken":    tfhelper.SecretValue(d, "session_token"),
 			"assumeRoleArn":   d.Get("role_to_assume").(string),
 			"roleSessionName": d.Get("role_session_name").(string),
 			"externalId":      d.Get("external_id").(string),
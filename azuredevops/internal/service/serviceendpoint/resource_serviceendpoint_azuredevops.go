@@ -54,6 +54,7 @@ func ResourceServiceEndpointAzureDevOps() *schema.Resource {
 		Description: "The Azure DevOps personal access token.",
 	}
 
+	tfhelper.AddWriteOnlySecrets(r, "personal_access_token")
 	return r
 }
 
@@ -124,7 +125,7 @@ func expandServiceEndpointAzureDevOps(d *schema.ResourceData) (*serviceendpoint.
 	serviceEndpoint, projectID := doBaseExpansion(d)
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 		Parameters: &map[string]string{
-			"apitoken": d.Get("personal_access_token").(string),
+			"apitoken": tfhelper.SecretValue(d, "personal_access_token"),
 		},
 		Scheme: converter.String("Token"),
 	}
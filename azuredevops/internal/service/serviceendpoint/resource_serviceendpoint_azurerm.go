@@ -168,6 +168,8 @@ func ResourceServiceEndpointAzureRM() *schema.Resource {
 			},
 		},
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "credentials.0.serviceprincipalkey")
 	return r
 }
 
@@ -337,7 +339,7 @@ func expandServiceEndpointAzureRM(d *schema.ResourceData) (*serviceendpoint.Serv
 				Parameters: &map[string]string{
 					"authenticationType":  "spnKey",
 					"serviceprincipalid":  credentials["serviceprincipalid"].(string),
-					"serviceprincipalkey": credentials["serviceprincipalkey"].(string),
+					"serviceprincipalkey": tfhelper.SecretValue(d, "credentials.0.serviceprincipalkey"),
 					"tenantid":            d.Get("azurerm_spn_tenantid").(string),
 				},
 				Scheme: converter.String(string(serviceEndPointAuthenticationScheme)),
@@ -44,6 +44,8 @@ func ResourceServiceEndpointBitBucket() *schema.Resource {
 		Description: "The bitbucket password which should be used.",
 		Sensitive:   true,
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "password")
 	return r
 }
 
@@ -115,7 +117,7 @@ func expandServiceEndpointBitBucket(d *schema.ResourceData) (*serviceendpoint.Se
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 		Parameters: &map[string]string{
 			"username": d.Get("username").(string),
-			"password": d.Get("password").(string),
+			"password": tfhelper.SecretValue(d, "password"),
 		},
 		Scheme: converter.String("UsernamePassword"),
 	}
@@ -62,6 +62,8 @@ func ResourceServiceEndpointDockerRegistry() *schema.Resource {
 		ValidateFunc: validation.StringInSlice([]string{"DockerHub", "Others"}, false),
 		ForceNew:     true,
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "docker_password")
 	return r
 }
 
@@ -135,7 +137,7 @@ func expandServiceEndpointDockerRegistry(d *schema.ResourceData) (*serviceendpoi
 		Parameters: &map[string]string{
 			"registry": d.Get("docker_registry").(string),
 			"username": d.Get("docker_username").(string),
-			"password": d.Get("docker_password").(string),
+			"password": tfhelper.SecretValue(d, "docker_password"),
 			"email":    d.Get("docker_email").(string),
 		},
 		Scheme: converter.String("UsernamePassword"),
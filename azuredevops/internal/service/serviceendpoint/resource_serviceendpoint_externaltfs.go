@@ -41,7 +41,7 @@ func ResourceServiceEndpointExternalTFS() *schema.Resource {
 	}
 
 	r.Schema["auth_personal"] = &schema.Schema{
-		Type:     schema.TypeSet,
+		Type:     schema.TypeList,
 		MinItems: 1,
 		MaxItems: 1,
 		Elem: &schema.Resource{
@@ -58,6 +58,8 @@ func ResourceServiceEndpointExternalTFS() *schema.Resource {
 		},
 		Required: true,
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "auth_personal.0.personal_access_token")
 	return r
 }
 
@@ -131,9 +133,9 @@ func expandServiceEndpointExternalTFS(d *schema.ResourceData) (*serviceendpoint.
 	scheme := "Token"
 	parameters := map[string]string{}
 
-	if config, ok := d.GetOk("auth_personal"); ok {
+	if _, ok := d.GetOk("auth_personal"); ok {
 		scheme = "Token"
-		parameters = expandAuthPersonalSetExternalTFS(config.(*schema.Set))
+		parameters = expandAuthPersonalExternalTFS(d)
 	}
 
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
@@ -143,11 +145,9 @@ func expandServiceEndpointExternalTFS(d *schema.ResourceData) (*serviceendpoint.
 	return serviceEndpoint, projectID, nil
 }
 
-func expandAuthPersonalSetExternalTFS(d *schema.Set) map[string]string {
+func expandAuthPersonalExternalTFS(d *schema.ResourceData) map[string]string {
 	authPerson := make(map[string]string)
-	val := d.List()[0].(map[string]interface{})
-
-	authPerson["apitoken"] = val[personalAccessTokenExternalTFS].(string)
+	authPerson["apitoken"] = tfhelper.SecretValue(d, "auth_personal.0."+personalAccessTokenExternalTFS)
 	return authPerson
 }
 
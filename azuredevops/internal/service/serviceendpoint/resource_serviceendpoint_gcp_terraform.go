@@ -61,6 +61,8 @@ func ResourceServiceEndpointGcp() *schema.Resource {
 		DefaultFunc: schema.EnvDefaultFunc("AZDO_GCP_SERVICE_CONNECTION_SCOPE", nil),
 		Description: "Scope to be provided",
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "private_key")
 	return r
 }
 
@@ -135,7 +137,7 @@ func expandServiceEndpointGcp(d *schema.ResourceData) (*serviceendpoint.ServiceE
 			"Issuer":     d.Get("client_email").(string),
 			"Audience":   d.Get("token_uri").(string),
 			"Scope":      d.Get("scope").(string),
-			"PrivateKey": d.Get("private_key").(string),
+			"PrivateKey": tfhelper.SecretValue(d, "private_key"),
 		},
 		Scheme: converter.String("JWT"),
 	}
@@ -49,6 +49,8 @@ func ResourceServiceEndpointGeneric() *schema.Resource {
 		Sensitive:   true,
 		Optional:    true,
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "password")
 	return r
 }
 
@@ -122,7 +124,7 @@ func expandServiceEndpointGeneric(d *schema.ResourceData) (*serviceendpoint.Serv
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 		Parameters: &map[string]string{
 			"username": d.Get("username").(string),
-			"password": d.Get("password").(string),
+			"password": tfhelper.SecretValue(d, "password"),
 		},
 		Scheme: converter.String("UsernamePassword"),
 	}
@@ -55,6 +55,8 @@ func ResourceServiceEndpointGenericGit() *schema.Resource {
 		Description: "A value indicating whether or not to attempt accessing this git server from Azure Pipelines.",
 		Optional:    true,
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "password")
 	return r
 }
 
@@ -131,7 +133,7 @@ func expandServiceEndpointGenericGit(d *schema.ResourceData) (*serviceendpoint.S
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 		Parameters: &map[string]string{
 			"username": d.Get("username").(string),
-			"password": d.Get("password").(string),
+			"password": tfhelper.SecretValue(d, "password"),
 		},
 		Scheme: converter.String("UsernamePassword"),
 	}
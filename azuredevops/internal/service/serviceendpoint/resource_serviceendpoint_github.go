@@ -37,7 +37,7 @@ func ResourceServiceEndpointGitHub() *schema.Resource {
 	}
 
 	r.Schema["auth_personal"] = &schema.Schema{
-		Type:     schema.TypeSet,
+		Type:     schema.TypeList,
 		Optional: true,
 		MinItems: 1,
 		MaxItems: 1,
@@ -72,6 +72,7 @@ func ResourceServiceEndpointGitHub() *schema.Resource {
 		ConflictsWith: []string{"auth_personal"},
 	}
 
+	tfhelper.AddWriteOnlySecrets(r, "auth_personal.0.personal_access_token")
 	return r
 }
 
@@ -145,9 +146,9 @@ func expandServiceEndpointGitHub(d *schema.ResourceData) (*serviceendpoint.Servi
 
 	parameters := map[string]string{}
 
-	if config, ok := d.GetOk("auth_personal"); ok {
+	if _, ok := d.GetOk("auth_personal"); ok {
 		scheme = "Token"
-		parameters = expandAuthPersonalSetGithub(config.(*schema.Set))
+		parameters = expandAuthPersonalGithub(d)
 	}
 
 	if config, ok := d.GetOk("auth_oauth"); ok {
@@ -165,10 +166,9 @@ func expandServiceEndpointGitHub(d *schema.ResourceData) (*serviceendpoint.Servi
 	return serviceEndpoint, projectID, nil
 }
 
-func expandAuthPersonalSetGithub(d *schema.Set) map[string]string {
+func expandAuthPersonalGithub(d *schema.ResourceData) map[string]string {
 	authPerson := make(map[string]string)
-	val := d.List()[0].(map[string]interface{}) //auth_personal only have one map configure structure
-	authPerson["AccessToken"] = val[personalAccessTokenGithub].(string)
+	authPerson["AccessToken"] = tfhelper.SecretValue(d, "auth_personal.0."+personalAccessTokenGithub) //auth_personal only have one map configure structure
 	return authPerson
 }
 
@@ -191,7 +191,7 @@ func flattenServiceEndpointGitHub(d *schema.ResourceData, serviceEndpoint *servi
 		})
 	}
 	if strings.EqualFold(*serviceEndpoint.Authorization.Scheme, "Token") {
-		authPersonalSet := d.Get("auth_personal").(*schema.Set).List()
+		authPersonalSet := d.Get("auth_personal").([]interface{})
 		authPersonal := flattenAuthPerson(d, authPersonalSet)
 		if authPersonal != nil {
 			d.Set("auth_personal", authPersonal)
@@ -32,7 +32,7 @@ func ResourceServiceEndpointGitHubEnterprise() *schema.Resource {
 		Schema:   baseSchema(),
 	}
 	r.Schema["auth_personal"] = &schema.Schema{
-		Type:     schema.TypeSet,
+		Type:     schema.TypeList,
 		Required: true,
 		MinItems: 1,
 		MaxItems: 1,
@@ -55,6 +55,8 @@ func ResourceServiceEndpointGitHubEnterprise() *schema.Resource {
 		ValidateFunc: validation.IsURLWithHTTPorHTTPS,
 		Required:     true,
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "auth_personal.0.personal_access_token")
 	return r
 }
 
@@ -125,7 +127,7 @@ func flattenServiceEndpointGitHubEnterprise(d *schema.ResourceData, serviceEndpo
 	doBaseFlattening(d, serviceEndpoint, projectID)
 
 	if strings.EqualFold(*serviceEndpoint.Authorization.Scheme, "Token") {
-		authPersonalSet := d.Get("auth_personal").(*schema.Set).List()
+		authPersonalSet := d.Get("auth_personal").([]interface{})
 		authPersonal := flattenAuthPersonGithubEnterprise(d, authPersonalSet)
 		if authPersonal != nil {
 			d.Set("auth_personal", authPersonal)
@@ -155,9 +157,9 @@ func expandServiceEndpointGitHubEnterprise(d *schema.ResourceData) (*serviceendp
 	scheme := "InstallationToken"
 	parameters := map[string]string{}
 
-	if config, ok := d.GetOk("auth_personal"); ok {
+	if _, ok := d.GetOk("auth_personal"); ok {
 		scheme = "Token"
-		parameters = expandAuthPersonalSetGithubEnterprise(config.(*schema.Set))
+		parameters = expandAuthPersonalGithubEnterprise(d)
 	}
 
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
@@ -168,10 +170,8 @@ func expandServiceEndpointGitHubEnterprise(d *schema.ResourceData) (*serviceendp
 	return serviceEndpoint, projectID, nil
 }
 
-func expandAuthPersonalSetGithubEnterprise(d *schema.Set) map[string]string {
+func expandAuthPersonalGithubEnterprise(d *schema.ResourceData) map[string]string {
 	authPerson := make(map[string]string)
-	val := d.List()[0].(map[string]interface{}) //auth_personal only have one map configure structure
-
-	authPerson["apitoken"] = val[personalAccessTokenGithub].(string)
+	authPerson["apitoken"] = tfhelper.SecretValue(d, "auth_personal.0."+personalAccessTokenGithub) //auth_personal only have one map configure structure
 	return authPerson
 }
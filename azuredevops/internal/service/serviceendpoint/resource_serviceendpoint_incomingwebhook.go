@@ -49,6 +49,8 @@ func ResourceServiceEndpointIncomingWebhook() *schema.Resource {
 		DefaultFunc: schema.EnvDefaultFunc("AZDO_INCOMING_WEBHOOK_SERVICE_CONNECTION_HTTP_HEADER", nil),
 		Description: "Optional http header name on which checksum will be sent.",
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "secret")
 	return r
 }
 
@@ -123,7 +125,7 @@ func expandServiceEndpointIncomingWebhook(d *schema.ResourceData) (*serviceendpo
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 		Parameters: &map[string]string{
 			"webhookname": d.Get("webhook_name").(string),
-			"secret":      d.Get("secret").(string),
+			"secret":      tfhelper.SecretValue(d, "secret"),
 			"header":      d.Get("http_header").(string),
 		},
 		Scheme: converter.String("None"),
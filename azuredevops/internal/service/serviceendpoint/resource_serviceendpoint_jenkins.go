@@ -70,6 +70,7 @@ func ResourceServiceEndpointJenkins() *schema.Resource {
 		Sensitive:   true,
 	}
 
+	tfhelper.AddWriteOnlySecrets(r, "password")
 	return r
 }
 func resourceServiceEndpointJenkinsCreate(d *schema.ResourceData, m interface{}) error {
@@ -144,7 +145,7 @@ func expandServiceEndpointJenkins(d *schema.ResourceData) (*serviceendpoint.Serv
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 		Parameters: &map[string]string{
 			"username": d.Get("username").(string),
-			"password": d.Get("password").(string),
+			"password": tfhelper.SecretValue(d, "password"),
 		},
 		Scheme: converter.String("UsernamePassword"),
 	}
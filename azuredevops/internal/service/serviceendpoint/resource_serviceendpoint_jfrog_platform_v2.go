@@ -1,7 +1,6 @@
 package serviceendpoint
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -96,6 +95,7 @@ func ResourceServiceEndpointJFrogPlatformV2() *schema.Resource {
 		Elem:     aup,
 	}
 
+	tfhelper.AddWriteOnlySecrets(r, "authentication_token.0.token", "authentication_basic.0.username", "authentication_basic.0.password")
 	return r
 }
 
@@ -171,24 +171,13 @@ func expandServiceEndpointJFrogPlatformV2(d *schema.ResourceData) (*serviceendpo
 
 	authParams := make(map[string]string)
 
-	if x, ok := d.GetOk("authentication_token"); ok {
+	if _, ok := d.GetOk("authentication_token"); ok {
 		authScheme = "Token"
-		msi := x.([]interface{})[0].(map[string]interface{})
-		authParams["apitoken"], ok = msi["token"].(string)
-		if !ok {
-			return nil, nil, errors.New("Unable to read 'token'")
-		}
-	} else if x, ok := d.GetOk("authentication_basic"); ok {
+		authParams["apitoken"] = tfhelper.SecretValue(d, "authentication_token.0.token")
+	} else if _, ok := d.GetOk("authentication_basic"); ok {
 		authScheme = "UsernamePassword"
-		msi := x.([]interface{})[0].(map[string]interface{})
-		authParams["username"], ok = msi["username"].(string)
-		if !ok {
-			return nil, nil, errors.New("Unable to read 'username'")
-		}
-		authParams["password"], ok = msi["password"].(string)
-		if !ok {
-			return nil, nil, errors.New("Unable to read 'password'")
-		}
+		authParams["username"] = tfhelper.SecretValue(d, "authentication_basic.0.username")
+		authParams["password"] = tfhelper.SecretValue(d, "authentication_basic.0.password")
 	}
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 		Parameters: &authParams,
@@ -181,6 +181,7 @@ func ResourceServiceEndpointKubernetes() *schema.Resource {
 	makeSchemaKubeconfig(r)
 	makeSchemaServiceAccount(r)
 
+	tfhelper.AddWriteOnlySecrets(r, "kubeconfig.0.kube_config", "service_account.0.ca_cert", "service_account.0.token")
 	return r
 }
 
@@ -280,7 +281,7 @@ func expandServiceEndpointKubernetes(d *schema.ResourceData) (*serviceendpoint.S
 
 		clusterContextInput := configuration["cluster_context"].(string)
 		if clusterContextInput == "" {
-			kubeConfigYAML := configuration["kube_config"].(string)
+			kubeConfigYAML := tfhelper.SecretValue(d, "kubeconfig.0.kube_config")
 			var kubeConfigYAMLUnmarshalled map[string]interface{}
 			err := yaml.Unmarshal([]byte(kubeConfigYAML), &kubeConfigYAMLUnmarshalled)
 			if err != nil {
@@ -294,7 +295,7 @@ func expandServiceEndpointKubernetes(d *schema.ResourceData) (*serviceendpoint.S
 		serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 			Parameters: &map[string]string{
 				"clusterContext": clusterContextInput,
-				"kubeconfig":     configuration["kube_config"].(string),
+				"kubeconfig":     tfhelper.SecretValue(d, "kubeconfig.0.kube_config"),
 			},
 			Scheme: converter.String("Kubernetes"),
 		}
@@ -304,13 +305,10 @@ func expandServiceEndpointKubernetes(d *schema.ResourceData) (*serviceendpoint.S
 			"acceptUntrustedCerts": fmt.Sprintf("%v", configuration["accept_untrusted_certs"].(bool)),
 		}
 	case "ServiceAccount":
-		configurationRaw := d.Get(resourceBlockServiceAccount).([]interface{})
-		configuration := configurationRaw[0].(map[string]interface{})
-
 		serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 			Parameters: &map[string]string{
-				"apiToken":                  configuration["token"].(string),
-				"serviceAccountCertificate": configuration["ca_cert"].(string),
+				"apiToken":                  tfhelper.SecretValue(d, "service_account.0.token"),
+				"serviceAccountCertificate": tfhelper.SecretValue(d, "service_account.0.ca_cert"),
 			},
 			Scheme: converter.String("Token"),
 		}
@@ -365,6 +363,7 @@ func flattenServiceEndpointKubernetes(d *schema.ResourceData, serviceEndpoint *s
 		acceptUntrustedCerts, _ := strconv.ParseBool((*serviceEndpoint.Data)["acceptUntrustedCerts"])
 		kubeconfig = map[string]interface{}{
 			"kube_config":            configuration["kube_config"].(string),
+			"kube_config_wo_version": configuration["kube_config_wo_version"].(int),
 			"cluster_context":        (*serviceEndpoint.Authorization.Parameters)["clusterContext"],
 			"accept_untrusted_certs": acceptUntrustedCerts,
 		}
@@ -384,8 +383,10 @@ func flattenServiceEndpointKubernetes(d *schema.ResourceData, serviceEndpoint *s
 		} else {
 			configuration := serviceAccountSet[0].(map[string]interface{})
 			serviceAccount = map[string]interface{}{
-				"token":   configuration["token"].(string),
-				"ca_cert": configuration["ca_cert"].(string),
+				"token":              configuration["token"].(string),
+				"token_wo_version":   configuration["token_wo_version"].(int),
+				"ca_cert":            configuration["ca_cert"].(string),
+				"ca_cert_wo_version": configuration["ca_cert_wo_version"].(int),
 			}
 		}
 
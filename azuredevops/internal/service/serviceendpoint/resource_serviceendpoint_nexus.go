@@ -62,6 +62,7 @@ func ResourceServiceEndpointNexus() *schema.Resource {
 		Sensitive:   true,
 	}
 
+	tfhelper.AddWriteOnlySecrets(r, "password")
 	return r
 }
 
@@ -137,7 +138,7 @@ func expandServiceEndpointNexus(d *schema.ResourceData) (*serviceendpoint.Servic
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 		Parameters: &map[string]string{
 			"username": d.Get("username").(string),
-			"password": d.Get("password").(string),
+			"password": tfhelper.SecretValue(d, "password"),
 		},
 		Scheme: converter.String("UsernamePassword"),
 	}
@@ -45,6 +45,8 @@ func ResourceServiceEndpointNpm() *schema.Resource {
 		ValidateFunc: validation.StringIsNotWhiteSpace,
 		Description:  "The access token for npm registry",
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "access_token")
 	return r
 }
 
@@ -116,7 +118,7 @@ func expandServiceEndpointNpm(d *schema.ResourceData) (*serviceendpoint.ServiceE
 	serviceEndpoint, projectID := doBaseExpansion(d)
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 		Parameters: &map[string]string{
-			"apitoken": d.Get("access_token").(string),
+			"apitoken": tfhelper.SecretValue(d, "access_token"),
 		},
 		Scheme: converter.String("Token"),
 	}
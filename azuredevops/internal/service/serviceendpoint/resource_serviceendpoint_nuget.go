@@ -1,10 +1,12 @@
 package serviceendpoint
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/serviceendpoint"
@@ -41,8 +43,8 @@ func ResourceServiceEndpointNuGet() *schema.Resource {
 		Optional:      true,
 		Sensitive:     true,
 		ValidateFunc:  validation.StringIsNotEmpty,
-		ConflictsWith: []string{"personal_access_token", "username", "password"},
-		AtLeastOneOf:  []string{"api_key", "personal_access_token", "username", "password"},
+		ConflictsWith: []string{"personal_access_token", "personal_access_token_wo", "username", "password", "password_wo"},
+		AtLeastOneOf:  []string{"api_key", "api_key_wo", "personal_access_token", "personal_access_token_wo", "username", "password", "password_wo"},
 	}
 
 	r.Schema["personal_access_token"] = &schema.Schema{
@@ -50,15 +52,14 @@ func ResourceServiceEndpointNuGet() *schema.Resource {
 		Optional:      true,
 		Sensitive:     true,
 		ValidateFunc:  validation.StringIsNotEmpty,
-		ConflictsWith: []string{"api_key", "username", "password"},
+		ConflictsWith: []string{"api_key", "api_key_wo", "username", "password", "password_wo"},
 	}
 
 	r.Schema["username"] = &schema.Schema{
 		Type:          schema.TypeString,
 		Optional:      true,
 		ValidateFunc:  validation.StringIsNotEmpty,
-		ConflictsWith: []string{"personal_access_token", "api_key"},
-		RequiredWith:  []string{"password"},
+		ConflictsWith: []string{"personal_access_token", "personal_access_token_wo", "api_key", "api_key_wo"},
 	}
 
 	r.Schema["password"] = &schema.Schema{
@@ -66,9 +67,19 @@ func ResourceServiceEndpointNuGet() *schema.Resource {
 		Optional:      true,
 		Sensitive:     true,
 		ValidateFunc:  validation.StringIsNotEmpty,
-		ConflictsWith: []string{"personal_access_token", "api_key"},
+		ConflictsWith: []string{"personal_access_token", "personal_access_token_wo", "api_key", "api_key_wo"},
 		RequiredWith:  []string{"username"},
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "api_key", "personal_access_token", "password")
+	r.Schema["api_key_wo"].ConflictsWith = []string{"api_key", "personal_access_token", "personal_access_token_wo", "username", "password", "password_wo"}
+	r.Schema["personal_access_token_wo"].ConflictsWith = []string{"personal_access_token", "api_key", "api_key_wo", "username", "password", "password_wo"}
+	r.Schema["password_wo"].ConflictsWith = []string{"password", "personal_access_token", "personal_access_token_wo", "api_key", "api_key_wo"}
+	r.Schema["password_wo"].RequiredWith = []string{"username"}
+	// the password can be set through either `password` or `password_wo`
+	r.CustomizeDiff = customdiff.IfValue("username", func(_ context.Context, v, _ interface{}) bool {
+		return v.(string) != ""
+	}, tfhelper.RequireSecrets("password"))
 	return r
 }
 
@@ -139,20 +150,20 @@ func expandServiceEndpointNuGet(d *schema.ResourceData) (*serviceendpoint.Servic
 	serviceEndpoint, projectID := doBaseExpansion(d)
 	serviceEndpoint.Type = converter.String("externalnugetfeed")
 	serviceEndpoint.Url = converter.String(d.Get("feed_url").(string))
-	if apiKey := d.Get("api_key"); apiKey != "" {
+	if apiKey := tfhelper.SecretValue(d, "api_key"); apiKey != "" {
 		serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 			Parameters: &map[string]string{
-				"nugetkey": apiKey.(string),
+				"nugetkey": apiKey,
 			},
 			Scheme: converter.String("None"),
 		}
 	}
 
-	if pat := d.Get("personal_access_token"); pat != "" {
+	if pat := tfhelper.SecretValue(d, "personal_access_token"); pat != "" {
 		serviceEndpoint.Type = converter.String("externalnugetfeed")
 		serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 			Parameters: &map[string]string{
-				"apitoken": pat.(string),
+				"apitoken": pat,
 			},
 			Scheme: converter.String("Token"),
 		}
@@ -163,7 +174,7 @@ func expandServiceEndpointNuGet(d *schema.ResourceData) (*serviceendpoint.Servic
 		serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 			Parameters: &map[string]string{
 				"username": uname.(string),
-				"password": d.Get("password").(string),
+				"password": tfhelper.SecretValue(d, "password"),
 			},
 			Scheme: converter.String("UsernamePassword"),
 		}
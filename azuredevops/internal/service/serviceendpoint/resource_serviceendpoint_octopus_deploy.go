@@ -80,8 +80,7 @@ func resourceServiceEndpointOctopusDeployRead(d *schema.ResourceData, m interfac
 		return fmt.Errorf(" looking up service endpoint given ID (%v) and project ID (%v): %v", getArgs.EndpointId, getArgs.Project, err)
 	}
 
-	flattenServiceEndpointOctopusDeploy(d, serviceEndpoint, (*serviceEndpoint.ServiceEndpointProjectReferences)[0].ProjectReference.Id.String())
-	return nil
+	return flattenServiceEndpointOctopusDeploy(d, serviceEndpoint, (*serviceEndpoint.ServiceEndpointProjectReferences)[0].ProjectReference.Id.String())
 }
 
 func resourceServiceEndpointOctopusDeployUpdate(d *schema.ResourceData, m interface{}) error {
@@ -97,7 +96,9 @@ func resourceServiceEndpointOctopusDeployUpdate(d *schema.ResourceData, m interf
 		return fmt.Errorf("Error updating service endpoint in Azure DevOps: %+v", err)
 	}
 
-	flattenServiceEndpointOctopusDeploy(d, updatedServiceEndpoint, projectID.String())
+	if err := flattenServiceEndpointOctopusDeploy(d, updatedServiceEndpoint, projectID.String()); err != nil {
+		return err
+	}
 	return resourceServiceEndpointOctopusDeployRead(d, m)
 }
 
@@ -128,15 +129,16 @@ func expandServiceEndpointOctopusDeploy(d *schema.ResourceData) (*serviceendpoin
 	return serviceEndpoint, projectID, nil
 }
 
-func flattenServiceEndpointOctopusDeploy(d *schema.ResourceData, serviceEndpoint *serviceendpoint.ServiceEndpoint, projectID string) {
+func flattenServiceEndpointOctopusDeploy(d *schema.ResourceData, serviceEndpoint *serviceendpoint.ServiceEndpoint, projectID string) error {
 	doBaseFlattening(d, serviceEndpoint, projectID)
 	d.Set("url", *serviceEndpoint.Url)
 
 	if v, ok := (*serviceEndpoint.Data)["ignoreSslErrors"]; ok && v != "" {
 		ignoreSslErrors, err := strconv.ParseBool(v)
 		if err != nil {
-			panic(fmt.Errorf(" Failed to parse OctopusDeploy.ignore_ssl_error.(Project: %s), (service endpoint:%s) ,Error: %+v", *serviceEndpoint.Name, projectID, err))
+			return fmt.Errorf(" Failed to parse OctopusDeploy.ignore_ssl_error.(Project: %s), (service endpoint:%s) ,Error: %w", projectID, *serviceEndpoint.Name, err)
 		}
 		d.Set("ignore_ssl_error", ignoreSslErrors)
 	}
+	return nil
 }
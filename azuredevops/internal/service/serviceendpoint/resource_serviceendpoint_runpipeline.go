@@ -38,7 +38,7 @@ func ResourceServiceEndpointRunPipeline() *schema.Resource {
 	}
 
 	r.Schema["auth_personal"] = &schema.Schema{
-		Type:     schema.TypeSet,
+		Type:     schema.TypeList,
 		Required: true,
 		MinItems: 1,
 		MaxItems: 1,
@@ -56,6 +56,7 @@ func ResourceServiceEndpointRunPipeline() *schema.Resource {
 		},
 	}
 
+	tfhelper.AddWriteOnlySecrets(r, "auth_personal.0.personal_access_token")
 	return r
 }
 
@@ -128,7 +129,7 @@ func expandServiceEndpointRunPipeline(d *schema.ResourceData) (*serviceendpoint.
 	serviceEndpoint.Type = converter.String("azdoapi")
 
 	scheme := "Token"
-	parameters := rpExpandAuthPersonalSet(d.Get("auth_personal").(*schema.Set))
+	parameters := rpExpandAuthPersonal(d)
 
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 		Parameters: &parameters,
@@ -146,11 +147,10 @@ func expandServiceEndpointRunPipeline(d *schema.ResourceData) (*serviceendpoint.
 	return serviceEndpoint, projectID, nil
 }
 
-func rpExpandAuthPersonalSet(d *schema.Set) map[string]string {
+func rpExpandAuthPersonal(d *schema.ResourceData) map[string]string {
 	authPerson := make(map[string]string)
-	if len(d.List()) == 1 {
-		val := d.List()[0].(map[string]interface{}) //auth_personal block may have only one element inside
-		authPerson["apitoken"] = val["personal_access_token"].(string)
+	if len(d.Get("auth_personal").([]interface{})) == 1 { //auth_personal block may have only one element inside
+		authPerson["apitoken"] = tfhelper.SecretValue(d, "auth_personal.0.personal_access_token")
 	}
 	return authPerson
 }
@@ -158,7 +158,7 @@ func rpExpandAuthPersonalSet(d *schema.Set) map[string]string {
 // Convert AzDO data structure to internal Terraform data structure
 func flattenServiceEndpointRunPipeline(d *schema.ResourceData, serviceEndpoint *serviceendpoint.ServiceEndpoint, projectID string) {
 	doBaseFlattening(d, serviceEndpoint, projectID)
-	authPersonalSet := d.Get("auth_personal").(*schema.Set).List()
+	authPersonalSet := d.Get("auth_personal").([]interface{})
 	if len(authPersonalSet) == 1 {
 		if authPersonal, ok := authPersonalSet[0].(map[string]interface{}); ok {
 			d.Set("auth_personal", []interface{}{authPersonal})
@@ -122,6 +122,11 @@ func ResourceServiceEndpointServiceFabric() *schema.Resource {
 		ConflictsWith: []string{resourceBlockServiceFabricCertificate, resourceBlockServiceFabricAzureActiveDirectory},
 	}
 
+	tfhelper.AddWriteOnlySecrets(r,
+		resourceBlockServiceFabricAzureActiveDirectory+".0.password",
+		resourceBlockServiceFabricCertificate+".0.client_certificate",
+		resourceBlockServiceFabricCertificate+".0.client_certificate_password",
+	)
 	return r
 }
 
@@ -228,8 +233,8 @@ func expandServiceEndpointServiceFabric(d *schema.ResourceData) (*serviceendpoin
 	if certificateOk {
 		configuration := certificate.([]interface{})[0].(map[string]interface{})
 		parameters := expandServiceEndpointServiceFabricServerCertificateLookup(configuration)
-		parameters["certificate"] = configuration["client_certificate"].(string)
-		parameters["certificatepassword"] = configuration["client_certificate_password"].(string)
+		parameters["certificate"] = tfhelper.SecretValue(d, resourceBlockServiceFabricCertificate+".0.client_certificate")
+		parameters["certificatepassword"] = tfhelper.SecretValue(d, resourceBlockServiceFabricCertificate+".0.client_certificate_password")
 		serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 			Parameters: &parameters,
 			Scheme:     converter.String("Certificate"),
@@ -242,7 +247,7 @@ func expandServiceEndpointServiceFabric(d *schema.ResourceData) (*serviceendpoin
 		configuration := azureActiveDirectory.([]interface{})[0].(map[string]interface{})
 		parameters := expandServiceEndpointServiceFabricServerCertificateLookup(configuration)
 		parameters["username"] = configuration["username"].(string)
-		parameters["password"] = configuration["password"].(string)
+		parameters["password"] = tfhelper.SecretValue(d, resourceBlockServiceFabricAzureActiveDirectory+".0.password")
 		serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 			Parameters: &parameters,
 			Scheme:     converter.String("UsernamePassword"),
@@ -291,6 +296,8 @@ func flattenServiceFabricCertificate(d *schema.ResourceData, serviceEndpoint *se
 		if v, ok := configuration["client_certificate_password"]; ok {
 			result[0]["client_certificate_password"] = v.(string)
 		}
+		result[0]["client_certificate_wo_version"] = configuration["client_certificate_wo_version"]
+		result[0]["client_certificate_password_wo_version"] = configuration["client_certificate_password_wo_version"]
 	}
 
 	return result
@@ -304,6 +311,7 @@ func flattenServiceFabricAzureActiveDirectory(d *schema.ResourceData, serviceEnd
 		if v, ok := configuration["password"]; ok {
 			result[0]["password"] = v.(string)
 		}
+		result[0]["password_wo_version"] = configuration["password_wo_version"]
 	}
 	return result
 }
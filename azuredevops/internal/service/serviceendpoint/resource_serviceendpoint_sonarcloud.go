@@ -38,6 +38,8 @@ func ResourceServiceEndpointSonarCloud() *schema.Resource {
 		ValidateFunc: validation.StringIsNotWhiteSpace,
 		Description:  "Authentication Token generated through SonarCloud (go to My Account > Security > Generate Tokens)",
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "token")
 	return r
 }
 
@@ -110,7 +112,7 @@ func expandServiceEndpointSonarCloud(d *schema.ResourceData) (*serviceendpoint.S
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 		Scheme: converter.String("Token"),
 		Parameters: &map[string]string{
-			"apitoken": d.Get("token").(string),
+			"apitoken": tfhelper.SecretValue(d, "token"),
 		},
 	}
 	serviceEndpoint.Type = converter.String("sonarcloud")
@@ -46,6 +46,7 @@ func ResourceServiceEndpointSonarQube() *schema.Resource {
 		Description:  "Authentication Token generated through SonarQube (go to My Account > Security > Generate Tokens)",
 	}
 
+	tfhelper.AddWriteOnlySecrets(r, "token")
 	return r
 }
 
@@ -118,7 +119,7 @@ func expandServiceEndpointSonarQube(d *schema.ResourceData) (*serviceendpoint.Se
 	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
 		Scheme: converter.String("UsernamePassword"),
 		Parameters: &map[string]string{
-			"username": d.Get("token").(string),
+			"username": tfhelper.SecretValue(d, "token"),
 		},
 	}
 	serviceEndpoint.Type = converter.String("sonarqube")
@@ -63,6 +63,8 @@ func ResourceServiceEndpointSSH() *schema.Resource {
 		Sensitive:    true,
 		ValidateFunc: validation.StringIsNotEmpty,
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "password", "private_key")
 	return r
 }
 
@@ -137,8 +139,8 @@ func expandServiceEndpointSSH(d *schema.ResourceData) (*serviceendpoint.ServiceE
 	serviceEndpoint.Type = converter.String("ssh")
 	parameters := map[string]string{}
 	parameters["username"] = d.Get("username").(string)
-	if pwd, ok := d.GetOk("password"); ok {
-		parameters["password"] = pwd.(string)
+	if pwd := tfhelper.SecretValue(d, "password"); pwd != "" {
+		parameters["password"] = pwd
 	}
 	serviceEndpoint.Authorization.Parameters = &parameters
 
@@ -147,8 +149,8 @@ func expandServiceEndpointSSH(d *schema.ResourceData) (*serviceendpoint.ServiceE
 	if port, ok := d.GetOk("port"); ok {
 		data["Port"] = strconv.Itoa(port.(int))
 	}
-	if privateKey, ok := d.GetOk("private_key"); ok {
-		data["PrivateKey"] = privateKey.(string)
+	if privateKey := tfhelper.SecretValue(d, "private_key"); privateKey != "" {
+		data["PrivateKey"] = privateKey
 	}
 	serviceEndpoint.Data = &data
 
@@ -0,0 +1,187 @@
+package servicehook
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/forminput"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/servicehooks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+// redactedValue replaces the secrets of the URLs of the subscriptions
+const redactedValue = "REDACTED"
+
+// DataServicehookSubscriptions schema and implementation for the service hook subscriptions data source
+func DataServicehookSubscriptions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceServicehookSubscriptionsRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"publisher_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"consumer_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"event_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"subscriptions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"publisher_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"event_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"consumer_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"consumer_action_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_by": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceServicehookSubscriptionsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	publisherID := d.Get("publisher_id").(string)
+	consumerID := d.Get("consumer_id").(string)
+	eventType := d.Get("event_type").(string)
+
+	query := &servicehooks.SubscriptionsQuery{
+		PublisherInputFilters: &[]forminput.InputFilter{{
+			Conditions: &[]forminput.InputFilterCondition{{
+				InputId:    converter.String("projectId"),
+				InputValue: converter.String(projectID),
+				Operator:   &forminput.InputFilterOperatorValues.Equals,
+			}},
+		}},
+	}
+	if publisherID != "" {
+		query.PublisherId = converter.String(publisherID)
+	}
+	if consumerID != "" {
+		query.ConsumerId = converter.String(consumerID)
+	}
+	if eventType != "" {
+		query.EventType = converter.String(eventType)
+	}
+
+	result, err := clients.ServiceHooksClient.CreateSubscriptionsQuery(clients.Ctx, servicehooks.CreateSubscriptionsQueryArgs{Query: query})
+	if err != nil {
+		return fmt.Errorf(" querying service hook subscriptions of project %s: %+v", projectID, err)
+	}
+
+	var subscriptions []servicehooks.Subscription
+	if result != nil && result.Results != nil {
+		for _, subscription := range *result.Results {
+			// not every publisher supports the filter of the project, which is why it is checked again
+			if subscription.PublisherInputs == nil || !strings.EqualFold((*subscription.PublisherInputs)["projectId"], projectID) {
+				continue
+			}
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] service hook subscriptions of project %s", len(subscriptions), projectID)
+
+	if err := d.Set("subscriptions", flattenServicehookSubscriptions(subscriptions)); err != nil {
+		return fmt.Errorf(" setting subscriptions: %+v", err)
+	}
+	d.SetId(fmt.Sprintf("servicehooksubscriptions-%s-%s-%s-%s", projectID, publisherID, consumerID, eventType))
+	return nil
+}
+
+func flattenServicehookSubscriptions(subscriptions []servicehooks.Subscription) []interface{} {
+	sort.SliceStable(subscriptions, func(i, j int) bool {
+		return converter.ToString(subscriptions[i].PublisherId, "")+converter.ToString(subscriptions[i].EventType, "") <
+			converter.ToString(subscriptions[j].PublisherId, "")+converter.ToString(subscriptions[j].EventType, "")
+	})
+
+	results := make([]interface{}, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		result := map[string]interface{}{
+			"publisher_id":       converter.ToString(subscription.PublisherId, ""),
+			"event_type":         converter.ToString(subscription.EventType, ""),
+			"consumer_id":        converter.ToString(subscription.ConsumerId, ""),
+			"consumer_action_id": converter.ToString(subscription.ConsumerActionId, ""),
+		}
+		if subscription.Id != nil {
+			result["id"] = subscription.Id.String()
+		}
+		if subscription.Status != nil {
+			result["status"] = string(*subscription.Status)
+		}
+		if subscription.CreatedBy != nil {
+			result["created_by"] = converter.ToString(subscription.CreatedBy.UniqueName, converter.ToString(subscription.CreatedBy.DisplayName, ""))
+		}
+		if subscription.ConsumerInputs != nil {
+			result["url"] = redactSubscriptionURL((*subscription.ConsumerInputs)["url"])
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// redactSubscriptionURL only keeps the scheme and the host of the URL of a web hook, as secrets are commonly part
+// of the user info, the path or the query, e.g. the tokens of Slack and Teams web hooks or the key of an Azure Function
+func redactSubscriptionURL(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return redactedValue
+	}
+
+	redacted := u.Scheme + "://" + u.Host
+	if u.User != nil || strings.Trim(u.Path, "/") != "" || u.RawQuery != "" || u.Fragment != "" {
+		redacted += "/" + redactedValue
+	}
+	return redacted
+}
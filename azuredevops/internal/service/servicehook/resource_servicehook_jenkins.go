@@ -0,0 +1,172 @@
+package servicehook
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/servicehooks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// ResourceServicehookJenkins schema and implementation for a service hook triggering a Jenkins job on code and
+// build events
+func ResourceServicehookJenkins() *schema.Resource {
+	resourceSchema := genTfsCodePublisherSchema()
+	resourceSchema["project_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.IsUUID,
+		Description:  "The ID of the project",
+	}
+	resourceSchema["server_url"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+		Description:  "The base URL of the Jenkins server",
+	}
+	resourceSchema["username"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+		Description:  "The name of the Jenkins user triggering the job",
+	}
+	resourceSchema["password"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		Sensitive:    true,
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+		Description:  "The API token or password of the Jenkins user",
+	}
+	resourceSchema["job_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+		Description:  "The name of the Jenkins job to trigger, jobs in folders are separated by slashes",
+	}
+	resourceSchema["build_token"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Sensitive:   true,
+		Description: "The authentication token of the Jenkins job to trigger builds remotely",
+	}
+	resourceSchema["build_parameters"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The parameters of the triggered builds of a parameterized Jenkins job",
+	}
+
+	r := &schema.Resource{
+		Create: resourceServicehookJenkinsCreate,
+		Read:   resourceServicehookJenkinsRead,
+		Update: resourceServicehookJenkinsUpdate,
+		Delete: resourceServicehookJenkinsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: resourceSchema,
+	}
+
+	tfhelper.AddWriteOnlySecrets(r, "password", "build_token")
+	return r
+}
+
+func resourceServicehookJenkinsCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	subscription := expandServicehookJenkins(d)
+
+	createdSubscription, err := createSubscription(d, clients, subscription)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(createdSubscription.Id.String())
+	return resourceServicehookJenkinsRead(d, m)
+}
+
+func resourceServicehookJenkinsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	subscription, err := getSubscription(clients, converter.UUID(d.Id()))
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(" reading Jenkins service hook %s: %+v", d.Id(), err)
+	}
+	flattenServicehookJenkins(d, subscription)
+	return nil
+}
+
+func resourceServicehookJenkinsUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	if _, err := updateSubscription(clients, expandServicehookJenkins(d)); err != nil {
+		return fmt.Errorf(" updating Jenkins service hook %s: %+v", d.Id(), err)
+	}
+	return resourceServicehookJenkinsRead(d, m)
+}
+
+func resourceServicehookJenkinsDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	return clients.ServiceHooksClient.DeleteSubscription(clients.Ctx, servicehooks.DeleteSubscriptionArgs{
+		SubscriptionId: converter.UUID(d.Id()),
+	})
+}
+
+func expandServicehookJenkins(d *schema.ResourceData) *servicehooks.Subscription {
+	var subscriptionId *uuid.UUID
+	if parsedID, err := uuid.Parse(d.Id()); err == nil {
+		subscriptionId = &parsedID
+	}
+
+	consumerInputs := map[string]string{
+		"serverBaseUrl": d.Get("server_url").(string),
+		"username":      d.Get("username").(string),
+		"password":      tfhelper.SecretValue(d, "password"),
+		"buildName":     d.Get("job_name").(string),
+	}
+	if token := tfhelper.SecretValue(d, "build_token"); token != "" {
+		consumerInputs["buildAuthToken"] = token
+	}
+	if parameters := d.Get("build_parameters").(map[string]interface{}); len(parameters) > 0 {
+		consumerInputs["buildParameterized"] = "true"
+		consumerInputs["buildParams"] = expandNameValueLines(parameters)
+	}
+
+	publisherInputs, eventType := expandTfsEventConfig(d, tfsCodeEvents)
+	return &servicehooks.Subscription{
+		Id:               subscriptionId,
+		ConsumerActionId: converter.String("triggerGenericBuild"),
+		ConsumerId:       converter.String("jenkins"),
+		ConsumerInputs:   &consumerInputs,
+		EventType:        &eventType,
+		PublisherId:      converter.String("tfs"),
+		PublisherInputs:  &publisherInputs,
+		ResourceVersion:  converter.String("1.0"),
+	}
+}
+
+// flattenServicehookJenkins sets the arguments of a subscription, the secrets are masked by the service and
+// keep the values of the configuration
+func flattenServicehookJenkins(d *schema.ResourceData, subscription *servicehooks.Subscription) {
+	d.SetId(subscription.Id.String())
+
+	setTfsEventConfig(d, subscription, tfsCodeEvents)
+	if subscription.PublisherInputs != nil {
+		d.Set("project_id", (*subscription.PublisherInputs)["projectId"])
+	}
+	if subscription.ConsumerInputs != nil {
+		inputs := *subscription.ConsumerInputs
+		d.Set("server_url", inputs["serverBaseUrl"])
+		d.Set("username", inputs["username"])
+		d.Set("job_name", inputs["buildName"])
+		d.Set("build_parameters", flattenNameValueLines(inputs["buildParams"]))
+	}
+}
@@ -0,0 +1,182 @@
+//go:build (all || resource_servicehook_jenkins) && !exclude_subscriptions
+// +build all resource_servicehook_jenkins
+// +build !exclude_subscriptions
+
+package servicehook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/servicehooks"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var subscriptionJenkinsID = uuid.New()
+
+var testResourceSubscriptionJenkins = []servicehooks.Subscription{
+	{
+		Id:               &subscriptionJenkinsID,
+		ConsumerActionId: converter.String("triggerGenericBuild"),
+		ConsumerId:       converter.String("jenkins"),
+		ConsumerInputs: &map[string]string{
+			"serverBaseUrl": "https://jenkins.example.com",
+			"username":      "myuser",
+			"password":      "mypassword",
+			"buildName":     "folder/myjob",
+		},
+		EventType:   converter.String("git.push"),
+		PublisherId: converter.String("tfs"),
+		PublisherInputs: &map[string]string{
+			"projectId": "myprojectid",
+		},
+		ResourceVersion: converter.String("1.0"),
+	},
+	{
+		Id:               &subscriptionJenkinsID,
+		ConsumerActionId: converter.String("triggerGenericBuild"),
+		ConsumerId:       converter.String("jenkins"),
+		ConsumerInputs: &map[string]string{
+			"serverBaseUrl":      "https://jenkins.example.com",
+			"username":           "myuser",
+			"password":           "mypassword",
+			"buildName":          "myjob",
+			"buildAuthToken":     "mytoken",
+			"buildParameterized": "true",
+			"buildParams":        "branch:main\nenvironment:dev",
+		},
+		EventType:   converter.String("git.pullrequest.merged"),
+		PublisherId: converter.String("tfs"),
+		PublisherInputs: &map[string]string{
+			"projectId":   "myprojectid",
+			"repository":  "myrepositoryid",
+			"branch":      "refs/heads/main",
+			"mergeResult": "Succeeded",
+		},
+		ResourceVersion: converter.String("1.0"),
+	},
+	{
+		Id:               &subscriptionJenkinsID,
+		ConsumerActionId: converter.String("triggerGenericBuild"),
+		ConsumerId:       converter.String("jenkins"),
+		ConsumerInputs: &map[string]string{
+			"serverBaseUrl": "https://jenkins.example.com",
+			"username":      "myuser",
+			"password":      "mypassword",
+			"buildName":     "myjob",
+		},
+		EventType:   converter.String("build.complete"),
+		PublisherId: converter.String("tfs"),
+		PublisherInputs: &map[string]string{
+			"projectId":      "myprojectid",
+			"definitionName": "mydefinition",
+			"buildStatus":    "Failed",
+		},
+		ResourceVersion: converter.String("1.0"),
+	},
+}
+
+func setJenkinsSecrets(resourceData *schema.ResourceData, subscription servicehooks.Subscription) {
+	resourceData.Set("password", (*subscription.ConsumerInputs)["password"])
+	resourceData.Set("build_token", (*subscription.ConsumerInputs)["buildAuthToken"])
+}
+
+func TestServicehookJenkins_FlattenExpandRoundTrip(t *testing.T) {
+	for _, subscription := range testResourceSubscriptionJenkins {
+		resourceData := schema.TestResourceDataRaw(t, ResourceServicehookJenkins().Schema, nil)
+		flattenServicehookJenkins(resourceData, &subscription)
+		setJenkinsSecrets(resourceData, subscription)
+		subscriptionAfterRoundTrip := expandServicehookJenkins(resourceData)
+
+		require.Equal(t, subscription, *subscriptionAfterRoundTrip)
+	}
+}
+
+func TestServicehookJenkins_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceServicehookJenkins()
+	for _, subscription := range testResourceSubscriptionJenkins {
+		resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+		flattenServicehookJenkins(resourceData, &subscription)
+		setJenkinsSecrets(resourceData, subscription)
+
+		mockClient := azdosdkmocks.NewMockServicehooksClient(ctrl)
+		clients := &client.AggregatedClient{ServiceHooksClient: mockClient, Ctx: context.Background()}
+
+		mockClient.
+			EXPECT().
+			CreateSubscription(clients.Ctx, servicehooks.CreateSubscriptionArgs{Subscription: &subscription}).
+			Return(nil, errors.New("CreateSubscription() Failed")).
+			Times(1)
+
+		err := r.Create(resourceData, clients)
+		require.Contains(t, err.Error(), "CreateSubscription() Failed")
+	}
+}
+
+// verifies that the secrets masked by the service keep the values of the configuration
+func TestServicehookJenkins_Read_KeepsSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceServicehookJenkins()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	resourceData.SetId(subscriptionJenkinsID.String())
+	resourceData.Set("password", "mypassword")
+
+	mockClient := azdosdkmocks.NewMockServicehooksClient(ctrl)
+	clients := &client.AggregatedClient{ServiceHooksClient: mockClient, Ctx: context.Background()}
+
+	subscription := testResourceSubscriptionJenkins[1]
+	masked := map[string]string{}
+	for k, v := range *subscription.ConsumerInputs {
+		masked[k] = v
+	}
+	masked["password"] = "********"
+	subscription.ConsumerInputs = &masked
+
+	mockClient.
+		EXPECT().
+		GetSubscription(clients.Ctx, servicehooks.GetSubscriptionArgs{SubscriptionId: &subscriptionJenkinsID}).
+		Return(&subscription, nil).
+		Times(1)
+
+	err := r.Read(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "mypassword", resourceData.Get("password"))
+	require.Equal(t, map[string]interface{}{"branch": "main", "environment": "dev"}, resourceData.Get("build_parameters"))
+	require.Len(t, resourceData.Get("git_pull_request_merged_event").([]interface{}), 1)
+	require.Empty(t, resourceData.Get("git_push_event").([]interface{}))
+}
+
+func TestServicehookJenkins_Read_RemovesDeletedSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceServicehookJenkins()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	resourceData.SetId(subscriptionJenkinsID.String())
+
+	mockClient := azdosdkmocks.NewMockServicehooksClient(ctrl)
+	clients := &client.AggregatedClient{ServiceHooksClient: mockClient, Ctx: context.Background()}
+
+	mockClient.
+		EXPECT().
+		GetSubscription(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(404)}).
+		Times(1)
+
+	err := r.Read(resourceData, clients)
+	require.Nil(t, err)
+	require.Empty(t, resourceData.Id())
+}
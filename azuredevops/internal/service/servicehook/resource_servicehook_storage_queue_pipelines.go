@@ -10,6 +10,7 @@ import (
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/servicehooks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
 )
 
 func ResourceServicehookStorageQueuePipelines() *schema.Resource {
@@ -51,7 +52,7 @@ func ResourceServicehookStorageQueuePipelines() *schema.Resource {
 		Description: "event time-to-live - the duration a message can remain in the queue before it's automatically removed",
 	}
 
-	return &schema.Resource{
+	r := &schema.Resource{
 		Create: resourceServicehookStorageQueuePipelinesCreate,
 		Read:   resourceServicehookStorageQueuePipelinesRead,
 		Update: resourceServicehookStorageQueuePipelinesUpdate,
@@ -62,6 +63,9 @@ func ResourceServicehookStorageQueuePipelines() *schema.Resource {
 		},
 		Schema: resourceSchema,
 	}
+
+	tfhelper.AddWriteOnlySecrets(r, "account_key")
+	return r
 }
 
 func resourceServicehookStorageQueuePipelinesCreate(d *schema.ResourceData, m interface{}) error {
@@ -130,7 +134,7 @@ func expandServicehookStorageQueuePipelines(d *schema.ResourceData) (*servicehoo
 		ConsumerId:       converter.String("azureStorageQueue"),
 		ConsumerInputs: &map[string]string{
 			"accountName": d.Get("account_name").(string),
-			"accountKey":  d.Get("account_key").(string),
+			"accountKey":  tfhelper.SecretValue(d, "account_key"),
 			"queueName":   d.Get("queue_name").(string),
 			"visiTimeout": visiTimeout,
 			"ttl":         ttl,
@@ -0,0 +1,182 @@
+package servicehook
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/servicehooks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// ResourceServicehookWebhookWorkItems schema and implementation for a web hook posting work item events, which
+// are filtered by area path, work item type and changed field
+func ResourceServicehookWebhookWorkItems() *schema.Resource {
+	resourceSchema := genTfsWorkItemPublisherSchema()
+	resourceSchema["project_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.IsUUID,
+		Description:  "The ID of the project",
+	}
+	resourceSchema["url"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.IsURLWithHTTPS,
+		Description:  "The URL the events are posted to",
+	}
+	resourceSchema["basic_auth_username"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The user name of the basic authentication of the requests",
+	}
+	resourceSchema["basic_auth_password"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Sensitive:   true,
+		Description: "The password of the basic authentication of the requests",
+	}
+	resourceSchema["http_headers"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The HTTP headers of the requests",
+	}
+	resourceSchema["resource_details_to_send"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "all",
+		ValidateFunc: validation.StringInSlice([]string{"all", "minimal", "none"}, false),
+		Description:  "The details of the work item which are sent with the events",
+	}
+	resourceSchema["messages_to_send"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "all",
+		ValidateFunc: validation.StringInSlice([]string{"all", "text", "html", "markdown", "none"}, false),
+		Description:  "The formats of the messages which are sent with the events",
+	}
+	resourceSchema["detailed_messages_to_send"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "all",
+		ValidateFunc: validation.StringInSlice([]string{"all", "text", "html", "markdown", "none"}, false),
+		Description:  "The formats of the detailed messages which are sent with the events",
+	}
+
+	r := &schema.Resource{
+		Create: resourceServicehookWebhookWorkItemsCreate,
+		Read:   resourceServicehookWebhookWorkItemsRead,
+		Update: resourceServicehookWebhookWorkItemsUpdate,
+		Delete: resourceServicehookWebhookWorkItemsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: resourceSchema,
+	}
+
+	tfhelper.AddWriteOnlySecrets(r, "basic_auth_password")
+	return r
+}
+
+func resourceServicehookWebhookWorkItemsCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	subscription := expandServicehookWebhookWorkItems(d)
+
+	createdSubscription, err := createSubscription(d, clients, subscription)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(createdSubscription.Id.String())
+	return resourceServicehookWebhookWorkItemsRead(d, m)
+}
+
+func resourceServicehookWebhookWorkItemsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	subscription, err := getSubscription(clients, converter.UUID(d.Id()))
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(" reading work item web hook %s: %+v", d.Id(), err)
+	}
+	flattenServicehookWebhookWorkItems(d, subscription)
+	return nil
+}
+
+func resourceServicehookWebhookWorkItemsUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	if _, err := updateSubscription(clients, expandServicehookWebhookWorkItems(d)); err != nil {
+		return fmt.Errorf(" updating work item web hook %s: %+v", d.Id(), err)
+	}
+	return resourceServicehookWebhookWorkItemsRead(d, m)
+}
+
+func resourceServicehookWebhookWorkItemsDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	return clients.ServiceHooksClient.DeleteSubscription(clients.Ctx, servicehooks.DeleteSubscriptionArgs{
+		SubscriptionId: converter.UUID(d.Id()),
+	})
+}
+
+func expandServicehookWebhookWorkItems(d *schema.ResourceData) *servicehooks.Subscription {
+	var subscriptionId *uuid.UUID
+	if parsedID, err := uuid.Parse(d.Id()); err == nil {
+		subscriptionId = &parsedID
+	}
+
+	consumerInputs := map[string]string{
+		"url":                    d.Get("url").(string),
+		"resourceDetailsToSend":  d.Get("resource_details_to_send").(string),
+		"messagesToSend":         d.Get("messages_to_send").(string),
+		"detailedMessagesToSend": d.Get("detailed_messages_to_send").(string),
+	}
+	if username := d.Get("basic_auth_username").(string); username != "" {
+		consumerInputs["basicAuthUsername"] = username
+	}
+	if password := tfhelper.SecretValue(d, "basic_auth_password"); password != "" {
+		consumerInputs["basicAuthPassword"] = password
+	}
+	if headers := d.Get("http_headers").(map[string]interface{}); len(headers) > 0 {
+		consumerInputs["httpHeaders"] = expandNameValueLines(headers)
+	}
+
+	publisherInputs, eventType := expandTfsEventConfig(d, tfsWorkItemEvents)
+	return &servicehooks.Subscription{
+		Id:               subscriptionId,
+		ConsumerActionId: converter.String("httpRequest"),
+		ConsumerId:       converter.String("webHooks"),
+		ConsumerInputs:   &consumerInputs,
+		EventType:        &eventType,
+		PublisherId:      converter.String("tfs"),
+		PublisherInputs:  &publisherInputs,
+		ResourceVersion:  converter.String("1.0"),
+	}
+}
+
+// flattenServicehookWebhookWorkItems sets the arguments of a subscription, the password and the HTTP headers
+// are masked by the service and keep the values of the configuration
+func flattenServicehookWebhookWorkItems(d *schema.ResourceData, subscription *servicehooks.Subscription) {
+	d.SetId(subscription.Id.String())
+
+	setTfsEventConfig(d, subscription, tfsWorkItemEvents)
+	if subscription.PublisherInputs != nil {
+		d.Set("project_id", (*subscription.PublisherInputs)["projectId"])
+	}
+	if subscription.ConsumerInputs != nil {
+		inputs := *subscription.ConsumerInputs
+		d.Set("url", inputs["url"])
+		d.Set("basic_auth_username", inputs["basicAuthUsername"])
+		d.Set("resource_details_to_send", inputs["resourceDetailsToSend"])
+		d.Set("messages_to_send", inputs["messagesToSend"])
+		d.Set("detailed_messages_to_send", inputs["detailedMessagesToSend"])
+	}
+}
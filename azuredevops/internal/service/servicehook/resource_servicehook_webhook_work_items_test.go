@@ -0,0 +1,149 @@
+//go:build (all || resource_servicehook_webhook_work_items) && !exclude_subscriptions
+// +build all resource_servicehook_webhook_work_items
+// +build !exclude_subscriptions
+
+package servicehook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/servicehooks"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var subscriptionWebhookWorkItemsID = uuid.New()
+
+var testResourceSubscriptionWebhookWorkItems = []servicehooks.Subscription{
+	{
+		Id:               &subscriptionWebhookWorkItemsID,
+		ConsumerActionId: converter.String("httpRequest"),
+		ConsumerId:       converter.String("webHooks"),
+		ConsumerInputs: &map[string]string{
+			"url":                    "https://itsm.example.com/hooks",
+			"resourceDetailsToSend":  "all",
+			"messagesToSend":         "all",
+			"detailedMessagesToSend": "all",
+		},
+		EventType:   converter.String("workitem.created"),
+		PublisherId: converter.String("tfs"),
+		PublisherInputs: &map[string]string{
+			"projectId": "myprojectid",
+		},
+		ResourceVersion: converter.String("1.0"),
+	},
+	{
+		Id:               &subscriptionWebhookWorkItemsID,
+		ConsumerActionId: converter.String("httpRequest"),
+		ConsumerId:       converter.String("webHooks"),
+		ConsumerInputs: &map[string]string{
+			"url":                    "https://itsm.example.com/hooks",
+			"basicAuthUsername":      "myuser",
+			"basicAuthPassword":      "mypassword",
+			"httpHeaders":            "X-Source:azure-devops",
+			"resourceDetailsToSend":  "minimal",
+			"messagesToSend":         "none",
+			"detailedMessagesToSend": "none",
+		},
+		EventType:   converter.String("workitem.updated"),
+		PublisherId: converter.String("tfs"),
+		PublisherInputs: &map[string]string{
+			"projectId":     "myprojectid",
+			"areaPath":      "myproject\\myarea",
+			"workItemType":  "Incident",
+			"changedFields": "System.State",
+		},
+		ResourceVersion: converter.String("1.0"),
+	},
+}
+
+func setWebhookWorkItemsSecrets(resourceData *schema.ResourceData, subscription servicehooks.Subscription) {
+	inputs := *subscription.ConsumerInputs
+	resourceData.Set("basic_auth_password", inputs["basicAuthPassword"])
+	resourceData.Set("http_headers", flattenNameValueLines(inputs["httpHeaders"]))
+}
+
+func TestServicehookWebhookWorkItems_FlattenExpandRoundTrip(t *testing.T) {
+	for _, subscription := range testResourceSubscriptionWebhookWorkItems {
+		resourceData := schema.TestResourceDataRaw(t, ResourceServicehookWebhookWorkItems().Schema, nil)
+		flattenServicehookWebhookWorkItems(resourceData, &subscription)
+		setWebhookWorkItemsSecrets(resourceData, subscription)
+		subscriptionAfterRoundTrip := expandServicehookWebhookWorkItems(resourceData)
+
+		require.Equal(t, subscription, *subscriptionAfterRoundTrip)
+	}
+}
+
+// verifies that the filters of the work item events are set on the block of the event type only
+func TestServicehookWebhookWorkItems_Flatten_SetsFiltersOfEventType(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceServicehookWebhookWorkItems().Schema, nil)
+	resourceData.Set("work_item_created_event", []interface{}{map[string]interface{}{"work_item_type": "Bug"}})
+
+	flattenServicehookWebhookWorkItems(resourceData, &testResourceSubscriptionWebhookWorkItems[1])
+
+	require.Empty(t, resourceData.Get("work_item_created_event").([]interface{}))
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"area_path":      "myproject\\myarea",
+			"work_item_type": "Incident",
+			"changed_field":  "System.State",
+		},
+	}, resourceData.Get("work_item_updated_event"))
+}
+
+func TestServicehookWebhookWorkItems_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceServicehookWebhookWorkItems()
+	for _, subscription := range testResourceSubscriptionWebhookWorkItems {
+		resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+		flattenServicehookWebhookWorkItems(resourceData, &subscription)
+		setWebhookWorkItemsSecrets(resourceData, subscription)
+
+		mockClient := azdosdkmocks.NewMockServicehooksClient(ctrl)
+		clients := &client.AggregatedClient{ServiceHooksClient: mockClient, Ctx: context.Background()}
+
+		mockClient.
+			EXPECT().
+			CreateSubscription(clients.Ctx, servicehooks.CreateSubscriptionArgs{Subscription: &subscription}).
+			Return(nil, errors.New("CreateSubscription() Failed")).
+			Times(1)
+
+		err := r.Create(resourceData, clients)
+		require.Contains(t, err.Error(), "CreateSubscription() Failed")
+	}
+}
+
+func TestServicehookWebhookWorkItems_Update_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceServicehookWebhookWorkItems()
+	subscription := testResourceSubscriptionWebhookWorkItems[1]
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenServicehookWebhookWorkItems(resourceData, &subscription)
+	setWebhookWorkItemsSecrets(resourceData, subscription)
+
+	mockClient := azdosdkmocks.NewMockServicehooksClient(ctrl)
+	clients := &client.AggregatedClient{ServiceHooksClient: mockClient, Ctx: context.Background()}
+
+	mockClient.
+		EXPECT().
+		ReplaceSubscription(clients.Ctx, servicehooks.ReplaceSubscriptionArgs{
+			Subscription:   &subscription,
+			SubscriptionId: subscription.Id,
+		}).
+		Return(nil, errors.New("ReplaceSubscription() Failed")).
+		Times(1)
+
+	err := r.Update(resourceData, clients)
+	require.Contains(t, err.Error(), "ReplaceSubscription() Failed")
+}
@@ -0,0 +1,261 @@
+package servicehook
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/servicehooks"
+)
+
+// tfsEvent maps the block of an event of the "tfs" publisher to its event type and the arguments of the
+// block to the publisher inputs of the event
+type tfsEvent struct {
+	eventType string
+	inputs    map[string]string
+}
+
+var tfsCodeEvents = map[string]tfsEvent{
+	"git_push_event": {
+		eventType: "git.push",
+		inputs: map[string]string{
+			"repository_id": "repository",
+			"branch":        "branch",
+			"pushed_by":     "pushedBy",
+		},
+	},
+	"git_pull_request_merged_event": {
+		eventType: "git.pullrequest.merged",
+		inputs: map[string]string{
+			"repository_id": "repository",
+			"branch":        "branch",
+			"merge_result":  "mergeResult",
+		},
+	},
+	"build_completed_event": {
+		eventType: "build.complete",
+		inputs: map[string]string{
+			"definition_name": "definitionName",
+			"build_status":    "buildStatus",
+		},
+	},
+}
+
+func genTfsCodePublisherSchema() map[string]*schema.Schema {
+	eventBlocks := []string{"git_push_event", "git_pull_request_merged_event", "build_completed_event"}
+	return map[string]*schema.Schema{
+		"git_push_event": {
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: eventBlocks,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"repository_id": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.IsUUID,
+						Description:  "The ID of the repository to be monitored. If not specified, all repositories in the project will trigger the event",
+					},
+					"branch": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The branch to be monitored. If not specified, all branches will trigger the event",
+					},
+					"pushed_by": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The group whose members pushing the code should trigger the event. If not specified, all pushes will trigger the event",
+					},
+				},
+			},
+		},
+		"git_pull_request_merged_event": {
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: eventBlocks,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"repository_id": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.IsUUID,
+						Description:  "The ID of the repository to be monitored. If not specified, all repositories in the project will trigger the event",
+					},
+					"branch": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The target branch of the pull requests to be monitored. If not specified, all branches will trigger the event",
+					},
+					"merge_result": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice([]string{"Succeeded", "Unsuccessful"}, false),
+						Description:  "Which merge result should generate an event. If not specified, all results will trigger the event",
+					},
+				},
+			},
+		},
+		"build_completed_event": {
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: eventBlocks,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"definition_name": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The name of the build definition to be monitored. If not specified, all build definitions in the project will trigger the event",
+					},
+					"build_status": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice([]string{"Succeeded", "PartiallySucceeded", "Failed", "Stopped"}, false),
+						Description:  "Which build status should generate an event. If not specified, all statuses will trigger the event",
+					},
+				},
+			},
+		},
+	}
+}
+
+var tfsWorkItemEvents = map[string]tfsEvent{
+	"work_item_created_event": {
+		eventType: "workitem.created",
+		inputs: map[string]string{
+			"area_path":      "areaPath",
+			"work_item_type": "workItemType",
+		},
+	},
+	"work_item_updated_event": {
+		eventType: "workitem.updated",
+		inputs: map[string]string{
+			"area_path":      "areaPath",
+			"work_item_type": "workItemType",
+			"changed_field":  "changedFields",
+		},
+	},
+}
+
+func genTfsWorkItemPublisherSchema() map[string]*schema.Schema {
+	eventBlocks := []string{"work_item_created_event", "work_item_updated_event"}
+	filterSchema := func() map[string]*schema.Schema {
+		return map[string]*schema.Schema{
+			"area_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The area path of the work items to be monitored, including the work items of its child areas. If not specified, all areas will trigger the event",
+			},
+			"work_item_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The type of the work items to be monitored, e.g. `Bug`. If not specified, all types will trigger the event",
+			},
+		}
+	}
+	updatedSchema := filterSchema()
+	updatedSchema["changed_field"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+		Description:  "The reference name of the field whose changes should trigger the event, e.g. `System.State`. If not specified, all changes will trigger the event",
+	}
+
+	return map[string]*schema.Schema{
+		"work_item_created_event": {
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: eventBlocks,
+			Elem:         &schema.Resource{Schema: filterSchema()},
+		},
+		"work_item_updated_event": {
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: eventBlocks,
+			Elem:         &schema.Resource{Schema: updatedSchema},
+		},
+	}
+}
+
+// expandTfsEventConfig returns the publisher inputs and the event type of the configured event block
+func expandTfsEventConfig(d *schema.ResourceData, events map[string]tfsEvent) (map[string]string, string) {
+	eventConfig := map[string]string{}
+	var eventType string
+	for block, event := range events {
+		inputsList, ok := d.Get(block).([]interface{})
+		if !ok || len(inputsList) == 0 {
+			continue
+		}
+		eventType = event.eventType
+		if inputs, ok := inputsList[0].(map[string]interface{}); ok {
+			for argument, input := range event.inputs {
+				if v := inputs[argument].(string); v != "" {
+					eventConfig[input] = v
+				}
+			}
+		}
+	}
+	eventConfig["projectId"] = d.Get("project_id").(string)
+	return eventConfig, eventType
+}
+
+// flattenTfsEventConfig returns the event block of the event type of a subscription and its arguments
+func flattenTfsEventConfig(subscription *servicehooks.Subscription, events map[string]tfsEvent) (string, []interface{}) {
+	for block, event := range events {
+		if subscription.EventType == nil || *subscription.EventType != event.eventType {
+			continue
+		}
+		if subscription.PublisherInputs == nil || isNilEventConfig(*subscription.PublisherInputs) {
+			return block, []interface{}{nil}
+		}
+		eventConfig := map[string]interface{}{}
+		for argument, input := range event.inputs {
+			eventConfig[argument] = (*subscription.PublisherInputs)[input]
+		}
+		return block, []interface{}{eventConfig}
+	}
+	return "", nil
+}
+
+// setTfsEventConfig sets the event block of the event type of a subscription and clears the other event blocks
+func setTfsEventConfig(d *schema.ResourceData, subscription *servicehooks.Subscription, events map[string]tfsEvent) {
+	eventBlock, eventConfig := flattenTfsEventConfig(subscription, events)
+	for block := range events {
+		if block == eventBlock {
+			d.Set(block, eventConfig)
+		} else {
+			d.Set(block, nil)
+		}
+	}
+}
+
+// expandNameValueLines formats name-value pairs, like build parameters or HTTP headers, as consumer input with
+// the names and values separated by colons, one pair per line
+func expandNameValueLines(values map[string]interface{}) string {
+	lines := make([]string, 0, len(values))
+	for name, value := range values {
+		lines = append(lines, fmt.Sprintf("%s:%s", name, value.(string)))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+func flattenNameValueLines(input string) map[string]interface{} {
+	values := map[string]interface{}{}
+	for _, line := range strings.Split(input, "\n") {
+		name, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok || name == "" {
+			continue
+		}
+		values[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return values
+}
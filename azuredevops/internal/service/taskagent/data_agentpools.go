@@ -3,13 +3,20 @@ package taskagent
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
 )
 
+// agentPoolTypeElastic selects the automation pools backed by virtual machine scale sets, which are not a pool
+// type of their own
+const agentPoolTypeElastic = "elastic"
+
 // DataAgentPools schema and implementation for agent pools data source
 func DataAgentPools() *schema.Resource {
 	baseSchema := ResourceAgentPool()
@@ -21,6 +28,14 @@ func DataAgentPools() *schema.Resource {
 		Computed: true,
 	}
 
+	// maintenance definitions are not read by the data source
+	delete(baseSchema.Schema, "maintenance")
+
+	baseSchema.Schema["is_hosted"] = &schema.Schema{Type: schema.TypeBool}
+	baseSchema.Schema["is_elastic"] = &schema.Schema{Type: schema.TypeBool}
+	baseSchema.Schema["size"] = &schema.Schema{Type: schema.TypeInt}
+	baseSchema.Schema["target_size"] = &schema.Schema{Type: schema.TypeInt}
+
 	for k, v := range baseSchema.Schema {
 		baseSchema.Schema[k] = &schema.Schema{
 			Type:     v.Type,
@@ -32,6 +47,19 @@ func DataAgentPools() *schema.Resource {
 		Read: dataSourceAgentPoolsRead,
 
 		Schema: map[string]*schema.Schema{
+			"pool_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(taskagent.TaskAgentPoolTypeValues.Automation),
+					string(taskagent.TaskAgentPoolTypeValues.Deployment),
+					agentPoolTypeElastic,
+				}, false),
+			},
+			"auto_provision": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"agent_pools": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -46,12 +74,21 @@ func DataAgentPools() *schema.Resource {
 func dataSourceAgentPoolsRead(d *schema.ResourceData, m interface{}) error {
 	clients := m.(*client.AggregatedClient)
 
-	agentPools, err := getAgentPools(clients)
+	poolType := d.Get("pool_type").(string)
+	agentPools, err := getAgentPools(clients, poolType)
 	if err != nil {
 		return fmt.Errorf("Error finding agent pools. Error: %v", err)
 	}
 	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] agent pools from current organization", len(*agentPools))
 
+	var autoProvision *bool
+	if raw := d.GetRawConfig(); !raw.IsNull() {
+		if value := raw.GetAttr("auto_provision"); value.IsKnown() && !value.IsNull() {
+			autoProvision = converter.Bool(value.True())
+		}
+	}
+	agentPools = filterAgentPools(agentPools, poolType == agentPoolTypeElastic, autoProvision)
+
 	err = d.Set("agent_pools", flattenAgentPoolReferences(agentPools))
 	if err != nil {
 		return fmt.Errorf("Error setting agent_pools field in state. Error: %v", err)
@@ -90,12 +127,68 @@ func flattenAgentPoolReferences(input *[]taskagent.TaskAgentPool) []interface{}
 			output["auto_update"] = *element.AutoUpdate
 		}
 
+		if element.IsHosted != nil {
+			output["is_hosted"] = *element.IsHosted
+		}
+
+		if element.Size != nil {
+			output["size"] = *element.Size
+		}
+
+		if element.TargetSize != nil {
+			output["target_size"] = *element.TargetSize
+		}
+
+		output["is_elastic"] = isElasticAgentPool(element)
+
 		results = append(results, output)
 	}
 
 	return results
 }
 
-func getAgentPools(clients *client.AggregatedClient) (*[]taskagent.TaskAgentPool, error) {
-	return clients.TaskAgentClient.GetAgentPools(clients.Ctx, taskagent.GetAgentPoolsArgs{})
+// getAgentPools lists the agent pools of a pool type, elastic pools are listed as automation pools
+func getAgentPools(clients *client.AggregatedClient, poolType string) (*[]taskagent.TaskAgentPool, error) {
+	args := taskagent.GetAgentPoolsArgs{}
+	switch poolType {
+	case "":
+	case agentPoolTypeElastic:
+		args.PoolType = &taskagent.TaskAgentPoolTypeValues.Automation
+	default:
+		args.PoolType = converter.ToPtr(taskagent.TaskAgentPoolType(poolType))
+	}
+	return clients.TaskAgentClient.GetAgentPools(clients.Ctx, args)
+}
+
+// filterAgentPools keeps the elastic pools when elasticOnly is set, and the pools with a matching auto
+// provisioning setting when autoProvision is set
+func filterAgentPools(input *[]taskagent.TaskAgentPool, elasticOnly bool, autoProvision *bool) *[]taskagent.TaskAgentPool {
+	if input == nil {
+		return input
+	}
+
+	results := []taskagent.TaskAgentPool{}
+	for _, element := range *input {
+		if elasticOnly && !isElasticAgentPool(element) {
+			continue
+		}
+		if autoProvision != nil && (element.AutoProvision == nil || *element.AutoProvision != *autoProvision) {
+			continue
+		}
+		results = append(results, element)
+	}
+	return &results
+}
+
+// isElasticAgentPool reports whether the options flags of a pool include elasticPool
+func isElasticAgentPool(pool taskagent.TaskAgentPool) bool {
+	if pool.Options == nil {
+		return false
+	}
+	for _, option := range strings.Split(string(*pool.Options), ",") {
+		if strings.EqualFold(strings.TrimSpace(option), string(taskagent.TaskAgentPoolOptionsValues.ElasticPool)) {
+			return true
+		}
+	}
+	return false
 }
@@ -92,3 +92,76 @@ func TestDataSourceAgentPools_Read_TestFindAllAgentPools(t *testing.T) {
 	require.NotNil(t, agentPools)
 	require.Equal(t, len(dataTestAgentPools), len(agentPools))
 }
+
+func TestDataSourceAgentPools_Read_FiltersElasticPools(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{
+		TaskAgentClient: taskAgentClient,
+		Ctx:             context.Background(),
+	}
+
+	elasticPools := append([]taskagent.TaskAgentPool{{
+		Id:            converter.Int(7),
+		Name:          converter.String("AgentPool_Elastic"),
+		PoolType:      &taskagent.TaskAgentPoolTypeValues.Automation,
+		Options:       converter.ToPtr(taskagent.TaskAgentPoolOptions("elasticPool, singleUseAgents")),
+		AutoProvision: converter.Bool(false),
+		Size:          converter.Int(2),
+		TargetSize:    converter.Int(4),
+	}}, dataTestAgentPools[:2]...)
+	taskAgentClient.
+		EXPECT().
+		GetAgentPools(clients.Ctx, taskagent.GetAgentPoolsArgs{PoolType: &taskagent.TaskAgentPoolTypeValues.Automation}).
+		Return(&elasticPools, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataAgentPools().Schema, map[string]interface{}{
+		"pool_type": "elastic",
+	})
+	err := dataSourceAgentPoolsRead(resourceData, clients)
+	require.Nil(t, err)
+	agentPools := resourceData.Get("agent_pools").([]interface{})
+	require.Len(t, agentPools, 1)
+	agentPool := agentPools[0].(map[string]interface{})
+	require.Equal(t, "AgentPool_Elastic", agentPool["name"])
+	require.True(t, agentPool["is_elastic"].(bool))
+	require.Equal(t, 2, agentPool["size"])
+	require.Equal(t, 4, agentPool["target_size"])
+}
+
+func TestDataSourceAgentPools_FilterAgentPools_ByAutoProvision(t *testing.T) {
+	agentPools := filterAgentPools(&dataTestAgentPools, false, converter.Bool(false))
+	require.Len(t, *agentPools, 2)
+	for _, agentPool := range *agentPools {
+		require.False(t, *agentPool.AutoProvision)
+	}
+
+	agentPools = filterAgentPools(&dataTestAgentPools, false, nil)
+	require.Len(t, *agentPools, len(dataTestAgentPools))
+}
+
+func TestDataSourceAgentPools_Read_PassesPoolTypeToService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{
+		TaskAgentClient: taskAgentClient,
+		Ctx:             context.Background(),
+	}
+
+	deploymentPools := dataTestAgentPools[2:]
+	taskAgentClient.
+		EXPECT().
+		GetAgentPools(clients.Ctx, taskagent.GetAgentPoolsArgs{PoolType: &taskagent.TaskAgentPoolTypeValues.Deployment}).
+		Return(&deploymentPools, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataAgentPools().Schema, map[string]interface{}{
+		"pool_type": "deployment",
+	})
+	err := dataSourceAgentPoolsRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Len(t, resourceData.Get("agent_pools").([]interface{}), 1)
+}
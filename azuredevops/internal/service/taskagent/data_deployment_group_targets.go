@@ -0,0 +1,189 @@
+package taskagent
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// DataDeploymentGroupTargets schema and implementation for the deployment group targets data source
+func DataDeploymentGroupTargets() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDeploymentGroupTargetsRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"deployment_group_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"agent_status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(taskagent.TaskAgentStatusFilterValues.Online),
+					string(taskagent.TaskAgentStatusFilterValues.Offline),
+				}, false),
+			},
+			"targets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"agent_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"agent_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"agent_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"system_capabilities": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"user_capabilities": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDeploymentGroupTargetsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	deploymentGroupID := d.Get("deployment_group_id").(int)
+
+	args := taskagent.GetDeploymentTargetsArgs{
+		Project:           &projectID,
+		DeploymentGroupId: &deploymentGroupID,
+		Expand:            &taskagent.DeploymentTargetExpandsValues.Capabilities,
+	}
+	tags := tfhelper.ExpandStringSet(d.Get("tags").(*schema.Set))
+	sort.Strings(tags)
+	if len(tags) > 0 {
+		args.Tags = &tags
+	}
+	name := d.Get("name").(string)
+	if name != "" {
+		args.Name = &name
+		args.PartialNameMatch = converter.Bool(strings.Contains(name, "*"))
+	}
+	agentStatus := d.Get("agent_status").(string)
+	if agentStatus != "" {
+		args.AgentStatus = converter.ToPtr(taskagent.TaskAgentStatusFilter(agentStatus))
+	}
+
+	targets, err := getDeploymentTargets(clients, args)
+	if err != nil {
+		return fmt.Errorf(" finding targets of deployment group %d: %+v", deploymentGroupID, err)
+	}
+	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] targets of deployment group %d", len(targets), deploymentGroupID)
+
+	if err := d.Set("targets", flattenDeploymentTargets(targets)); err != nil {
+		return fmt.Errorf(" setting targets: %+v", err)
+	}
+	d.SetId(fmt.Sprintf("deploymentgrouptargets-%s-%d-%s-%s-%s", projectID, deploymentGroupID, strings.Join(tags, ","), name, agentStatus))
+	return nil
+}
+
+// getDeploymentTargets lists the targets of a deployment group, following the continuation tokens
+func getDeploymentTargets(clients *client.AggregatedClient, args taskagent.GetDeploymentTargetsArgs) ([]taskagent.DeploymentMachine, error) {
+	var targets []taskagent.DeploymentMachine
+	for {
+		page, err := clients.TaskAgentClient.GetDeploymentTargets(clients.Ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			return targets, nil
+		}
+		targets = append(targets, page.Value...)
+		if page.ContinuationToken == "" || len(page.Value) == 0 {
+			return targets, nil
+		}
+		args.ContinuationToken = converter.String(page.ContinuationToken)
+	}
+}
+
+func flattenDeploymentTargets(targets []taskagent.DeploymentMachine) []interface{} {
+	results := make([]interface{}, 0, len(targets))
+	for _, target := range targets {
+		result := map[string]interface{}{
+			"id":   converter.ToInt(target.Id, 0),
+			"tags": []string{},
+		}
+		if target.Tags != nil {
+			result["tags"] = *target.Tags
+		}
+		if agent := target.Agent; agent != nil {
+			result["name"] = converter.ToString(agent.Name, "")
+			result["agent_id"] = converter.ToInt(agent.Id, 0)
+			result["agent_version"] = converter.ToString(agent.Version, "")
+			result["enabled"] = converter.ToBool(agent.Enabled, false)
+			if agent.Status != nil {
+				result["agent_status"] = string(*agent.Status)
+			}
+			if agent.SystemCapabilities != nil {
+				result["system_capabilities"] = *agent.SystemCapabilities
+			}
+			if agent.UserCapabilities != nil {
+				result["user_capabilities"] = *agent.UserCapabilities
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
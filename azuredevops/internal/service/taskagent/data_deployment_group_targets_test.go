@@ -0,0 +1,150 @@
+//go:build (all || data_sources || data_deployment_group_targets) && (!exclude_data_sources || !exclude_data_deployment_group_targets)
+// +build all data_sources data_deployment_group_targets
+// +build !exclude_data_sources !exclude_data_deployment_group_targets
+
+package taskagent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceDeploymentGroupTargets_Read_ReadsAllPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	projectID := uuid.New().String()
+	deploymentGroupID := 12
+	tags := []string{"db", "web"}
+	args := taskagent.GetDeploymentTargetsArgs{
+		Project:           &projectID,
+		DeploymentGroupId: &deploymentGroupID,
+		Expand:            &taskagent.DeploymentTargetExpandsValues.Capabilities,
+		Tags:              &tags,
+		AgentStatus:       &taskagent.TaskAgentStatusFilterValues.Online,
+	}
+	nextArgs := args
+	nextArgs.ContinuationToken = converter.String("next")
+
+	gomock.InOrder(
+		taskAgentClient.EXPECT().
+			GetDeploymentTargets(clients.Ctx, args).
+			Return(&taskagent.GetDeploymentTargetsResponseValue{
+				Value:             []taskagent.DeploymentMachine{{Id: converter.Int(1)}},
+				ContinuationToken: "next",
+			}, nil).
+			Times(1),
+		taskAgentClient.EXPECT().
+			GetDeploymentTargets(clients.Ctx, nextArgs).
+			Return(&taskagent.GetDeploymentTargetsResponseValue{
+				Value: []taskagent.DeploymentMachine{{Id: converter.Int(2)}},
+			}, nil).
+			Times(1),
+	)
+
+	d := schema.TestResourceDataRaw(t, DataDeploymentGroupTargets().Schema, map[string]interface{}{
+		"project_id":          projectID,
+		"deployment_group_id": deploymentGroupID,
+		"tags":                []interface{}{"web", "db"},
+		"agent_status":        "online",
+	})
+	require.Nil(t, dataSourceDeploymentGroupTargetsRead(d, clients))
+
+	targets := d.Get("targets").([]interface{})
+	require.Len(t, targets, 2)
+	require.Equal(t, 1, targets[0].(map[string]interface{})["id"])
+	require.Equal(t, 2, targets[1].(map[string]interface{})["id"])
+}
+
+func TestDataSourceDeploymentGroupTargets_Read_MatchesWildcardNamesPartially(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.EXPECT().
+		GetDeploymentTargets(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args taskagent.GetDeploymentTargetsArgs) (*taskagent.GetDeploymentTargetsResponseValue, error) {
+			require.Equal(t, "web-*", *args.Name)
+			require.True(t, *args.PartialNameMatch)
+			require.Nil(t, args.Tags)
+			require.Nil(t, args.AgentStatus)
+			return &taskagent.GetDeploymentTargetsResponseValue{}, nil
+		}).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, DataDeploymentGroupTargets().Schema, map[string]interface{}{
+		"project_id":          uuid.New().String(),
+		"deployment_group_id": 12,
+		"name":                "web-*",
+	})
+	require.Nil(t, dataSourceDeploymentGroupTargetsRead(d, clients))
+	require.Empty(t, d.Get("targets"))
+}
+
+func TestDataSourceDeploymentGroupTargets_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.EXPECT().
+		GetDeploymentTargets(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetDeploymentTargets() Failed")).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, DataDeploymentGroupTargets().Schema, map[string]interface{}{
+		"project_id":          uuid.New().String(),
+		"deployment_group_id": 12,
+	})
+	err := dataSourceDeploymentGroupTargetsRead(d, clients)
+	require.Contains(t, err.Error(), "GetDeploymentTargets() Failed")
+}
+
+func TestDataSourceDeploymentGroupTargets_Flatten_ReadsAgentDetails(t *testing.T) {
+	online := taskagent.TaskAgentStatusValues.Online
+	targets := flattenDeploymentTargets([]taskagent.DeploymentMachine{
+		{
+			Id:   converter.Int(3),
+			Tags: &[]string{"web"},
+			Agent: &taskagent.TaskAgent{
+				Id:                 converter.Int(30),
+				Name:               converter.String("web-01"),
+				Version:            converter.String("3.236.1"),
+				Enabled:            converter.Bool(true),
+				Status:             &online,
+				SystemCapabilities: &map[string]string{"Agent.OS": "Linux"},
+				UserCapabilities:   &map[string]string{"dotnet": "8"},
+			},
+		},
+		{Id: converter.Int(4)},
+	})
+
+	require.Equal(t, map[string]interface{}{
+		"id":                  3,
+		"name":                "web-01",
+		"tags":                []string{"web"},
+		"agent_id":            30,
+		"agent_status":        "online",
+		"agent_version":       "3.236.1",
+		"enabled":             true,
+		"system_capabilities": map[string]string{"Agent.OS": "Linux"},
+		"user_capabilities":   map[string]string{"dotnet": "8"},
+	}, targets[0])
+	require.Equal(t, map[string]interface{}{"id": 4, "tags": []string{}}, targets[1])
+}
@@ -0,0 +1,153 @@
+package taskagent
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+// environmentsPageSize is the number of environments requested per page
+const environmentsPageSize = 100
+
+// DataEnvironments schema and implementation for the environments data source
+func DataEnvironments() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEnvironmentsRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"environments": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"kubernetes_resource_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"virtual_machine_resource_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceEnvironmentsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	environments, err := getEnvironments(clients, projectID)
+	if err != nil {
+		return fmt.Errorf(" finding environments of project %s: %w", projectID, err)
+	}
+	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] environments of project %s", len(environments), projectID)
+
+	// the environments are listed without their resources, which are only returned for a single environment
+	results := make([]interface{}, 0, len(environments))
+	for _, environment := range environments {
+		details, err := clients.TaskAgentClient.GetEnvironmentById(clients.Ctx, taskagent.GetEnvironmentByIdArgs{
+			Project:       &projectID,
+			EnvironmentId: environment.Id,
+			Expands:       &taskagent.EnvironmentExpandsValues.ResourceReferences,
+		})
+		if err != nil {
+			return fmt.Errorf(" reading resources of environment %d: %w", *environment.Id, err)
+		}
+		results = append(results, flattenEnvironmentReference(&environment, details.Resources))
+	}
+
+	if err := d.Set("environments", results); err != nil {
+		return fmt.Errorf(" setting environments: %w", err)
+	}
+	d.SetId(fmt.Sprintf("environments-%s", projectID))
+	return nil
+}
+
+// getEnvironments lists the environments of a project in the order of their IDs
+func getEnvironments(clients *client.AggregatedClient, projectID string) ([]taskagent.EnvironmentInstance, error) {
+	args := taskagent.GetEnvironmentsArgs{
+		Project: &projectID,
+		Top:     converter.Int(environmentsPageSize),
+	}
+
+	var environments []taskagent.EnvironmentInstance
+	for {
+		page, err := clients.TaskAgentClient.GetEnvironments(clients.Ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			break
+		}
+		for _, environment := range page.Value {
+			if environment.Id != nil {
+				environments = append(environments, environment)
+			}
+		}
+		if page.ContinuationToken == "" {
+			break
+		}
+		args.ContinuationToken = converter.String(page.ContinuationToken)
+	}
+
+	sort.Slice(environments, func(i, j int) bool {
+		return *environments[i].Id < *environments[j].Id
+	})
+	return environments, nil
+}
+
+func flattenEnvironmentReference(environment *taskagent.EnvironmentInstance, resources *[]taskagent.EnvironmentResourceReference) map[string]interface{} {
+	resourceCount, kubernetesCount, virtualMachineCount := 0, 0, 0
+	if resources != nil {
+		for _, resource := range *resources {
+			resourceCount++
+			if resource.Type == nil {
+				continue
+			}
+			switch *resource.Type {
+			case taskagent.EnvironmentResourceTypeValues.Kubernetes:
+				kubernetesCount++
+			case taskagent.EnvironmentResourceTypeValues.VirtualMachine:
+				virtualMachineCount++
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"id":                             *environment.Id,
+		"name":                           converter.ToString(environment.Name, ""),
+		"description":                    converter.ToString(environment.Description, ""),
+		"resource_count":                 resourceCount,
+		"kubernetes_resource_count":      kubernetesCount,
+		"virtual_machine_resource_count": virtualMachineCount,
+	}
+}
@@ -0,0 +1,114 @@
+//go:build (all || data_sources || data_environments) && (!exclude_data_sources || !exclude_data_environments)
+// +build all data_sources data_environments
+// +build !exclude_data_sources !exclude_data_environments
+
+package taskagent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceEnvironments_Read_FlattensEnvironmentsOfAllPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	projectID := uuid.New().String()
+	gomock.InOrder(
+		taskAgentClient.
+			EXPECT().
+			GetEnvironments(clients.Ctx, taskagent.GetEnvironmentsArgs{
+				Project: &projectID,
+				Top:     converter.Int(environmentsPageSize),
+			}).
+			Return(&taskagent.GetEnvironmentsResponseValue{
+				Value:             []taskagent.EnvironmentInstance{{Id: converter.Int(2), Name: converter.String("production")}},
+				ContinuationToken: "next",
+			}, nil),
+		taskAgentClient.
+			EXPECT().
+			GetEnvironments(clients.Ctx, taskagent.GetEnvironmentsArgs{
+				Project:           &projectID,
+				Top:               converter.Int(environmentsPageSize),
+				ContinuationToken: converter.String("next"),
+			}).
+			Return(&taskagent.GetEnvironmentsResponseValue{
+				Value: []taskagent.EnvironmentInstance{{Id: converter.Int(1), Name: converter.String("staging"), Description: converter.String("description")}},
+			}, nil),
+	)
+	taskAgentClient.
+		EXPECT().
+		GetEnvironmentById(clients.Ctx, taskagent.GetEnvironmentByIdArgs{
+			Project:       &projectID,
+			EnvironmentId: converter.Int(1),
+			Expands:       &taskagent.EnvironmentExpandsValues.ResourceReferences,
+		}).
+		Return(&taskagent.EnvironmentInstance{Id: converter.Int(1)}, nil).
+		Times(1)
+	taskAgentClient.
+		EXPECT().
+		GetEnvironmentById(clients.Ctx, taskagent.GetEnvironmentByIdArgs{
+			Project:       &projectID,
+			EnvironmentId: converter.Int(2),
+			Expands:       &taskagent.EnvironmentExpandsValues.ResourceReferences,
+		}).
+		Return(&taskagent.EnvironmentInstance{Id: converter.Int(2), Resources: &[]taskagent.EnvironmentResourceReference{
+			{Id: converter.Int(10), Type: &taskagent.EnvironmentResourceTypeValues.Kubernetes},
+			{Id: converter.Int(11), Type: &taskagent.EnvironmentResourceTypeValues.Kubernetes},
+			{Id: converter.Int(12), Type: &taskagent.EnvironmentResourceTypeValues.VirtualMachine},
+		}}, nil).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, DataEnvironments().Schema, map[string]interface{}{"project_id": projectID})
+	require.Nil(t, dataSourceEnvironmentsRead(d, clients))
+
+	environments := d.Get("environments").([]interface{})
+	require.Len(t, environments, 2)
+	require.Equal(t, map[string]interface{}{
+		"id":                             1,
+		"name":                           "staging",
+		"description":                    "description",
+		"resource_count":                 0,
+		"kubernetes_resource_count":      0,
+		"virtual_machine_resource_count": 0,
+	}, environments[0])
+	require.Equal(t, map[string]interface{}{
+		"id":                             2,
+		"name":                           "production",
+		"description":                    "",
+		"resource_count":                 3,
+		"kubernetes_resource_count":      2,
+		"virtual_machine_resource_count": 1,
+	}, environments[1])
+}
+
+func TestDataSourceEnvironments_Read_ReturnsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetEnvironments(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetEnvironments() Failed")).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, DataEnvironments().Schema, map[string]interface{}{"project_id": uuid.New().String()})
+	err := dataSourceEnvironmentsRead(d, clients)
+	require.ErrorContains(t, err, "GetEnvironments() Failed")
+}
@@ -84,6 +84,13 @@ func DataVariableGroup() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						vgLinkedSecrets: {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
 					},
 				},
 			},
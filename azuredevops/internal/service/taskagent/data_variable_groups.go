@@ -0,0 +1,129 @@
+package taskagent
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+// variableGroupsPageSize is the number of variable groups requested per page
+const variableGroupsPageSize = 500
+
+// DataVariableGroups schema and implementation for the variable groups data source
+func DataVariableGroups() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceVariableGroupsRead,
+		Schema: map[string]*schema.Schema{
+			vgProjectID: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			vgName: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"variable_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						vgName: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						vgDescription: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key_vault_linked": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVariableGroupsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get(vgProjectID).(string)
+	name := d.Get(vgName).(string)
+
+	variableGroups, err := getVariableGroups(clients, projectID, name)
+	if err != nil {
+		return fmt.Errorf(" finding variable groups of project %s: %+v", projectID, err)
+	}
+	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] variable groups of project %s", len(variableGroups), projectID)
+
+	if err := d.Set("variable_groups", flattenVariableGroupReferences(variableGroups)); err != nil {
+		return fmt.Errorf(" setting variable_groups: %+v", err)
+	}
+	d.SetId(fmt.Sprintf("variablegroups-%s-%s", projectID, name))
+	return nil
+}
+
+// getVariableGroups lists the variable groups of a project, optionally filtered by a name which may contain
+// wildcards, in the order of their IDs
+func getVariableGroups(clients *client.AggregatedClient, projectID string, name string) ([]taskagent.VariableGroup, error) {
+	args := taskagent.GetVariableGroupsArgs{
+		Project:    &projectID,
+		Top:        converter.Int(variableGroupsPageSize),
+		QueryOrder: &taskagent.VariableGroupQueryOrderValues.IdAscending,
+	}
+	if name != "" {
+		args.GroupName = &name
+	}
+
+	var variableGroups []taskagent.VariableGroup
+	seen := map[int]bool{}
+	for {
+		page, err := clients.TaskAgentClient.GetVariableGroups(clients.Ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		if page == nil {
+			return variableGroups, nil
+		}
+
+		// the continuation token is the ID of the last group of the page, skip it should it be returned again
+		added := 0
+		for _, variableGroup := range *page {
+			if variableGroup.Id == nil || seen[*variableGroup.Id] {
+				continue
+			}
+			seen[*variableGroup.Id] = true
+			variableGroups = append(variableGroups, variableGroup)
+			added++
+		}
+		if added == 0 || len(*page) < variableGroupsPageSize {
+			return variableGroups, nil
+		}
+		args.ContinuationToken = (*page)[len(*page)-1].Id
+	}
+}
+
+func flattenVariableGroupReferences(variableGroups []taskagent.VariableGroup) []interface{} {
+	results := make([]interface{}, 0, len(variableGroups))
+	for _, variableGroup := range variableGroups {
+		results = append(results, map[string]interface{}{
+			"id":               *variableGroup.Id,
+			vgName:             converter.ToString(variableGroup.Name, ""),
+			vgDescription:      converter.ToString(variableGroup.Description, ""),
+			"key_vault_linked": isKeyVaultVariableGroupType(variableGroup.Type),
+		})
+	}
+	return results
+}
@@ -0,0 +1,105 @@
+//go:build (all || data_sources || data_variable_groups) && (!exclude_data_sources || !exclude_data_variable_groups)
+// +build all data_sources data_variable_groups
+// +build !exclude_data_sources !exclude_data_variable_groups
+
+package taskagent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceVariableGroups_Read_FlattensVariableGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	projectID := uuid.New().String()
+	taskAgentClient.
+		EXPECT().
+		GetVariableGroups(clients.Ctx, taskagent.GetVariableGroupsArgs{
+			Project:    &projectID,
+			Top:        converter.Int(variableGroupsPageSize),
+			QueryOrder: &taskagent.VariableGroupQueryOrderValues.IdAscending,
+		}).
+		Return(&[]taskagent.VariableGroup{
+			{Id: converter.Int(1), Name: converter.String("plain"), Description: converter.String("description"), Type: converter.String("Vsts")},
+			{Id: converter.Int(2), Name: converter.String("secrets"), Type: converter.String(azureKeyVaultType)},
+		}, nil).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, DataVariableGroups().Schema, map[string]interface{}{vgProjectID: projectID})
+	require.Nil(t, dataSourceVariableGroupsRead(d, clients))
+
+	variableGroups := d.Get("variable_groups").([]interface{})
+	require.Len(t, variableGroups, 2)
+	require.Equal(t, map[string]interface{}{"id": 1, "name": "plain", "description": "description", "key_vault_linked": false}, variableGroups[0])
+	require.Equal(t, map[string]interface{}{"id": 2, "name": "secrets", "description": "", "key_vault_linked": true}, variableGroups[1])
+}
+
+func TestDataSourceVariableGroups_Read_ReadsAllPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	firstPage := make([]taskagent.VariableGroup, variableGroupsPageSize)
+	for i := range firstPage {
+		firstPage[i] = taskagent.VariableGroup{Id: converter.Int(i + 1), Name: converter.String("group")}
+	}
+	gomock.InOrder(
+		taskAgentClient.
+			EXPECT().
+			GetVariableGroups(clients.Ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, args taskagent.GetVariableGroupsArgs) (*[]taskagent.VariableGroup, error) {
+				require.Nil(t, args.ContinuationToken)
+				require.Equal(t, "group*", *args.GroupName)
+				return &firstPage, nil
+			}),
+		taskAgentClient.
+			EXPECT().
+			GetVariableGroups(clients.Ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, args taskagent.GetVariableGroupsArgs) (*[]taskagent.VariableGroup, error) {
+				require.Equal(t, variableGroupsPageSize, *args.ContinuationToken)
+				return &[]taskagent.VariableGroup{{Id: converter.Int(variableGroupsPageSize + 1), Name: converter.String("group")}}, nil
+			}),
+	)
+
+	d := schema.TestResourceDataRaw(t, DataVariableGroups().Schema, map[string]interface{}{
+		vgProjectID: uuid.New().String(),
+		vgName:      "group*",
+	})
+	require.Nil(t, dataSourceVariableGroupsRead(d, clients))
+	require.Len(t, d.Get("variable_groups").([]interface{}), variableGroupsPageSize+1)
+}
+
+func TestDataSourceVariableGroups_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetVariableGroups(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetVariableGroups() Failed")).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, DataVariableGroups().Schema, map[string]interface{}{vgProjectID: uuid.New().String()})
+	err := dataSourceVariableGroupsRead(d, clients)
+	require.Contains(t, err.Error(), "GetVariableGroups() Failed")
+}
@@ -2,7 +2,10 @@ package taskagent
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -13,8 +16,22 @@ import (
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/suppress"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/taskagentextras"
 )
 
+// maintenanceScheduleDays are the days maintenance jobs can be scheduled on, the service returns "all" when
+// every day is selected
+var maintenanceScheduleDays = []string{
+	string(taskagent.TaskAgentPoolMaintenanceScheduleDaysValues.Monday),
+	string(taskagent.TaskAgentPoolMaintenanceScheduleDaysValues.Tuesday),
+	string(taskagent.TaskAgentPoolMaintenanceScheduleDaysValues.Wednesday),
+	string(taskagent.TaskAgentPoolMaintenanceScheduleDaysValues.Thursday),
+	string(taskagent.TaskAgentPoolMaintenanceScheduleDaysValues.Friday),
+	string(taskagent.TaskAgentPoolMaintenanceScheduleDaysValues.Saturday),
+	string(taskagent.TaskAgentPoolMaintenanceScheduleDaysValues.Sunday),
+}
+
 // ResourceAgentPool schema and implementation for agent pool resource
 func ResourceAgentPool() *schema.Resource {
 	return &schema.Resource{
@@ -23,7 +40,7 @@ func ResourceAgentPool() *schema.Resource {
 		Update: resourceAzureAgentPoolUpdate,
 		Delete: resourceAzureAgentPoolDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			State: importAzureAgentPool,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -58,6 +75,80 @@ func ResourceAgentPool() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"maintenance": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"job_timeout_in_minutes": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      60,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"max_concurrent_agents_percentage": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      25,
+							ValidateFunc: validation.IntBetween(1, 100),
+						},
+						"working_directory_expiration_in_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      30,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"history_records_to_keep": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"schedule": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"days_to_build": {
+										Type:     schema.TypeSet,
+										Required: true,
+										MinItems: 1,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validation.StringInSlice(maintenanceScheduleDays, false),
+										},
+									},
+									"start_hours": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      0,
+										ValidateFunc: validation.IntBetween(0, 23),
+									},
+									"start_minutes": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      0,
+										ValidateFunc: validation.IntBetween(0, 59),
+									},
+									"time_zone_id": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "UTC",
+										ValidateFunc: validation.StringIsNotWhiteSpace,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -101,6 +192,12 @@ func resourceAzureAgentPoolCreate(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 	d.SetId(strconv.Itoa(*agentPool.Id))
+
+	if len(d.Get("maintenance").([]interface{})) > 0 {
+		if err := updateAgentPoolMaintenance(d, clients, *agentPool.Id); err != nil {
+			return err
+		}
+	}
 	return resourceAzureAgentPoolRead(d, m)
 }
 
@@ -112,7 +209,11 @@ func resourceAzureAgentPoolRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf(" parse agent pool ID: %+v", err)
 	}
 
-	agentPool, err := clients.TaskAgentClient.GetAgentPool(clients.Ctx, taskagent.GetAgentPoolArgs{PoolId: &poolID})
+	var agentPool *taskagent.TaskAgentPool
+	err = clients.ReadResource(d, func() (err error) {
+		agentPool, err = clients.TaskAgentClient.GetAgentPool(clients.Ctx, taskagent.GetAgentPoolArgs{PoolId: &poolID})
+		return err
+	})
 	if err != nil {
 		if utils.ResponseWasNotFound(err) {
 			d.SetId("")
@@ -128,9 +229,37 @@ func resourceAzureAgentPoolRead(d *schema.ResourceData, m interface{}) error {
 	if agentPool.AutoUpdate != nil {
 		d.Set("auto_update", agentPool.AutoUpdate)
 	}
+
+	// maintenance definitions created outside of Terraform are only read when the maintenance is managed
+	if len(d.Get("maintenance").([]interface{})) > 0 {
+		definition, err := getAgentPoolMaintenanceDefinition(clients, poolID)
+		if err != nil {
+			return err
+		}
+		d.Set("maintenance", flattenAgentPoolMaintenance(definition))
+	}
 	return nil
 }
 
+// importAzureAgentPool takes over the maintenance definition of the agent pool, if there is one
+func importAzureAgentPool(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	clients := m.(*client.AggregatedClient)
+
+	poolID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf(" parse agent pool ID: %w", err)
+	}
+
+	definition, err := getAgentPoolMaintenanceDefinition(clients, poolID)
+	if err != nil {
+		return nil, err
+	}
+	if definition != nil {
+		d.Set("maintenance", flattenAgentPoolMaintenance(definition))
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceAzureAgentPoolUpdate(d *schema.ResourceData, m interface{}) error {
 	clients := m.(*client.AggregatedClient)
 
@@ -157,6 +286,11 @@ func resourceAzureAgentPoolUpdate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
+	if d.HasChange("maintenance") {
+		if err := updateAgentPoolMaintenance(d, clients, poolID); err != nil {
+			return err
+		}
+	}
 	return resourceAzureAgentPoolRead(d, m)
 }
 
@@ -228,3 +362,164 @@ func syncStatus(params taskagent.UpdateAgentPoolArgs, client *client.AggregatedC
 	}
 	return nil
 }
+
+// getAgentPoolMaintenanceDefinition returns the maintenance definition of an agent pool, or nil if maintenance
+// has not been configured or is not supported by the pool. The UI only manages a single definition per pool, so
+// the first one is returned.
+func getAgentPoolMaintenanceDefinition(clients *client.AggregatedClient, poolID int) (*taskagent.TaskAgentPoolMaintenanceDefinition, error) {
+	definitions, err := clients.TaskAgentClientExtras.GetAgentPoolMaintenanceDefinitions(clients.Ctx, taskagentextras.GetAgentPoolMaintenanceDefinitionsArgs{
+		PoolId: &poolID,
+	})
+	if utils.ResponseWasNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf(" looking up maintenance definitions of Agent Pool with ID %d. Error: %v", poolID, err)
+	}
+	if definitions == nil || len(*definitions) == 0 {
+		return nil, nil
+	}
+	return &(*definitions)[0], nil
+}
+
+// updateAgentPoolMaintenance creates, updates or deletes the maintenance definition of an agent pool to match
+// the configuration
+func updateAgentPoolMaintenance(d *schema.ResourceData, clients *client.AggregatedClient, poolID int) error {
+	existing, err := getAgentPoolMaintenanceDefinition(clients, poolID)
+	if err != nil {
+		return err
+	}
+
+	definition := expandAgentPoolMaintenance(d, poolID)
+	if definition == nil {
+		if existing == nil {
+			return nil
+		}
+		err := clients.TaskAgentClientExtras.DeleteAgentPoolMaintenanceDefinition(clients.Ctx, taskagentextras.DeleteAgentPoolMaintenanceDefinitionArgs{
+			PoolId:       &poolID,
+			DefinitionId: existing.Id,
+		})
+		if err != nil && !utils.ResponseWasNotFound(err) {
+			return fmt.Errorf(" deleting maintenance definition of agent pool in Azure DevOps: %+v", err)
+		}
+		return nil
+	}
+
+	if existing == nil {
+		_, err = clients.TaskAgentClientExtras.CreateAgentPoolMaintenanceDefinition(clients.Ctx, taskagentextras.CreateAgentPoolMaintenanceDefinitionArgs{
+			PoolId:     &poolID,
+			Definition: definition,
+		})
+		if err != nil {
+			return fmt.Errorf(" creating maintenance definition of agent pool in Azure DevOps: %+v", err)
+		}
+		return nil
+	}
+
+	definition.Id = existing.Id
+	if existing.ScheduleSetting != nil {
+		definition.ScheduleSetting.ScheduleJobId = existing.ScheduleSetting.ScheduleJobId
+	}
+	_, err = clients.TaskAgentClientExtras.UpdateAgentPoolMaintenanceDefinition(clients.Ctx, taskagentextras.UpdateAgentPoolMaintenanceDefinitionArgs{
+		PoolId:       &poolID,
+		DefinitionId: existing.Id,
+		Definition:   definition,
+	})
+	if err != nil {
+		return fmt.Errorf(" updating maintenance definition of agent pool in Azure DevOps: %+v", err)
+	}
+	return nil
+}
+
+func expandAgentPoolMaintenance(d *schema.ResourceData, poolID int) *taskagent.TaskAgentPoolMaintenanceDefinition {
+	maintenance := d.Get("maintenance").([]interface{})
+	if len(maintenance) == 0 || maintenance[0] == nil {
+		return nil
+	}
+	config := maintenance[0].(map[string]interface{})
+
+	// maintenance jobs are not scheduled without a schedule, they can still be queued manually
+	schedule := &taskagent.TaskAgentPoolMaintenanceSchedule{
+		DaysToBuild:  &taskagent.TaskAgentPoolMaintenanceScheduleDaysValues.None,
+		StartHours:   converter.Int(0),
+		StartMinutes: converter.Int(0),
+		TimeZoneId:   converter.String("UTC"),
+	}
+	if schedules := config["schedule"].([]interface{}); len(schedules) > 0 && schedules[0] != nil {
+		scheduleConfig := schedules[0].(map[string]interface{})
+		days := tfhelper.ExpandStringSet(scheduleConfig["days_to_build"].(*schema.Set))
+		sort.Slice(days, func(i, j int) bool {
+			return slices.Index(maintenanceScheduleDays, days[i]) < slices.Index(maintenanceScheduleDays, days[j])
+		})
+		schedule.DaysToBuild = converter.ToPtr(taskagent.TaskAgentPoolMaintenanceScheduleDays(strings.Join(days, ", ")))
+		schedule.StartHours = converter.Int(scheduleConfig["start_hours"].(int))
+		schedule.StartMinutes = converter.Int(scheduleConfig["start_minutes"].(int))
+		schedule.TimeZoneId = converter.String(scheduleConfig["time_zone_id"].(string))
+	}
+
+	return &taskagent.TaskAgentPoolMaintenanceDefinition{
+		Enabled:                       converter.Bool(config["enabled"].(bool)),
+		JobTimeoutInMinutes:           converter.Int(config["job_timeout_in_minutes"].(int)),
+		MaxConcurrentAgentsPercentage: converter.Int(config["max_concurrent_agents_percentage"].(int)),
+		Options: &taskagent.TaskAgentPoolMaintenanceOptions{
+			WorkingDirectoryExpirationInDays: converter.Int(config["working_directory_expiration_in_days"].(int)),
+		},
+		Pool: &taskagent.TaskAgentPoolReference{Id: &poolID},
+		RetentionPolicy: &taskagent.TaskAgentPoolMaintenanceRetentionPolicy{
+			NumberOfHistoryRecordsToKeep: converter.Int(config["history_records_to_keep"].(int)),
+		},
+		ScheduleSetting: schedule,
+	}
+}
+
+func flattenAgentPoolMaintenance(definition *taskagent.TaskAgentPoolMaintenanceDefinition) []interface{} {
+	if definition == nil {
+		return nil
+	}
+
+	maintenance := map[string]interface{}{
+		"enabled":                              converter.ToBool(definition.Enabled, false),
+		"job_timeout_in_minutes":               converter.ToInt(definition.JobTimeoutInMinutes, 0),
+		"max_concurrent_agents_percentage":     converter.ToInt(definition.MaxConcurrentAgentsPercentage, 0),
+		"working_directory_expiration_in_days": 0,
+		"history_records_to_keep":              0,
+		"schedule":                             []interface{}{},
+	}
+	if definition.Options != nil {
+		maintenance["working_directory_expiration_in_days"] = converter.ToInt(definition.Options.WorkingDirectoryExpirationInDays, 0)
+	}
+	if definition.RetentionPolicy != nil {
+		maintenance["history_records_to_keep"] = converter.ToInt(definition.RetentionPolicy.NumberOfHistoryRecordsToKeep, 0)
+	}
+	if days := flattenMaintenanceScheduleDays(definition.ScheduleSetting); len(days) > 0 {
+		maintenance["schedule"] = []interface{}{map[string]interface{}{
+			"days_to_build": days,
+			"start_hours":   converter.ToInt(definition.ScheduleSetting.StartHours, 0),
+			"start_minutes": converter.ToInt(definition.ScheduleSetting.StartMinutes, 0),
+			"time_zone_id":  converter.ToString(definition.ScheduleSetting.TimeZoneId, ""),
+		}}
+	}
+	return []interface{}{maintenance}
+}
+
+// flattenMaintenanceScheduleDays splits the days flags of a schedule, "none" is returned for schedules which
+// do not run and "all" for schedules which run every day
+func flattenMaintenanceScheduleDays(schedule *taskagent.TaskAgentPoolMaintenanceSchedule) []interface{} {
+	if schedule == nil || schedule.DaysToBuild == nil {
+		return nil
+	}
+	var days []interface{}
+	for _, day := range strings.Split(string(*schedule.DaysToBuild), ",") {
+		day = strings.ToLower(strings.TrimSpace(day))
+		switch day {
+		case "", string(taskagent.TaskAgentPoolMaintenanceScheduleDaysValues.None):
+		case string(taskagent.TaskAgentPoolMaintenanceScheduleDaysValues.All):
+			for _, scheduleDay := range maintenanceScheduleDays {
+				days = append(days, scheduleDay)
+			}
+		default:
+			days = append(days, day)
+		}
+	}
+	return days
+}
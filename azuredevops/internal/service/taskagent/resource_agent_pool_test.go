@@ -0,0 +1,208 @@
+//go:build all || resource_agent_pool
+// +build all resource_agent_pool
+
+package taskagent
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/taskagentextras"
+	"github.com/stretchr/testify/require"
+)
+
+var maintenancePoolID = 100
+var agentPoolMaintenanceID = 7
+
+func agentPoolMaintenanceConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"name": "foo-pool",
+		"maintenance": []interface{}{map[string]interface{}{
+			"enabled":                              true,
+			"job_timeout_in_minutes":               90,
+			"max_concurrent_agents_percentage":     50,
+			"working_directory_expiration_in_days": 14,
+			"history_records_to_keep":              5,
+			"schedule": []interface{}{map[string]interface{}{
+				"days_to_build": []interface{}{"sunday", "monday"},
+				"start_hours":   3,
+				"start_minutes": 30,
+				"time_zone_id":  "W. Europe Standard Time",
+			}},
+		}},
+	}
+}
+
+func TestAgentPool_ExpandMaintenance_SendsDaysInWeekOrder(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceAgentPool().Schema, agentPoolMaintenanceConfig())
+
+	definition := expandAgentPoolMaintenance(d, maintenancePoolID)
+
+	require.NotNil(t, definition)
+	require.True(t, *definition.Enabled)
+	require.Equal(t, 90, *definition.JobTimeoutInMinutes)
+	require.Equal(t, 50, *definition.MaxConcurrentAgentsPercentage)
+	require.Equal(t, 14, *definition.Options.WorkingDirectoryExpirationInDays)
+	require.Equal(t, 5, *definition.RetentionPolicy.NumberOfHistoryRecordsToKeep)
+	require.Equal(t, maintenancePoolID, *definition.Pool.Id)
+	require.Equal(t, taskagent.TaskAgentPoolMaintenanceScheduleDays("monday, sunday"), *definition.ScheduleSetting.DaysToBuild)
+	require.Equal(t, 3, *definition.ScheduleSetting.StartHours)
+	require.Equal(t, 30, *definition.ScheduleSetting.StartMinutes)
+	require.Equal(t, "W. Europe Standard Time", *definition.ScheduleSetting.TimeZoneId)
+}
+
+func TestAgentPool_ExpandMaintenance_WithoutScheduleDoesNotRun(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceAgentPool().Schema, map[string]interface{}{
+		"name":        "foo-pool",
+		"maintenance": []interface{}{map[string]interface{}{}},
+	})
+
+	definition := expandAgentPoolMaintenance(d, maintenancePoolID)
+
+	require.NotNil(t, definition)
+	require.Equal(t, 60, *definition.JobTimeoutInMinutes)
+	require.Equal(t, 25, *definition.MaxConcurrentAgentsPercentage)
+	require.Equal(t, taskagent.TaskAgentPoolMaintenanceScheduleDaysValues.None, *definition.ScheduleSetting.DaysToBuild)
+}
+
+func TestAgentPool_FlattenMaintenance_ExpandsAllDays(t *testing.T) {
+	maintenance := flattenAgentPoolMaintenance(&taskagent.TaskAgentPoolMaintenanceDefinition{
+		Enabled:             converter.Bool(true),
+		JobTimeoutInMinutes: converter.Int(60),
+		ScheduleSetting: &taskagent.TaskAgentPoolMaintenanceSchedule{
+			DaysToBuild: &taskagent.TaskAgentPoolMaintenanceScheduleDaysValues.All,
+			TimeZoneId:  converter.String("UTC"),
+		},
+	})
+
+	require.Len(t, maintenance, 1)
+	schedule := maintenance[0].(map[string]interface{})["schedule"].([]interface{})
+	require.Len(t, schedule, 1)
+	require.Len(t, schedule[0].(map[string]interface{})["days_to_build"], 7)
+}
+
+func TestAgentPool_FlattenMaintenance_OmitsScheduleWhichDoesNotRun(t *testing.T) {
+	maintenance := flattenAgentPoolMaintenance(&taskagent.TaskAgentPoolMaintenanceDefinition{
+		ScheduleSetting: &taskagent.TaskAgentPoolMaintenanceSchedule{
+			DaysToBuild: &taskagent.TaskAgentPoolMaintenanceScheduleDaysValues.None,
+		},
+	})
+
+	require.Empty(t, maintenance[0].(map[string]interface{})["schedule"])
+	require.Nil(t, flattenAgentPoolMaintenance(nil))
+}
+
+func TestAgentPool_UpdateMaintenance_UpdatesExistingDefinition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	extrasClient := azdosdkmocks.NewMockTaskagentextrasClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClientExtras: extrasClient, Ctx: context.Background()}
+	d := schema.TestResourceDataRaw(t, ResourceAgentPool().Schema, agentPoolMaintenanceConfig())
+	d.SetId(strconv.Itoa(maintenancePoolID))
+
+	extrasClient.EXPECT().
+		GetAgentPoolMaintenanceDefinitions(clients.Ctx, taskagentextras.GetAgentPoolMaintenanceDefinitionsArgs{PoolId: &maintenancePoolID}).
+		Return(&[]taskagent.TaskAgentPoolMaintenanceDefinition{{Id: &agentPoolMaintenanceID}}, nil).
+		Times(1)
+	extrasClient.EXPECT().
+		UpdateAgentPoolMaintenanceDefinition(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, args taskagentextras.UpdateAgentPoolMaintenanceDefinitionArgs) (*taskagent.TaskAgentPoolMaintenanceDefinition, error) {
+			require.Equal(t, agentPoolMaintenanceID, *args.DefinitionId)
+			require.Equal(t, agentPoolMaintenanceID, *args.Definition.Id)
+			return args.Definition, nil
+		}).
+		Times(1)
+
+	require.Nil(t, updateAgentPoolMaintenance(d, clients, maintenancePoolID))
+}
+
+func TestAgentPool_UpdateMaintenance_DeletesRemovedDefinition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	extrasClient := azdosdkmocks.NewMockTaskagentextrasClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClientExtras: extrasClient, Ctx: context.Background()}
+	d := schema.TestResourceDataRaw(t, ResourceAgentPool().Schema, map[string]interface{}{"name": "foo-pool"})
+	d.SetId(strconv.Itoa(maintenancePoolID))
+
+	extrasClient.EXPECT().
+		GetAgentPoolMaintenanceDefinitions(clients.Ctx, gomock.Any()).
+		Return(&[]taskagent.TaskAgentPoolMaintenanceDefinition{{Id: &agentPoolMaintenanceID}}, nil).
+		Times(1)
+	extrasClient.EXPECT().
+		DeleteAgentPoolMaintenanceDefinition(clients.Ctx, taskagentextras.DeleteAgentPoolMaintenanceDefinitionArgs{
+			PoolId:       &maintenancePoolID,
+			DefinitionId: &agentPoolMaintenanceID,
+		}).
+		Return(nil).
+		Times(1)
+
+	require.Nil(t, updateAgentPoolMaintenance(d, clients, maintenancePoolID))
+}
+
+func TestAgentPool_UpdateMaintenance_DoesNotSwallowLookupErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	extrasClient := azdosdkmocks.NewMockTaskagentextrasClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClientExtras: extrasClient, Ctx: context.Background()}
+	d := schema.TestResourceDataRaw(t, ResourceAgentPool().Schema, agentPoolMaintenanceConfig())
+
+	extrasClient.EXPECT().
+		GetAgentPoolMaintenanceDefinitions(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetAgentPoolMaintenanceDefinitions() Failed")).
+		Times(1)
+
+	err := updateAgentPoolMaintenance(d, clients, maintenancePoolID)
+	require.Contains(t, err.Error(), "GetAgentPoolMaintenanceDefinitions() Failed")
+}
+
+func TestAgentPool_GetMaintenance_TreatsNotFoundAsUnconfigured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	extrasClient := azdosdkmocks.NewMockTaskagentextrasClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClientExtras: extrasClient, Ctx: context.Background()}
+
+	extrasClient.EXPECT().
+		GetAgentPoolMaintenanceDefinitions(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(http.StatusNotFound)}).
+		Times(1)
+
+	definition, err := getAgentPoolMaintenanceDefinition(clients, maintenancePoolID)
+	require.Nil(t, err)
+	require.Nil(t, definition)
+}
+
+func TestAgentPool_Read_DoesNotReadUnmanagedMaintenance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	extrasClient := azdosdkmocks.NewMockTaskagentextrasClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, TaskAgentClientExtras: extrasClient, Ctx: context.Background()}
+	d := schema.TestResourceDataRaw(t, ResourceAgentPool().Schema, map[string]interface{}{"name": "foo-pool"})
+	d.SetId(strconv.Itoa(maintenancePoolID))
+
+	taskAgentClient.EXPECT().
+		GetAgentPool(clients.Ctx, taskagent.GetAgentPoolArgs{PoolId: &maintenancePoolID}).
+		Return(&taskagent.TaskAgentPool{Id: &maintenancePoolID, Name: converter.String("foo-pool")}, nil).
+		Times(1)
+	extrasClient.EXPECT().
+		GetAgentPoolMaintenanceDefinitions(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	require.Nil(t, resourceAzureAgentPoolRead(d, clients))
+	require.Empty(t, d.Get("maintenance"))
+}
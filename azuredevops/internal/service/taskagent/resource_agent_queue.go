@@ -117,9 +117,13 @@ func resourceAgentQueueRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf(invalidQueueIDErrorMessageFormat, err)
 	}
 
-	queue, err := clients.TaskAgentClient.GetAgentQueue(clients.Ctx, taskagent.GetAgentQueueArgs{
-		QueueId: queueID,
-		Project: converter.String(d.Get(projectID).(string)),
+	var queue *taskagent.TaskAgentQueue
+	err = clients.ReadResource(d, func() (err error) {
+		queue, err = clients.TaskAgentClient.GetAgentQueue(clients.Ctx, taskagent.GetAgentQueueArgs{
+			QueueId: queueID,
+			Project: converter.String(d.Get(projectID).(string)),
+		})
+		return err
 	})
 
 	if utils.ResponseWasNotFound(err) {
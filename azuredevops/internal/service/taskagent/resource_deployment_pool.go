@@ -0,0 +1,336 @@
+package taskagent
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/datahelper"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// ResourceDeploymentPool schema and implementation for the deployment pool resource, which creates an organization
+// deployment pool and provisions it into projects as deployment groups
+func ResourceDeploymentPool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDeploymentPoolCreate,
+		Read:   resourceDeploymentPoolRead,
+		Update: resourceDeploymentPoolUpdate,
+		Delete: resourceDeploymentPoolDelete,
+		Importer: &schema.ResourceImporter{
+			State: importDeploymentPool,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"project_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsUUID,
+				},
+			},
+			"pool_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"deployment_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"organization_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"registration_command": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceDeploymentPoolCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	pool, err := clients.TaskAgentClient.AddAgentPool(clients.Ctx, taskagent.AddAgentPoolArgs{
+		Pool: &taskagent.TaskAgentPool{
+			Name:     converter.String(d.Get("name").(string)),
+			PoolType: &taskagent.TaskAgentPoolTypeValues.Deployment,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf(" creating deployment pool in Azure DevOps: %+v", err)
+	}
+	d.SetId(strconv.Itoa(*pool.Id))
+
+	for _, projectID := range tfhelper.ExpandStringSet(d.Get("project_ids").(*schema.Set)) {
+		if err := addPoolDeploymentGroup(clients, projectID, *pool.Id, *pool.Name); err != nil {
+			return err
+		}
+	}
+	return resourceDeploymentPoolRead(d, m)
+}
+
+func resourceDeploymentPoolRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	poolID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf(" parse deployment pool ID: %+v", err)
+	}
+
+	var pool *taskagent.TaskAgentPool
+	err = clients.ReadResource(d, func() (err error) {
+		pool, err = clients.TaskAgentClient.GetAgentPool(clients.Ctx, taskagent.GetAgentPoolArgs{PoolId: &poolID})
+		return err
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(" looking up deployment pool with ID %d. Error: %v", poolID, err)
+	}
+
+	projectIDs := tfhelper.ExpandStringSet(d.Get("project_ids").(*schema.Set))
+	sort.Strings(projectIDs)
+	var foundProjectIDs []string
+	var deploymentGroups []interface{}
+	for _, projectID := range projectIDs {
+		group, err := findPoolDeploymentGroup(clients, projectID, poolID)
+		if err != nil {
+			return err
+		}
+		if group == nil {
+			continue
+		}
+		foundProjectIDs = append(foundProjectIDs, projectID)
+		deploymentGroups = append(deploymentGroups, map[string]interface{}{
+			"project_id": projectID,
+			"id":         converter.ToInt(group.Id, 0),
+			"name":       converter.ToString(group.Name, ""),
+		})
+	}
+
+	d.Set("name", pool.Name)
+	d.Set("pool_id", poolID)
+	d.Set("project_ids", foundProjectIDs)
+	d.Set("deployment_groups", deploymentGroups)
+	d.Set("organization_url", clients.OrganizationURL)
+	d.Set("registration_command", fmt.Sprintf("./config.sh --deploymentpool --deploymentpoolname %s --url %s",
+		utils.ShellQuote(converter.ToString(pool.Name, "")), utils.ShellQuote(clients.OrganizationURL)))
+	return nil
+}
+
+func resourceDeploymentPoolUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	poolID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf(" parse deployment pool ID: %+v", err)
+	}
+
+	if d.HasChange("name") {
+		_, err := clients.TaskAgentClient.UpdateAgentPool(clients.Ctx, taskagent.UpdateAgentPoolArgs{
+			PoolId: &poolID,
+			Pool: &taskagent.TaskAgentPool{
+				Name:     converter.String(d.Get("name").(string)),
+				PoolType: &taskagent.TaskAgentPoolTypeValues.Deployment,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf(" updating deployment pool in Azure DevOps: %+v", err)
+		}
+	}
+
+	if d.HasChange("project_ids") {
+		oldProjects, newProjects := d.GetChange("project_ids")
+		for _, projectID := range tfhelper.ExpandStringSet(oldProjects.(*schema.Set).Difference(newProjects.(*schema.Set))) {
+			if err := deletePoolDeploymentGroup(clients, projectID, poolID); err != nil {
+				return err
+			}
+		}
+		for _, projectID := range tfhelper.ExpandStringSet(newProjects.(*schema.Set).Difference(oldProjects.(*schema.Set))) {
+			if err := addPoolDeploymentGroup(clients, projectID, poolID, d.Get("name").(string)); err != nil {
+				return err
+			}
+		}
+	}
+	return resourceDeploymentPoolRead(d, m)
+}
+
+func resourceDeploymentPoolDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	poolID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf(" parse deployment pool ID: %+v", err)
+	}
+
+	// the pool cannot be deleted while deployment groups are using it
+	for _, projectID := range tfhelper.ExpandStringSet(d.Get("project_ids").(*schema.Set)) {
+		if err := deletePoolDeploymentGroup(clients, projectID, poolID); err != nil {
+			return err
+		}
+	}
+
+	err = clients.TaskAgentClient.DeleteAgentPool(clients.Ctx, taskagent.DeleteAgentPoolArgs{PoolId: &poolID})
+	if err != nil && !utils.ResponseWasNotFound(err) {
+		return fmt.Errorf(" deleting deployment pool in Azure DevOps: %+v", err)
+	}
+	return nil
+}
+
+// importDeploymentPool imports the pool with the projects it has been provisioned into, which are looked up in the
+// deployment groups of every project of the organization
+func importDeploymentPool(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	clients := m.(*client.AggregatedClient)
+
+	poolID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf(" the import ID must be the ID of the deployment pool, got %q", d.Id())
+	}
+
+	var projectIDs []string
+	args := core.GetProjectsArgs{}
+	for {
+		page, err := clients.CoreClient.GetProjects(clients.Ctx, args)
+		if err != nil {
+			return nil, fmt.Errorf(" listing the projects of the organization: %+v", err)
+		}
+		for _, project := range page.Value {
+			if project.Id != nil {
+				projectIDs = append(projectIDs, project.Id.String())
+			}
+		}
+		if page.ContinuationToken == "" {
+			break
+		}
+		token, err := strconv.Atoi(page.ContinuationToken)
+		if err != nil {
+			return nil, err
+		}
+		args.ContinuationToken = &token
+	}
+
+	provisioned := make([]bool, len(projectIDs))
+	err = datahelper.ForEachConcurrently(len(projectIDs), datahelper.DefaultConcurrentWorkers, func(i int) error {
+		group, err := findPoolDeploymentGroup(clients, projectIDs[i], poolID)
+		provisioned[i] = group != nil
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var poolProjectIDs []string
+	for i, projectID := range projectIDs {
+		if provisioned[i] {
+			poolProjectIDs = append(poolProjectIDs, projectID)
+		}
+	}
+	d.Set("project_ids", poolProjectIDs)
+	return []*schema.ResourceData{d}, nil
+}
+
+// addPoolDeploymentGroup provisions the pool into a project with a deployment group of the same name, like the
+// "Auto-provision this deployment pool in the selected projects" option of the UI does. Projects which already have
+// a deployment group of the pool are left as they are.
+func addPoolDeploymentGroup(clients *client.AggregatedClient, projectID string, poolID int, name string) error {
+	existing, err := findPoolDeploymentGroup(clients, projectID, poolID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+
+	_, err = clients.TaskAgentClient.AddDeploymentGroup(clients.Ctx, taskagent.AddDeploymentGroupArgs{
+		Project: converter.String(projectID),
+		DeploymentGroup: &taskagent.DeploymentGroupCreateParameter{
+			Name:   converter.String(name),
+			PoolId: converter.Int(poolID),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf(" provisioning deployment pool %d in project %s: %+v", poolID, projectID, err)
+	}
+	return nil
+}
+
+func deletePoolDeploymentGroup(clients *client.AggregatedClient, projectID string, poolID int) error {
+	group, err := findPoolDeploymentGroup(clients, projectID, poolID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return nil
+	}
+
+	err = clients.TaskAgentClient.DeleteDeploymentGroup(clients.Ctx, taskagent.DeleteDeploymentGroupArgs{
+		Project:           converter.String(projectID),
+		DeploymentGroupId: group.Id,
+	})
+	if err != nil && !utils.ResponseWasNotFound(err) {
+		return fmt.Errorf(" deleting deployment group %d of deployment pool %d in project %s: %+v", *group.Id, poolID, projectID, err)
+	}
+	return nil
+}
+
+// findPoolDeploymentGroup returns the deployment group of a project which uses the pool, or nil if the pool has not
+// been provisioned into the project
+func findPoolDeploymentGroup(clients *client.AggregatedClient, projectID string, poolID int) (*taskagent.DeploymentGroup, error) {
+	args := taskagent.GetDeploymentGroupsArgs{
+		Project: converter.String(projectID),
+	}
+	for {
+		page, err := clients.TaskAgentClient.GetDeploymentGroups(clients.Ctx, args)
+		if err != nil {
+			if utils.ResponseWasNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf(" listing deployment groups of project %s: %+v", projectID, err)
+		}
+		if page == nil {
+			return nil, nil
+		}
+		for _, group := range page.Value {
+			if group.Pool != nil && group.Pool.Id != nil && *group.Pool.Id == poolID {
+				return &group, nil
+			}
+		}
+		if strings.TrimSpace(page.ContinuationToken) == "" || len(page.Value) == 0 {
+			return nil, nil
+		}
+		args.ContinuationToken = converter.String(page.ContinuationToken)
+	}
+}
@@ -0,0 +1,242 @@
+//go:build all || resource_deployment_pool
+// +build all resource_deployment_pool
+
+package taskagent
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var deploymentPoolID = 42
+var deploymentPoolProjectID = "9083e944-8e9e-405e-960a-c80180aa71e6"
+
+func TestDeploymentPool_Create_ProvisionsDeploymentGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{
+		TaskAgentClient: taskAgentClient,
+		OrganizationURL: "https://dev.azure.com/myorg",
+		Ctx:             context.Background(),
+	}
+
+	pool := taskagent.TaskAgentPool{
+		Id:       converter.Int(deploymentPoolID),
+		Name:     converter.String("shared-vms"),
+		PoolType: &taskagent.TaskAgentPoolTypeValues.Deployment,
+	}
+	taskAgentClient.EXPECT().
+		AddAgentPool(clients.Ctx, taskagent.AddAgentPoolArgs{
+			Pool: &taskagent.TaskAgentPool{
+				Name:     converter.String("shared-vms"),
+				PoolType: &taskagent.TaskAgentPoolTypeValues.Deployment,
+			},
+		}).
+		Return(&pool, nil).
+		Times(1)
+	taskAgentClient.EXPECT().
+		GetDeploymentGroups(clients.Ctx, taskagent.GetDeploymentGroupsArgs{Project: converter.String(deploymentPoolProjectID)}).
+		Return(&taskagent.GetDeploymentGroupsResponseValue{}, nil).
+		Times(1)
+	taskAgentClient.EXPECT().
+		AddDeploymentGroup(clients.Ctx, taskagent.AddDeploymentGroupArgs{
+			Project: converter.String(deploymentPoolProjectID),
+			DeploymentGroup: &taskagent.DeploymentGroupCreateParameter{
+				Name:   converter.String("shared-vms"),
+				PoolId: converter.Int(deploymentPoolID),
+			},
+		}).
+		Return(&taskagent.DeploymentGroup{Id: converter.Int(7)}, nil).
+		Times(1)
+	taskAgentClient.EXPECT().
+		GetAgentPool(clients.Ctx, taskagent.GetAgentPoolArgs{PoolId: converter.Int(deploymentPoolID)}).
+		Return(&pool, nil).
+		Times(1)
+	taskAgentClient.EXPECT().
+		GetDeploymentGroups(clients.Ctx, taskagent.GetDeploymentGroupsArgs{Project: converter.String(deploymentPoolProjectID)}).
+		Return(&taskagent.GetDeploymentGroupsResponseValue{Value: []taskagent.DeploymentGroup{
+			{Id: converter.Int(3), Name: converter.String("other"), Pool: &taskagent.TaskAgentPoolReference{Id: converter.Int(1)}},
+			{Id: converter.Int(7), Name: converter.String("shared-vms"), Pool: &taskagent.TaskAgentPoolReference{Id: converter.Int(deploymentPoolID)}},
+		}}, nil).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, ResourceDeploymentPool().Schema, map[string]interface{}{
+		"name":        "shared-vms",
+		"project_ids": []interface{}{deploymentPoolProjectID},
+	})
+	require.NoError(t, resourceDeploymentPoolCreate(d, clients))
+
+	require.Equal(t, strconv.Itoa(deploymentPoolID), d.Id())
+	require.Equal(t, deploymentPoolID, d.Get("pool_id"))
+	require.Equal(t, 7, d.Get("deployment_groups.0.id"))
+	require.Equal(t, deploymentPoolProjectID, d.Get("deployment_groups.0.project_id"))
+	require.Equal(t, `./config.sh --deploymentpool --deploymentpoolname 'shared-vms' --url 'https://dev.azure.com/myorg'`, d.Get("registration_command"))
+}
+
+func TestDeploymentPool_Read_RemovesProjectsWithoutDeploymentGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.EXPECT().
+		GetAgentPool(clients.Ctx, gomock.Any()).
+		Return(&taskagent.TaskAgentPool{Id: converter.Int(deploymentPoolID), Name: converter.String("shared-vms")}, nil).
+		Times(1)
+	taskAgentClient.EXPECT().
+		GetDeploymentGroups(clients.Ctx, gomock.Any()).
+		Return(&taskagent.GetDeploymentGroupsResponseValue{}, nil).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, ResourceDeploymentPool().Schema, map[string]interface{}{
+		"name":        "shared-vms",
+		"project_ids": []interface{}{deploymentPoolProjectID},
+	})
+	d.SetId(strconv.Itoa(deploymentPoolID))
+	require.NoError(t, resourceDeploymentPoolRead(d, clients))
+
+	require.Equal(t, 0, d.Get("project_ids").(*schema.Set).Len())
+	require.Empty(t, d.Get("deployment_groups"))
+}
+
+func TestDeploymentPool_Delete_DeletesDeploymentGroupsFirst(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	gomock.InOrder(
+		taskAgentClient.EXPECT().
+			GetDeploymentGroups(clients.Ctx, gomock.Any()).
+			Return(&taskagent.GetDeploymentGroupsResponseValue{Value: []taskagent.DeploymentGroup{
+				{Id: converter.Int(7), Pool: &taskagent.TaskAgentPoolReference{Id: converter.Int(deploymentPoolID)}},
+			}}, nil).
+			Times(1),
+		taskAgentClient.EXPECT().
+			DeleteDeploymentGroup(clients.Ctx, taskagent.DeleteDeploymentGroupArgs{
+				Project:           converter.String(deploymentPoolProjectID),
+				DeploymentGroupId: converter.Int(7),
+			}).
+			Return(nil).
+			Times(1),
+		taskAgentClient.EXPECT().
+			DeleteAgentPool(clients.Ctx, taskagent.DeleteAgentPoolArgs{PoolId: converter.Int(deploymentPoolID)}).
+			Return(nil).
+			Times(1),
+	)
+
+	d := schema.TestResourceDataRaw(t, ResourceDeploymentPool().Schema, map[string]interface{}{
+		"name":        "shared-vms",
+		"project_ids": []interface{}{deploymentPoolProjectID},
+	})
+	d.SetId(strconv.Itoa(deploymentPoolID))
+	require.NoError(t, resourceDeploymentPoolDelete(d, clients))
+}
+
+func TestDeploymentPool_FindDeploymentGroup_FollowsContinuationToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	args := taskagent.GetDeploymentGroupsArgs{Project: converter.String(deploymentPoolProjectID)}
+	nextArgs := args
+	nextArgs.ContinuationToken = converter.String("next")
+	gomock.InOrder(
+		taskAgentClient.EXPECT().
+			GetDeploymentGroups(clients.Ctx, args).
+			Return(&taskagent.GetDeploymentGroupsResponseValue{
+				Value:             []taskagent.DeploymentGroup{{Id: converter.Int(3), Pool: &taskagent.TaskAgentPoolReference{Id: converter.Int(1)}}},
+				ContinuationToken: "next",
+			}, nil).
+			Times(1),
+		taskAgentClient.EXPECT().
+			GetDeploymentGroups(clients.Ctx, nextArgs).
+			Return(&taskagent.GetDeploymentGroupsResponseValue{
+				Value: []taskagent.DeploymentGroup{{Id: converter.Int(7), Pool: &taskagent.TaskAgentPoolReference{Id: converter.Int(deploymentPoolID)}}},
+			}, nil).
+			Times(1),
+	)
+
+	group, err := findPoolDeploymentGroup(clients, deploymentPoolProjectID, deploymentPoolID)
+	require.NoError(t, err)
+	require.Equal(t, 7, *group.Id)
+}
+
+func TestDeploymentPool_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.EXPECT().
+		AddAgentPool(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("AddAgentPool() Failed")).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, ResourceDeploymentPool().Schema, map[string]interface{}{"name": "shared-vms"})
+	err := resourceDeploymentPoolCreate(d, clients)
+	require.ErrorContains(t, err, "AddAgentPool() Failed")
+	require.Empty(t, d.Id())
+}
+
+func TestDeploymentPool_Import_SetsProvisionedProjects(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{CoreClient: coreClient, TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	otherProjectID := uuid.New()
+	coreClient.EXPECT().
+		GetProjects(clients.Ctx, core.GetProjectsArgs{}).
+		Return(&core.GetProjectsResponseValue{
+			Value:             []core.TeamProjectReference{{Id: converter.UUID(deploymentPoolProjectID)}},
+			ContinuationToken: "1",
+		}, nil).
+		Times(1)
+	coreClient.EXPECT().
+		GetProjects(clients.Ctx, core.GetProjectsArgs{ContinuationToken: converter.Int(1)}).
+		Return(&core.GetProjectsResponseValue{
+			Value: []core.TeamProjectReference{{Id: &otherProjectID}},
+		}, nil).
+		Times(1)
+	taskAgentClient.EXPECT().
+		GetDeploymentGroups(clients.Ctx, taskagent.GetDeploymentGroupsArgs{Project: converter.String(deploymentPoolProjectID)}).
+		Return(&taskagent.GetDeploymentGroupsResponseValue{Value: []taskagent.DeploymentGroup{
+			{Id: converter.Int(7), Pool: &taskagent.TaskAgentPoolReference{Id: converter.Int(deploymentPoolID)}},
+		}}, nil).
+		Times(1)
+	taskAgentClient.EXPECT().
+		GetDeploymentGroups(clients.Ctx, taskagent.GetDeploymentGroupsArgs{Project: converter.String(otherProjectID.String())}).
+		Return(&taskagent.GetDeploymentGroupsResponseValue{Value: []taskagent.DeploymentGroup{
+			{Id: converter.Int(3), Pool: &taskagent.TaskAgentPoolReference{Id: converter.Int(1)}},
+		}}, nil).
+		Times(1)
+
+	d := schema.TestResourceDataRaw(t, ResourceDeploymentPool().Schema, nil)
+	d.SetId(strconv.Itoa(deploymentPoolID))
+	result, err := importDeploymentPool(d, clients)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, []interface{}{deploymentPoolProjectID}, d.Get("project_ids").(*schema.Set).List())
+}
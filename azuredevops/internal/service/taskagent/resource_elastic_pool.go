@@ -182,8 +182,12 @@ func resourceAzureAgentPoolVMSSRead(d *schema.ResourceData, m interface{}) error
 		return fmt.Errorf(" parse Elastic Pool ID: %+v", err)
 	}
 
-	elasticPool, err := clients.ElasticClient.GetElasticPool(clients.Ctx, elastic.GetElasticPoolArgs{
-		PoolId: &poolID,
+	var elasticPool *elastic.ElasticPool
+	err = clients.ReadResource(d, func() (err error) {
+		elasticPool, err = clients.ElasticClient.GetElasticPool(clients.Ctx, elastic.GetElasticPoolArgs{
+			PoolId: &poolID,
+		})
+		return err
 	})
 	if err != nil {
 		if utils.ResponseWasNotFound(err) {
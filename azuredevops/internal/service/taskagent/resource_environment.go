@@ -73,9 +73,13 @@ func resourceEnvironmentRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("Error getting environment Id: %+v", err)
 	}
 
-	environment, err := clients.TaskAgentClient.GetEnvironmentById(clients.Ctx, taskagent.GetEnvironmentByIdArgs{
-		EnvironmentId: &environmentID,
-		Project:       converter.String(d.Get(projectID).(string)),
+	var environment *taskagent.EnvironmentInstance
+	err = clients.ReadResource(d, func() (err error) {
+		environment, err = clients.TaskAgentClient.GetEnvironmentById(clients.Ctx, taskagent.GetEnvironmentByIdArgs{
+			EnvironmentId: &environmentID,
+			Project:       converter.String(d.Get(projectID).(string)),
+		})
+		return err
 	})
 
 	if err != nil {
@@ -2,7 +2,9 @@ package taskagent
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -31,6 +33,9 @@ func ResourceEnvironmentKubernetes() *schema.Resource {
 		Create: resourceEnvironmentKubernetesCreate,
 		Read:   resourceEnvironmentKubernetesRead,
 		Delete: resourceEnvironmentKubernetesDelete,
+		Importer: &schema.ResourceImporter{
+			State: importEnvironmentKubernetes,
+		},
 		Schema: map[string]*schema.Schema{
 			kubeResProjectId: {
 				Type:         schema.TypeString,
@@ -112,10 +117,14 @@ func resourceEnvironmentKubernetesRead(d *schema.ResourceData, m interface{}) er
 	}
 
 	clients := m.(*client.AggregatedClient)
-	fetchedResource, err := clients.TaskAgentClient.GetKubernetesResource(clients.Ctx, taskagent.GetKubernetesResourceArgs{
-		Project:       converter.String(project.Id.String()),
-		EnvironmentId: resource.EnvironmentReference.Id,
-		ResourceId:    resource.Id,
+	var fetchedResource *taskagent.KubernetesResource
+	err = clients.ReadResource(d, func() (err error) {
+		fetchedResource, err = clients.TaskAgentClient.GetKubernetesResource(clients.Ctx, taskagent.GetKubernetesResourceArgs{
+			Project:       converter.String(project.Id.String()),
+			EnvironmentId: resource.EnvironmentReference.Id,
+			ResourceId:    resource.Id,
+		})
+		return err
 	})
 	if err != nil {
 		if utils.ResponseWasNotFound(err) {
@@ -149,6 +158,46 @@ func resourceEnvironmentKubernetesDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
+// importEnvironmentKubernetes imports a Kubernetes resource of an environment by an ID like
+// <project name or ID>/<environment ID>/<resource ID>
+func importEnvironmentKubernetes(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	clients := m.(*client.AggregatedClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || slices.Contains(parts, "") {
+		return nil, fmt.Errorf(" unexpected format of ID (%s), expected <project name or ID>/<environment ID>/<resource ID>", d.Id())
+	}
+	environmentID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf(" environment ID was expected to be integer, but was not: %w", err)
+	}
+	resourceID, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf(" resource ID was expected to be integer, but was not: %w", err)
+	}
+
+	projectID, err := tfhelper.GetRealProjectId(parts[0], m)
+	if err != nil {
+		return nil, err
+	}
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse project ID to UUID: %s, %w", projectID, err)
+	}
+
+	resource, err := clients.TaskAgentClient.GetKubernetesResource(clients.Ctx, taskagent.GetKubernetesResourceArgs{
+		Project:       converter.String(projectID),
+		EnvironmentId: converter.Int(environmentID),
+		ResourceId:    converter.Int(resourceID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error reading the Kubernetes resource: %w", err)
+	}
+
+	flattenEnvironmentKubernetesResource(d, &taskagent.ProjectReference{Id: &projectUUID}, resource)
+	return []*schema.ResourceData{d}, nil
+}
+
 func expandEnvironmentKubernetesResource(d *schema.ResourceData) (*taskagent.ProjectReference, *taskagent.KubernetesResource, error) {
 	projectId, err := uuid.Parse(d.Get(kubeResProjectId).(string))
 	if err != nil {
@@ -7,6 +7,7 @@ package taskagent
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/rand"
 	"testing"
 
@@ -261,3 +262,44 @@ func TestEnvironmentKubernetesResource_DeleteKubernetesResourceReturnsErrorOnFai
 	err := resourceEnvironmentKubernetesDelete(resourceData, clients)
 	assert.Contains(t, err.Error(), expectedError.Error())
 }
+
+func TestEnvironmentKubernetesResource_Import_ReadsResource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{
+		TaskAgentClient: taskAgentClient,
+		Ctx:             context.Background(),
+	}
+
+	expectedArgs := taskagent.GetKubernetesResourceArgs{
+		Project:       converter.String(testEnvironmentKubernetesResourceProject.Id.String()),
+		EnvironmentId: testEnvironmentKubernetesResource.EnvironmentReference.Id,
+		ResourceId:    testEnvironmentKubernetesResource.Id,
+	}
+
+	taskAgentClient.
+		EXPECT().
+		GetKubernetesResource(clients.Ctx, expectedArgs).
+		Return(&testEnvironmentKubernetesResource, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceEnvironmentKubernetes().Schema, nil)
+	resourceData.SetId(fmt.Sprintf("%s/%d/%d", testEnvironmentKubernetesResourceProjectId, testEnvironmentKubernetesResourceEnvironmentId, testEnvironmentKubernetesResourceId))
+	imported, err := importEnvironmentKubernetes(resourceData, clients)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+
+	project, resource, err := expandEnvironmentKubernetesResource(imported[0])
+	require.NoError(t, err)
+	assert.Equal(t, testEnvironmentKubernetesResourceProject, *project)
+	assert.Equal(t, testEnvironmentKubernetesResource, *resource)
+}
+
+func TestEnvironmentKubernetesResource_Import_RejectsMalformedID(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceEnvironmentKubernetes().Schema, nil)
+	resourceData.SetId(fmt.Sprintf("%s/%d", testEnvironmentKubernetesResourceProjectId, testEnvironmentKubernetesResourceEnvironmentId))
+	_, err := importEnvironmentKubernetes(resourceData, &client.AggregatedClient{Ctx: context.Background()})
+	require.ErrorContains(t, err, "unexpected format of ID")
+}
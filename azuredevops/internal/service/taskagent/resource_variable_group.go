@@ -1,9 +1,13 @@
 package taskagent
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -31,11 +35,22 @@ const (
 	vgValue             = "value"
 	secretVgValue       = "secret_value"
 	vgIsSecret          = "is_secret"
+	vgSecretVariable    = "secret_variable"
+	vgValueWO           = "value_wo"
+	vgValueWOVersion    = "value_wo_version"
 	vgKeyVault          = "key_vault"
 	vgServiceEndpointID = "service_endpoint_id"
 	vgContentType       = "content_type"
 	vgEnabled           = "enabled"
 	vgExpires           = "expires"
+	vgSearchDepth       = "search_depth"
+	vgSecretFilters     = "secret_filters"
+	vgSearchByTags      = "search_by_tags"
+	vgRefreshTrigger    = "refresh_trigger"
+	vgLinkedSecrets     = "linked_secrets"
+	vgSecretHashes      = "secret_variable_hashes"
+	vgSecretHashSalt    = "secret_variable_hash_salt"
+	vgSharedProject     = "shared_project"
 )
 
 const (
@@ -54,11 +69,20 @@ type KeyVaultSecretAttributes struct {
 }
 
 type KeyVaultSecret struct {
-	ContentType              *string `json:"contentType,omitempty"`
-	ID                       *string `json:"id,omitempty"`
+	ContentType              *string            `json:"contentType,omitempty"`
+	ID                       *string            `json:"id,omitempty"`
+	Tags                     map[string]*string `json:"tags,omitempty"`
 	KeyVaultSecretAttributes `json:"attributes,omitempty"`
 }
 
+// keyVaultSecretSelection selects the secrets of a Key Vault linked to a variable group, either by their
+// names or by name filters and tags
+type keyVaultSecretSelection struct {
+	names   map[string]string
+	filters []string
+	tags    map[string]string
+}
+
 type KeyVaultSecretResult struct {
 	Value    *[]KeyVaultSecret `json:"value,omitempty"`
 	NextLink *string           `json:"nextLink,omitempty"`
@@ -67,11 +91,12 @@ type KeyVaultSecretResult struct {
 // ResourceVariableGroup schema and implementation for variable group resource
 func ResourceVariableGroup() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceVariableGroupCreate,
-		Read:     resourceVariableGroupRead,
-		Update:   resourceVariableGroupUpdate,
-		Delete:   resourceVariableGroupDelete,
-		Importer: tfhelper.ImportProjectQualifiedResource(),
+		Create:        resourceVariableGroupCreate,
+		Read:          resourceVariableGroupRead,
+		Update:        resourceVariableGroupUpdate,
+		Delete:        resourceVariableGroupDelete,
+		Importer:      tfhelper.ImportProjectQualifiedResource(),
+		CustomizeDiff: customizeDiffSecretVariableHashes,
 		Schema: map[string]*schema.Schema{
 			vgProjectID: {
 				Type:         schema.TypeString,
@@ -95,9 +120,10 @@ func ResourceVariableGroup() *schema.Resource {
 				Default:  false,
 			},
 			vgVariable: {
-				Type:     schema.TypeSet,
-				Required: true,
-				MinItems: 1,
+				Type:         schema.TypeSet,
+				Optional:     true,
+				MinItems:     1,
+				AtLeastOneOf: []string{vgVariable, vgSecretVariable, vgKeyVault + ".0." + vgSecretFilters, vgKeyVault + ".0." + vgSearchByTags},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						vgName: {
@@ -116,6 +142,7 @@ func ResourceVariableGroup() *schema.Resource {
 							Sensitive:     true,
 							Default:       "",
 							ConflictsWith: []string{vgKeyVault},
+							Deprecated:    "`secret_value` is stored in the plan and the state, use a `secret_variable` block with the write-only `value_wo` instead.",
 						},
 						vgIsSecret: {
 							Type:          schema.TypeBool,
@@ -138,6 +165,70 @@ func ResourceVariableGroup() *schema.Resource {
 					},
 				},
 			},
+			vgSecretVariable: {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{vgKeyVault},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						vgName: {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						vgValueWO: {
+							Type:        schema.TypeString,
+							Required:    true,
+							WriteOnly:   true,
+							Sensitive:   true,
+							Description: "The value of the secret variable, which is never persisted in the plan or the state.",
+						},
+						vgValueWOVersion: {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "The version of `value_wo`, changing it sends the current value of `value_wo` to Azure DevOps even if the value did not change.",
+						},
+					},
+				},
+			},
+			vgSharedProject: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The projects the variable group is shared with, in addition to the project of the variable group.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						vgProjectID: {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+						vgName: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of the variable group in the project, defaults to the name of the variable group.",
+						},
+						vgDescription: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The description of the variable group in the project, defaults to the description of the variable group.",
+						},
+					},
+				},
+			},
+			vgSecretHashes: {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The HMAC-SHA256 hashes of the values of the secret variables by name, used to detect changes of the write-only values.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			vgSecretHashSalt: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The random salt the hashes of the values of the secret variables are keyed with.",
+			},
 			vgKeyVault: {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -154,11 +245,41 @@ func ResourceVariableGroup() *schema.Resource {
 							Required:     true,
 							ValidateFunc: validation.IsUUID,
 						},
-						"search_depth": {
+						vgSearchDepth: {
 							Type:     schema.TypeInt,
 							Optional: true,
 							Default:  20,
 						},
+						vgSecretFilters: {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Links the enabled secrets whose names match any of the filters, in addition to the secrets of the `variable` blocks. Filters support the wildcards `*` and `?`.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validateSecretFilter,
+							},
+						},
+						vgSearchByTags: {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Links the enabled secrets which have all of the tags, in addition to the secrets of the `variable` blocks.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						vgRefreshTrigger: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Changing the value lists the secrets of the Key Vault again, linking the secrets added since the last refresh.",
+						},
+						vgLinkedSecrets: {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The names of the secrets linked to the variable group.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
 					},
 				},
 			},
@@ -177,6 +298,9 @@ func resourceVariableGroupCreate(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return fmt.Errorf(" creating variable group in Azure DevOps: %+v", err)
 	}
+	if err := setSecretVariableHashes(d); err != nil {
+		return err
+	}
 
 	err = flattenVariableGroup(d, addedVariableGroup, projectID)
 
@@ -204,24 +328,27 @@ func resourceVariableGroupRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf(invalidVariableGroupIDErrorMessageFormat, err)
 	}
 
-	variableGroup, err := clients.TaskAgentClient.GetVariableGroup(
-		clients.Ctx,
-		taskagent.GetVariableGroupArgs{
-			GroupId: &variableGroupID,
-			Project: &projectID,
-		},
-	)
+	var variableGroup *taskagent.VariableGroup
+	err = clients.ReadResource(d, func() (err error) {
+		variableGroup, err = clients.TaskAgentClient.GetVariableGroup(
+			clients.Ctx,
+			taskagent.GetVariableGroupArgs{
+				GroupId: &variableGroupID,
+				Project: &projectID,
+			},
+		)
+		if err == nil && (variableGroup == nil || variableGroup.Id == nil) {
+			return client.ErrResourceNotFound
+		}
+		return err
+	})
 	if err != nil {
-		if utils.ResponseWasNotFound(err) {
+		if utils.ResponseWasNotFound(err) || errors.Is(err, client.ErrResourceNotFound) {
 			d.SetId("")
 			return nil
 		}
 		return fmt.Errorf("Error looking up variable group given ID (%v) and project ID (%v): %v", variableGroupID, projectID, err)
 	}
-	if variableGroup.Id == nil {
-		d.SetId("")
-		return nil
-	}
 
 	err = flattenVariableGroup(d, variableGroup, &projectID)
 
@@ -263,10 +390,26 @@ func resourceVariableGroupUpdate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf(invalidVariableGroupIDErrorMessageFormat, err)
 	}
 
+	variableGroupParams.VariableGroupProjectReferences, err = appendUnmanagedProjectReferences(clients, d, *projectID, variableGroupID, variableGroupParams.VariableGroupProjectReferences)
+	if err != nil {
+		return fmt.Errorf(" reading the projects variable group %d is shared with: %w", variableGroupID, err)
+	}
+
+	// sharing the variable group with other projects does not require sending the variables again
+	if !d.HasChangesExcept(vgSharedProject) {
+		if err := shareVariableGroup(clients, variableGroupParams.VariableGroupProjectReferences, &variableGroupID); err != nil {
+			return fmt.Errorf(" sharing variable group %d: %+v", variableGroupID, err)
+		}
+		return resourceVariableGroupRead(d, m)
+	}
+
 	updatedVariableGroup, err := updateVariableGroup(clients, variableGroupParams, &variableGroupID, projectID)
 	if err != nil {
 		return fmt.Errorf("Error updating variable group in Azure DevOps: %+v", err)
 	}
+	if err := setSecretVariableHashes(d); err != nil {
+		return err
+	}
 
 	err = flattenVariableGroup(d, updatedVariableGroup, projectID)
 
@@ -298,8 +441,12 @@ func resourceVariableGroupDelete(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return fmt.Errorf("Error deleting the allow access definitionResource for variable group ID (%v) and project ID (%v): %v", variableGroupID, projectID, err)
 	}
-	//delete the variable group
-	return deleteVariableGroup(clients, &projectID, &variableGroupID)
+	//delete the variable group from its project and the projects it is shared with
+	projectIDs := []string{projectID}
+	for _, sharedProject := range d.Get(vgSharedProject).(*schema.Set).List() {
+		projectIDs = append(projectIDs, sharedProject.(map[string]interface{})[vgProjectID].(string))
+	}
+	return deleteVariableGroup(clients, projectIDs, &variableGroupID)
 }
 
 // Make the Azure DevOps API call to create the variable group
@@ -356,12 +503,23 @@ func updateVariableGroup(clients *client.AggregatedClient, parameters *taskagent
 	return updatedVariableGroup, err
 }
 
+// Make the Azure DevOps API call to share the variable group with the projects of the references, the variable
+// group is removed from the projects which are not referenced anymore
+func shareVariableGroup(clients *client.AggregatedClient, references *[]taskagent.VariableGroupProjectReference, variableGroupID *int) error {
+	return clients.TaskAgentClient.ShareVariableGroup(
+		clients.Ctx,
+		taskagent.ShareVariableGroupArgs{
+			VariableGroupProjectReferences: references,
+			VariableGroupId:                variableGroupID,
+		})
+}
+
 // Make the Azure DevOps API call to delete the variable group
-func deleteVariableGroup(clients *client.AggregatedClient, projectId *string, variableGroupID *int) error {
+func deleteVariableGroup(clients *client.AggregatedClient, projectIds []string, variableGroupID *int) error {
 	err := clients.TaskAgentClient.DeleteVariableGroup(
 		clients.Ctx,
 		taskagent.DeleteVariableGroupArgs{
-			ProjectIds: &[]string{*projectId},
+			ProjectIds: &projectIds,
 			GroupId:    variableGroupID,
 		})
 
@@ -394,24 +552,33 @@ func expandVariableGroupParameters(clients *client.AggregatedClient, d *schema.R
 		}
 	}
 
+	// values of secret variables are write-only and have to be read from the configuration
+	for i, secretVariable := range d.Get(vgSecretVariable).([]interface{}) {
+		varName := secretVariable.(map[string]interface{})[vgName].(string)
+		if _, ok := variableMap[varName]; ok {
+			return nil, nil, fmt.Errorf(" variable %s is defined more than once", varName)
+		}
+		variableMap[varName] = taskagent.VariableValue{
+			Value:    converter.String(tfhelper.WriteOnlyString(d, fmt.Sprintf("%s.%d.%s", vgSecretVariable, i, vgValueWO))),
+			IsSecret: converter.Bool(true),
+		}
+	}
+
 	projectUUId, err := uuid.Parse(*projectID)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	projectReferences, err := expandVariableGroupProjectReferences(d, projectUUId)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	variableGroup := &taskagent.VariableGroupParameters{
-		Name:        name,
-		Description: description,
-		Variables:   &variableMap,
-		VariableGroupProjectReferences: &[]taskagent.VariableGroupProjectReference{
-			{
-				Description: description,
-				Name:        name,
-				ProjectReference: &taskagent.ProjectReference{
-					Id: &projectUUId,
-				},
-			},
-		},
+		Name:                           name,
+		Description:                    description,
+		Variables:                      &variableMap,
+		VariableGroupProjectReferences: projectReferences,
 	}
 
 	keyVault := d.Get(vgKeyVault).([]interface{})
@@ -421,7 +588,7 @@ func expandVariableGroupParameters(clients *client.AggregatedClient, d *schema.R
 		kvConfigures := keyVault[0].(map[string]interface{})
 		kvName := kvConfigures[vgName].(string)
 		serviceEndpointID := kvConfigures[vgServiceEndpointID].(string)
-		depth := kvConfigures["search_depth"].(int)
+		depth := kvConfigures[vgSearchDepth].(int)
 
 		serviceEndpointUUID, err := uuid.Parse(serviceEndpointID)
 		if err != nil {
@@ -435,7 +602,7 @@ func expandVariableGroupParameters(clients *client.AggregatedClient, d *schema.R
 		}
 
 		variableGroup.Type = converter.String(azureKeyVaultType)
-		kvVariables, invalidVariables, err := searchAzureKVSecrets(clients, *projectID, kvName, serviceEndpointID, variables, depth)
+		kvVariables, invalidVariables, err := searchAzureKVSecrets(clients, *projectID, kvName, serviceEndpointID, expandKeyVaultSecretSelection(kvConfigures, variables), depth)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -451,6 +618,77 @@ func expandVariableGroupParameters(clients *client.AggregatedClient, d *schema.R
 	return variableGroup, projectID, nil
 }
 
+// The variable group is referenced by its project and the projects it is shared with, the name and the
+// description of the variable group apply unless they are overridden for a project
+func expandVariableGroupProjectReferences(d *schema.ResourceData, projectID uuid.UUID) (*[]taskagent.VariableGroupProjectReference, error) {
+	name := d.Get(vgName).(string)
+	description := d.Get(vgDescription).(string)
+	references := []taskagent.VariableGroupProjectReference{{
+		Description:      converter.String(description),
+		Name:             converter.String(name),
+		ProjectReference: &taskagent.ProjectReference{Id: &projectID},
+	}}
+
+	for _, sharedProject := range d.Get(vgSharedProject).(*schema.Set).List() {
+		sharedProjectMap := sharedProject.(map[string]interface{})
+		sharedProjectID, err := uuid.Parse(sharedProjectMap[vgProjectID].(string))
+		if err != nil {
+			return nil, err
+		}
+		if sharedProjectID == projectID {
+			return nil, fmt.Errorf(" the variable group cannot be shared with its own project %s", projectID)
+		}
+
+		reference := taskagent.VariableGroupProjectReference{
+			Description:      converter.String(description),
+			Name:             converter.String(name),
+			ProjectReference: &taskagent.ProjectReference{Id: &sharedProjectID},
+		}
+		if v := sharedProjectMap[vgName].(string); v != "" {
+			reference.Name = converter.String(v)
+		}
+		if v := sharedProjectMap[vgDescription].(string); v != "" {
+			reference.Description = converter.String(v)
+		}
+		references = append(references, reference)
+	}
+	return &references, nil
+}
+
+// The projects the variable group was shared with outside of Terraform are not managed, their references are kept
+// so that updating the variable group does not remove it from these projects
+func appendUnmanagedProjectReferences(clients *client.AggregatedClient, d *schema.ResourceData, projectID string, variableGroupID int, references *[]taskagent.VariableGroupProjectReference) (*[]taskagent.VariableGroupProjectReference, error) {
+	variableGroup, err := clients.TaskAgentClient.GetVariableGroup(clients.Ctx, taskagent.GetVariableGroupArgs{
+		GroupId: &variableGroupID,
+		Project: &projectID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if variableGroup == nil || variableGroup.VariableGroupProjectReferences == nil {
+		return references, nil
+	}
+
+	managed := map[string]bool{strings.ToLower(projectID): true}
+	oldSharedProjects, newSharedProjects := d.GetChange(vgSharedProject)
+	for _, sharedProjects := range []interface{}{oldSharedProjects, newSharedProjects} {
+		for _, sharedProject := range sharedProjects.(*schema.Set).List() {
+			managed[strings.ToLower(sharedProject.(map[string]interface{})[vgProjectID].(string))] = true
+		}
+	}
+
+	result := *references
+	for _, reference := range *variableGroup.VariableGroupProjectReferences {
+		if reference.ProjectReference == nil || reference.ProjectReference.Id == nil {
+			continue
+		}
+		if !managed[reference.ProjectReference.Id.String()] {
+			result = append(result, reference)
+		}
+	}
+	return &result, nil
+}
+
 // Convert AzDO data structure to internal Terraform data structure
 func flattenVariableGroup(d *schema.ResourceData, variableGroup *taskagent.VariableGroup, projectID *string) error {
 	d.SetId(fmt.Sprintf("%d", *variableGroup.Id))
@@ -468,6 +706,19 @@ func flattenVariableGroup(d *schema.ResourceData, variableGroup *taskagent.Varia
 		return err
 	}
 
+	// the data source does not manage secret variables
+	if _, ok := d.Get(vgSecretVariable).([]interface{}); ok {
+		if err = d.Set(vgSecretVariable, flattenSecretVariables(d, variableGroup)); err != nil {
+			return err
+		}
+		if err = d.Set(vgSecretHashes, flattenSecretVariableHashes(d, variableGroup)); err != nil {
+			return err
+		}
+		if err = d.Set(vgSharedProject, flattenSharedProjects(d, variableGroup, converter.ToString(projectID, ""))); err != nil {
+			return err
+		}
+	}
+
 	if isKeyVaultVariableGroupType(variableGroup.Type) {
 		keyVault, err := flattenKeyVault(d, variableGroup)
 
@@ -492,31 +743,177 @@ func isKeyVaultVariableGroupType(variableGrouptype *string) bool {
 //
 //	variables marked as secret will need to be pulled from the state itself
 func flattenVariables(d *schema.ResourceData, variableGroup *taskagent.VariableGroup) (interface{}, error) {
-	variables := make([]map[string]interface{}, len(*variableGroup.Variables))
+	secretVariableNames := map[string]bool{}
+	secretVariables, _ := d.Get(vgSecretVariable).([]interface{})
+	for _, secretVariable := range secretVariables {
+		secretVariableNames[secretVariable.(map[string]interface{})[vgName].(string)] = true
+	}
+
+	// secrets linked through filters or tags are only listed by the key_vault block, unless they are
+	// configured as variables as well
+	var configuredVariableNames map[string]bool
+	if isKeyVaultVariableGroupType(variableGroup.Type) && hasKeyVaultSecretFilters(d) {
+		configuredVariableNames = map[string]bool{}
+		for _, variable := range d.Get(vgVariable).(*schema.Set).List() {
+			configuredVariableNames[variable.(map[string]interface{})[vgName].(string)] = true
+		}
+	}
 
-	index := 0
+	variables := make([]map[string]interface{}, 0, len(*variableGroup.Variables))
 	for varName, varVal := range *variableGroup.Variables {
+		// secret variables with write-only values are tracked in the secret_variable block
+		if secretVariableNames[varName] {
+			continue
+		}
+		if configuredVariableNames != nil && !configuredVariableNames[varName] {
+			continue
+		}
+
 		variableAsJSON, err := json.Marshal(varVal)
 		if err != nil {
 			return nil, fmt.Errorf("Unable to marshal variable into JSON: %+v", err)
 		}
 
+		var variable map[string]interface{}
 		if isKeyVaultVariableGroupType(variableGroup.Type) {
-			variables[index], err = flattenKeyVaultVariable(variableAsJSON, varName)
+			variable, err = flattenKeyVaultVariable(variableAsJSON, varName)
 		} else {
-			variables[index], err = flattenVariable(d, variableAsJSON, varName)
+			variable, err = flattenVariable(d, variableAsJSON, varName)
 		}
 
 		if err != nil {
 			return nil, err
 		}
-
-		index = index + 1
+		variables = append(variables, variable)
 	}
 
 	return variables, nil
 }
 
+// Secret variables with write-only values are kept from the state as long as they exist in the variable group,
+// the values are never returned by the service nor stored in the state.
+func flattenSecretVariables(d *schema.ResourceData, variableGroup *taskagent.VariableGroup) []interface{} {
+	secretVariables := []interface{}{}
+	for _, secretVariable := range d.Get(vgSecretVariable).([]interface{}) {
+		secretVariableMap := secretVariable.(map[string]interface{})
+		if _, ok := (*variableGroup.Variables)[secretVariableMap[vgName].(string)]; ok {
+			secretVariables = append(secretVariables, map[string]interface{}{
+				vgName:           secretVariableMap[vgName],
+				vgValueWOVersion: secretVariableMap[vgValueWOVersion],
+			})
+		}
+	}
+	return secretVariables
+}
+
+// The projects the variable group is shared with are the references of the projects of the shared_project blocks,
+// projects it was shared with outside of Terraform are not managed. Names and descriptions matching the variable
+// group are only kept when they are configured.
+func flattenSharedProjects(d *schema.ResourceData, variableGroup *taskagent.VariableGroup, projectID string) []interface{} {
+	configured := map[string]map[string]interface{}{}
+	for _, sharedProject := range d.Get(vgSharedProject).(*schema.Set).List() {
+		sharedProjectMap := sharedProject.(map[string]interface{})
+		configured[strings.ToLower(sharedProjectMap[vgProjectID].(string))] = sharedProjectMap
+	}
+
+	sharedProjects := []interface{}{}
+	if variableGroup.VariableGroupProjectReferences == nil {
+		return sharedProjects
+	}
+	for _, reference := range *variableGroup.VariableGroupProjectReferences {
+		if reference.ProjectReference == nil || reference.ProjectReference.Id == nil {
+			continue
+		}
+		sharedProjectID := reference.ProjectReference.Id.String()
+		current, ok := configured[sharedProjectID]
+		if !ok || strings.EqualFold(sharedProjectID, projectID) {
+			continue
+		}
+
+		name := converter.ToString(reference.Name, "")
+		description := converter.ToString(reference.Description, "")
+		if current[vgName].(string) == "" {
+			if name == converter.ToString(variableGroup.Name, "") {
+				name = ""
+			}
+		}
+		if current[vgDescription].(string) == "" {
+			if description == converter.ToString(variableGroup.Description, "") {
+				description = ""
+			}
+		}
+		sharedProjects = append(sharedProjects, map[string]interface{}{
+			vgProjectID:   sharedProjectID,
+			vgName:        name,
+			vgDescription: description,
+		})
+	}
+	return sharedProjects
+}
+
+// The hashes of the values of secret variables are kept from the state as long as the variables exist in the
+// variable group, the service never returns the values.
+func flattenSecretVariableHashes(d *schema.ResourceData, variableGroup *taskagent.VariableGroup) map[string]interface{} {
+	hashes := map[string]interface{}{}
+	for name, hash := range d.Get(vgSecretHashes).(map[string]interface{}) {
+		if _, ok := (*variableGroup.Variables)[name]; ok {
+			hashes[name] = hash
+		}
+	}
+	return hashes
+}
+
+// setSecretVariableHashes records the hashes of the write-only values of the secret variables sent to Azure
+// DevOps, the values are only available in the configuration. The salt of the hashes is generated with the
+// first hashes, hashes of earlier versions of the provider were not salted.
+func setSecretVariableHashes(d *schema.ResourceData) error {
+	salt := d.Get(vgSecretHashSalt).(string)
+	if salt == "" {
+		var err error
+		if salt, err = tfhelper.NewWriteOnlyHashSalt(); err != nil {
+			return fmt.Errorf(" hashing secret variables: %w", err)
+		}
+		d.Set(vgSecretHashSalt, salt)
+	}
+
+	if hashes, ok := tfhelper.WriteOnlyHashes(d.GetRawConfig(), vgSecretVariable, vgName, vgValueWO, salt); ok {
+		d.Set(vgSecretHashes, hashes)
+	}
+	return nil
+}
+
+// customizeDiffSecretVariableHashes plans an update of the variable group when the hash of the write-only
+// value of a secret variable differs from the hash of the value last sent to Azure DevOps
+func customizeDiffSecretVariableHashes(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	salt := d.Get(vgSecretHashSalt).(string)
+	hashes, ok := tfhelper.WriteOnlyHashes(d.GetRawConfig(), vgSecretVariable, vgName, vgValueWO, salt)
+	if !ok {
+		return d.SetNewComputed(vgSecretHashes)
+	}
+	if salt == "" {
+		// the salt is only generated when the variable group is saved, the hashes are not known until then
+		if len(hashes) == 0 && len(d.Get(vgSecretHashes).(map[string]interface{})) == 0 {
+			return nil
+		}
+		if err := d.SetNewComputed(vgSecretHashSalt); err != nil {
+			return err
+		}
+		return d.SetNewComputed(vgSecretHashes)
+	}
+
+	current := d.Get(vgSecretHashes).(map[string]interface{})
+	changed := len(current) != len(hashes)
+	for name, hash := range hashes {
+		if current[name] != hash {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return d.SetNew(vgSecretHashes, hashes)
+}
+
 func flattenKeyVaultVariable(variableAsJSON []byte, varName string) (map[string]interface{}, error) {
 	var variable taskagent.AzureKeyVaultVariableValue
 	err := json.Unmarshal(variableAsJSON, &variable)
@@ -573,15 +970,26 @@ func flattenKeyVault(d *schema.ResourceData, variableGroup *taskagent.VariableGr
 		return nil, fmt.Errorf("Unable to unmarshal provider data (%+v): %+v", providerData, err)
 	}
 
+	linkedSecrets := make([]string, 0, len(*variableGroup.Variables))
+	for varName := range *variableGroup.Variables {
+		linkedSecrets = append(linkedSecrets, varName)
+	}
+	sort.Strings(linkedSecrets)
+
 	keyVault := []map[string]interface{}{{
 		vgName:              providerData.Vault,
 		vgServiceEndpointID: providerData.ServiceEndpointId.String(),
+		vgLinkedSecrets:     linkedSecrets,
 	}}
 
-	keyVaultRaw := d.Get("key_vault").([]interface{})
-	if len(keyVaultRaw) == 1 {
+	keyVaultRaw := d.Get(vgKeyVault).([]interface{})
+	if len(keyVaultRaw) == 1 && keyVaultRaw[0] != nil {
 		kvConfigures := keyVaultRaw[0].(map[string]interface{})
-		keyVault[0]["search_depth"] = kvConfigures["search_depth"].(int)
+		for _, key := range []string{vgSearchDepth, vgSecretFilters, vgSearchByTags, vgRefreshTrigger} {
+			if value, ok := kvConfigures[key]; ok {
+				keyVault[0][key] = value
+			}
+		}
 	}
 
 	return keyVault, nil
@@ -657,18 +1065,89 @@ func flattenAllowAccess(d *schema.ResourceData, definitionResource *[]build.Defi
 	d.Set(vgAllowAccess, allowAccess)
 }
 
-func searchAzureKVSecrets(clients *client.AggregatedClient, projectID, kvName, serviceEndpointID string, variables []interface{}, depth int) (kvSecrets map[string]interface{}, invalidSecrets []string, error error) {
+// expandKeyVaultSecretSelection selects the secrets named by the variables, and the secrets matching the
+// filters or the tags of the key_vault block
+func expandKeyVaultSecretSelection(kvConfigures map[string]interface{}, variables []interface{}) *keyVaultSecretSelection {
+	selection := &keyVaultSecretSelection{
+		names: map[string]string{},
+		tags:  map[string]string{},
+	}
+	for _, val := range variables {
+		name := val.(map[string]interface{})[vgName].(string)
+		selection.names[name] = name
+	}
+	if filters, ok := kvConfigures[vgSecretFilters].([]interface{}); ok {
+		for _, filter := range filters {
+			selection.filters = append(selection.filters, filter.(string))
+		}
+	}
+	if tags, ok := kvConfigures[vgSearchByTags].(map[string]interface{}); ok {
+		for k, v := range tags {
+			selection.tags[k] = v.(string)
+		}
+	}
+	return selection
+}
+
+// hasKeyVaultSecretFilters reports whether secrets are linked through filters or tags
+func hasKeyVaultSecretFilters(d *schema.ResourceData) bool {
+	keyVault := d.Get(vgKeyVault).([]interface{})
+	if len(keyVault) != 1 || keyVault[0] == nil {
+		return false
+	}
+	return expandKeyVaultSecretSelection(keyVault[0].(map[string]interface{}), nil).searchesAll()
+}
+
+// searchesAll reports whether all secrets of the Key Vault have to be listed, instead of stopping once the
+// named secrets have been found
+func (s *keyVaultSecretSelection) searchesAll() bool {
+	return len(s.filters) > 0 || len(s.tags) > 0
+}
+
+// matches reports whether a secret is selected by the filters or the tags
+func (s *keyVaultSecretSelection) matches(name string, tags map[string]*string) bool {
+	for _, filter := range s.filters {
+		if matched, _ := path.Match(filter, name); matched {
+			return true
+		}
+	}
+	if len(s.tags) == 0 {
+		return false
+	}
+	for k, v := range s.tags {
+		if value, ok := tags[k]; !ok || value == nil || *value != v {
+			return false
+		}
+	}
+	return true
+}
+
+func validateSecretFilter(i interface{}, k string) ([]string, []error) {
+	filter, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if strings.TrimSpace(filter) == "" {
+		return nil, []error{fmt.Errorf("%s must not be empty", k)}
+	}
+	if _, err := path.Match(filter, ""); err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid filter: %+v", k, err)}
+	}
+	return nil, nil
+}
+
+func searchAzureKVSecrets(clients *client.AggregatedClient, projectID, kvName, serviceEndpointID string, selection *keyVaultSecretSelection, depth int) (kvSecrets map[string]interface{}, invalidSecrets []string, error error) {
 	var token, loop, azkvSecretsRaw = "", 0, &KeyVaultSecretResult{}
 	kvSecrets = make(map[string]interface{})
 	invalidSecrets = make([]string, 0)
 
 	secretNames := make(map[string]string)
-	for _, val := range variables {
-		name := val.(map[string]interface{})["name"].(string)
+	for name := range selection.names {
 		secretNames[name] = name
 	}
 	for {
 		kvSecretsMap := make(map[string]taskagent.AzureKeyVaultVariableValue)
+		kvSecretTags := make(map[string]map[string]*string)
 		if azKVSecrets, err := getKVSecretServiceEndpointProxy(clients, kvName, projectID, serviceEndpointID, token); err == nil {
 			azkvSecretsRaw, token, err = parseKVSecretResp(azKVSecrets)
 			if err != nil {
@@ -692,21 +1171,27 @@ func searchAzureKVSecrets(clients *client.AggregatedClient, projectID, kvName, s
 					}
 				}
 				kvSecretsMap[name] = kvVariable
+				kvSecretTags[name] = secret.Tags
 			}
 
 			// search secret
 			for name, secret := range kvSecretsMap {
-				if len(secretNames) == 0 {
+				if len(secretNames) == 0 && !selection.searchesAll() {
 					break
 				}
-				if _, ok := secretNames[name]; ok && *secret.Enabled {
+				if !converter.ToBool(secret.Enabled, false) {
+					continue
+				}
+				if _, ok := secretNames[name]; ok {
 					kvSecrets[name] = secret
 					delete(secretNames, name)
+				} else if selection.matches(name, kvSecretTags[name]) {
+					kvSecrets[name] = secret
 				}
 			}
 
 			// stop search
-			if token == "" || loop == depth || len(secretNames) == 0 {
+			if token == "" || loop == depth || (len(secretNames) == 0 && !selection.searchesAll()) {
 				for k := range secretNames {
 					invalidSecrets = append(invalidSecrets, k)
 				}
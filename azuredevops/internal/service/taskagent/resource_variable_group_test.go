@@ -7,6 +7,127 @@ package taskagent
 // The tests in this file use the mock clients in mock_client.go to mock out
 // the Azure DevOps client operations.
 
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/serviceendpoint"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+	"github.com/stretchr/testify/require"
+)
+
+var keyVaultSecretsResult = &serviceendpoint.ServiceEndpointRequestResult{
+	ErrorMessage: converter.String(""),
+	Result: []interface{}{
+		`{"value": [
+			{"id": "https://mock.vault.azure.net/secrets/app-key", "attributes": {"enabled": true}, "tags": {}},
+			{"id": "https://mock.vault.azure.net/secrets/app-disabled", "attributes": {"enabled": false}, "tags": {}},
+			{"id": "https://mock.vault.azure.net/secrets/db-password", "attributes": {"enabled": true}, "tags": {"pipeline": "example"}},
+			{"id": "https://mock.vault.azure.net/secrets/db-user", "attributes": {"enabled": true}, "tags": {"pipeline": "other"}},
+			{"id": "https://mock.vault.azure.net/secrets/named", "attributes": {"enabled": true}, "tags": {}}
+		], "nextLink": null}`,
+	},
+	StatusCode: converter.String("ok"),
+}
+
+func TestVariableGroup_SearchKeyVaultSecrets_LinksSecretsMatchingFiltersAndTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serviceEndpointClient := azdosdkmocks.NewMockServiceendpointClient(ctrl)
+	clients := &client.AggregatedClient{ServiceEndpointClient: serviceEndpointClient, Ctx: context.Background()}
+
+	serviceEndpointClient.
+		EXPECT().
+		ExecuteServiceEndpointRequest(clients.Ctx, gomock.Any()).
+		Return(keyVaultSecretsResult, nil).
+		Times(1)
+
+	selection := expandKeyVaultSecretSelection(map[string]interface{}{
+		vgSecretFilters: []interface{}{"app-*"},
+		vgSearchByTags:  map[string]interface{}{"pipeline": "example"},
+	}, []interface{}{map[string]interface{}{vgName: "named"}})
+
+	secrets, invalidSecrets, err := searchAzureKVSecrets(clients, uuid.New().String(), "mock", uuid.New().String(), selection, 20)
+	require.Nil(t, err)
+	require.Empty(t, invalidSecrets)
+	require.Len(t, secrets, 3)
+	require.Contains(t, secrets, "app-key")
+	require.Contains(t, secrets, "db-password")
+	require.Contains(t, secrets, "named")
+}
+
+func TestVariableGroup_SearchKeyVaultSecrets_ReportsMissingNamedSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serviceEndpointClient := azdosdkmocks.NewMockServiceendpointClient(ctrl)
+	clients := &client.AggregatedClient{ServiceEndpointClient: serviceEndpointClient, Ctx: context.Background()}
+
+	serviceEndpointClient.
+		EXPECT().
+		ExecuteServiceEndpointRequest(clients.Ctx, gomock.Any()).
+		Return(keyVaultSecretsResult, nil).
+		Times(1)
+
+	selection := expandKeyVaultSecretSelection(map[string]interface{}{}, []interface{}{
+		map[string]interface{}{vgName: "app-disabled"},
+		map[string]interface{}{vgName: "named"},
+	})
+
+	secrets, invalidSecrets, err := searchAzureKVSecrets(clients, uuid.New().String(), "mock", uuid.New().String(), selection, 20)
+	require.Nil(t, err)
+	require.Equal(t, []string{"app-disabled"}, invalidSecrets)
+	require.Len(t, secrets, 1)
+	require.Contains(t, secrets, "named")
+}
+
+func TestVariableGroup_FlattenKeyVault_OmitsFilteredSecretsFromVariables(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, ResourceVariableGroup().Schema, map[string]interface{}{
+		vgProjectID: uuid.New().String(),
+		vgName:      "Name",
+		vgVariable:  []interface{}{map[string]interface{}{vgName: "named"}},
+		vgKeyVault: []interface{}{map[string]interface{}{
+			vgName:              "mock",
+			vgServiceEndpointID: uuid.New().String(),
+			vgSecretFilters:     []interface{}{"app-*"},
+		}},
+	})
+
+	variableGroup := &taskagent.VariableGroup{
+		Id:   converter.Int(100),
+		Name: converter.String("Name"),
+		Type: converter.String(azureKeyVaultType),
+		Variables: &map[string]interface{}{
+			"named":   map[string]interface{}{"isSecret": true, "enabled": true},
+			"app-key": map[string]interface{}{"isSecret": true, "enabled": true},
+		},
+		ProviderData: map[string]interface{}{
+			"serviceEndpointId": uuid.New().String(),
+			"vault":             "mock",
+		},
+	}
+	projectID := d.Get(vgProjectID).(string)
+	require.Nil(t, flattenVariableGroup(d, variableGroup, &projectID))
+
+	variables := d.Get(vgVariable).(*schema.Set).List()
+	require.Len(t, variables, 1)
+	require.Equal(t, "named", variables[0].(map[string]interface{})[vgName])
+	require.Equal(t, []interface{}{"app-key", "named"}, d.Get(vgKeyVault+".0."+vgLinkedSecrets))
+	require.Equal(t, []interface{}{"app-*"}, d.Get(vgKeyVault+".0."+vgSecretFilters))
+}
+
 //var serviceEndpointResult = &serviceendpoint.ServiceEndpointRequestResult{
 //	ErrorMessage: converter.String(""),
 //	Result: []interface{}{
@@ -147,3 +268,249 @@ package taskagent
 //	providerDataActual, _ := json.Marshal(variableGroupParams.ProviderData)
 //	require.Equal(t, providerDataExpected, providerDataActual)
 //}
+
+// planSecretVariable plans the variable group with a secret variable, the state holds the hash of the value
+// last sent to Azure DevOps
+func planSecretVariable(t *testing.T, value string, salt string, hash string) *terraform.InstanceDiff {
+	r := ResourceVariableGroup()
+	projectID := uuid.New().String()
+
+	attrs := map[string]cty.Value{}
+	for name, attrTy := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		attrs[name] = cty.NullVal(attrTy)
+	}
+	attrs[vgProjectID] = cty.StringVal(projectID)
+	attrs[vgName] = cty.StringVal("Name")
+	attrs[vgSecretVariable] = cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+		vgName:           cty.StringVal("secret"),
+		vgValueWO:        cty.StringVal(value),
+		vgValueWOVersion: cty.NullVal(cty.Number),
+	})})
+	config := cty.ObjectVal(attrs)
+
+	state := &terraform.InstanceState{
+		ID: "100",
+		Attributes: map[string]string{
+			"id":                         "100",
+			vgProjectID:                  projectID,
+			vgName:                       "Name",
+			vgDescription:                "",
+			vgAllowAccess:                "false",
+			vgSecretVariable + ".#":      "1",
+			vgSecretVariable + ".0.name": "secret",
+			vgSecretHashes + ".%":        "1",
+			vgSecretHashes + ".secret":   hash,
+			vgSecretHashSalt:             salt,
+		},
+		RawConfig: config,
+	}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(config, r.CoreConfigSchema()), nil)
+	require.Nil(t, err)
+	return diff
+}
+
+func TestVariableGroup_CustomizeDiff_PlansUpdateWhenSecretValueChanges(t *testing.T) {
+	diff := planSecretVariable(t, "new", "salt", tfhelper.WriteOnlyHash("salt", "old"))
+	require.NotNil(t, diff)
+	require.Equal(t, tfhelper.WriteOnlyHash("salt", "new"), diff.Attributes[vgSecretHashes+".secret"].New)
+}
+
+func TestVariableGroup_CustomizeDiff_DoesNotPlanUpdateForUnchangedSecretValue(t *testing.T) {
+	diff := planSecretVariable(t, "same", "salt", tfhelper.WriteOnlyHash("salt", "same"))
+	if diff != nil {
+		require.NotContains(t, diff.Attributes, vgSecretHashes+".secret")
+	}
+}
+
+func TestVariableGroup_CustomizeDiff_SaltsUnsaltedHashes(t *testing.T) {
+	unsalted := sha256.Sum256([]byte("same"))
+	diff := planSecretVariable(t, "same", "", hex.EncodeToString(unsalted[:]))
+	require.NotNil(t, diff)
+	require.True(t, diff.Attributes[vgSecretHashSalt].NewComputed)
+	require.True(t, diff.Attributes[vgSecretHashes+".%"].NewComputed)
+}
+
+func TestVariableGroup_FlattenForDataSource_IgnoresSecretVariables(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, DataVariableGroup().Schema, nil)
+	variableGroup := &taskagent.VariableGroup{
+		Id:        converter.Int(100),
+		Name:      converter.String("Name"),
+		Variables: &map[string]interface{}{"var": map[string]interface{}{"value": "value", "isSecret": false}},
+	}
+	projectID := uuid.New().String()
+	require.Nil(t, flattenVariableGroup(d, variableGroup, &projectID))
+	require.Len(t, d.Get(vgVariable).(*schema.Set).List(), 1)
+}
+
+func TestVariableGroup_ExpandProjectReferences_SharesWithProjects(t *testing.T) {
+	projectID := uuid.New()
+	sharedProjectID := uuid.New()
+	renamedProjectID := uuid.New()
+	d := schema.TestResourceDataRaw(t, ResourceVariableGroup().Schema, map[string]interface{}{
+		vgProjectID:   projectID.String(),
+		vgName:        "Name",
+		vgDescription: "Description",
+		vgSharedProject: []interface{}{
+			map[string]interface{}{vgProjectID: sharedProjectID.String()},
+			map[string]interface{}{vgProjectID: renamedProjectID.String(), vgName: "Renamed", vgDescription: "Other"},
+		},
+	})
+
+	references, err := expandVariableGroupProjectReferences(d, projectID)
+	require.Nil(t, err)
+	require.Len(t, *references, 3)
+
+	byProject := map[uuid.UUID]taskagent.VariableGroupProjectReference{}
+	for _, reference := range *references {
+		byProject[*reference.ProjectReference.Id] = reference
+	}
+	require.Equal(t, "Name", *byProject[projectID].Name)
+	require.Equal(t, "Name", *byProject[sharedProjectID].Name)
+	require.Equal(t, "Description", *byProject[sharedProjectID].Description)
+	require.Equal(t, "Renamed", *byProject[renamedProjectID].Name)
+	require.Equal(t, "Other", *byProject[renamedProjectID].Description)
+}
+
+func TestVariableGroup_ExpandProjectReferences_RejectsOwnProject(t *testing.T) {
+	projectID := uuid.New()
+	d := schema.TestResourceDataRaw(t, ResourceVariableGroup().Schema, map[string]interface{}{
+		vgProjectID:     projectID.String(),
+		vgName:          "Name",
+		vgSharedProject: []interface{}{map[string]interface{}{vgProjectID: projectID.String()}},
+	})
+
+	_, err := expandVariableGroupProjectReferences(d, projectID)
+	require.NotNil(t, err)
+}
+
+func TestVariableGroup_FlattenSharedProjects_OmitsProjectOfVariableGroup(t *testing.T) {
+	projectID := uuid.New()
+	sharedProjectID := uuid.New()
+	renamedProjectID := uuid.New()
+	d := schema.TestResourceDataRaw(t, ResourceVariableGroup().Schema, map[string]interface{}{
+		vgProjectID: projectID.String(),
+		vgName:      "Name",
+		vgSharedProject: []interface{}{
+			map[string]interface{}{vgProjectID: sharedProjectID.String()},
+			map[string]interface{}{vgProjectID: renamedProjectID.String()},
+		},
+	})
+
+	variableGroup := &taskagent.VariableGroup{
+		Id:          converter.Int(100),
+		Name:        converter.String("Name"),
+		Description: converter.String("Description"),
+		VariableGroupProjectReferences: &[]taskagent.VariableGroupProjectReference{
+			{Name: converter.String("Name"), Description: converter.String("Description"), ProjectReference: &taskagent.ProjectReference{Id: &projectID}},
+			{Name: converter.String("Name"), Description: converter.String("Description"), ProjectReference: &taskagent.ProjectReference{Id: &sharedProjectID}},
+			{Name: converter.String("Renamed"), Description: converter.String("Description"), ProjectReference: &taskagent.ProjectReference{Id: &renamedProjectID}},
+		},
+	}
+
+	sharedProjects := flattenSharedProjects(d, variableGroup, projectID.String())
+	require.ElementsMatch(t, []interface{}{
+		map[string]interface{}{vgProjectID: sharedProjectID.String(), vgName: "", vgDescription: ""},
+		map[string]interface{}{vgProjectID: renamedProjectID.String(), vgName: "Renamed", vgDescription: ""},
+	}, sharedProjects)
+}
+
+func TestVariableGroup_FlattenSharedProjects_IgnoresUnmanagedProjects(t *testing.T) {
+	projectID := uuid.New()
+	sharedProjectID := uuid.New()
+	unmanagedProjectID := uuid.New()
+	d := schema.TestResourceDataRaw(t, ResourceVariableGroup().Schema, map[string]interface{}{
+		vgProjectID:     projectID.String(),
+		vgName:          "Name",
+		vgSharedProject: []interface{}{map[string]interface{}{vgProjectID: sharedProjectID.String()}},
+	})
+
+	variableGroup := &taskagent.VariableGroup{
+		Id:   converter.Int(100),
+		Name: converter.String("Name"),
+		VariableGroupProjectReferences: &[]taskagent.VariableGroupProjectReference{
+			{Name: converter.String("Name"), ProjectReference: &taskagent.ProjectReference{Id: &projectID}},
+			{Name: converter.String("Name"), ProjectReference: &taskagent.ProjectReference{Id: &sharedProjectID}},
+			{Name: converter.String("Name"), ProjectReference: &taskagent.ProjectReference{Id: &unmanagedProjectID}},
+		},
+	}
+
+	sharedProjects := flattenSharedProjects(d, variableGroup, projectID.String())
+	require.Equal(t, []interface{}{
+		map[string]interface{}{vgProjectID: sharedProjectID.String(), vgName: "", vgDescription: ""},
+	}, sharedProjects)
+}
+
+func TestVariableGroup_AppendUnmanagedProjectReferences_KeepsProjectsSharedOutsideOfTerraform(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	projectID := uuid.New()
+	sharedProjectID := uuid.New()
+	unmanagedProjectID := uuid.New()
+	d := schema.TestResourceDataRaw(t, ResourceVariableGroup().Schema, map[string]interface{}{
+		vgProjectID:     projectID.String(),
+		vgName:          "Name",
+		vgSharedProject: []interface{}{map[string]interface{}{vgProjectID: sharedProjectID.String()}},
+	})
+
+	taskAgentClient.
+		EXPECT().
+		GetVariableGroup(clients.Ctx, taskagent.GetVariableGroupArgs{GroupId: converter.Int(100), Project: converter.String(projectID.String())}).
+		Return(&taskagent.VariableGroup{
+			Id: converter.Int(100),
+			VariableGroupProjectReferences: &[]taskagent.VariableGroupProjectReference{
+				{Name: converter.String("Name"), ProjectReference: &taskagent.ProjectReference{Id: &projectID}},
+				{Name: converter.String("Name"), ProjectReference: &taskagent.ProjectReference{Id: &sharedProjectID}},
+				{Name: converter.String("Other"), ProjectReference: &taskagent.ProjectReference{Id: &unmanagedProjectID}},
+			},
+		}, nil).
+		Times(1)
+
+	references, err := expandVariableGroupProjectReferences(d, projectID)
+	require.Nil(t, err)
+	references, err = appendUnmanagedProjectReferences(clients, d, projectID.String(), 100, references)
+	require.Nil(t, err)
+
+	projectIDs := []uuid.UUID{}
+	for _, reference := range *references {
+		projectIDs = append(projectIDs, *reference.ProjectReference.Id)
+	}
+	require.ElementsMatch(t, []uuid.UUID{projectID, sharedProjectID, unmanagedProjectID}, projectIDs)
+}
+
+func TestVariableGroup_Delete_DeletesFromSharedProjects(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, BuildClient: buildClient, Ctx: context.Background()}
+
+	projectID := uuid.New().String()
+	sharedProjectID := uuid.New().String()
+	d := schema.TestResourceDataRaw(t, ResourceVariableGroup().Schema, map[string]interface{}{
+		vgProjectID:     projectID,
+		vgName:          "Name",
+		vgSharedProject: []interface{}{map[string]interface{}{vgProjectID: sharedProjectID}},
+	})
+	d.SetId("100")
+
+	buildClient.
+		EXPECT().
+		AuthorizeProjectResources(clients.Ctx, gomock.Any()).
+		Return(nil, nil).
+		Times(1)
+	taskAgentClient.
+		EXPECT().
+		DeleteVariableGroup(clients.Ctx, taskagent.DeleteVariableGroupArgs{
+			ProjectIds: &[]string{projectID, sharedProjectID},
+			GroupId:    converter.Int(100),
+		}).
+		Return(nil).
+		Times(1)
+
+	require.Nil(t, resourceVariableGroupDelete(d, clients))
+}
@@ -0,0 +1,237 @@
+package tokens
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/tokens"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &agentRegistrationTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &agentRegistrationTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &agentRegistrationTokenEphemeralResource{}
+)
+
+// agentRegistrationScopes are the scopes agents need to register with a pool of a pool type
+var agentRegistrationScopes = map[taskagent.TaskAgentPoolType]string{
+	taskagent.TaskAgentPoolTypeValues.Automation: "vso.agentpools_manage",
+	taskagent.TaskAgentPoolTypeValues.Deployment: "vso.machinegroup_manage",
+}
+
+// NewAgentRegistrationTokenEphemeralResource creates a short-lived personal access token scoped to register
+// agents with an agent pool. The token expires after its validity, agents configured after the Terraform run
+// still need it, it is therefore only revoked once Terraform is done with it when requested.
+func NewAgentRegistrationTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &agentRegistrationTokenEphemeralResource{}
+}
+
+type agentRegistrationTokenEphemeralResource struct {
+	clients *client.AggregatedClient
+}
+
+type agentRegistrationTokenModel struct {
+	PoolId          types.Int64  `tfsdk:"pool_id"`
+	ValidForMinutes types.Int64  `tfsdk:"valid_for_minutes"`
+	RevokeOnClose   types.Bool   `tfsdk:"revoke_on_close"`
+	PoolName        types.String `tfsdk:"pool_name"`
+	PoolType        types.String `tfsdk:"pool_type"`
+	OrganizationUrl types.String `tfsdk:"organization_url"`
+	AuthorizationId types.String `tfsdk:"authorization_id"`
+	Token           types.String `tfsdk:"token"`
+	ValidTo         types.String `tfsdk:"valid_to"`
+	ConfigArguments types.String `tfsdk:"config_arguments"`
+}
+
+func (r *agentRegistrationTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent_registration_token"
+}
+
+func (r *agentRegistrationTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a short-lived token to register agents with an agent pool, the token expires after its validity.",
+		Attributes: map[string]schema.Attribute{
+			"pool_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "The ID of the agent pool the agents register with.",
+			},
+			"valid_for_minutes": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of minutes the token is valid for. Defaults to `60`.",
+			},
+			"revoke_on_close": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Revoke the token once Terraform is done with it instead of letting it expire. Defaults to `false`.",
+			},
+			"pool_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the agent pool.",
+			},
+			"pool_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The type of the agent pool, `automation` or `deployment`.",
+			},
+			"organization_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "The URL of the organization the agents connect to.",
+			},
+			"authorization_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The authorization ID of the token.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token to register agents with.",
+			},
+			"valid_to": schema.StringAttribute{
+				Computed:    true,
+				Description: "The expiration date of the token in RFC3339 format.",
+			},
+			"config_arguments": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The arguments of the agent configuration script to register an agent unattended with the pool.",
+			},
+		},
+	}
+}
+
+func (r *agentRegistrationTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*client.AggregatedClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *client.AggregatedClient, got %T", req.ProviderData))
+		return
+	}
+	r.clients = clients
+}
+
+func (r *agentRegistrationTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model agentRegistrationTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validFor := personalAccessTokenDefaultValidity
+	if !model.ValidForMinutes.IsNull() {
+		if model.ValidForMinutes.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("valid_for_minutes"), "Invalid validity", "valid_for_minutes must be at least 1")
+			return
+		}
+		validFor = time.Duration(model.ValidForMinutes.ValueInt64()) * time.Minute
+	}
+
+	pool, err := getRegistrationAgentPool(ctx, r.clients, int(model.PoolId.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("pool_id"), "Looking up agent pool", err.Error())
+		return
+	}
+	poolName := converter.ToString(pool.Name, "")
+	poolType := *pool.PoolType
+
+	token, err := createPersonalAccessToken(ctx, r.clients, &tokens.PatTokenCreateRequest{
+		AllOrgs:     converter.Bool(false),
+		DisplayName: converter.String(fmt.Sprintf("Agent registration for pool %s", poolName)),
+		Scope:       converter.String(agentRegistrationScopes[poolType]),
+		ValidTo:     &azuredevops.Time{Time: time.Now().Add(validFor).UTC()},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Creating agent registration token", err.Error())
+		return
+	}
+
+	model.PoolName = types.StringValue(poolName)
+	model.PoolType = types.StringValue(string(poolType))
+	model.OrganizationUrl = types.StringValue(r.clients.OrganizationURL)
+	model.AuthorizationId = types.StringValue(token.AuthorizationId.String())
+	model.Token = types.StringValue(converter.ToString(token.Token, ""))
+	model.ConfigArguments = types.StringValue(agentConfigArguments(r.clients.OrganizationURL, poolName, poolType, model.Token.ValueString()))
+	if token.ValidTo != nil {
+		model.ValidTo = types.StringValue(token.ValidTo.Time.Format(time.RFC3339))
+	} else {
+		model.ValidTo = types.StringNull()
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+
+	// agents configured after the Terraform run, e.g. by cloud-init, still need the token
+	if !model.RevokeOnClose.ValueBool() {
+		return
+	}
+
+	// private state values have to be valid JSON
+	privateValue, _ := json.Marshal(token.AuthorizationId.String())
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, personalAccessTokenPrivateKey, privateValue)...)
+}
+
+func (r *agentRegistrationTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateValue, diags := req.Private.GetKey(ctx, personalAccessTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(privateValue) == 0 {
+		return
+	}
+
+	var authorizationId string
+	if err := json.Unmarshal(privateValue, &authorizationId); err != nil {
+		resp.Diagnostics.AddError("Revoking agent registration token", fmt.Sprintf(" reading the authorization ID: %+v", err))
+		return
+	}
+
+	if err := revokePersonalAccessToken(ctx, r.clients, authorizationId); err != nil {
+		resp.Diagnostics.AddError("Revoking agent registration token", err.Error())
+	}
+}
+
+// getRegistrationAgentPool looks up the agent pool agents register with, hosted pools do not accept agents
+func getRegistrationAgentPool(ctx context.Context, clients *client.AggregatedClient, poolID int) (*taskagent.TaskAgentPool, error) {
+	if clients == nil {
+		return nil, fmt.Errorf(" the provider has not been configured")
+	}
+
+	pool, err := clients.TaskAgentClient.GetAgentPool(ctx, taskagent.GetAgentPoolArgs{PoolId: &poolID})
+	if err != nil {
+		return nil, fmt.Errorf(" looking up Agent Pool with ID %d. Error: %v", poolID, err)
+	}
+	if pool == nil || pool.PoolType == nil {
+		return nil, fmt.Errorf(" Agent Pool with ID %d was not found", poolID)
+	}
+	if converter.ToBool(pool.IsHosted, false) {
+		return nil, fmt.Errorf(" Agent Pool with ID %d is hosted by Azure DevOps, agents cannot be registered with it", poolID)
+	}
+	if _, ok := agentRegistrationScopes[*pool.PoolType]; !ok {
+		return nil, fmt.Errorf(" agents cannot be registered with Agent Pools of type %s", *pool.PoolType)
+	}
+	return pool, nil
+}
+
+// agentConfigArguments returns the arguments of config.sh to register an agent unattended, quoted for POSIX shells
+func agentConfigArguments(organizationURL string, poolName string, poolType taskagent.TaskAgentPoolType, token string) string {
+	arguments := []string{
+		"--unattended",
+		"--url", utils.ShellQuote(organizationURL),
+		"--auth", "pat",
+		"--token", utils.ShellQuote(token),
+	}
+	if poolType == taskagent.TaskAgentPoolTypeValues.Deployment {
+		arguments = append(arguments, "--deploymentpool", "--deploymentpoolname", utils.ShellQuote(poolName))
+	} else {
+		arguments = append(arguments, "--pool", utils.ShellQuote(poolName))
+	}
+	return strings.Join(arguments, " ")
+}
@@ -0,0 +1,79 @@
+//go:build (all || ephemeral_agent_registration_token) && !exclude_tokens
+// +build all ephemeral_agent_registration_token
+// +build !exclude_tokens
+
+package tokens
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var testRegistrationPoolID = 42
+
+func TestAgentRegistrationToken_GetPool_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentPool(clients.Ctx, taskagent.GetAgentPoolArgs{PoolId: &testRegistrationPoolID}).
+		Return(nil, errors.New("GetAgentPool() Failed")).
+		Times(1)
+
+	_, err := getRegistrationAgentPool(clients.Ctx, clients, testRegistrationPoolID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "GetAgentPool() Failed")
+}
+
+func TestAgentRegistrationToken_GetPool_RejectsHostedPools(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClient := azdosdkmocks.NewMockTaskagentClient(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClient: taskAgentClient, Ctx: context.Background()}
+
+	taskAgentClient.
+		EXPECT().
+		GetAgentPool(clients.Ctx, gomock.Any()).
+		Return(&taskagent.TaskAgentPool{
+			Id:       &testRegistrationPoolID,
+			Name:     converter.String("Azure Pipelines"),
+			PoolType: &taskagent.TaskAgentPoolTypeValues.Automation,
+			IsHosted: converter.Bool(true),
+		}, nil).
+		Times(1)
+
+	_, err := getRegistrationAgentPool(clients.Ctx, clients, testRegistrationPoolID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "hosted")
+}
+
+func TestAgentRegistrationToken_ConfigArguments_DependOnPoolType(t *testing.T) {
+	arguments := agentConfigArguments("https://dev.azure.com/org", "Self Hosted", taskagent.TaskAgentPoolTypeValues.Automation, "secret")
+	require.Equal(t, `--unattended --url 'https://dev.azure.com/org' --auth pat --token 'secret' --pool 'Self Hosted'`, arguments)
+
+	arguments = agentConfigArguments("https://dev.azure.com/org", "Deploy", taskagent.TaskAgentPoolTypeValues.Deployment, "secret")
+	require.Equal(t, `--unattended --url 'https://dev.azure.com/org' --auth pat --token 'secret' --deploymentpool --deploymentpoolname 'Deploy'`, arguments)
+}
+
+func TestAgentRegistrationToken_ConfigArguments_QuotesForPosixShells(t *testing.T) {
+	arguments := agentConfigArguments("https://dev.azure.com/org", `Bob's "$(pool)"`, taskagent.TaskAgentPoolTypeValues.Automation, "secret")
+	require.Equal(t, `--unattended --url 'https://dev.azure.com/org' --auth pat --token 'secret' --pool 'Bob'\''s "$(pool)"'`, arguments)
+}
+
+func TestAgentRegistrationToken_Scopes_CoverRegistrablePoolTypes(t *testing.T) {
+	require.Equal(t, "vso.agentpools_manage", agentRegistrationScopes[taskagent.TaskAgentPoolTypeValues.Automation])
+	require.Equal(t, "vso.machinegroup_manage", agentRegistrationScopes[taskagent.TaskAgentPoolTypeValues.Deployment])
+}
@@ -0,0 +1,199 @@
+package tokens
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/tokens"
+)
+
+const (
+	personalAccessTokenDefaultValidity = time.Hour
+	personalAccessTokenPrivateKey      = "authorization_id"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &personalAccessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &personalAccessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &personalAccessTokenEphemeralResource{}
+)
+
+// NewPersonalAccessTokenEphemeralResource creates a short-lived personal access token, which is revoked
+// as soon as Terraform does not need it anymore
+func NewPersonalAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &personalAccessTokenEphemeralResource{}
+}
+
+type personalAccessTokenEphemeralResource struct {
+	clients *client.AggregatedClient
+}
+
+type personalAccessTokenModel struct {
+	DisplayName     types.String `tfsdk:"display_name"`
+	Scopes          types.List   `tfsdk:"scopes"`
+	ValidForMinutes types.Int64  `tfsdk:"valid_for_minutes"`
+	AllOrgs         types.Bool   `tfsdk:"all_orgs"`
+	AuthorizationId types.String `tfsdk:"authorization_id"`
+	Token           types.String `tfsdk:"token"`
+	ValidTo         types.String `tfsdk:"valid_to"`
+}
+
+func (r *personalAccessTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_personal_access_token"
+}
+
+func (r *personalAccessTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a short-lived personal access token of the authenticated identity, the token is revoked once Terraform is done with it.",
+		Attributes: map[string]schema.Attribute{
+			"display_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The display name of the personal access token.",
+			},
+			"scopes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "The scopes granted to the personal access token, e.g. `vso.agentpools`.",
+			},
+			"valid_for_minutes": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of minutes the personal access token is valid for. Defaults to `60`.",
+			},
+			"all_orgs": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether the personal access token is valid for all organizations accessible by the identity. Defaults to `false`.",
+			},
+			"authorization_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The authorization ID of the personal access token.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The personal access token.",
+			},
+			"valid_to": schema.StringAttribute{
+				Computed:    true,
+				Description: "The expiration date of the personal access token in RFC3339 format.",
+			},
+		},
+	}
+}
+
+func (r *personalAccessTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*client.AggregatedClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *client.AggregatedClient, got %T", req.ProviderData))
+		return
+	}
+	r.clients = clients
+}
+
+func (r *personalAccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model personalAccessTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var scopes []string
+	resp.Diagnostics.Append(model.Scopes.ElementsAs(ctx, &scopes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validFor := personalAccessTokenDefaultValidity
+	if !model.ValidForMinutes.IsNull() {
+		if model.ValidForMinutes.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("valid_for_minutes"), "Invalid validity", "valid_for_minutes must be at least 1")
+			return
+		}
+		validFor = time.Duration(model.ValidForMinutes.ValueInt64()) * time.Minute
+	}
+
+	token, err := createPersonalAccessToken(ctx, r.clients, &tokens.PatTokenCreateRequest{
+		AllOrgs:     converter.Bool(model.AllOrgs.ValueBool()),
+		DisplayName: converter.String(model.DisplayName.ValueString()),
+		Scope:       converter.String(strings.Join(scopes, " ")),
+		ValidTo:     &azuredevops.Time{Time: time.Now().Add(validFor).UTC()},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Creating personal access token", err.Error())
+		return
+	}
+
+	model.AuthorizationId = types.StringValue(token.AuthorizationId.String())
+	model.Token = types.StringValue(converter.ToString(token.Token, ""))
+	if token.ValidTo != nil {
+		model.ValidTo = types.StringValue(token.ValidTo.Time.Format(time.RFC3339))
+	} else {
+		model.ValidTo = types.StringNull()
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+
+	// private state values have to be valid JSON
+	privateValue, _ := json.Marshal(token.AuthorizationId.String())
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, personalAccessTokenPrivateKey, privateValue)...)
+}
+
+func (r *personalAccessTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateValue, diags := req.Private.GetKey(ctx, personalAccessTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(privateValue) == 0 {
+		return
+	}
+
+	var authorizationId string
+	if err := json.Unmarshal(privateValue, &authorizationId); err != nil {
+		resp.Diagnostics.AddError("Revoking personal access token", fmt.Sprintf(" reading the authorization ID: %+v", err))
+		return
+	}
+
+	if err := revokePersonalAccessToken(ctx, r.clients, authorizationId); err != nil {
+		resp.Diagnostics.AddError("Revoking personal access token", err.Error())
+	}
+}
+
+func createPersonalAccessToken(ctx context.Context, clients *client.AggregatedClient, request *tokens.PatTokenCreateRequest) (*tokens.PatToken, error) {
+	if clients == nil {
+		return nil, fmt.Errorf(" the provider has not been configured")
+	}
+
+	result, err := clients.TokensClient.CreatePersonalAccessToken(ctx, tokens.CreatePersonalAccessTokenArgs{
+		Token: request,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tokenError := converter.ToString(result.PatTokenError, "none"); !strings.EqualFold(tokenError, "none") {
+		return nil, fmt.Errorf(" the personal access token could not be created: %s", tokenError)
+	}
+	if result.PatToken == nil || result.PatToken.AuthorizationId == nil {
+		return nil, fmt.Errorf(" the service did not return a personal access token")
+	}
+	return result.PatToken, nil
+}
+
+func revokePersonalAccessToken(ctx context.Context, clients *client.AggregatedClient, authorizationId string) error {
+	if clients == nil {
+		return fmt.Errorf(" the provider has not been configured")
+	}
+
+	return clients.TokensClient.RevokePersonalAccessToken(ctx, tokens.RevokePersonalAccessTokenArgs{
+		AuthorizationId: converter.String(authorizationId),
+	})
+}
@@ -0,0 +1,106 @@
+//go:build (all || ephemeral_personal_access_token) && !exclude_tokens
+// +build all ephemeral_personal_access_token
+// +build !exclude_tokens
+
+package tokens
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/tokens"
+	"github.com/stretchr/testify/require"
+)
+
+var testPatRequest = &tokens.PatTokenCreateRequest{
+	DisplayName: converter.String("agent-bootstrap"),
+	Scope:       converter.String("vso.agentpools_manage"),
+}
+
+// verifies that an error is produced if the service refuses to issue a token
+func TestPersonalAccessToken_Create_ReturnsTokenError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokensClient := azdosdkmocks.NewMockTokensClient(ctrl)
+	clients := &client.AggregatedClient{TokensClient: tokensClient, Ctx: context.Background()}
+
+	tokensClient.
+		EXPECT().
+		CreatePersonalAccessToken(clients.Ctx, tokens.CreatePersonalAccessTokenArgs{Token: testPatRequest}).
+		Return(&tokens.PatTokenResult{PatTokenError: converter.String("invalidScope")}, nil).
+		Times(1)
+
+	token, err := createPersonalAccessToken(clients.Ctx, clients, testPatRequest)
+	require.Nil(t, token)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalidScope")
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestPersonalAccessToken_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokensClient := azdosdkmocks.NewMockTokensClient(ctrl)
+	clients := &client.AggregatedClient{TokensClient: tokensClient, Ctx: context.Background()}
+
+	tokensClient.
+		EXPECT().
+		CreatePersonalAccessToken(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreatePersonalAccessToken() Failed")).
+		Times(1)
+
+	_, err := createPersonalAccessToken(clients.Ctx, clients, testPatRequest)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "CreatePersonalAccessToken() Failed")
+}
+
+func TestPersonalAccessToken_Create_ReturnsToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokensClient := azdosdkmocks.NewMockTokensClient(ctrl)
+	clients := &client.AggregatedClient{TokensClient: tokensClient, Ctx: context.Background()}
+
+	authorizationId := uuid.New()
+	tokensClient.
+		EXPECT().
+		CreatePersonalAccessToken(clients.Ctx, gomock.Any()).
+		Return(&tokens.PatTokenResult{
+			PatToken: &tokens.PatToken{
+				AuthorizationId: &authorizationId,
+				Token:           converter.String("secret"),
+			},
+			PatTokenError: converter.String("none"),
+		}, nil).
+		Times(1)
+
+	token, err := createPersonalAccessToken(clients.Ctx, clients, testPatRequest)
+	require.NoError(t, err)
+	require.Equal(t, authorizationId, *token.AuthorizationId)
+	require.Equal(t, "secret", *token.Token)
+}
+
+func TestPersonalAccessToken_Revoke_UsesAuthorizationId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokensClient := azdosdkmocks.NewMockTokensClient(ctrl)
+	clients := &client.AggregatedClient{TokensClient: tokensClient, Ctx: context.Background()}
+
+	authorizationId := uuid.New().String()
+	tokensClient.
+		EXPECT().
+		RevokePersonalAccessToken(clients.Ctx, tokens.RevokePersonalAccessTokenArgs{AuthorizationId: &authorizationId}).
+		Return(nil).
+		Times(1)
+
+	require.NoError(t, revokePersonalAccessToken(clients.Ctx, clients, authorizationId))
+}
@@ -0,0 +1,427 @@
+package workitemtracking
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/workitemtrackingprocess"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// portfolioBacklogBehavior is the behavior new portfolio backlog levels inherit from
+const portfolioBacklogBehavior = "System.PortfolioBacklogBehavior"
+
+var hexColorRegexp = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+// ResourceProcessBacklogLevel schema and implementation for a backlog level of an inherited process. New
+// portfolio backlog levels are created, the existing backlog levels of the process are renamed.
+func ResourceProcessBacklogLevel() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProcessBacklogLevelCreate,
+		Read:   resourceProcessBacklogLevelRead,
+		Update: resourceProcessBacklogLevelUpdate,
+		Delete: resourceProcessBacklogLevelDelete,
+		Importer: &schema.ResourceImporter{
+			State: importProcessBacklogLevel,
+		},
+		Schema: map[string]*schema.Schema{
+			"process_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"reference_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"color": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringMatch(hexColorRegexp, "the color must be a hexadecimal RGB color without a leading #, e.g. 009CCC"),
+			},
+			"inherits": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"work_item_types": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"managed_work_item_types": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"default_work_item_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"customization": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rank": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceProcessBacklogLevelCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	processID := uuid.MustParse(d.Get("process_id").(string))
+	referenceName := d.Get("reference_name").(string)
+
+	// an existing backlog level of the process, e.g. one inherited from the parent process, is renamed
+	var existing *workitemtrackingprocess.ProcessBehavior
+	var assignedTypes []string
+	var assignedDefault string
+	if referenceName != "" {
+		behavior, err := clients.WorkItemTrackingProcessClient.GetProcessBehavior(clients.Ctx, workitemtrackingprocess.GetProcessBehaviorArgs{
+			ProcessId:       &processID,
+			BehaviorRefName: &referenceName,
+		})
+		if err != nil && !utils.ResponseWasNotFound(err) {
+			return fmt.Errorf(" looking up backlog level %s of process %s: %w", referenceName, processID, err)
+		}
+		existing = behavior
+	}
+
+	if existing != nil {
+		if err := updateProcessBacklogLevel(clients, processID, referenceName, d); err != nil {
+			return err
+		}
+		// work item types already assigned to the adopted level are kept as they are
+		if d.Get("work_item_types").(*schema.Set).Len() > 0 {
+			var err error
+			if assignedTypes, assignedDefault, err = getBacklogLevelWorkItemTypes(clients, processID, referenceName); err != nil {
+				return err
+			}
+		}
+	} else {
+		inherits := d.Get("inherits").(string)
+		if inherits == "" {
+			inherits = portfolioBacklogBehavior
+		}
+		request := &workitemtrackingprocess.ProcessBehaviorCreateRequest{
+			Name:     converter.String(d.Get("name").(string)),
+			Inherits: &inherits,
+		}
+		if color := d.Get("color").(string); color != "" {
+			request.Color = &color
+		}
+		if referenceName != "" {
+			request.ReferenceName = &referenceName
+		}
+		behavior, err := clients.WorkItemTrackingProcessClient.CreateProcessBehavior(clients.Ctx, workitemtrackingprocess.CreateProcessBehaviorArgs{
+			ProcessId: &processID,
+			Behavior:  request,
+		})
+		if err != nil {
+			return fmt.Errorf(" creating backlog level %s of process %s: %w", d.Get("name").(string), processID, err)
+		}
+		referenceName = *behavior.ReferenceName
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", processID, referenceName))
+	if err := updateBacklogLevelWorkItemTypes(clients, processID, referenceName, nil, assignedTypes, assignedDefault, d); err != nil {
+		return err
+	}
+	return resourceProcessBacklogLevelRead(d, m)
+}
+
+func resourceProcessBacklogLevelRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	processID, referenceName, err := parseProcessBacklogLevelID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	behavior, err := clients.WorkItemTrackingProcessClient.GetProcessBehavior(clients.Ctx, workitemtrackingprocess.GetProcessBehaviorArgs{
+		ProcessId:       &processID,
+		BehaviorRefName: &referenceName,
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(" reading backlog level %s of process %s: %w", referenceName, processID, err)
+	}
+
+	workItemTypes, defaultWorkItemType, err := getBacklogLevelWorkItemTypes(clients, processID, referenceName)
+	if err != nil {
+		return err
+	}
+
+	d.Set("process_id", processID.String())
+	d.Set("reference_name", behavior.ReferenceName)
+	d.Set("name", behavior.Name)
+	d.Set("color", behavior.Color)
+	d.Set("rank", behavior.Rank)
+	if behavior.Customization != nil {
+		d.Set("customization", string(*behavior.Customization))
+	}
+	if behavior.Inherits != nil && behavior.Inherits.BehaviorRefName != nil {
+		d.Set("inherits", *behavior.Inherits.BehaviorRefName)
+	}
+	d.Set("default_work_item_type", defaultWorkItemType)
+
+	// work item types Terraform assigned which were removed from the level outside of Terraform are not managed anymore
+	managed := []interface{}{}
+	for _, workItemType := range workItemTypes {
+		if d.Get("managed_work_item_types").(*schema.Set).Contains(workItemType) {
+			managed = append(managed, workItemType)
+		}
+	}
+	d.Set("managed_work_item_types", managed)
+	d.Set("work_item_types", filterBacklogLevelWorkItemTypes(workItemTypes, d.Get("work_item_types").(*schema.Set), managed))
+	return nil
+}
+
+// filterBacklogLevelWorkItemTypes returns the work item types of the backlog level which are configured or managed,
+// so that work item types the level had before it was adopted do not show up as a diff. All work item types are
+// returned when none are known yet, e.g. on import.
+func filterBacklogLevelWorkItemTypes(workItemTypes []string, known *schema.Set, managed []interface{}) []string {
+	if known.Len() == 0 {
+		return workItemTypes
+	}
+	keep := map[string]bool{}
+	for _, workItemType := range append(known.List(), managed...) {
+		keep[strings.ToLower(workItemType.(string))] = true
+	}
+	filtered := []string{}
+	for _, workItemType := range workItemTypes {
+		if keep[strings.ToLower(workItemType)] {
+			filtered = append(filtered, workItemType)
+		}
+	}
+	return filtered
+}
+
+func resourceProcessBacklogLevelUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	processID, referenceName, err := parseProcessBacklogLevelID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChanges("name", "color") {
+		if err := updateProcessBacklogLevel(clients, processID, referenceName, d); err != nil {
+			return err
+		}
+	}
+	if d.HasChanges("work_item_types", "default_work_item_type") {
+		oldTypes, _ := d.GetChange("work_item_types")
+		// the state only holds the configured work item types, the level may have others
+		assignedTypes, assignedDefault, err := getBacklogLevelWorkItemTypes(clients, processID, referenceName)
+		if err != nil {
+			return err
+		}
+		if err := updateBacklogLevelWorkItemTypes(clients, processID, referenceName, oldTypes.(*schema.Set), assignedTypes, assignedDefault, d); err != nil {
+			return err
+		}
+	}
+	return resourceProcessBacklogLevelRead(d, m)
+}
+
+func resourceProcessBacklogLevelDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	processID, referenceName, err := parseProcessBacklogLevelID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// only the work item types Terraform assigned are removed, the level may be inherited with its own types
+	for _, workItemType := range tfhelper.ExpandStringSet(d.Get("managed_work_item_types").(*schema.Set)) {
+		err := clients.WorkItemTrackingProcessClient.RemoveBehaviorFromWorkItemType(clients.Ctx, workitemtrackingprocess.RemoveBehaviorFromWorkItemTypeArgs{
+			ProcessId:              &processID,
+			WitRefNameForBehaviors: &workItemType,
+			BehaviorRefName:        &referenceName,
+		})
+		if err != nil && !utils.ResponseWasNotFound(err) {
+			return fmt.Errorf(" removing work item type %s from backlog level %s: %w", workItemType, referenceName, err)
+		}
+	}
+
+	// backlog levels inherited from the parent process cannot be deleted, they keep their name
+	if d.Get("customization").(string) == string(workitemtrackingprocess.CustomizationTypeValues.Custom) {
+		err := clients.WorkItemTrackingProcessClient.DeleteProcessBehavior(clients.Ctx, workitemtrackingprocess.DeleteProcessBehaviorArgs{
+			ProcessId:       &processID,
+			BehaviorRefName: &referenceName,
+		})
+		if err != nil && !utils.ResponseWasNotFound(err) {
+			return fmt.Errorf(" deleting backlog level %s of process %s: %w", referenceName, processID, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func importProcessBacklogLevel(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseProcessBacklogLevelID(d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func parseProcessBacklogLevelID(id string) (uuid.UUID, string, error) {
+	processID, referenceName, ok := strings.Cut(id, "/")
+	if !ok || referenceName == "" {
+		return uuid.Nil, "", fmt.Errorf(" the ID of a backlog level must have the format <process ID>/<reference name>, got %s", id)
+	}
+	parsedProcessID, err := uuid.Parse(processID)
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf(" parsing the process ID %s: %w", processID, err)
+	}
+	return parsedProcessID, referenceName, nil
+}
+
+func updateProcessBacklogLevel(clients *client.AggregatedClient, processID uuid.UUID, referenceName string, d *schema.ResourceData) error {
+	request := &workitemtrackingprocess.ProcessBehaviorUpdateRequest{
+		Name: converter.String(d.Get("name").(string)),
+	}
+	if color := d.Get("color").(string); color != "" {
+		request.Color = &color
+	}
+	_, err := clients.WorkItemTrackingProcessClient.UpdateProcessBehavior(clients.Ctx, workitemtrackingprocess.UpdateProcessBehaviorArgs{
+		ProcessId:       &processID,
+		BehaviorRefName: &referenceName,
+		BehaviorData:    request,
+	})
+	if err != nil {
+		return fmt.Errorf(" updating backlog level %s of process %s: %w", referenceName, processID, err)
+	}
+	return nil
+}
+
+// updateBacklogLevelWorkItemTypes assigns the configured work item types to the backlog level and removes the
+// work item types Terraform assigned which are no longer configured. Work item types already assigned to the level are only
+// updated when they become or stop being the default work item type. The work item types Terraform assigned
+// are recorded, so that only those are removed when the backlog level is destroyed.
+func updateBacklogLevelWorkItemTypes(clients *client.AggregatedClient, processID uuid.UUID, referenceName string, oldTypes *schema.Set, assignedTypes []string, assignedDefault string, d *schema.ResourceData) error {
+	newTypes := d.Get("work_item_types").(*schema.Set)
+	defaultType := d.Get("default_work_item_type").(string)
+	managed := d.Get("managed_work_item_types").(*schema.Set)
+	assigned := map[string]bool{}
+	for _, workItemType := range assignedTypes {
+		assigned[strings.ToLower(workItemType)] = true
+	}
+
+	if oldTypes != nil {
+		for _, workItemType := range tfhelper.ExpandStringSet(oldTypes.Difference(newTypes)) {
+			// work item types the level had before it was adopted are left alone
+			if !managed.Contains(workItemType) {
+				continue
+			}
+			err := clients.WorkItemTrackingProcessClient.RemoveBehaviorFromWorkItemType(clients.Ctx, workitemtrackingprocess.RemoveBehaviorFromWorkItemTypeArgs{
+				ProcessId:              &processID,
+				WitRefNameForBehaviors: &workItemType,
+				BehaviorRefName:        &referenceName,
+			})
+			if err != nil && !utils.ResponseWasNotFound(err) {
+				return fmt.Errorf(" removing work item type %s from backlog level %s: %w", workItemType, referenceName, err)
+			}
+			managed.Remove(workItemType)
+		}
+	}
+
+	for _, workItemType := range tfhelper.ExpandStringSet(newTypes) {
+		isDefault := strings.EqualFold(workItemType, defaultType)
+		behavior := &workitemtrackingprocess.WorkItemTypeBehavior{
+			Behavior:  &workitemtrackingprocess.WorkItemBehaviorReference{Id: &referenceName},
+			IsDefault: converter.Bool(isDefault),
+		}
+		var err error
+		if assigned[strings.ToLower(workItemType)] {
+			// the default work item type is kept when none is configured
+			if defaultType == "" || isDefault == strings.EqualFold(workItemType, assignedDefault) {
+				continue
+			}
+			_, err = clients.WorkItemTrackingProcessClient.UpdateBehaviorToWorkItemType(clients.Ctx, workitemtrackingprocess.UpdateBehaviorToWorkItemTypeArgs{
+				ProcessId:              &processID,
+				WitRefNameForBehaviors: &workItemType,
+				Behavior:               behavior,
+			})
+		} else {
+			_, err = clients.WorkItemTrackingProcessClient.AddBehaviorToWorkItemType(clients.Ctx, workitemtrackingprocess.AddBehaviorToWorkItemTypeArgs{
+				ProcessId:              &processID,
+				WitRefNameForBehaviors: &workItemType,
+				Behavior:               behavior,
+			})
+			if err == nil {
+				managed.Add(workItemType)
+			}
+		}
+		if err != nil {
+			d.Set("managed_work_item_types", managed.List())
+			return fmt.Errorf(" assigning work item type %s to backlog level %s: %w", workItemType, referenceName, err)
+		}
+	}
+	d.Set("managed_work_item_types", managed.List())
+	return nil
+}
+
+// getBacklogLevelWorkItemTypes returns the work item types of the process assigned to the backlog level and
+// the default work item type of the backlog level
+func getBacklogLevelWorkItemTypes(clients *client.AggregatedClient, processID uuid.UUID, referenceName string) ([]string, string, error) {
+	workItemTypes, err := clients.WorkItemTrackingProcessClient.GetProcessWorkItemTypes(clients.Ctx, workitemtrackingprocess.GetProcessWorkItemTypesArgs{
+		ProcessId: &processID,
+		Expand:    &workitemtrackingprocess.GetWorkItemTypeExpandValues.Behaviors,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf(" reading work item types of process %s: %w", processID, err)
+	}
+
+	assigned := []string{}
+	defaultType := ""
+	if workItemTypes == nil {
+		return assigned, defaultType, nil
+	}
+	for _, workItemType := range *workItemTypes {
+		if workItemType.ReferenceName == nil || workItemType.Behaviors == nil {
+			continue
+		}
+		for _, behavior := range *workItemType.Behaviors {
+			if behavior.Behavior == nil || behavior.Behavior.Id == nil || *behavior.Behavior.Id != referenceName {
+				continue
+			}
+			assigned = append(assigned, *workItemType.ReferenceName)
+			if converter.ToBool(behavior.IsDefault, false) {
+				defaultType = *workItemType.ReferenceName
+			}
+		}
+	}
+	return assigned, defaultType, nil
+}
@@ -0,0 +1,377 @@
+//go:build (all || resource_process_backlog_level) && !exclude_resource_process_backlog_level
+// +build all resource_process_backlog_level
+// +build !exclude_resource_process_backlog_level
+
+package workitemtracking
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/workitemtrackingprocess"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var testBacklogProcessID = uuid.New()
+
+func testBacklogLevelWorkItemTypes(referenceName string, defaultType string, types ...string) *[]workitemtrackingprocess.ProcessWorkItemType {
+	workItemTypes := []workitemtrackingprocess.ProcessWorkItemType{
+		{
+			ReferenceName: converter.String("Microsoft.VSTS.WorkItemTypes.Bug"),
+			Behaviors:     &[]workitemtrackingprocess.WorkItemTypeBehavior{},
+		},
+	}
+	for _, workItemType := range types {
+		workItemTypes = append(workItemTypes, workitemtrackingprocess.ProcessWorkItemType{
+			ReferenceName: converter.String(workItemType),
+			Behaviors: &[]workitemtrackingprocess.WorkItemTypeBehavior{
+				{
+					Behavior:  &workitemtrackingprocess.WorkItemBehaviorReference{Id: &referenceName},
+					IsDefault: converter.Bool(workItemType == defaultType),
+				},
+			},
+		})
+	}
+	return &workItemTypes
+}
+
+// verifies that a new portfolio backlog level is created and the work item types are assigned to it
+func TestProcessBacklogLevel_Create_CreatesPortfolioLevel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	processClient := azdosdkmocks.NewMockWorkitemtrackingprocessClient(ctrl)
+	clients := &client.AggregatedClient{WorkItemTrackingProcessClient: processClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceProcessBacklogLevel().Schema, nil)
+	resourceData.Set("process_id", testBacklogProcessID.String())
+	resourceData.Set("name", "Initiatives")
+	resourceData.Set("work_item_types", []interface{}{"Custom.Initiative"})
+	resourceData.Set("default_work_item_type", "Custom.Initiative")
+
+	referenceName := "Custom.InitiativeBehavior"
+	processClient.
+		EXPECT().
+		CreateProcessBehavior(clients.Ctx, workitemtrackingprocess.CreateProcessBehaviorArgs{
+			ProcessId: &testBacklogProcessID,
+			Behavior: &workitemtrackingprocess.ProcessBehaviorCreateRequest{
+				Name:     converter.String("Initiatives"),
+				Inherits: converter.String(portfolioBacklogBehavior),
+			},
+		}).
+		Return(&workitemtrackingprocess.ProcessBehavior{ReferenceName: &referenceName}, nil).
+		Times(1)
+
+	processClient.
+		EXPECT().
+		AddBehaviorToWorkItemType(clients.Ctx, workitemtrackingprocess.AddBehaviorToWorkItemTypeArgs{
+			ProcessId:              &testBacklogProcessID,
+			WitRefNameForBehaviors: converter.String("Custom.Initiative"),
+			Behavior: &workitemtrackingprocess.WorkItemTypeBehavior{
+				Behavior:  &workitemtrackingprocess.WorkItemBehaviorReference{Id: &referenceName},
+				IsDefault: converter.Bool(true),
+			},
+		}).
+		Return(nil, nil).
+		Times(1)
+
+	processClient.
+		EXPECT().
+		GetProcessBehavior(clients.Ctx, workitemtrackingprocess.GetProcessBehaviorArgs{
+			ProcessId:       &testBacklogProcessID,
+			BehaviorRefName: &referenceName,
+		}).
+		Return(&workitemtrackingprocess.ProcessBehavior{
+			ReferenceName: &referenceName,
+			Name:          converter.String("Initiatives"),
+			Color:         converter.String("009CCC"),
+			Rank:          converter.Int(4),
+			Customization: &workitemtrackingprocess.CustomizationTypeValues.Custom,
+			Inherits:      &workitemtrackingprocess.ProcessBehaviorReference{BehaviorRefName: converter.String(portfolioBacklogBehavior)},
+		}, nil).
+		Times(1)
+
+	processClient.
+		EXPECT().
+		GetProcessWorkItemTypes(clients.Ctx, gomock.Any()).
+		Return(testBacklogLevelWorkItemTypes(referenceName, "Custom.Initiative", "Custom.Initiative"), nil).
+		Times(1)
+
+	err := resourceProcessBacklogLevelCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, testBacklogProcessID.String()+"/"+referenceName, resourceData.Id())
+	require.Equal(t, "custom", resourceData.Get("customization"))
+	require.Equal(t, 4, resourceData.Get("rank"))
+	require.Equal(t, []interface{}{"Custom.Initiative"}, resourceData.Get("work_item_types").(*schema.Set).List())
+	require.Equal(t, []interface{}{"Custom.Initiative"}, resourceData.Get("managed_work_item_types").(*schema.Set).List())
+	require.Equal(t, "Custom.Initiative", resourceData.Get("default_work_item_type"))
+}
+
+// verifies that an existing backlog level of the process is renamed instead of created
+func TestProcessBacklogLevel_Create_RenamesExistingLevel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	processClient := azdosdkmocks.NewMockWorkitemtrackingprocessClient(ctrl)
+	clients := &client.AggregatedClient{WorkItemTrackingProcessClient: processClient, Ctx: context.Background()}
+
+	referenceName := "Microsoft.VSTS.Agile.FeatureBacklogBehavior"
+	resourceData := schema.TestResourceDataRaw(t, ResourceProcessBacklogLevel().Schema, nil)
+	resourceData.Set("process_id", testBacklogProcessID.String())
+	resourceData.Set("reference_name", referenceName)
+	resourceData.Set("name", "Capabilities")
+
+	existing := &workitemtrackingprocess.ProcessBehavior{
+		ReferenceName: &referenceName,
+		Name:          converter.String("Capabilities"),
+		Customization: &workitemtrackingprocess.CustomizationTypeValues.Inherited,
+	}
+	processClient.
+		EXPECT().
+		GetProcessBehavior(clients.Ctx, gomock.Any()).
+		Return(existing, nil).
+		Times(2)
+
+	processClient.
+		EXPECT().
+		UpdateProcessBehavior(clients.Ctx, workitemtrackingprocess.UpdateProcessBehaviorArgs{
+			ProcessId:       &testBacklogProcessID,
+			BehaviorRefName: &referenceName,
+			BehaviorData:    &workitemtrackingprocess.ProcessBehaviorUpdateRequest{Name: converter.String("Capabilities")},
+		}).
+		Return(existing, nil).
+		Times(1)
+
+	processClient.
+		EXPECT().
+		GetProcessWorkItemTypes(clients.Ctx, gomock.Any()).
+		Return(testBacklogLevelWorkItemTypes(referenceName, "Microsoft.VSTS.WorkItemTypes.Feature", "Microsoft.VSTS.WorkItemTypes.Feature"), nil).
+		Times(1)
+
+	err := resourceProcessBacklogLevelCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "inherited", resourceData.Get("customization"))
+	require.Equal(t, []interface{}{"Microsoft.VSTS.WorkItemTypes.Feature"}, resourceData.Get("work_item_types").(*schema.Set).List())
+}
+
+// verifies that only the work item types which are not assigned to an adopted backlog level yet are assigned
+func TestProcessBacklogLevel_Create_SkipsAssignedWorkItemTypesOfExistingLevel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	processClient := azdosdkmocks.NewMockWorkitemtrackingprocessClient(ctrl)
+	clients := &client.AggregatedClient{WorkItemTrackingProcessClient: processClient, Ctx: context.Background()}
+
+	referenceName := "Microsoft.VSTS.Agile.FeatureBacklogBehavior"
+	resourceData := schema.TestResourceDataRaw(t, ResourceProcessBacklogLevel().Schema, nil)
+	resourceData.Set("process_id", testBacklogProcessID.String())
+	resourceData.Set("reference_name", referenceName)
+	resourceData.Set("name", "Capabilities")
+	resourceData.Set("work_item_types", []interface{}{"Microsoft.VSTS.WorkItemTypes.Feature", "Custom.Capability"})
+
+	existing := &workitemtrackingprocess.ProcessBehavior{
+		ReferenceName: &referenceName,
+		Name:          converter.String("Capabilities"),
+		Customization: &workitemtrackingprocess.CustomizationTypeValues.Inherited,
+	}
+	processClient.
+		EXPECT().
+		GetProcessBehavior(clients.Ctx, gomock.Any()).
+		Return(existing, nil).
+		Times(2)
+	processClient.
+		EXPECT().
+		UpdateProcessBehavior(clients.Ctx, gomock.Any()).
+		Return(existing, nil).
+		Times(1)
+
+	gomock.InOrder(
+		processClient.
+			EXPECT().
+			GetProcessWorkItemTypes(clients.Ctx, gomock.Any()).
+			Return(testBacklogLevelWorkItemTypes(referenceName, "Microsoft.VSTS.WorkItemTypes.Feature", "Microsoft.VSTS.WorkItemTypes.Feature"), nil),
+		processClient.
+			EXPECT().
+			GetProcessWorkItemTypes(clients.Ctx, gomock.Any()).
+			Return(testBacklogLevelWorkItemTypes(referenceName, "Microsoft.VSTS.WorkItemTypes.Feature", "Microsoft.VSTS.WorkItemTypes.Feature", "Custom.Capability"), nil),
+	)
+
+	processClient.
+		EXPECT().
+		AddBehaviorToWorkItemType(clients.Ctx, workitemtrackingprocess.AddBehaviorToWorkItemTypeArgs{
+			ProcessId:              &testBacklogProcessID,
+			WitRefNameForBehaviors: converter.String("Custom.Capability"),
+			Behavior: &workitemtrackingprocess.WorkItemTypeBehavior{
+				Behavior:  &workitemtrackingprocess.WorkItemBehaviorReference{Id: &referenceName},
+				IsDefault: converter.Bool(false),
+			},
+		}).
+		Return(nil, nil).
+		Times(1)
+	processClient.
+		EXPECT().
+		UpdateBehaviorToWorkItemType(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	err := resourceProcessBacklogLevelCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{"Custom.Capability"}, resourceData.Get("managed_work_item_types").(*schema.Set).List())
+	require.Equal(t, "Microsoft.VSTS.WorkItemTypes.Feature", resourceData.Get("default_work_item_type"))
+}
+
+// verifies that only the work item types Terraform assigned are removed from an adopted level
+func TestProcessBacklogLevel_UpdateWorkItemTypes_RemovesOnlyManagedTypes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	processClient := azdosdkmocks.NewMockWorkitemtrackingprocessClient(ctrl)
+	clients := &client.AggregatedClient{WorkItemTrackingProcessClient: processClient, Ctx: context.Background()}
+
+	referenceName := "Microsoft.VSTS.Agile.FeatureBacklogBehavior"
+	resourceData := schema.TestResourceDataRaw(t, ResourceProcessBacklogLevel().Schema, nil)
+	resourceData.Set("managed_work_item_types", []interface{}{"Custom.Capability"})
+	oldTypes := schema.NewSet(schema.HashString, []interface{}{"Microsoft.VSTS.WorkItemTypes.Feature", "Custom.Capability"})
+
+	processClient.
+		EXPECT().
+		RemoveBehaviorFromWorkItemType(clients.Ctx, workitemtrackingprocess.RemoveBehaviorFromWorkItemTypeArgs{
+			ProcessId:              &testBacklogProcessID,
+			WitRefNameForBehaviors: converter.String("Custom.Capability"),
+			BehaviorRefName:        &referenceName,
+		}).
+		Return(nil).
+		Times(1)
+
+	err := updateBacklogLevelWorkItemTypes(clients, testBacklogProcessID, referenceName, oldTypes, []string{"Microsoft.VSTS.WorkItemTypes.Feature", "Custom.Capability"}, "Microsoft.VSTS.WorkItemTypes.Feature", resourceData)
+	require.Nil(t, err)
+	require.Empty(t, resourceData.Get("managed_work_item_types").(*schema.Set).List())
+}
+
+// verifies that the work item types an adopted level had before are not read into the state
+func TestProcessBacklogLevel_Read_FiltersUnconfiguredWorkItemTypes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	processClient := azdosdkmocks.NewMockWorkitemtrackingprocessClient(ctrl)
+	clients := &client.AggregatedClient{WorkItemTrackingProcessClient: processClient, Ctx: context.Background()}
+
+	referenceName := "Microsoft.VSTS.Agile.FeatureBacklogBehavior"
+	resourceData := schema.TestResourceDataRaw(t, ResourceProcessBacklogLevel().Schema, nil)
+	resourceData.SetId(testBacklogProcessID.String() + "/" + referenceName)
+	resourceData.Set("work_item_types", []interface{}{"custom.capability"})
+	resourceData.Set("managed_work_item_types", []interface{}{"Custom.Capability"})
+
+	processClient.
+		EXPECT().
+		GetProcessBehavior(clients.Ctx, gomock.Any()).
+		Return(&workitemtrackingprocess.ProcessBehavior{ReferenceName: &referenceName, Name: converter.String("Capabilities")}, nil).
+		Times(1)
+	processClient.
+		EXPECT().
+		GetProcessWorkItemTypes(clients.Ctx, gomock.Any()).
+		Return(testBacklogLevelWorkItemTypes(referenceName, "Microsoft.VSTS.WorkItemTypes.Feature", "Microsoft.VSTS.WorkItemTypes.Feature", "Custom.Capability"), nil).
+		Times(1)
+
+	err := resourceProcessBacklogLevelRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{"Custom.Capability"}, resourceData.Get("work_item_types").(*schema.Set).List())
+	require.Equal(t, []interface{}{"Custom.Capability"}, resourceData.Get("managed_work_item_types").(*schema.Set).List())
+}
+
+// verifies that backlog levels inherited from the parent process are not deleted
+func TestProcessBacklogLevel_Delete_KeepsInheritedLevel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	processClient := azdosdkmocks.NewMockWorkitemtrackingprocessClient(ctrl)
+	clients := &client.AggregatedClient{WorkItemTrackingProcessClient: processClient, Ctx: context.Background()}
+
+	referenceName := "Microsoft.VSTS.Agile.FeatureBacklogBehavior"
+	resourceData := schema.TestResourceDataRaw(t, ResourceProcessBacklogLevel().Schema, nil)
+	resourceData.SetId(testBacklogProcessID.String() + "/" + referenceName)
+	resourceData.Set("customization", "inherited")
+	resourceData.Set("work_item_types", []interface{}{"Microsoft.VSTS.WorkItemTypes.Feature", "Custom.Capability"})
+	resourceData.Set("managed_work_item_types", []interface{}{"Custom.Capability"})
+
+	processClient.
+		EXPECT().
+		RemoveBehaviorFromWorkItemType(clients.Ctx, workitemtrackingprocess.RemoveBehaviorFromWorkItemTypeArgs{
+			ProcessId:              &testBacklogProcessID,
+			WitRefNameForBehaviors: converter.String("Custom.Capability"),
+			BehaviorRefName:        &referenceName,
+		}).
+		Return(nil).
+		Times(1)
+
+	processClient.
+		EXPECT().
+		DeleteProcessBehavior(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	err := resourceProcessBacklogLevelDelete(resourceData, clients)
+	require.Nil(t, err)
+}
+
+func TestProcessBacklogLevel_Delete_DeletesCustomLevel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	processClient := azdosdkmocks.NewMockWorkitemtrackingprocessClient(ctrl)
+	clients := &client.AggregatedClient{WorkItemTrackingProcessClient: processClient, Ctx: context.Background()}
+
+	referenceName := "Custom.InitiativeBehavior"
+	resourceData := schema.TestResourceDataRaw(t, ResourceProcessBacklogLevel().Schema, nil)
+	resourceData.SetId(testBacklogProcessID.String() + "/" + referenceName)
+	resourceData.Set("customization", "custom")
+
+	processClient.
+		EXPECT().
+		DeleteProcessBehavior(clients.Ctx, workitemtrackingprocess.DeleteProcessBehaviorArgs{
+			ProcessId:       &testBacklogProcessID,
+			BehaviorRefName: &referenceName,
+		}).
+		Return(errors.New("DeleteProcessBehavior() Failed")).
+		Times(1)
+
+	err := resourceProcessBacklogLevelDelete(resourceData, clients)
+	require.ErrorContains(t, err, "DeleteProcessBehavior() Failed")
+}
+
+func TestProcessBacklogLevel_Read_RemovesDeletedLevel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	processClient := azdosdkmocks.NewMockWorkitemtrackingprocessClient(ctrl)
+	clients := &client.AggregatedClient{WorkItemTrackingProcessClient: processClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceProcessBacklogLevel().Schema, nil)
+	resourceData.SetId(testBacklogProcessID.String() + "/Custom.InitiativeBehavior")
+
+	processClient.
+		EXPECT().
+		GetProcessBehavior(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(404)}).
+		Times(1)
+
+	err := resourceProcessBacklogLevelRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Empty(t, resourceData.Id())
+}
+
+func TestProcessBacklogLevel_ParseID(t *testing.T) {
+	processID, referenceName, err := parseProcessBacklogLevelID(testBacklogProcessID.String() + "/Custom.InitiativeBehavior")
+	require.Nil(t, err)
+	require.Equal(t, testBacklogProcessID, processID)
+	require.Equal(t, "Custom.InitiativeBehavior", referenceName)
+
+	_, _, err = parseProcessBacklogLevelID("Custom.InitiativeBehavior")
+	require.Error(t, err)
+}
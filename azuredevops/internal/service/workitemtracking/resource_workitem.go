@@ -18,10 +18,11 @@ import (
 // ResourceWorkItem schema and implementation for Project WorkItem resource
 func ResourceWorkItem() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWorkItemCreate,
-		Read:   resourceWorkItemRead,
-		Update: resourceWorkItemUpdate,
-		Delete: resourceWorkItemDelete,
+		Create:   resourceWorkItemCreate,
+		Read:     resourceWorkItemRead,
+		Update:   resourceWorkItemUpdate,
+		Delete:   resourceWorkItemDelete,
+		Importer: tfhelper.ImportProjectQualifiedResourceInteger(),
 		Schema: map[string]*schema.Schema{
 			"title": {
 				Type:         schema.TypeString,
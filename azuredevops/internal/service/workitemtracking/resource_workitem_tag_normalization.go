@@ -0,0 +1,330 @@
+package workitemtracking
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/workitemtracking"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// the maximum number of work items of a single GetWorkItems request
+const workItemsBatchSize = 200
+
+// ResourceWorkItemTagNormalization schema and implementation for enforcing the allowed work item tags of a project
+func ResourceWorkItemTagNormalization() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceWorkItemTagNormalizationCreateUpdate,
+		Read:   resourceWorkItemTagNormalizationRead,
+		Update: resourceWorkItemTagNormalizationCreateUpdate,
+		Delete: resourceWorkItemTagNormalizationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeDiffWorkItemTagNormalization,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"allowed_tags": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"tag_mappings": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"delete_unmapped_tags": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"non_conforming_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// tagNormalization holds the allowed tags and the tag mappings keyed by the lower-cased tag names, as tag names
+// are case-insensitive
+type tagNormalization struct {
+	allowed        map[string]string
+	mappings       map[string]string
+	deleteUnmapped bool
+}
+
+func resourceWorkItemTagNormalizationCreateUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	tags, err := clients.WorkItemTrackingClient.GetTags(clients.Ctx, workitemtracking.GetTagsArgs{
+		Project: &projectID,
+	})
+	if err != nil {
+		return fmt.Errorf(" reading the work item tags of project %s: %+v", projectID, err)
+	}
+
+	normalization := expandTagNormalization(d)
+	existing := map[string]workitemtracking.WorkItemTagDefinition{}
+	for _, tag := range *tags {
+		if tag.Name != nil {
+			existing[strings.ToLower(*tag.Name)] = tag
+		}
+	}
+
+	for _, tag := range *tags {
+		if tag.Name == nil || tag.Id == nil {
+			continue
+		}
+		name := *tag.Name
+		if _, ok := normalization.allowed[strings.ToLower(name)]; ok {
+			continue
+		}
+
+		target, mapped := normalization.mappings[strings.ToLower(name)]
+		if !mapped {
+			if !normalization.deleteUnmapped {
+				continue
+			}
+			if err := clients.WorkItemTrackingClient.DeleteTag(clients.Ctx, workitemtracking.DeleteTagArgs{
+				Project:     &projectID,
+				TagIdOrName: converter.String(tag.Id.String()),
+			}); err != nil && !utils.ResponseWasNotFound(err) {
+				return fmt.Errorf(" deleting work item tag %s of project %s: %+v", name, projectID, err)
+			}
+			continue
+		}
+
+		if targetTag, ok := existing[strings.ToLower(target)]; ok && targetTag.Id != nil && *targetTag.Id != *tag.Id {
+			if err := mergeWorkItemTag(clients, projectID, name, target); err != nil {
+				return err
+			}
+			if err := clients.WorkItemTrackingClient.DeleteTag(clients.Ctx, workitemtracking.DeleteTagArgs{
+				Project:     &projectID,
+				TagIdOrName: converter.String(tag.Id.String()),
+			}); err != nil && !utils.ResponseWasNotFound(err) {
+				return fmt.Errorf(" deleting merged work item tag %s of project %s: %+v", name, projectID, err)
+			}
+			continue
+		}
+
+		renamed, err := clients.WorkItemTrackingClient.UpdateTag(clients.Ctx, workitemtracking.UpdateTagArgs{
+			Project:     &projectID,
+			TagIdOrName: converter.String(tag.Id.String()),
+			TagData:     &workitemtracking.WorkItemTagDefinition{Name: &target},
+		})
+		if err != nil {
+			return fmt.Errorf(" renaming work item tag %s of project %s to %s: %+v", name, projectID, target, err)
+		}
+		existing[strings.ToLower(target)] = *renamed
+	}
+
+	d.SetId(projectID)
+	return resourceWorkItemTagNormalizationRead(d, m)
+}
+
+func resourceWorkItemTagNormalizationRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Id()
+
+	tags, err := clients.WorkItemTrackingClient.GetTags(clients.Ctx, workitemtracking.GetTagsArgs{
+		Project: &projectID,
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(" reading the work item tags of project %s: %+v", projectID, err)
+	}
+
+	normalization := expandTagNormalization(d)
+	nonConforming := []string{}
+	for _, tag := range *tags {
+		if tag.Name == nil {
+			continue
+		}
+		if _, ok := normalization.allowed[strings.ToLower(*tag.Name)]; !ok {
+			nonConforming = append(nonConforming, *tag.Name)
+		}
+	}
+
+	d.Set("project_id", projectID)
+	d.Set("non_conforming_tags", nonConforming)
+	return nil
+}
+
+// resourceWorkItemTagNormalizationDelete only removes the resource from the state, the tags of the project are kept
+func resourceWorkItemTagNormalizationDelete(d *schema.ResourceData, _ interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// customizeDiffWorkItemTagNormalization validates the tag mappings and plans the non-conforming tags of the project
+// that are left after the tags are normalized, so that an apply is planned as long as tags can be normalized
+func customizeDiffWorkItemTagNormalization(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("allowed_tags") || !d.NewValueKnown("tag_mappings") {
+		return nil
+	}
+
+	normalization := tagNormalization{
+		allowed:        lowerCasedTags(tfhelper.ExpandStringSet(d.Get("allowed_tags").(*schema.Set))),
+		mappings:       map[string]string{},
+		deleteUnmapped: d.Get("delete_unmapped_tags").(bool),
+	}
+	for tag, target := range d.Get("tag_mappings").(map[string]interface{}) {
+		if _, ok := normalization.allowed[strings.ToLower(tag)]; ok {
+			return fmt.Errorf(" the tag %s of `tag_mappings` is an allowed tag and cannot be mapped", tag)
+		}
+		if _, ok := normalization.allowed[strings.ToLower(target.(string))]; !ok {
+			return fmt.Errorf(" the tag %s is mapped to %s, which is not an allowed tag", tag, target)
+		}
+		normalization.mappings[strings.ToLower(tag)] = target.(string)
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+	current := tfhelper.ExpandStringSet(d.Get("non_conforming_tags").(*schema.Set))
+	remaining := []string{}
+	for _, tag := range current {
+		if _, ok := normalization.allowed[strings.ToLower(tag)]; ok {
+			continue
+		}
+		if _, ok := normalization.mappings[strings.ToLower(tag)]; ok || normalization.deleteUnmapped {
+			continue
+		}
+		remaining = append(remaining, tag)
+	}
+	if len(remaining) == len(current) {
+		return nil
+	}
+	return d.SetNew("non_conforming_tags", remaining)
+}
+
+// mergeWorkItemTag replaces a tag with an existing tag on all work items of a project
+func mergeWorkItemTag(clients *client.AggregatedClient, projectID string, tag string, target string) error {
+	query := fmt.Sprintf(
+		"SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project AND [System.Tags] CONTAINS '%s'",
+		strings.ReplaceAll(tag, "'", "''"))
+	result, err := clients.WorkItemTrackingClient.QueryByWiql(clients.Ctx, workitemtracking.QueryByWiqlArgs{
+		Project: &projectID,
+		Wiql:    &workitemtracking.Wiql{Query: &query},
+	})
+	if err != nil {
+		return fmt.Errorf(" querying the work items tagged %s in project %s: %+v", tag, projectID, err)
+	}
+	if result.WorkItems == nil {
+		return nil
+	}
+
+	ids := []int{}
+	for _, reference := range *result.WorkItems {
+		if reference.Id != nil {
+			ids = append(ids, *reference.Id)
+		}
+	}
+
+	for start := 0; start < len(ids); start += workItemsBatchSize {
+		end := start + workItemsBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+		workItems, err := clients.WorkItemTrackingClient.GetWorkItems(clients.Ctx, workitemtracking.GetWorkItemsArgs{
+			Ids:     &batch,
+			Project: &projectID,
+			Fields:  &[]string{"System.Tags"},
+		})
+		if err != nil {
+			return fmt.Errorf(" reading the work items tagged %s in project %s: %+v", tag, projectID, err)
+		}
+
+		for _, workItem := range *workItems {
+			if workItem.Id == nil || workItem.Fields == nil {
+				continue
+			}
+			tags, _ := (*workItem.Fields)["System.Tags"].(string)
+			_, err := clients.WorkItemTrackingClient.UpdateWorkItem(clients.Ctx, workitemtracking.UpdateWorkItemArgs{
+				Id:      workItem.Id,
+				Project: &projectID,
+				Document: &[]webapi.JsonPatchOperation{
+					{
+						Op:    &webapi.OperationValues.Add,
+						Path:  converter.String("/fields/System.Tags"),
+						Value: replaceWorkItemTag(tags, tag, target),
+					},
+				},
+			})
+			if err != nil {
+				return fmt.Errorf(" replacing tag %s with %s on work item %d: %+v", tag, target, *workItem.Id, err)
+			}
+		}
+	}
+	return nil
+}
+
+// replaceWorkItemTag replaces a tag in the semicolon separated tags of a work item, keeping every tag once
+func replaceWorkItemTag(tags string, tag string, target string) string {
+	seen := map[string]bool{}
+	replaced := []string{}
+	for _, t := range strings.Split(tags, ";") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if strings.EqualFold(t, tag) {
+			t = target
+		}
+		if seen[strings.ToLower(t)] {
+			continue
+		}
+		seen[strings.ToLower(t)] = true
+		replaced = append(replaced, t)
+	}
+	sort.Strings(replaced)
+	return strings.Join(replaced, "; ")
+}
+
+func expandTagNormalization(d *schema.ResourceData) tagNormalization {
+	mappings := map[string]string{}
+	for tag, target := range d.Get("tag_mappings").(map[string]interface{}) {
+		mappings[strings.ToLower(tag)] = target.(string)
+	}
+	return tagNormalization{
+		allowed:        lowerCasedTags(tfhelper.ExpandStringSet(d.Get("allowed_tags").(*schema.Set))),
+		mappings:       mappings,
+		deleteUnmapped: d.Get("delete_unmapped_tags").(bool),
+	}
+}
+
+func lowerCasedTags(tags []string) map[string]string {
+	lowerCased := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		lowerCased[strings.ToLower(tag)] = tag
+	}
+	return lowerCased
+}
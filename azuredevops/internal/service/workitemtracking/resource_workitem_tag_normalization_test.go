@@ -0,0 +1,145 @@
+//go:build (all || resource_workitem_tag_normalization) && !exclude_resource_workitem_tag_normalization
+// +build all resource_workitem_tag_normalization
+// +build !exclude_resource_workitem_tag_normalization
+
+package workitemtracking
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/workitemtracking"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var testTagProjectID = uuid.New().String()
+
+func testWorkItemTag(name string) workitemtracking.WorkItemTagDefinition {
+	id := uuid.New()
+	return workitemtracking.WorkItemTagDefinition{Id: &id, Name: converter.String(name)}
+}
+
+// verifies that non-conforming tags are renamed, merged into existing tags or deleted
+func TestWorkItemTagNormalization_Create_NormalizesTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	witClient := azdosdkmocks.NewMockWorkitemtrackingClient(ctrl)
+	clients := &client.AggregatedClient{WorkItemTrackingClient: witClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceWorkItemTagNormalization().Schema, nil)
+	resourceData.Set("project_id", testTagProjectID)
+	resourceData.Set("allowed_tags", []interface{}{"frontend", "backend", "security"})
+	resourceData.Set("tag_mappings", map[string]interface{}{"ui": "frontend", "sec": "security"})
+	resourceData.Set("delete_unmapped_tags", true)
+
+	frontend := testWorkItemTag("Frontend")
+	ui := testWorkItemTag("UI")
+	sec := testWorkItemTag("sec")
+	obsolete := testWorkItemTag("obsolete")
+	tags := []workitemtracking.WorkItemTagDefinition{frontend, ui, sec, obsolete}
+
+	gomock.InOrder(
+		witClient.EXPECT().
+			GetTags(clients.Ctx, workitemtracking.GetTagsArgs{Project: &testTagProjectID}).
+			Return(&tags, nil).
+			Times(1),
+		witClient.EXPECT().
+			GetTags(clients.Ctx, workitemtracking.GetTagsArgs{Project: &testTagProjectID}).
+			Return(&[]workitemtracking.WorkItemTagDefinition{frontend, testWorkItemTag("security")}, nil).
+			Times(1),
+	)
+
+	// "UI" is merged into the existing "Frontend" tag
+	witClient.EXPECT().
+		QueryByWiql(clients.Ctx, gomock.Any()).
+		Return(&workitemtracking.WorkItemQueryResult{
+			WorkItems: &[]workitemtracking.WorkItemReference{{Id: converter.Int(7)}},
+		}, nil).
+		Times(1)
+	witClient.EXPECT().
+		GetWorkItems(clients.Ctx, workitemtracking.GetWorkItemsArgs{
+			Ids:     &[]int{7},
+			Project: &testTagProjectID,
+			Fields:  &[]string{"System.Tags"},
+		}).
+		Return(&[]workitemtracking.WorkItem{
+			{Id: converter.Int(7), Fields: &map[string]interface{}{"System.Tags": "Frontend; UI; urgent"}},
+		}, nil).
+		Times(1)
+	witClient.EXPECT().
+		UpdateWorkItem(clients.Ctx, workitemtracking.UpdateWorkItemArgs{
+			Id:      converter.Int(7),
+			Project: &testTagProjectID,
+			Document: &[]webapi.JsonPatchOperation{
+				{
+					Op:    &webapi.OperationValues.Add,
+					Path:  converter.String("/fields/System.Tags"),
+					Value: "Frontend; urgent",
+				},
+			},
+		}).
+		Return(nil, nil).
+		Times(1)
+	witClient.EXPECT().
+		DeleteTag(clients.Ctx, workitemtracking.DeleteTagArgs{Project: &testTagProjectID, TagIdOrName: converter.String(ui.Id.String())}).
+		Return(nil).
+		Times(1)
+
+	// "sec" is renamed to "security"
+	witClient.EXPECT().
+		UpdateTag(clients.Ctx, workitemtracking.UpdateTagArgs{
+			Project:     &testTagProjectID,
+			TagIdOrName: converter.String(sec.Id.String()),
+			TagData:     &workitemtracking.WorkItemTagDefinition{Name: converter.String("security")},
+		}).
+		Return(&workitemtracking.WorkItemTagDefinition{Id: sec.Id, Name: converter.String("security")}, nil).
+		Times(1)
+
+	// "obsolete" is deleted
+	witClient.EXPECT().
+		DeleteTag(clients.Ctx, workitemtracking.DeleteTagArgs{Project: &testTagProjectID, TagIdOrName: converter.String(obsolete.Id.String())}).
+		Return(nil).
+		Times(1)
+
+	err := resourceWorkItemTagNormalizationCreateUpdate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, testTagProjectID, resourceData.Id())
+	require.Empty(t, resourceData.Get("non_conforming_tags").(*schema.Set).List())
+}
+
+// verifies that unmapped tags are kept and reported when they should not be deleted
+func TestWorkItemTagNormalization_Read_ReportsNonConformingTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	witClient := azdosdkmocks.NewMockWorkitemtrackingClient(ctrl)
+	clients := &client.AggregatedClient{WorkItemTrackingClient: witClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceWorkItemTagNormalization().Schema, nil)
+	resourceData.SetId(testTagProjectID)
+	resourceData.Set("allowed_tags", []interface{}{"frontend"})
+
+	witClient.EXPECT().
+		GetTags(clients.Ctx, gomock.Any()).
+		Return(&[]workitemtracking.WorkItemTagDefinition{testWorkItemTag("FrontEnd"), testWorkItemTag("obsolete")}, nil).
+		Times(1)
+
+	err := resourceWorkItemTagNormalizationRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, testTagProjectID, resourceData.Get("project_id"))
+	require.Equal(t, []interface{}{"obsolete"}, resourceData.Get("non_conforming_tags").(*schema.Set).List())
+}
+
+func TestWorkItemTagNormalization_ReplaceWorkItemTag(t *testing.T) {
+	require.Equal(t, "backend; frontend", replaceWorkItemTag("UI; backend", "ui", "frontend"))
+	require.Equal(t, "frontend", replaceWorkItemTag("frontend; UI", "UI", "frontend"))
+	require.Equal(t, "", replaceWorkItemTag("", "UI", "frontend"))
+}
@@ -30,7 +30,7 @@ func ResponseWasStatusCode(err error, statusCode int) bool {
 	if err == nil {
 		return false
 	}
-	if wrapperErr, ok := err.(azuredevops.WrappedError); ok {
+	if wrapperErr, ok := responseError(err); ok {
 		if wrapperErr.StatusCode != nil && *wrapperErr.StatusCode == statusCode {
 			return true
 		}
@@ -43,7 +43,7 @@ func ResponseContainsStatusMessage(err error, statusMessage string) bool {
 	if err == nil {
 		return false
 	}
-	if wrapperErr, ok := err.(azuredevops.WrappedError); ok {
+	if wrapperErr, ok := responseError(err); ok {
 		if wrapperErr.Message == nil {
 			return false
 		}
@@ -51,3 +51,16 @@ func ResponseContainsStatusMessage(err error, statusMessage string) bool {
 	}
 	return false
 }
+
+// responseError returns err if it is the error of an Azure DevOps response. Unlike AsAzureDevOpsError the errors
+// wrapped by err are not inspected, so that a resource is not considered missing because a lookup of another
+// resource was not found.
+func responseError(err error) (*azuredevops.WrappedError, bool) {
+	switch wrapperErr := err.(type) {
+	case azuredevops.WrappedError:
+		return &wrapperErr, true
+	case *azuredevops.WrappedError:
+		return wrapperErr, wrapperErr != nil
+	}
+	return nil, false
+}
@@ -0,0 +1,91 @@
+package datahelper
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultConcurrentWorkers is the number of concurrent requests list data sources send by default. It is kept
+// small, the provider wide `max_concurrent_requests` of the transport still bounds the requests in flight.
+const DefaultConcurrentWorkers = 4
+
+// FeaturesSchema returns the `features` block of list data sources, which tunes how their items are read
+func FeaturesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"concurrent_workers": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// GetConcurrentWorkers returns the `concurrent_workers` of the `features` block, or DefaultConcurrentWorkers
+func GetConcurrentWorkers(d *schema.ResourceData) int {
+	features, ok := d.Get("features").(*schema.Set)
+	if !ok || features.Len() == 0 || features.List()[0] == nil {
+		return DefaultConcurrentWorkers
+	}
+	if v, ok := features.List()[0].(map[string]interface{})["concurrent_workers"].(int); ok && v > 0 {
+		return v
+	}
+	return DefaultConcurrentWorkers
+}
+
+// ForEachConcurrently calls f for every index in [0, n) with at most workers calls running at the same
+// time. The first error is returned once all running calls completed, no new call is started after it.
+func ForEachConcurrently(n int, workers int, f func(i int) error) error {
+	g, ctx := errgroup.WithContext(context.Background())
+	g.SetLimit(max(workers, 1))
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		g.Go(func() error {
+			// the error of another call may have occurred while this call waited for a worker
+			if ctx.Err() != nil {
+				return nil
+			}
+			return f(i)
+		})
+	}
+	return g.Wait()
+}
+
+// FetchPagesConcurrently reads all items of a list which is paged through skip and top, fetching up to
+// workers pages at the same time. Pages are read in windows of workers pages until a page is not full,
+// at the cost of up to workers - 1 requests past the end of the list.
+//
+// When done returns true for the items read so far, the remaining pages are not fetched.
+func FetchPagesConcurrently[T any](pageSize int, workers int, fetch func(skip int, top int) ([]T, error), done func(items []T) bool) ([]T, error) {
+	workers = max(workers, 1)
+	var items []T
+	for window := 0; ; window += workers {
+		pages := make([][]T, workers)
+		err := ForEachConcurrently(workers, workers, func(i int) error {
+			page, err := fetch((window+i)*pageSize, pageSize)
+			pages[i] = page
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, page := range pages {
+			items = append(items, page...)
+			if len(page) < pageSize {
+				return items, nil
+			}
+		}
+		if done != nil && done(items) {
+			return items, nil
+		}
+	}
+}
@@ -0,0 +1,110 @@
+//go:build all || helper || datahelper
+// +build all helper datahelper
+
+package datahelper
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachConcurrently_BoundsWorkers(t *testing.T) {
+	var running, maxRunning int32
+	var lock sync.Mutex
+	visited := map[int]bool{}
+
+	err := ForEachConcurrently(20, 3, func(i int) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			observed := atomic.LoadInt32(&maxRunning)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+				break
+			}
+		}
+
+		lock.Lock()
+		visited[i] = true
+		lock.Unlock()
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, visited, 20)
+	require.LessOrEqual(t, maxRunning, int32(3))
+}
+
+func TestForEachConcurrently_ReturnsError(t *testing.T) {
+	err := ForEachConcurrently(5, 2, func(i int) error {
+		if i == 3 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	require.EqualError(t, err, "failed")
+}
+
+func TestForEachConcurrently_DoesNotStartCallsAfterError(t *testing.T) {
+	var calls int32
+	err := ForEachConcurrently(10, 1, func(i int) error {
+		atomic.AddInt32(&calls, 1)
+		if i == 2 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	require.EqualError(t, err, "failed")
+	require.Equal(t, int32(3), calls)
+}
+
+func TestFetchPagesConcurrently_ReadsAllPagesInOrder(t *testing.T) {
+	items := make([]int, 23)
+	for i := range items {
+		items[i] = i
+	}
+	fetch := func(skip int, top int) ([]int, error) {
+		if skip >= len(items) {
+			return nil, nil
+		}
+		return items[skip:min(skip+top, len(items))], nil
+	}
+
+	for _, workers := range []int{1, 2, 4, 10} {
+		result, err := FetchPagesConcurrently(5, workers, fetch, nil)
+		require.NoError(t, err)
+		require.Equal(t, items, result, "workers: %d", workers)
+	}
+}
+
+func TestFetchPagesConcurrently_StopsWhenDone(t *testing.T) {
+	var requests int32
+	fetch := func(skip int, top int) ([]int, error) {
+		atomic.AddInt32(&requests, 1)
+		page := make([]int, top)
+		for i := range page {
+			page[i] = skip + i
+		}
+		return page, nil
+	}
+
+	result, err := FetchPagesConcurrently(10, 2, fetch, func(items []int) bool {
+		return len(items) >= 30
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 40)
+	require.Equal(t, int32(4), requests)
+}
+
+func TestFetchPagesConcurrently_ReturnsError(t *testing.T) {
+	_, err := FetchPagesConcurrently(10, 3, func(skip int, top int) ([]int, error) {
+		if skip == 20 {
+			return nil, errors.New("failed")
+		}
+		return make([]int, top), nil
+	}, nil)
+	require.EqualError(t, err, "failed")
+}
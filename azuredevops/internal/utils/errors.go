@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/sdk"
+)
+
+// retryableTypeKeys are the types of the errors Azure DevOps reports for operations which conflict with other
+// activity, e.g. a project which is still being created or a branch which was updated by another client
+var retryableTypeKeys = map[string]bool{
+	"ProjectWorkPendingException":               true,
+	"GitReferenceStaleException":                true,
+	"TeamFoundationServiceUnavailableException": true,
+}
+
+// AsAzureDevOpsError returns the error reported by Azure DevOps in the chain of err. The Azure DevOps Go API returns
+// its errors either as values or as pointers, both are found.
+func AsAzureDevOpsError(err error) (*azuredevops.WrappedError, bool) {
+	var wrappedErr azuredevops.WrappedError
+	if errors.As(err, &wrappedErr) {
+		return &wrappedErr, true
+	}
+	var wrappedErrPtr *azuredevops.WrappedError
+	if errors.As(err, &wrappedErrPtr) && wrappedErrPtr != nil {
+		return wrappedErrPtr, true
+	}
+	return nil, false
+}
+
+// ActivityID returns the ID Azure DevOps correlates the failed request of err with, or an empty string if it is unknown
+func ActivityID(err error) string {
+	wrappedErr, ok := AsAzureDevOpsError(err)
+	if !ok || wrappedErr.CustomProperties == nil {
+		return ""
+	}
+	id, _ := (*wrappedErr.CustomProperties)[sdk.ActivityIDProperty].(string)
+	return id
+}
+
+// IsRetryableError reports whether the operation which failed with err may succeed when it is retried, because
+// the service was throttling, temporarily unavailable or busy with conflicting activity, or the connection failed.
+// All other errors, like invalid or unauthorized requests and resources which do not exist, are terminal.
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if wrappedErr, ok := AsAzureDevOpsError(err); ok {
+		if wrappedErr.StatusCode != nil && sdk.IsRetryableStatusCode(*wrappedErr.StatusCode) {
+			return true
+		}
+		return wrappedErr.TypeKey != nil && retryableTypeKeys[*wrappedErr.TypeKey]
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// RetryError classifies err for retry.RetryContext, see IsRetryableError
+func RetryError(err error) *retry.RetryError {
+	if err == nil {
+		return nil
+	}
+	if IsRetryableError(err) {
+		return retry.RetryableError(err)
+	}
+	return retry.NonRetryableError(err)
+}
+
+// ErrorDiagnostics returns the diagnostics of err. The summary is the first line of the error, the following lines
+// go to the detail. The error code and the activity ID reported by Azure DevOps are added to the detail as well, so
+// that the failure can be looked up in the service, and transient errors are reported as such.
+func ErrorDiagnostics(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	summary, detail, _ := strings.Cut(strings.TrimSpace(err.Error()), "\n")
+
+	var details []string
+	if detail = strings.TrimSpace(detail); detail != "" {
+		details = append(details, detail)
+	}
+	var code []string
+	wrappedErr, ok := AsAzureDevOpsError(err)
+	if ok && wrappedErr.TypeKey != nil && *wrappedErr.TypeKey != "" {
+		code = append(code, *wrappedErr.TypeKey)
+	}
+	if ok && wrappedErr.StatusCode != nil {
+		code = append(code, fmt.Sprintf("HTTP %d", *wrappedErr.StatusCode))
+	}
+	if len(code) > 0 {
+		details = append(details, "Azure DevOps error: "+strings.Join(code, ", "))
+	}
+	if id := ActivityID(err); id != "" {
+		details = append(details, "Activity ID: "+id)
+	}
+	if IsRetryableError(err) {
+		details = append(details, "The error is transient, the operation may succeed when it is applied again.")
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   strings.Join(details, "\n"),
+	}}
+}
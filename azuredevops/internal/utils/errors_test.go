@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func getTypedError(statusCode int, typeKey string, activityID string) azuredevops.WrappedError {
+	err := GetError(statusCode, "VS402371: failed")
+	err.TypeKey = &typeKey
+	if activityID != "" {
+		err.CustomProperties = &map[string]interface{}{sdk.ActivityIDProperty: activityID}
+	}
+	return err
+}
+
+func TestAsAzureDevOpsError(t *testing.T) {
+	valueErr := GetError(404, "not found")
+	pointerErr := GetError(404, "not found")
+
+	cases := []struct {
+		Name   string
+		Error  error
+		Result bool
+	}{
+		{Name: "Value", Error: valueErr, Result: true},
+		{Name: "Pointer", Error: &pointerErr, Result: true},
+		{Name: "WrappedValue", Error: fmt.Errorf(" reading project: %w", valueErr), Result: true},
+		{Name: "WrappedPointer", Error: fmt.Errorf(" reading project: %w", &pointerErr), Result: true},
+		{Name: "Formatted", Error: fmt.Errorf(" reading project: %+v", valueErr), Result: false},
+		{Name: "Other", Error: errors.New("failed"), Result: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			wrappedErr, ok := AsAzureDevOpsError(tc.Error)
+			require.Equal(t, tc.Result, ok)
+			if tc.Result {
+				require.Equal(t, 404, *wrappedErr.StatusCode)
+			}
+		})
+	}
+}
+
+func TestActivityID(t *testing.T) {
+	require.Equal(t, "activity-1", ActivityID(fmt.Errorf(" creating team: %w", getTypedError(400, "InvalidArgumentValueException", "activity-1"))))
+	require.Equal(t, "", ActivityID(getTypedError(400, "InvalidArgumentValueException", "")))
+	require.Equal(t, "", ActivityID(errors.New("failed")))
+}
+
+func TestIsRetryableError(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Error  error
+		Result bool
+	}{
+		{Name: "Nil", Error: nil, Result: false},
+		{Name: "Throttled", Error: GetError(429, "throttled"), Result: true},
+		{Name: "Unavailable", Error: fmt.Errorf(" reading pool: %w", GetError(503, "unavailable")), Result: true},
+		{Name: "ProjectWorkPending", Error: getTypedError(400, "ProjectWorkPendingException", ""), Result: true},
+		{Name: "StaleReference", Error: getTypedError(409, "GitReferenceStaleException", ""), Result: true},
+		{Name: "BadRequest", Error: getTypedError(400, "InvalidArgumentValueException", ""), Result: false},
+		{Name: "NotFound", Error: GetError(404, "not found"), Result: false},
+		{Name: "Unauthorized", Error: GetError(401, "unauthorized"), Result: false},
+		{Name: "UnexpectedEOF", Error: fmt.Errorf(" reading feed: %w", io.ErrUnexpectedEOF), Result: true},
+		{Name: "Other", Error: errors.New("failed"), Result: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			require.Equal(t, tc.Result, IsRetryableError(tc.Error))
+		})
+	}
+}
+
+func TestRetryError(t *testing.T) {
+	require.Nil(t, RetryError(nil))
+	require.True(t, RetryError(GetError(503, "unavailable")).Retryable)
+	require.False(t, RetryError(GetError(404, "not found")).Retryable)
+}
+
+func TestErrorDiagnostics(t *testing.T) {
+	require.Nil(t, ErrorDiagnostics(nil))
+
+	diags := ErrorDiagnostics(fmt.Errorf(" creating repository: %w", getTypedError(503, "TeamFoundationServiceUnavailableException", "activity-1")))
+	require.Len(t, diags, 1)
+	require.Equal(t, diag.Error, diags[0].Severity)
+	require.Equal(t, "creating repository: VS402371: failed", diags[0].Summary)
+	require.True(t, strings.HasPrefix(diags[0].Detail, "Azure DevOps error: TeamFoundationServiceUnavailableException, HTTP 503\nActivity ID: activity-1\n"))
+
+	diags = ErrorDiagnostics(GetError(404, "not found"))
+	require.Equal(t, "Azure DevOps error: HTTP 404", diags[0].Detail)
+
+	diags = ErrorDiagnostics(errors.New("failed"))
+	require.Equal(t, "failed", diags[0].Summary)
+	require.Empty(t, diags[0].Detail)
+
+	diags = ErrorDiagnostics(fmt.Errorf(" reading pipeline: %w", io.ErrUnexpectedEOF))
+	require.Equal(t, "reading pipeline: unexpected EOF", diags[0].Summary)
+	require.Equal(t, "The error is transient, the operation may succeed when it is applied again.", diags[0].Detail)
+
+	diags = ErrorDiagnostics(errors.New(" validating service connection:\nthe server certificate is not trusted\n"))
+	require.Equal(t, "validating service connection:", diags[0].Summary)
+	require.Equal(t, "the server certificate is not trusted", diags[0].Detail)
+}
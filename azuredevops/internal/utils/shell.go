@@ -0,0 +1,9 @@
+package utils
+
+import "strings"
+
+// ShellQuote quotes a value for POSIX shells, single quotes within the value end the quoting, are escaped
+// and start it again
+func ShellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,50 @@
+package tfhelper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+)
+
+const projectIDKey = "project_id"
+
+// AddDefaultProjectID makes the required `project_id` argument of a resource optional. When it is omitted,
+// the `default_project_id` of the provider is used instead. Resources without a required `project_id`
+// argument are left unchanged.
+func AddDefaultProjectID(r *schema.Resource) {
+	projectID, ok := r.Schema[projectIDKey]
+	if !ok || !projectID.Required || projectID.Type != schema.TypeString {
+		return
+	}
+
+	projectID.Required = false
+	projectID.Optional = true
+	projectID.Computed = true
+
+	if r.CustomizeDiff == nil {
+		r.CustomizeDiff = setDefaultProjectID
+	} else {
+		r.CustomizeDiff = customdiff.All(setDefaultProjectID, r.CustomizeDiff)
+	}
+}
+
+// setDefaultProjectID plans the `default_project_id` of the provider as `project_id` if it is not configured.
+// As `project_id` usually forces a new resource, changing the default project replaces the resources using it.
+func setDefaultProjectID(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	configured := RawConfigValue(d.GetRawConfig(), projectIDKey)
+	if !configured.IsKnown() || !configured.IsNull() {
+		return nil
+	}
+
+	clients, ok := m.(*client.AggregatedClient)
+	if !ok || clients == nil || clients.DefaultProjectID == "" {
+		return fmt.Errorf(" `%s` must be set when `default_project_id` is not set in the provider configuration", projectIDKey)
+	}
+	if d.Get(projectIDKey).(string) == clients.DefaultProjectID {
+		return nil
+	}
+	return d.SetNew(projectIDKey, clients.DefaultProjectID)
+}
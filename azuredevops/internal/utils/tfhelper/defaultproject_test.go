@@ -0,0 +1,78 @@
+package tfhelper
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/stretchr/testify/require"
+)
+
+func testDefaultProjectResource() *schema.Resource {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+	AddDefaultProjectID(r)
+	return r
+}
+
+func TestAddDefaultProjectID_MakesProjectIDOptional(t *testing.T) {
+	r := testDefaultProjectResource()
+	require.NoError(t, r.InternalValidate(nil, true))
+
+	projectID := r.Schema["project_id"]
+	require.False(t, projectID.Required)
+	require.True(t, projectID.Optional)
+	require.True(t, projectID.Computed)
+	require.True(t, projectID.ForceNew)
+	require.NotNil(t, r.CustomizeDiff)
+}
+
+func TestAddDefaultProjectID_IgnoresOptionalProjectID(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+	AddDefaultProjectID(r)
+
+	require.False(t, r.Schema["project_id"].Computed)
+	require.Nil(t, r.CustomizeDiff)
+}
+
+func TestAddDefaultProjectID_UsesProviderDefault(t *testing.T) {
+	r := testDefaultProjectResource()
+	clients := &client.AggregatedClient{DefaultProjectID: "default-project"}
+
+	require.NoError(t, testDiff(t, r, map[string]cty.Value{
+		"name": cty.StringVal("name"),
+	}, clients))
+	require.NoError(t, testDiff(t, r, map[string]cty.Value{
+		"name":       cty.StringVal("name"),
+		"project_id": cty.StringVal("project"),
+	}, &client.AggregatedClient{}))
+}
+
+func TestAddDefaultProjectID_ErrorsWithoutProjectID(t *testing.T) {
+	r := testDefaultProjectResource()
+
+	err := testDiff(t, r, map[string]cty.Value{
+		"name": cty.StringVal("name"),
+	}, &client.AggregatedClient{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "default_project_id")
+}
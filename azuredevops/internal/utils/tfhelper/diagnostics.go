@@ -0,0 +1,40 @@
+package tfhelper
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+)
+
+// AddStructuredDiagnostics reports the errors of the operations of a resource or data source with the error code
+// and the activity ID of the failed Azure DevOps request, see utils.ErrorDiagnostics.
+//
+// The operations returning an error are replaced by their context aware counterparts, as plain errors are turned
+// into diagnostics by the plugin SDK without any detail. Operations returning diagnostics already are expected to
+// build them with utils.ErrorDiagnostics.
+func AddStructuredDiagnostics(r *schema.Resource) {
+	if r.Create != nil {
+		r.CreateContext = withErrorDiagnostics(r.Create)
+		r.Create = nil
+	}
+	if r.Read != nil {
+		r.ReadContext = withErrorDiagnostics(r.Read)
+		r.Read = nil
+	}
+	if r.Update != nil {
+		r.UpdateContext = withErrorDiagnostics(r.Update)
+		r.Update = nil
+	}
+	if r.Delete != nil {
+		r.DeleteContext = withErrorDiagnostics(r.Delete)
+		r.Delete = nil
+	}
+}
+
+func withErrorDiagnostics(f func(*schema.ResourceData, interface{}) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		return utils.ErrorDiagnostics(f(d, m))
+	}
+}
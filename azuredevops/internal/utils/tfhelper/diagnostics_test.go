@@ -0,0 +1,53 @@
+package tfhelper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddStructuredDiagnostics_ReplacesLegacyOperations(t *testing.T) {
+	statusCode := 409
+	message := "TF401019: conflict"
+	typeKey := "GitRepositoryNameAlreadyExistsException"
+	r := &schema.Resource{
+		Create: func(d *schema.ResourceData, m interface{}) error {
+			return fmt.Errorf(" creating repository: %w", azuredevops.WrappedError{
+				StatusCode: &statusCode,
+				Message:    &message,
+				TypeKey:    &typeKey,
+			})
+		},
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			return nil
+		},
+		Delete: func(d *schema.ResourceData, m interface{}) error {
+			return errors.New("failed")
+		},
+		Schema: map[string]*schema.Schema{},
+	}
+	AddStructuredDiagnostics(r)
+
+	require.Nil(t, r.Create)
+	require.Nil(t, r.Read)
+	require.Nil(t, r.Update)
+	require.Nil(t, r.Delete)
+	require.Nil(t, r.UpdateContext)
+
+	d := r.TestResourceData()
+	diags := r.CreateContext(context.Background(), d, nil)
+	require.Len(t, diags, 1)
+	require.Equal(t, "creating repository: TF401019: conflict", diags[0].Summary)
+	require.Equal(t, "Azure DevOps error: GitRepositoryNameAlreadyExistsException, HTTP 409", diags[0].Detail)
+
+	require.Nil(t, r.ReadContext(context.Background(), d, nil))
+
+	diags = r.DeleteContext(context.Background(), d, nil)
+	require.Len(t, diags, 1)
+	require.Equal(t, "failed", diags[0].Summary)
+}
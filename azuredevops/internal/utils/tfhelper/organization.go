@@ -0,0 +1,143 @@
+package tfhelper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+)
+
+const (
+	organizationKey = "organization"
+	// OrganizationImportSeparator separates the organization alias from the import ID of a resource
+	OrganizationImportSeparator = "::"
+)
+
+// AddOrganizationOverride adds an optional `organization` argument to a resource or data source, referencing
+// one of the `organization_aliases` of the provider. The operations of the resource are invoked with the
+// clients of that organization, or with the clients of the provider organization when it is omitted.
+func AddOrganizationOverride(r *schema.Resource, isDataSource bool) {
+	if _, ok := r.Schema[organizationKey]; ok {
+		return
+	}
+
+	r.Schema[organizationKey] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    !isDataSource,
+		Description: "The alias of the organization, as configured in `organization_aliases` of the provider, to manage the resource in. Defaults to the organization of the provider.",
+	}
+
+	r.Create = withOrganization(r.Create)
+	r.Read = withOrganization(r.Read)
+	r.Update = withOrganization(r.Update)
+	r.Delete = withOrganization(r.Delete)
+	r.CreateContext = withOrganizationContext(r.CreateContext)
+	r.ReadContext = withOrganizationContext(r.ReadContext)
+	r.UpdateContext = withOrganizationContext(r.UpdateContext)
+	r.DeleteContext = withOrganizationContext(r.DeleteContext)
+	r.CreateWithoutTimeout = withOrganizationContext(r.CreateWithoutTimeout)
+	r.ReadWithoutTimeout = withOrganizationContext(r.ReadWithoutTimeout)
+	r.UpdateWithoutTimeout = withOrganizationContext(r.UpdateWithoutTimeout)
+	r.DeleteWithoutTimeout = withOrganizationContext(r.DeleteWithoutTimeout)
+
+	if r.CustomizeDiff != nil {
+		customizeDiff := r.CustomizeDiff
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			clients, err := organizationClients(d.Get(organizationKey).(string), m)
+			if err != nil {
+				return err
+			}
+			return customizeDiff(ctx, d, clients)
+		}
+	}
+
+	if r.Importer != nil {
+		r.Importer = withOrganizationImporter(r.Importer)
+	}
+}
+
+// organizationClients returns the clients of the organization referenced by the alias. The meta of the
+// provider is returned as is when it is not configured, for instance during validation.
+func organizationClients(alias string, m interface{}) (interface{}, error) {
+	clients, ok := m.(*client.AggregatedClient)
+	if !ok || clients == nil {
+		return m, nil
+	}
+	return clients.ForOrganization(alias)
+}
+
+func withOrganization(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, m interface{}) error {
+		clients, err := organizationClients(d.Get(organizationKey).(string), m)
+		if err != nil {
+			return err
+		}
+		return f(d, clients)
+	}
+}
+
+func withOrganizationContext(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		clients, err := organizationClients(d.Get(organizationKey).(string), m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, clients)
+	}
+}
+
+// withOrganizationImporter accepts import IDs prefixed with an organization alias, i.e. `<alias>::<import ID>`
+func withOrganizationImporter(importer *schema.ResourceImporter) *schema.ResourceImporter {
+	importOrganization := func(d *schema.ResourceData, m interface{}) (interface{}, error) {
+		alias, id, ok := strings.Cut(d.Id(), OrganizationImportSeparator)
+		if !ok {
+			return m, nil
+		}
+		if alias == "" || id == "" {
+			return nil, fmt.Errorf(" import ID must be in the format <organization alias>%s<import ID>, got: %s", OrganizationImportSeparator, d.Id())
+		}
+
+		clients, err := organizationClients(alias, m)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+		if err := d.Set(organizationKey, alias); err != nil {
+			return nil, err
+		}
+		return clients, nil
+	}
+
+	wrapped := &schema.ResourceImporter{}
+	if importer.State != nil {
+		state := importer.State
+		wrapped.State = func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			clients, err := importOrganization(d, m)
+			if err != nil {
+				return nil, err
+			}
+			return state(d, clients)
+		}
+	}
+	if importer.StateContext != nil {
+		stateContext := importer.StateContext
+		wrapped.StateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			clients, err := importOrganization(d, m)
+			if err != nil {
+				return nil, err
+			}
+			return stateContext(ctx, d, clients)
+		}
+	}
+	return wrapped
+}
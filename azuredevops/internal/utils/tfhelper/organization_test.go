@@ -0,0 +1,117 @@
+package tfhelper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/stretchr/testify/require"
+)
+
+// testOrganizationResource records the organization URL of the clients passed to its operations
+func testOrganizationResource(organizationURL *string) *schema.Resource {
+	record := func(m interface{}) {
+		*organizationURL = m.(*client.AggregatedClient).OrganizationURL
+	}
+	r := &schema.Resource{
+		Create: func(d *schema.ResourceData, m interface{}) error {
+			record(m)
+			return nil
+		},
+		ReadContext: func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			record(m)
+			return nil
+		},
+		Delete: func(d *schema.ResourceData, m interface{}) error {
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				record(m)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+	AddOrganizationOverride(r, false)
+	return r
+}
+
+func testOrganizationClients() *client.AggregatedClient {
+	return (&client.AggregatedClient{OrganizationURL: "https://dev.azure.com/default"}).WithOrganizationClients(map[string]*client.AggregatedClient{
+		"other": {OrganizationURL: "https://dev.azure.com/other"},
+	})
+}
+
+func TestAddOrganizationOverride_AddsOrganizationArgument(t *testing.T) {
+	var organizationURL string
+	r := testOrganizationResource(&organizationURL)
+	require.NoError(t, r.InternalValidate(nil, true))
+
+	organization := r.Schema["organization"]
+	require.True(t, organization.Optional)
+	require.True(t, organization.ForceNew)
+}
+
+func TestAddOrganizationOverride_UsesClientsOfOrganization(t *testing.T) {
+	var organizationURL string
+	r := testOrganizationResource(&organizationURL)
+	clients := testOrganizationClients()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	require.NoError(t, r.Create(d, clients))
+	require.Equal(t, "https://dev.azure.com/default", organizationURL)
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"organization": "other"})
+	require.NoError(t, r.Create(d, clients))
+	require.Equal(t, "https://dev.azure.com/other", organizationURL)
+
+	require.False(t, r.ReadContext(context.Background(), d, clients).HasError())
+	require.Equal(t, "https://dev.azure.com/other", organizationURL)
+}
+
+func TestAddOrganizationOverride_ErrorsOnUnknownAlias(t *testing.T) {
+	var organizationURL string
+	r := testOrganizationResource(&organizationURL)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"organization": "missing"})
+	err := r.Create(d, testOrganizationClients())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `"missing"`)
+	require.Contains(t, err.Error(), "[other]")
+	require.Empty(t, organizationURL)
+}
+
+func TestAddOrganizationOverride_ImportsIntoOrganization(t *testing.T) {
+	var organizationURL string
+	r := testOrganizationResource(&organizationURL)
+	clients := testOrganizationClients()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("other::project/id")
+	_, err := r.Importer.State(d, clients)
+	require.NoError(t, err)
+	require.Equal(t, "project/id", d.Id())
+	require.Equal(t, "other", d.Get("organization"))
+	require.Equal(t, "https://dev.azure.com/other", organizationURL)
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("project/id")
+	_, err = r.Importer.State(d, clients)
+	require.NoError(t, err)
+	require.Equal(t, "project/id", d.Id())
+	require.Equal(t, "https://dev.azure.com/default", organizationURL)
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("::project/id")
+	_, err = r.Importer.State(d, clients)
+	require.Error(t, err)
+}
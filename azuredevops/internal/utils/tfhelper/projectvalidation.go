@@ -0,0 +1,37 @@
+package tfhelper
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+)
+
+// AddProjectValidation verifies the project referenced by the `project_id` argument of a resource exists when
+// `validate_project_references` is enabled in the provider. The project is checked when the resource is
+// created or its project changes, after the other customizations of the diff, e.g. the default project.
+// Resources without a `project_id` argument are left unchanged.
+func AddProjectValidation(r *schema.Resource) {
+	projectID, ok := r.Schema[projectIDKey]
+	if !ok || projectID.Type != schema.TypeString || (!projectID.Required && !projectID.Optional) {
+		return
+	}
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(ctx, d, m); err != nil {
+				return err
+			}
+		}
+
+		clients, ok := m.(*client.AggregatedClient)
+		if !ok || clients == nil || !d.NewValueKnown(projectIDKey) {
+			return nil
+		}
+		if d.Id() != "" && !d.HasChange(projectIDKey) {
+			return nil
+		}
+		return clients.ValidateProjectReference(d.Get(projectIDKey).(string))
+	}
+}
@@ -0,0 +1,80 @@
+package tfhelper
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/stretchr/testify/require"
+)
+
+func testProjectValidationResource() *schema.Resource {
+	r := &schema.Resource{
+		Create: func(d *schema.ResourceData, m interface{}) error { return nil },
+		Read:   func(d *schema.ResourceData, m interface{}) error { return nil },
+		Delete: func(d *schema.ResourceData, m interface{}) error { return nil },
+		Schema: map[string]*schema.Schema{
+			"project_id": {Type: schema.TypeString, Required: true, ForceNew: true},
+		},
+	}
+	AddProjectValidation(r)
+	return r
+}
+
+func TestAddProjectValidation_FailsPlanForMissingProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	clients := (&client.AggregatedClient{CoreClient: coreClient, Ctx: context.Background()}).WithProjectValidation()
+
+	projectID := "missing"
+	notFound := http.StatusNotFound
+	coreClient.EXPECT().
+		GetProject(clients.Ctx, core.GetProjectArgs{ProjectId: &projectID}).
+		Return(nil, azuredevops.WrappedError{StatusCode: &notFound}).
+		Times(1)
+
+	r := testProjectValidationResource()
+	require.NoError(t, r.InternalValidate(nil, true))
+	err := testDiff(t, r, map[string]cty.Value{"project_id": cty.StringVal(projectID)}, clients)
+	require.EqualError(t, err, " project missing does not exist or the credentials of the provider cannot access it")
+}
+
+func TestAddProjectValidation_LooksUpProjectsOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	clients := (&client.AggregatedClient{CoreClient: coreClient, Ctx: context.Background()}).WithProjectValidation()
+
+	projectID := "existing"
+	coreClient.EXPECT().
+		GetProject(clients.Ctx, core.GetProjectArgs{ProjectId: &projectID}).
+		Return(&core.TeamProject{Name: &projectID}, nil).
+		Times(1)
+
+	r := testProjectValidationResource()
+	for i := 0; i < 2; i++ {
+		require.NoError(t, testDiff(t, r, map[string]cty.Value{"project_id": cty.StringVal(projectID)}, clients))
+	}
+}
+
+func TestAddProjectValidation_SkipsWhenDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	clients := &client.AggregatedClient{CoreClient: coreClient, Ctx: context.Background()}
+
+	r := testProjectValidationResource()
+	require.NoError(t, testDiff(t, r, map[string]cty.Value{"project_id": cty.StringVal("any")}, clients))
+	require.NoError(t, testDiff(t, r, map[string]cty.Value{"project_id": cty.UnknownVal(cty.String)}, clients.WithProjectValidation()))
+}
@@ -0,0 +1,95 @@
+package tfhelper
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+)
+
+// AddTokenScopeValidation checks the scope a resource or data source requires against the scopes of the token
+// of the provider, when `validate_token_scopes` is enabled. Resources are checked when they are planned, data
+// sources before they are read. Authorization errors of the operations are explained with the scope, as the
+// scopes of PATs cannot be checked up front.
+func AddTokenScopeValidation(r *schema.Resource, resourceType string, scope string, isDataSource bool) {
+	if scope == "" {
+		return
+	}
+
+	if isDataSource {
+		r.Read = withTokenScope(r.Read, resourceType, scope, true)
+		r.ReadContext = withTokenScopeContext(r.ReadContext, resourceType, scope, true)
+		r.ReadWithoutTimeout = withTokenScopeContext(r.ReadWithoutTimeout, resourceType, scope, true)
+		return
+	}
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if clients, ok := m.(*client.AggregatedClient); ok && clients != nil {
+			if err := clients.CheckTokenScope(scope, resourceType); err != nil {
+				return err
+			}
+		}
+		if customizeDiff == nil {
+			return nil
+		}
+		return customizeDiff(ctx, d, m)
+	}
+
+	r.Create = withTokenScope(r.Create, resourceType, scope, false)
+	r.Read = withTokenScope(r.Read, resourceType, scope, false)
+	r.Update = withTokenScope(r.Update, resourceType, scope, false)
+	r.Delete = withTokenScope(r.Delete, resourceType, scope, false)
+	r.CreateContext = withTokenScopeContext(r.CreateContext, resourceType, scope, false)
+	r.ReadContext = withTokenScopeContext(r.ReadContext, resourceType, scope, false)
+	r.UpdateContext = withTokenScopeContext(r.UpdateContext, resourceType, scope, false)
+	r.DeleteContext = withTokenScopeContext(r.DeleteContext, resourceType, scope, false)
+	r.CreateWithoutTimeout = withTokenScopeContext(r.CreateWithoutTimeout, resourceType, scope, false)
+	r.ReadWithoutTimeout = withTokenScopeContext(r.ReadWithoutTimeout, resourceType, scope, false)
+	r.UpdateWithoutTimeout = withTokenScopeContext(r.UpdateWithoutTimeout, resourceType, scope, false)
+	r.DeleteWithoutTimeout = withTokenScopeContext(r.DeleteWithoutTimeout, resourceType, scope, false)
+}
+
+func withTokenScope(f func(*schema.ResourceData, interface{}) error, resourceType string, scope string, check bool) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, m interface{}) error {
+		clients, ok := m.(*client.AggregatedClient)
+		if !ok || clients == nil {
+			return f(d, m)
+		}
+		if check {
+			if err := clients.CheckTokenScope(scope, resourceType); err != nil {
+				return err
+			}
+		}
+		return clients.TokenScopeError(f(d, m), scope, resourceType)
+	}
+}
+
+func withTokenScopeContext(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, resourceType string, scope string, check bool) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		clients, ok := m.(*client.AggregatedClient)
+		if !ok || clients == nil {
+			return f(ctx, d, m)
+		}
+		if check {
+			if err := clients.CheckTokenScope(scope, resourceType); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		diags := f(ctx, d, m)
+		for i := range diags {
+			if diags[i].Severity == diag.Error {
+				diags[i].Summary = clients.TokenScopeError(errors.New(diags[i].Summary), scope, resourceType).Error()
+			}
+		}
+		return diags
+	}
+}
@@ -0,0 +1,82 @@
+package tfhelper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/stretchr/testify/require"
+)
+
+func testTokenScopeResource(err error) *schema.Resource {
+	r := &schema.Resource{
+		Create: func(d *schema.ResourceData, m interface{}) error { return err },
+		Read:   func(d *schema.ResourceData, m interface{}) error { return err },
+		Delete: func(d *schema.ResourceData, m interface{}) error { return nil },
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Required: true, ForceNew: true},
+		},
+	}
+	AddTokenScopeValidation(r, "azuredevops_feed", "vso.packaging_manage", false)
+	return r
+}
+
+func TestAddTokenScopeValidation_FailsPlanWithoutScope(t *testing.T) {
+	r := testTokenScopeResource(nil)
+	require.NoError(t, r.InternalValidate(nil, true))
+	config := map[string]cty.Value{"name": cty.StringVal("feed")}
+
+	clients := (&client.AggregatedClient{}).WithTokenScopes([]string{"vso.packaging", "vso.code_manage"})
+	err := testDiff(t, r, config, clients)
+	require.EqualError(t, err, " the token of the provider is missing the vso.packaging_manage scope required by azuredevops_feed")
+
+	clients = (&client.AggregatedClient{}).WithTokenScopes([]string{"vso.packaging_manage"})
+	require.NoError(t, testDiff(t, r, config, clients))
+}
+
+func TestAddTokenScopeValidation_SkipsUnknownScopes(t *testing.T) {
+	r := testTokenScopeResource(nil)
+	config := map[string]cty.Value{"name": cty.StringVal("feed")}
+
+	require.NoError(t, testDiff(t, r, config, (&client.AggregatedClient{}).WithTokenScopes(nil)))
+	require.NoError(t, testDiff(t, r, config, &client.AggregatedClient{}))
+}
+
+func TestAddTokenScopeValidation_ExplainsAuthorizationErrors(t *testing.T) {
+	r := testTokenScopeResource(errors.New(" creating feed: REST call returned status code 401"))
+	d := r.TestResourceData()
+
+	err := r.Create(d, (&client.AggregatedClient{}).WithTokenScopes(nil))
+	require.EqualError(t, err, " azuredevops_feed was denied access, make sure the token of the provider has the vso.packaging_manage scope:  creating feed: REST call returned status code 401")
+
+	// errors are returned as is when token scope validation is disabled
+	err = r.Create(d, &client.AggregatedClient{})
+	require.EqualError(t, err, " creating feed: REST call returned status code 401")
+
+	r = testTokenScopeResource(errors.New(" creating feed: REST call returned status code 400"))
+	err = r.Create(d, (&client.AggregatedClient{}).WithTokenScopes(nil))
+	require.EqualError(t, err, " creating feed: REST call returned status code 400")
+}
+
+func TestAddTokenScopeValidation_ChecksDataSourcesBeforeRead(t *testing.T) {
+	read := false
+	r := &schema.Resource{
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			read = true
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Optional: true},
+		},
+	}
+	AddTokenScopeValidation(r, "azuredevops_feed", "vso.packaging", true)
+
+	err := r.Read(r.TestResourceData(), (&client.AggregatedClient{}).WithTokenScopes([]string{"vso.code"}))
+	require.EqualError(t, err, " the token of the provider is missing the vso.packaging scope required by azuredevops_feed")
+	require.False(t, read)
+
+	require.NoError(t, r.Read(r.TestResourceData(), (&client.AggregatedClient{}).WithTokenScopes([]string{"vso.packaging_manage"})))
+	require.True(t, read)
+}
@@ -0,0 +1,228 @@
+package tfhelper
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// WriteOnlySuffix is appended to the name of a sensitive argument to name its write-only counterpart
+	WriteOnlySuffix = "_wo"
+	// WriteOnlyVersionSuffix is appended to the name of a sensitive argument to name the argument triggering
+	// the update of its write-only counterpart
+	WriteOnlyVersionSuffix = "_wo_version"
+)
+
+// AddWriteOnlySecrets adds a write-only counterpart `<name>_wo` to each of the sensitive arguments found at the
+// given addresses, e.g. `password` or `authentication_basic.0.password`. Write-only arguments are never
+// persisted in the plan or the state, which requires Terraform 1.11 or later. As Terraform cannot detect
+// changes of write-only arguments, the `<name>_wo_version` argument has to be changed to update the secret.
+//
+// Sensitive arguments which were required become optional, the requirement of one of both arguments being
+// set is enforced at plan time instead.
+func AddWriteOnlySecrets(r *schema.Resource, addresses ...string) {
+	var required []string
+	for _, address := range addresses {
+		parent, name := splitAddress(address)
+		schemaMap := resolveSchemaMap(r.Schema, parent)
+		secret, ok := schemaMap[name]
+		if !ok {
+			panic(fmt.Sprintf("sensitive argument %s does not exist", address))
+		}
+
+		if secret.Required {
+			secret.Required = false
+			secret.Optional = true
+			required = append(required, address)
+		}
+		secret.ConflictsWith = append(secret.ConflictsWith, address+WriteOnlySuffix)
+
+		schemaMap[name+WriteOnlySuffix] = &schema.Schema{
+			Type:          schema.TypeString,
+			Optional:      true,
+			WriteOnly:     true,
+			Sensitive:     true,
+			ValidateFunc:  secret.ValidateFunc,
+			ConflictsWith: []string{address},
+			Description:   fmt.Sprintf("Write-only variant of `%s`, which is never persisted in the plan or the state.", name),
+		}
+		schemaMap[name+WriteOnlyVersionSuffix] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			RequiredWith: []string{address + WriteOnlySuffix},
+			Description:  fmt.Sprintf("The version of `%s%s`, changing it sends the current value of `%s%s` to Azure DevOps.", name, WriteOnlySuffix, name, WriteOnlySuffix),
+		}
+	}
+
+	if len(required) > 0 {
+		requireSecrets := RequireSecrets(required...)
+		if r.CustomizeDiff == nil {
+			r.CustomizeDiff = requireSecrets
+		} else {
+			r.CustomizeDiff = customdiff.All(r.CustomizeDiff, requireSecrets)
+		}
+	}
+}
+
+// RequireSecrets ensures either the sensitive argument at each of the addresses or its write-only counterpart
+// is configured. Arguments nested in a block are only required when the block is configured.
+func RequireSecrets(addresses ...string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		for _, address := range addresses {
+			if parent, _ := splitAddress(address); parent != "" {
+				block := strings.SplitN(parent, ".", 2)[0]
+				if v, ok := d.GetOk(block); !ok || len(v.([]interface{})) == 0 {
+					continue
+				}
+			}
+			if !d.NewValueKnown(address) {
+				continue
+			}
+			if v, ok := d.GetOk(address); ok && v.(string) != "" {
+				continue
+			}
+
+			writeOnly := RawConfigValue(d.GetRawConfig(), address+WriteOnlySuffix)
+			if !writeOnly.IsKnown() || (!writeOnly.IsNull() && writeOnly.AsString() != "") {
+				continue
+			}
+			return fmt.Errorf(" one of `%s` or `%s%s` must be set", address, address, WriteOnlySuffix)
+		}
+		return nil
+	}
+}
+
+// SecretValue returns the value of the write-only counterpart of the sensitive argument at the address when
+// it is configured, the value of the sensitive argument otherwise.
+func SecretValue(d *schema.ResourceData, address string) string {
+	if v := WriteOnlyString(d, address+WriteOnlySuffix); v != "" {
+		return v
+	}
+	return d.Get(address).(string)
+}
+
+// WriteOnlyString returns the value of the write-only string argument at the address. Write-only arguments
+// are only available in the configuration, an empty string is returned if the configuration is not
+// available, e.g. when reading or deleting a resource.
+func WriteOnlyString(d *schema.ResourceData, address string) string {
+	v := RawConfigValue(d.GetRawConfig(), address)
+	if v.IsNull() || !v.IsKnown() || !v.Type().Equals(cty.String) {
+		return ""
+	}
+	return v.AsString()
+}
+
+// RawConfigValue returns the value at the address, e.g. `authentication_basic.0.password`, of a raw
+// configuration. A null value is returned if the address does not exist.
+func RawConfigValue(config cty.Value, address string) cty.Value {
+	v := config
+	for _, step := range strings.Split(address, ".") {
+		if v.IsNull() || !v.IsKnown() {
+			return v
+		}
+
+		if index, err := strconv.Atoi(step); err == nil {
+			if !v.Type().IsListType() && !v.Type().IsTupleType() {
+				return cty.NullVal(cty.DynamicPseudoType)
+			}
+			if index >= v.LengthInt() {
+				return cty.NullVal(cty.DynamicPseudoType)
+			}
+			v = v.Index(cty.NumberIntVal(int64(index)))
+			continue
+		}
+
+		if !v.Type().IsObjectType() || !v.Type().HasAttribute(step) {
+			return cty.NullVal(cty.DynamicPseudoType)
+		}
+		v = v.GetAttr(step)
+	}
+	return v
+}
+
+// NewWriteOnlyHashSalt returns a random salt to key the hashes of the write-only values of a resource with
+func NewWriteOnlyHashSalt() (string, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf(" generating salt: %w", err)
+	}
+	return hex.EncodeToString(salt), nil
+}
+
+// WriteOnlyHash returns the HMAC-SHA256 of a write-only value keyed with the salt of the resource. The hash is
+// persisted in the state instead of the value, so changes of the value are detected without a version
+// argument. The salt keeps low-entropy values from being looked up in precomputed tables.
+func WriteOnlyHash(salt string, value string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// WriteOnlyHashes returns the hashes of the write-only values of the blocks of the list at the address of a
+// raw configuration, keyed by the name attribute of the blocks. false is returned when any of the names or
+// values is not known yet.
+func WriteOnlyHashes(config cty.Value, address string, nameAttribute string, valueAttribute string, salt string) (map[string]string, bool) {
+	hashes := map[string]string{}
+	blocks := RawConfigValue(config, address)
+	if !blocks.IsKnown() {
+		return nil, false
+	}
+	if blocks.IsNull() || !(blocks.Type().IsListType() || blocks.Type().IsTupleType()) {
+		return hashes, true
+	}
+
+	for it := blocks.ElementIterator(); it.Next(); {
+		_, block := it.Element()
+		if !block.IsKnown() {
+			return nil, false
+		}
+		if block.IsNull() {
+			continue
+		}
+		name := block.GetAttr(nameAttribute)
+		value := block.GetAttr(valueAttribute)
+		if !name.IsKnown() || !value.IsKnown() {
+			return nil, false
+		}
+		if name.IsNull() || value.IsNull() {
+			continue
+		}
+		hashes[name.AsString()] = WriteOnlyHash(salt, value.AsString())
+	}
+	return hashes, true
+}
+
+func splitAddress(address string) (string, string) {
+	i := strings.LastIndex(address, ".")
+	if i < 0 {
+		return "", address
+	}
+	return address[:i], address[i+1:]
+}
+
+func resolveSchemaMap(s map[string]*schema.Schema, parent string) map[string]*schema.Schema {
+	if parent == "" {
+		return s
+	}
+	for _, step := range strings.Split(parent, ".") {
+		if _, err := strconv.Atoi(step); err == nil {
+			continue
+		}
+		block, ok := s[step]
+		if !ok {
+			panic(fmt.Sprintf("block %s does not exist", parent))
+		}
+		s = block.Elem.(*schema.Resource).Schema
+	}
+	return s
+}
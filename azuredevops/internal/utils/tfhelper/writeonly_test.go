@@ -0,0 +1,199 @@
+package tfhelper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func testWriteOnlyResource() *schema.Resource {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"password": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"authentication_token": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"token": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+		},
+	}
+	AddWriteOnlySecrets(r, "password", "authentication_token.0.token")
+	return r
+}
+
+// testDiff plans the creation of the resource with the configuration, unset arguments are null
+func testDiff(t *testing.T, r *schema.Resource, config map[string]cty.Value, meta interface{}) error {
+	t.Helper()
+
+	ty := r.CoreConfigSchema().ImpliedType()
+	attrs := map[string]cty.Value{}
+	for name, attrTy := range ty.AttributeTypes() {
+		if v, ok := config[name]; ok {
+			attrs[name] = v
+		} else {
+			attrs[name] = cty.NullVal(attrTy)
+		}
+	}
+
+	// the SDK passes the raw configuration to the diff through the prior state
+	configVal := cty.ObjectVal(attrs)
+	state := &terraform.InstanceState{RawConfig: configVal}
+	_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(configVal, r.CoreConfigSchema()), meta)
+	return err
+}
+
+func TestAddWriteOnlySecrets_AddsWriteOnlyCounterparts(t *testing.T) {
+	r := testWriteOnlyResource()
+	require.NoError(t, r.InternalValidate(nil, true))
+
+	require.True(t, r.Schema["password"].Optional)
+	require.False(t, r.Schema["password"].Required)
+	require.Equal(t, []string{"password_wo"}, r.Schema["password"].ConflictsWith)
+
+	passwordWO := r.Schema["password_wo"]
+	require.True(t, passwordWO.WriteOnly)
+	require.True(t, passwordWO.Sensitive)
+	require.Equal(t, []string{"password"}, passwordWO.ConflictsWith)
+	require.Equal(t, []string{"password_wo"}, r.Schema["password_wo_version"].RequiredWith)
+
+	tokenSchema := r.Schema["authentication_token"].Elem.(*schema.Resource).Schema
+	require.True(t, tokenSchema["token_wo"].WriteOnly)
+	require.Equal(t, []string{"authentication_token.0.token"}, tokenSchema["token_wo"].ConflictsWith)
+	require.Equal(t, []string{"authentication_token.0.token_wo"}, tokenSchema["token_wo_version"].RequiredWith)
+	require.NotNil(t, r.CustomizeDiff)
+}
+
+func TestAddWriteOnlySecrets_PanicsOnMissingArgument(t *testing.T) {
+	r := &schema.Resource{Schema: map[string]*schema.Schema{}}
+	require.Panics(t, func() { AddWriteOnlySecrets(r, "password") })
+	require.Panics(t, func() { AddWriteOnlySecrets(r, "authentication_token.0.token") })
+}
+
+func TestRequireSecrets_AcceptsEitherArgument(t *testing.T) {
+	r := testWriteOnlyResource()
+
+	require.NoError(t, testDiff(t, r, map[string]cty.Value{
+		"password": cty.StringVal("secret"),
+	}, nil))
+	require.NoError(t, testDiff(t, r, map[string]cty.Value{
+		"password_wo": cty.StringVal("secret"),
+	}, nil))
+	require.NoError(t, testDiff(t, r, map[string]cty.Value{
+		"password_wo": cty.UnknownVal(cty.String),
+	}, nil))
+}
+
+func TestRequireSecrets_ErrorsWhenNoArgumentIsSet(t *testing.T) {
+	r := testWriteOnlyResource()
+
+	err := testDiff(t, r, map[string]cty.Value{}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "`password` or `password_wo`")
+}
+
+func TestRequireSecrets_OnlyRequiresNestedArgumentsOfConfiguredBlocks(t *testing.T) {
+	r := testWriteOnlyResource()
+
+	require.NoError(t, testDiff(t, r, map[string]cty.Value{
+		"password": cty.StringVal("secret"),
+	}, nil))
+
+	err := testDiff(t, r, map[string]cty.Value{
+		"password": cty.StringVal("secret"),
+		"authentication_token": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"token":            cty.NullVal(cty.String),
+			"token_wo":         cty.NullVal(cty.String),
+			"token_wo_version": cty.NumberIntVal(1),
+		})}),
+	}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "`authentication_token.0.token` or `authentication_token.0.token_wo`")
+
+	require.NoError(t, testDiff(t, r, map[string]cty.Value{
+		"password": cty.StringVal("secret"),
+		"authentication_token": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"token":            cty.NullVal(cty.String),
+			"token_wo":         cty.StringVal("secret"),
+			"token_wo_version": cty.NullVal(cty.Number),
+		})}),
+	}, nil))
+}
+
+func TestRawConfigValue(t *testing.T) {
+	config := cty.ObjectVal(map[string]cty.Value{
+		"password": cty.StringVal("secret"),
+		"authentication_token": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"token_wo": cty.StringVal("token"),
+		})}),
+		"authentication_basic": cty.NullVal(cty.List(cty.Object(map[string]cty.Type{
+			"password_wo": cty.String,
+		}))),
+	})
+
+	require.Equal(t, cty.StringVal("secret"), RawConfigValue(config, "password"))
+	require.Equal(t, cty.StringVal("token"), RawConfigValue(config, "authentication_token.0.token_wo"))
+	require.True(t, RawConfigValue(config, "authentication_token.1.token_wo").IsNull())
+	require.True(t, RawConfigValue(config, "authentication_basic.0.password_wo").IsNull())
+	require.True(t, RawConfigValue(config, "missing").IsNull())
+	require.True(t, RawConfigValue(cty.NullVal(config.Type()), "password").IsNull())
+	require.False(t, RawConfigValue(cty.UnknownVal(config.Type()), "password").IsKnown())
+}
+
+func TestWriteOnlyHashes(t *testing.T) {
+	blockType := cty.Object(map[string]cty.Type{"name": cty.String, "value_wo": cty.String})
+	config := cty.ObjectVal(map[string]cty.Value{
+		"secret_variable": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("first"), "value_wo": cty.StringVal("secret")}),
+			cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("second"), "value_wo": cty.NullVal(cty.String)}),
+		}),
+		"unknown_variable": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("first"), "value_wo": cty.UnknownVal(cty.String)}),
+		}),
+		"empty_variable": cty.NullVal(cty.List(blockType)),
+	})
+
+	hashes, known := WriteOnlyHashes(config, "secret_variable", "name", "value_wo", "salt")
+	require.True(t, known)
+	require.Equal(t, map[string]string{"first": WriteOnlyHash("salt", "secret")}, hashes)
+	require.NotEqual(t, WriteOnlyHash("salt", "secret"), WriteOnlyHash("salt", "other"))
+
+	_, known = WriteOnlyHashes(config, "unknown_variable", "name", "value_wo", "salt")
+	require.False(t, known)
+
+	hashes, known = WriteOnlyHashes(config, "empty_variable", "name", "value_wo", "salt")
+	require.True(t, known)
+	require.Empty(t, hashes)
+}
+
+func TestWriteOnlyHash_IsKeyedWithSalt(t *testing.T) {
+	first, err := NewWriteOnlyHashSalt()
+	require.NoError(t, err)
+	second, err := NewWriteOnlyHashSalt()
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+
+	require.Equal(t, WriteOnlyHash(first, "secret"), WriteOnlyHash(first, "secret"))
+	require.NotEqual(t, WriteOnlyHash(first, "secret"), WriteOnlyHash(second, "secret"))
+
+	unsalted := sha256.Sum256([]byte("secret"))
+	require.NotEqual(t, hex.EncodeToString(unsalted[:]), WriteOnlyHash(first, "secret"))
+}
@@ -2,6 +2,11 @@ package azuredevops
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -24,6 +29,7 @@ import (
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/servicehook"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/taskagent"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/workitemtracking"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/utils/sdk"
 )
 
@@ -41,10 +47,12 @@ func Provider() *schema.Provider {
 			"azuredevops_branch_policy_merge_types":              branch.ResourceBranchPolicyMergeTypes(),
 			"azuredevops_branch_policy_status_check":             branch.ResourceBranchPolicyStatusCheck(),
 			"azuredevops_build_definition":                       build.ResourceBuildDefinition(),
+			"azuredevops_build_definition_variable_group_link":   build.ResourceBuildDefinitionVariableGroupLink(),
 			"azuredevops_build_folder":                           build.ResourceBuildFolder(),
 			"azuredevops_project":                                core.ResourceProject(),
 			"azuredevops_project_features":                       core.ResourceProjectFeatures(),
 			"azuredevops_project_pipeline_settings":              core.ResourceProjectPipelineSettings(),
+			"azuredevops_organization_auditing":                  core.ResourceOrganizationAuditing(),
 			"azuredevops_variable_group":                         taskagent.ResourceVariableGroup(),
 			"azuredevops_repository_policy_author_email_pattern": repository.ResourceRepositoryPolicyAuthorEmailPatterns(),
 			"azuredevops_repository_policy_file_path_pattern":    repository.ResourceRepositoryFilePathPatterns(),
@@ -53,6 +61,7 @@ func Provider() *schema.Provider {
 			"azuredevops_repository_policy_max_path_length":      repository.ResourceRepositoryMaxPathLength(),
 			"azuredevops_repository_policy_max_file_size":        repository.ResourceRepositoryMaxFileSize(),
 			"azuredevops_repository_policy_check_credentials":    repository.ResourceRepositoryPolicyCheckCredentials(),
+			"azuredevops_project_repository_defaults":            repository.ResourceProjectRepositoryDefaults(),
 			"azuredevops_check_approval":                         approvalsandchecks.ResourceCheckApproval(),
 			"azuredevops_check_exclusive_lock":                   approvalsandchecks.ResourceCheckExclusiveLock(),
 			"azuredevops_check_branch_control":                   approvalsandchecks.ResourceCheckBranchControl(),
@@ -98,12 +107,15 @@ func Provider() *schema.Provider {
 			"azuredevops_group_membership":                       graph.ResourceGroupMembership(),
 			"azuredevops_agent_pool":                             taskagent.ResourceAgentPool(),
 			"azuredevops_elastic_pool":                           taskagent.ResourceAgentPoolVMSS(),
+			"azuredevops_deployment_pool":                        taskagent.ResourceDeploymentPool(),
 			"azuredevops_agent_queue":                            taskagent.ResourceAgentQueue(),
 			"azuredevops_group":                                  graph.ResourceGroup(),
 			"azuredevops_project_permissions":                    permissions.ResourceProjectPermissions(),
 			"azuredevops_git_permissions":                        permissions.ResourceGitPermissions(),
 			"azuredevops_workitemquery_permissions":              permissions.ResourceWorkItemQueryPermissions(),
 			"azuredevops_area_permissions":                       permissions.ResourceAreaPermissions(),
+			"azuredevops_test_plan_permissions":                  permissions.ResourceTestPlanPermissions(),
+			"azuredevops_process_permissions":                    permissions.ResourceProcessPermissions(),
 			"azuredevops_iteration_permissions":                  permissions.ResourceIterationPermissions(),
 			"azuredevops_build_definition_permissions":           permissions.ResourceBuildDefinitionPermissions(),
 			"azuredevops_build_folder_permissions":               permissions.ResourceBuildFolderPermissions(),
@@ -118,39 +130,52 @@ func Provider() *schema.Provider {
 			"azuredevops_environment":                            taskagent.ResourceEnvironment(),
 			"azuredevops_environment_resource_kubernetes":        taskagent.ResourceEnvironmentKubernetes(),
 			"azuredevops_workitem":                               workitemtracking.ResourceWorkItem(),
+			"azuredevops_process_backlog_level":                  workitemtracking.ResourceProcessBacklogLevel(),
+			"azuredevops_workitem_tag_normalization":             workitemtracking.ResourceWorkItemTagNormalization(),
+			"azuredevops_servicehook_jenkins":                    servicehook.ResourceServicehookJenkins(),
 			"azuredevops_servicehook_storage_queue_pipelines":    servicehook.ResourceServicehookStorageQueuePipelines(),
+			"azuredevops_servicehook_webhook_work_items":         servicehook.ResourceServicehookWebhookWorkItems(),
 			"azuredevops_feed":                                   feed.ResourceFeed(),
 			"azuredevops_feed_permission":                        feed.ResourceFeedPermission(),
+			"azuredevops_feed_permissions":                       feed.ResourceFeedPermissions(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"azuredevops_build_definition":           build.DataBuildDefinition(),
-			"azuredevops_agent_pool":                 taskagent.DataAgentPool(),
-			"azuredevops_agent_pools":                taskagent.DataAgentPools(),
-			"azuredevops_agent_queue":                taskagent.DataAgentQueue(),
-			"azuredevops_client_config":              service.DataClientConfig(),
-			"azuredevops_environment":                taskagent.DataEnvironment(),
-			"azuredevops_group":                      graph.DataGroup(),
-			"azuredevops_project":                    core.DataProject(),
-			"azuredevops_projects":                   core.DataProjects(),
-			"azuredevops_git_repositories":           git.DataGitRepositories(),
-			"azuredevops_git_repository":             git.DataGitRepository(),
-			"azuredevops_users":                      graph.DataUsers(),
-			"azuredevops_area":                       workitemtracking.DataArea(),
-			"azuredevops_iteration":                  workitemtracking.DataIteration(),
-			"azuredevops_team":                       core.DataTeam(),
-			"azuredevops_teams":                      core.DataTeams(),
-			"azuredevops_groups":                     graph.DataGroups(),
-			"azuredevops_identity_groups":            identity.DataIdentityGroups(),
-			"azuredevops_identity_group":             identity.DataIdentityGroup(),
-			"azuredevops_identity_user":              identity.DataIdentityUser(),
-			"azuredevops_variable_group":             taskagent.DataVariableGroup(),
-			"azuredevops_securityrole_definitions":   securityroles.DataSecurityRoleDefinitions(),
-			"azuredevops_serviceendpoint_azurerm":    serviceendpoint.DataServiceEndpointAzureRM(),
-			"azuredevops_serviceendpoint_github":     serviceendpoint.DataServiceEndpointGithub(),
-			"azuredevops_serviceendpoint_npm":        serviceendpoint.DataResourceServiceEndpointNpm(),
-			"azuredevops_serviceendpoint_azurecr":    serviceendpoint.DataResourceServiceEndpointAzureCR(),
-			"azuredevops_serviceendpoint_sonarcloud": serviceendpoint.DataResourceServiceEndpointSonarCloud(),
-			"azuredevops_feed":                       feed.DataFeed(),
+			"azuredevops_build_definition":             build.DataBuildDefinition(),
+			"azuredevops_agent_pool":                   taskagent.DataAgentPool(),
+			"azuredevops_agent_pools":                  taskagent.DataAgentPools(),
+			"azuredevops_agent_queue":                  taskagent.DataAgentQueue(),
+			"azuredevops_client_config":                service.DataClientConfig(),
+			"azuredevops_environment":                  taskagent.DataEnvironment(),
+			"azuredevops_environments":                 taskagent.DataEnvironments(),
+			"azuredevops_group":                        graph.DataGroup(),
+			"azuredevops_project":                      core.DataProject(),
+			"azuredevops_projects":                     core.DataProjects(),
+			"azuredevops_git_repositories":             git.DataGitRepositories(),
+			"azuredevops_git_repository":               git.DataGitRepository(),
+			"azuredevops_users":                        graph.DataUsers(),
+			"azuredevops_service_principal":            graph.DataServicePrincipal(),
+			"azuredevops_area":                         workitemtracking.DataArea(),
+			"azuredevops_iteration":                    workitemtracking.DataIteration(),
+			"azuredevops_team":                         core.DataTeam(),
+			"azuredevops_teams":                        core.DataTeams(),
+			"azuredevops_groups":                       graph.DataGroups(),
+			"azuredevops_identity_groups":              identity.DataIdentityGroups(),
+			"azuredevops_identity_group":               identity.DataIdentityGroup(),
+			"azuredevops_identity_user":                identity.DataIdentityUser(),
+			"azuredevops_variable_group":               taskagent.DataVariableGroup(),
+			"azuredevops_variable_groups":              taskagent.DataVariableGroups(),
+			"azuredevops_deployment_group_targets":     taskagent.DataDeploymentGroupTargets(),
+			"azuredevops_securityrole_definitions":     securityroles.DataSecurityRoleDefinitions(),
+			"azuredevops_securityrole_scopes":          securityroles.DataSecurityRoleScopes(),
+			"azuredevops_security_namespaces":          permissions.DataSecurityNamespaces(),
+			"azuredevops_serviceendpoint_azurerm":      serviceendpoint.DataServiceEndpointAzureRM(),
+			"azuredevops_serviceendpoint_github":       serviceendpoint.DataServiceEndpointGithub(),
+			"azuredevops_serviceendpoint_npm":          serviceendpoint.DataResourceServiceEndpointNpm(),
+			"azuredevops_serviceendpoint_azurecr":      serviceendpoint.DataResourceServiceEndpointAzureCR(),
+			"azuredevops_serviceendpoint_sonarcloud":   serviceendpoint.DataResourceServiceEndpointSonarCloud(),
+			"azuredevops_serviceendpoint_oidc_subject": serviceendpoint.DataServiceEndpointOidcSubject(),
+			"azuredevops_feed":                         feed.DataFeed(),
+			"azuredevops_servicehook_subscriptions":    servicehook.DataServicehookSubscriptions(),
 		},
 		Schema: map[string]*schema.Schema{
 			"org_service_url": {
@@ -255,14 +280,14 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_CLIENT_CERTIFICATE_PATH", nil),
-				Description: "Path to a certificate to use to authenticate to the service principal.",
+				Description: "Path to a PKCS#12 or PEM certificate to use to authenticate to the service principal.",
 			},
 			"client_certificate": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_CLIENT_CERTIFICATE", nil),
-				Description: "Base64 encoded certificate to use to authenticate to the service principal.",
+				Description: "Base64 encoded PKCS#12 certificate or PEM encoded certificate and private key to use to authenticate to the service principal.",
 			},
 			"client_certificate_password": {
 				Type:        schema.TypeString,
@@ -290,9 +315,133 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("ARM_USE_MSI", nil),
 				Description: "Use an Azure Managed Service Identity.",
 			},
+			"msi_resource_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_MSI_RESOURCE_ID", nil),
+				Description:  "The Azure resource id of the user assigned managed identity which should be used.",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_MAX_RETRIES", 3),
+				Description:  "The maximum number of times a request throttled by Azure DevOps is retried.",
+				ValidateFunc: validation.IntBetween(0, 20),
+			},
+			"retry_base_delay_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_RETRY_BASE_DELAY_SECONDS", 2),
+				Description:  "The delay in seconds before the first retry of a throttled request, when Azure DevOps does not return a Retry-After header.",
+				ValidateFunc: validation.IntBetween(1, 300),
+			},
+			"read_after_write_timeout_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_READ_AFTER_WRITE_TIMEOUT_SECONDS", 60),
+				Description:  "How long the read of a resource which was just created is retried while Azure DevOps does not find it yet, and the read of any resource after a transient error. Set to `0` to disable the retries.",
+				ValidateFunc: validation.IntBetween(0, 600),
+			},
+			"read_after_write_delay_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_READ_AFTER_WRITE_DELAY_SECONDS", 1),
+				Description:  "The delay in seconds before the first retry of the read of a resource which was just created. The delay doubles for every further retry.",
+				ValidateFunc: validation.IntBetween(1, 30),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_MAX_CONCURRENT_REQUESTS", 0),
+				Description:  "The maximum number of requests sent to an Azure DevOps organization at the same time, independent of the parallelism of Terraform. Defaults to `0`, meaning no limit.",
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+			"max_concurrent_identity_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_MAX_CONCURRENT_IDENTITY_REQUESTS", 0),
+				Description:  "The maximum number of requests sent to the Graph and Member Entitlement Management services of an Azure DevOps organization at the same time. Defaults to `0`, meaning no limit other than `max_concurrent_requests`.",
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+			"adaptive_throttling": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AZDO_ADAPTIVE_THROTTLING", nil),
+				Description: "Slow down requests when the rate limit reported by Azure DevOps is nearly exhausted.",
+			},
+			"default_project_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_DEFAULT_PROJECT_ID", nil),
+				Description:  "The ID of the project used by resources for which `project_id` is not set.",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"http_debug_logging": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AZDO_HTTP_DEBUG_LOGGING", nil),
+				Description: "Log the requests sent to Azure DevOps and their responses when `TF_LOG` is `DEBUG` or `TRACE`, with secrets redacted.",
+			},
+			"validate_token_scopes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AZDO_VALIDATE_TOKEN_SCOPES", nil),
+				Description: "Verify the credentials against the organization when the provider is configured, and check the scopes required by the planned resources against the scopes of the token.",
+			},
+			"personal_access_token_scopes": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AZDO_PERSONAL_ACCESS_TOKEN_SCOPES", nil),
+				Description: "The scopes granted to the personal access token, separated by spaces or commas, e.g. `vso.code_write vso.packaging_manage`. The scopes of personal access tokens cannot be read from the token, they are checked when `validate_token_scopes` is enabled.",
+			},
+			"validate_project_references": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AZDO_VALIDATE_PROJECT_REFERENCES", nil),
+				Description: "Verify the projects referenced by the `project_id` argument of resources exist when the resources are planned.",
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_PROXY_URL", nil),
+				Description:  "The URL of the proxy requests to Azure DevOps and Azure AD are sent through, e.g. `http://proxy.example.com:8080`. Defaults to the proxy configured in the `HTTPS_PROXY` environment variable.",
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+			},
+			"ca_certificate_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_CA_CERTIFICATE_PATH", nil),
+				Description:  "The path to a file containing PEM encoded certificate authorities trusted in addition to the ones of the system.",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"organization_aliases": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A map of aliases to the URL of additional Azure DevOps organizations, referenced by the `organization` argument of resources and data sources.",
+				ValidateDiagFunc: validation.AllDiag(
+					validation.MapKeyMatch(regexp.MustCompile(`^[a-zA-Z0-9_-]+$`), "organization aliases may only contain letters, digits, underscores and dashes"),
+					validation.MapValueMatch(regexp.MustCompile(`^https?://`), "organization URLs must start with http:// or https://"),
+				),
+			},
 		},
 	}
 
+	for name, r := range p.ResourcesMap {
+		tfhelper.AddDefaultProjectID(r)
+		tfhelper.AddProjectValidation(r)
+		tfhelper.AddTokenScopeValidation(r, name, requiredTokenScope(name), false)
+		tfhelper.AddOrganizationOverride(r, false)
+		tfhelper.AddStructuredDiagnostics(r)
+	}
+	for name, r := range p.DataSourcesMap {
+		tfhelper.AddTokenScopeValidation(r, name, sdk.ReadTokenScope(requiredTokenScope(name)), true)
+		tfhelper.AddOrganizationOverride(r, true)
+		tfhelper.AddStructuredDiagnostics(r)
+	}
+
 	p.ConfigureContextFunc = providerConfigure(p)
 
 	return p
@@ -307,12 +456,55 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			terraformVersion = "0.11+compatible"
 		}
 
-		tokenFunction, err := sdk.GetAuthTokenProvider(ctx, d, sdk.AzIdentityFuncsImpl{})
+		transport, err := newHTTPTransport(d)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
 
-		azdoClient, err := client.GetAzdoClient(tokenFunction, d.Get("org_service_url").(string), terraformVersion)
-		return azdoClient, diag.FromErr(err)
+		tokenFunction, err := sdk.GetAuthTokenProvider(ctx, d, sdk.AzIdentityFuncsImpl{Transport: transport})
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		options := &client.ClientOptions{
+			MaxRetries:                    d.Get("max_retries").(int),
+			RetryBaseDelay:                time.Duration(d.Get("retry_base_delay_seconds").(int)) * time.Second,
+			AdaptiveThrottling:            d.Get("adaptive_throttling").(bool),
+			MaxConcurrentRequests:         d.Get("max_concurrent_requests").(int),
+			MaxConcurrentIdentityRequests: d.Get("max_concurrent_identity_requests").(int),
+			Transport:                     transport,
+			DebugLogging:                  d.Get("http_debug_logging").(bool),
+			ValidateTokenScopes:           d.Get("validate_token_scopes").(bool),
+			PersonalAccessTokenScopes:     parseTokenScopes(d.Get("personal_access_token_scopes").(string)),
+			ValidateProjectReferences:     d.Get("validate_project_references").(bool),
+			ReadAfterWriteTimeout:         time.Duration(d.Get("read_after_write_timeout_seconds").(int)) * time.Second,
+			ReadAfterWriteDelay:           time.Duration(d.Get("read_after_write_delay_seconds").(int)) * time.Second,
+		}
+		if aliases, ok := d.GetOk("organization_aliases"); ok {
+			options.OrganizationAliases = map[string]string{}
+			for alias, url := range aliases.(map[string]interface{}) {
+				options.OrganizationAliases[alias] = url.(string)
+			}
+		}
+
+		azdoClient, err := client.GetAzdoClient(tokenFunction, d.Get("org_service_url").(string), terraformVersion, options)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		azdoClient.DefaultProjectID = d.Get("default_project_id").(string)
+		return azdoClient, nil
+	}
+}
+
+// newHTTPTransport creates the transport honoring the proxy and certificate authorities of the provider configuration
+func newHTTPTransport(d *schema.ResourceData) (http.RoundTripper, error) {
+	var caBundle []byte
+	if caCertificatePath, ok := d.GetOk("ca_certificate_path"); ok {
+		var err error
+		caBundle, err = os.ReadFile(caCertificatePath.(string))
+		if err != nil {
+			return nil, fmt.Errorf(" reading the CA certificates from %s: %+v", caCertificatePath, err)
+		}
 	}
+	return sdk.NewHTTPTransport(d.Get("proxy_url").(string), caBundle)
 }
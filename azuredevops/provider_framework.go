@@ -0,0 +1,262 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/graph"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/permissions"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/serviceendpoint"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/tokens"
+)
+
+var (
+	_ provider.Provider                       = &frameworkProvider{}
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+	_ provider.ProviderWithFunctions          = &frameworkProvider{}
+)
+
+// frameworkProvider serves the resources and data sources implemented with terraform-plugin-framework.
+// It is muxed together with the SDKv2 provider returned by Provider(), with which it shares its
+// configuration schema and its client.
+type frameworkProvider struct {
+	// sdkProvider is the SDKv2 half of the mux, whose client is shared once it has been configured
+	sdkProvider *schema.Provider
+}
+
+// NewFrameworkProvider returns the terraform-plugin-framework half of the provider
+func NewFrameworkProvider() provider.Provider {
+	return &frameworkProvider{}
+}
+
+// NewMuxProviderServer combines the SDKv2 and the terraform-plugin-framework providers into a single
+// protocol version 6 provider server
+func NewMuxProviderServer(ctx context.Context) (func() tfprotov6.ProviderServer, error) {
+	sdkProvider := Provider()
+	upgradedSdkServer, err := tf5to6server.UpgradeServer(ctx, sdkProvider.GRPCProvider)
+	if err != nil {
+		return nil, fmt.Errorf(" upgrading SDKv2 provider server to protocol version 6: %+v", err)
+	}
+
+	servers := []func() tfprotov6.ProviderServer{
+		func() tfprotov6.ProviderServer {
+			return replacementWarningServer{ProviderServer: upgradedSdkServer}
+		},
+		providerserver.NewProtocol6(&frameworkProvider{sdkProvider: sdkProvider}),
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx, servers...)
+	if err != nil {
+		return nil, fmt.Errorf(" creating provider mux server: %+v", err)
+	}
+	return muxServer.ProviderServer, nil
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "azuredevops"
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	s, err := frameworkProviderSchema(Provider().Schema)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider schema", err.Error())
+		return
+	}
+	resp.Schema = *s
+}
+
+// Configure shares the client of the SDKv2 half of the mux, which the mux configures first, so that both
+// halves share the concurrency limits, the rate limiter and the caches of a single client. Without a
+// configured SDKv2 half the client is built through a new SDKv2 provider, so that the configuration
+// is handled identically, including environment variable defaults and validation.
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	raw, known, err := frameworkProviderRawConfig(req.Config.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider configuration", err.Error())
+		return
+	}
+	if !known {
+		// The configuration is only partially known during planning, resources have to cope with
+		// a missing client until the provider is configured again with the final values.
+		return
+	}
+
+	if p.sdkProvider != nil && p.sdkProvider.Meta() != nil {
+		resp.DataSourceData = p.sdkProvider.Meta()
+		resp.EphemeralResourceData = p.sdkProvider.Meta()
+		resp.ResourceData = p.sdkProvider.Meta()
+		return
+	}
+
+	sdkProvider := Provider()
+	sdkProvider.TerraformVersion = req.TerraformVersion
+	for _, d := range sdkProvider.Configure(ctx, terraform.NewResourceConfigRaw(raw)) {
+		if d.Severity == diag.Error {
+			resp.Diagnostics.AddError(d.Summary, d.Detail)
+		} else {
+			resp.Diagnostics.AddWarning(d.Summary, d.Detail)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.DataSourceData = sdkProvider.Meta()
+	resp.EphemeralResourceData = sdkProvider.Meta()
+	resp.ResourceData = sdkProvider.Meta()
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{}
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{}
+}
+
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		tokens.NewPersonalAccessTokenEphemeralResource,
+		tokens.NewAgentRegistrationTokenEphemeralResource,
+		serviceendpoint.NewServiceEndpointVerificationEphemeralResource,
+	}
+}
+
+func (p *frameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		graph.NewParseDescriptorFunction,
+		permissions.NewGitTokenFunction,
+		serviceendpoint.NewOidcSubjectFunction,
+	}
+}
+
+// frameworkProviderSchema mirrors the SDKv2 provider schema, the mux server requires both providers
+// to expose exactly the same configuration schema.
+func frameworkProviderSchema(sdkSchema map[string]*schema.Schema) (*fwschema.Schema, error) {
+	attributes := make(map[string]fwschema.Attribute, len(sdkSchema))
+	for name, s := range sdkSchema {
+		switch s.Type {
+		case schema.TypeString:
+			attributes[name] = fwschema.StringAttribute{
+				Required:           s.Required,
+				Optional:           s.Optional,
+				Sensitive:          s.Sensitive,
+				Description:        s.Description,
+				DeprecationMessage: s.Deprecated,
+			}
+		case schema.TypeBool:
+			attributes[name] = fwschema.BoolAttribute{
+				Required:           s.Required,
+				Optional:           s.Optional,
+				Sensitive:          s.Sensitive,
+				Description:        s.Description,
+				DeprecationMessage: s.Deprecated,
+			}
+		case schema.TypeInt:
+			attributes[name] = fwschema.Int64Attribute{
+				Required:           s.Required,
+				Optional:           s.Optional,
+				Sensitive:          s.Sensitive,
+				Description:        s.Description,
+				DeprecationMessage: s.Deprecated,
+			}
+		case schema.TypeMap:
+			// only maps of strings are used by the provider configuration
+			attributes[name] = fwschema.MapAttribute{
+				ElementType:        types.StringType,
+				Required:           s.Required,
+				Optional:           s.Optional,
+				Sensitive:          s.Sensitive,
+				Description:        s.Description,
+				DeprecationMessage: s.Deprecated,
+			}
+		default:
+			return nil, fmt.Errorf(" provider argument %s has unsupported type %s", name, s.Type)
+		}
+	}
+	return &fwschema.Schema{Attributes: attributes}, nil
+}
+
+// frameworkProviderRawConfig converts the provider configuration into the representation expected by
+// the SDKv2 provider. Null arguments are omitted so that their environment variable defaults apply.
+func frameworkProviderRawConfig(config tftypes.Value) (map[string]interface{}, bool, error) {
+	raw := map[string]interface{}{}
+	if config.IsNull() {
+		return raw, true, nil
+	}
+	if !config.IsKnown() {
+		return nil, false, nil
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := config.As(&attributes); err != nil {
+		return nil, false, err
+	}
+
+	for name, value := range attributes {
+		if !value.IsKnown() {
+			return nil, false, nil
+		}
+		if value.IsNull() {
+			continue
+		}
+
+		switch {
+		case value.Type().Is(tftypes.String):
+			var v string
+			if err := value.As(&v); err != nil {
+				return nil, false, err
+			}
+			raw[name] = v
+		case value.Type().Is(tftypes.Bool):
+			var v bool
+			if err := value.As(&v); err != nil {
+				return nil, false, err
+			}
+			raw[name] = v
+		case value.Type().Is(tftypes.Number):
+			v := new(big.Float)
+			if err := value.As(&v); err != nil {
+				return nil, false, err
+			}
+			i, _ := v.Int64()
+			raw[name] = int(i)
+		case value.Type().Is(tftypes.Map{ElementType: tftypes.String}):
+			var elements map[string]tftypes.Value
+			if err := value.As(&elements); err != nil {
+				return nil, false, err
+			}
+			m := make(map[string]interface{}, len(elements))
+			for k, element := range elements {
+				if !element.IsKnown() {
+					return nil, false, nil
+				}
+				var v string
+				if err := element.As(&v); err != nil {
+					return nil, false, err
+				}
+				m[k] = v
+			}
+			raw[name] = m
+		default:
+			return nil, false, fmt.Errorf(" provider argument %s has unsupported type %s", name, value.Type())
+		}
+	}
+	return raw, true, nil
+}
@@ -0,0 +1,29 @@
+package azuredevops
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameworkProvider_Configure_SharesClientOfSdkProvider(t *testing.T) {
+	clients := &client.AggregatedClient{}
+	sdkProvider := Provider()
+	sdkProvider.SetMeta(clients)
+
+	req := provider.ConfigureRequest{
+		Config: tfsdk.Config{Raw: tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, nil)},
+	}
+	resp := &provider.ConfigureResponse{}
+	(&frameworkProvider{sdkProvider: sdkProvider}).Configure(context.Background(), req, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	require.Same(t, clients, resp.ResourceData)
+	require.Same(t, clients, resp.DataSourceData)
+	require.Same(t, clients, resp.EphemeralResourceData)
+}
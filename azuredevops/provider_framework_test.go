@@ -0,0 +1,79 @@
+package azuredevops_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameworkProvider_SchemaMatchesSDKProvider(t *testing.T) {
+	resp := &provider.SchemaResponse{}
+	azuredevops.NewFrameworkProvider().Schema(context.Background(), provider.SchemaRequest{}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	sdkSchema := azuredevops.Provider().Schema
+	require.Equal(t, len(sdkSchema), len(resp.Schema.Attributes))
+	for name, s := range sdkSchema {
+		attr, ok := resp.Schema.Attributes[name]
+		require.True(t, ok, "provider argument %s is missing from the framework provider", name)
+		require.Equal(t, s.Required, attr.IsRequired(), "required mismatch for %s", name)
+		require.Equal(t, s.Optional, attr.IsOptional(), "optional mismatch for %s", name)
+		require.Equal(t, s.Sensitive, attr.IsSensitive(), "sensitive mismatch for %s", name)
+	}
+}
+
+func TestMuxProviderServer_GetProviderSchema(t *testing.T) {
+	ctx := context.Background()
+	serverFunc, err := azuredevops.NewMuxProviderServer(ctx)
+	require.NoError(t, err)
+
+	resp, err := serverFunc().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	for _, d := range resp.Diagnostics {
+		require.NotEqual(t, tfprotov6.DiagnosticSeverityError, d.Severity, "%s: %s", d.Summary, d.Detail)
+	}
+	require.NotNil(t, resp.Provider)
+	require.Contains(t, resp.ResourceSchemas, "azuredevops_project")
+}
+
+func TestMuxProviderServer_HasEphemeralResources(t *testing.T) {
+	expectedEphemeralResources := []string{
+		"azuredevops_personal_access_token",
+		"azuredevops_agent_registration_token",
+		"azuredevops_serviceendpoint_verification",
+	}
+
+	ctx := context.Background()
+	serverFunc, err := azuredevops.NewMuxProviderServer(ctx)
+	require.NoError(t, err)
+
+	resp, err := serverFunc().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	require.Equal(t, len(expectedEphemeralResources), len(resp.EphemeralResourceSchemas), "There are an unexpected number of registered ephemeral resources")
+	for _, name := range expectedEphemeralResources {
+		require.Contains(t, resp.EphemeralResourceSchemas, name, "An expected ephemeral resource was not registered")
+	}
+}
+
+func TestMuxProviderServer_HasFunctions(t *testing.T) {
+	expectedFunctions := []string{
+		"git_token",
+		"parse_descriptor",
+		"serviceendpoint_oidc_subject",
+	}
+
+	ctx := context.Background()
+	serverFunc, err := azuredevops.NewMuxProviderServer(ctx)
+	require.NoError(t, err)
+
+	resp, err := serverFunc().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	require.Equal(t, len(expectedFunctions), len(resp.Functions), "There are an unexpected number of registered functions")
+	for _, name := range expectedFunctions {
+		require.Contains(t, resp.Functions, name, "An expected function was not registered")
+	}
+}
@@ -0,0 +1,97 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// replacementDataLoss describes the data lost when a resource with a high blast radius is replaced
+var replacementDataLoss = map[string]string{
+	"azuredevops_project":          "Deleting the project deletes all of its repositories, pipelines and their run history, work items, boards, feeds and test results.",
+	"azuredevops_git_repository":   "Deleting the repository deletes all of its commits, branches, tags and pull requests.",
+	"azuredevops_feed":             "Deleting the feed deletes all packages published to it. Unless `permanent_delete` is set, the feed can be restored from the recycle bin for 30 days, during which its name stays reserved.",
+	"azuredevops_build_definition": "Deleting the pipeline deletes its run history, including the logs and artifacts of its runs.",
+	"azuredevops_environment":      "Deleting the environment deletes its deployment history and the approvals and checks configured on it.",
+}
+
+// replacementWarningServer warns when the plan replaces a resource whose deletion loses data, so that
+// accidental recreations are caught when the plan is reviewed. The SDK does not support warnings from
+// CustomizeDiff, the warnings are therefore added to the planned changes of the SDK provider server.
+type replacementWarningServer struct {
+	tfprotov6.ProviderServer
+}
+
+// PlanResourceChange implements tfprotov6.ResourceServer
+func (s replacementWarningServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if err != nil || resp == nil || len(resp.RequiresReplace) == 0 {
+		return resp, err
+	}
+
+	dataLoss, ok := replacementDataLoss[req.TypeName]
+	if !ok {
+		return resp, nil
+	}
+
+	// the SDK also requires the replacement of resources which are created, there is nothing to lose
+	if req.PriorState == nil {
+		return resp, nil
+	}
+	if isNull, err := req.PriorState.IsNull(); err != nil || isNull {
+		return resp, nil
+	}
+
+	var paths []*tftypes.AttributePath
+	attributes := make([]string, 0, len(resp.RequiresReplace))
+	for _, path := range resp.RequiresReplace {
+		// the SDK requires the replacement of the id whenever the planned id is unknown
+		if isIDPath(path) {
+			continue
+		}
+		paths = append(paths, path)
+		attributes = append(attributes, "`"+formatAttributePath(path)+"`")
+	}
+	if len(paths) == 0 {
+		return resp, nil
+	}
+
+	resp.Diagnostics = append(resp.Diagnostics, &tfprotov6.Diagnostic{
+		Severity:  tfprotov6.DiagnosticSeverityWarning,
+		Summary:   fmt.Sprintf("%s will be destroyed and recreated", req.TypeName),
+		Detail:    fmt.Sprintf("Changing %s forces the replacement of the %s. %s", strings.Join(attributes, ", "), req.TypeName, dataLoss),
+		Attribute: paths[0],
+	})
+	return resp, nil
+}
+
+func isIDPath(path *tftypes.AttributePath) bool {
+	steps := path.Steps()
+	if len(steps) != 1 {
+		return false
+	}
+	name, ok := steps[0].(tftypes.AttributeName)
+	return ok && name == "id"
+}
+
+// formatAttributePath formats the path of an attribute the way it is written in the configuration
+func formatAttributePath(path *tftypes.AttributePath) string {
+	var sb strings.Builder
+	for _, step := range path.Steps() {
+		switch v := step.(type) {
+		case tftypes.AttributeName:
+			if sb.Len() > 0 {
+				sb.WriteString(".")
+			}
+			sb.WriteString(string(v))
+		case tftypes.ElementKeyInt:
+			fmt.Fprintf(&sb, "[%d]", int64(v))
+		case tftypes.ElementKeyString:
+			fmt.Fprintf(&sb, "[%q]", string(v))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,141 @@
+package azuredevops
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// planServer returns the same planned change for every resource
+type planServer struct {
+	tfprotov6.ProviderServer
+	resp *tfprotov6.PlanResourceChangeResponse
+}
+
+func (s planServer) PlanResourceChange(_ context.Context, _ *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	return s.resp, nil
+}
+
+var testRepositoryType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"id":   tftypes.String,
+	"name": tftypes.String,
+}}
+
+func testRepositoryState(t *testing.T, id interface{}, name string) *tfprotov6.DynamicValue {
+	value, err := tfprotov6.NewDynamicValue(testRepositoryType, tftypes.NewValue(testRepositoryType, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, id),
+		"name": tftypes.NewValue(tftypes.String, name),
+	}))
+	require.NoError(t, err)
+	return &value
+}
+
+// testSdkServer returns the server of an SDKv2 provider with a repository whose name forces its replacement
+func testSdkServer(t *testing.T) tfprotov6.ProviderServer {
+	p := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"azuredevops_git_repository": {
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+						ForceNew: true,
+					},
+				},
+			},
+		},
+	}
+	server, err := tf5to6server.UpgradeServer(context.Background(), p.GRPCProvider)
+	require.NoError(t, err)
+	return server
+}
+
+func TestReplacementWarningServer_WarnsAboutReplacedRepositories(t *testing.T) {
+	name := tftypes.NewAttributePath().WithAttributeName("name")
+	initialization := tftypes.NewAttributePath().WithAttributeName("initialization").WithElementKeyInt(0).WithAttributeName("init_type")
+	server := replacementWarningServer{ProviderServer: planServer{resp: &tfprotov6.PlanResourceChangeResponse{
+		RequiresReplace: []*tftypes.AttributePath{name, initialization},
+	}}}
+
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:   "azuredevops_git_repository",
+		PriorState: testRepositoryState(t, "1", "old"),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Diagnostics, 1)
+
+	warning := resp.Diagnostics[0]
+	require.Equal(t, tfprotov6.DiagnosticSeverityWarning, warning.Severity)
+	require.Equal(t, "azuredevops_git_repository will be destroyed and recreated", warning.Summary)
+	require.Contains(t, warning.Detail, "Changing `name`, `initialization[0].init_type` forces the replacement")
+	require.Contains(t, warning.Detail, "commits, branches, tags and pull requests")
+	require.Equal(t, name, warning.Attribute)
+}
+
+func TestReplacementWarningServer_IgnoresOtherChanges(t *testing.T) {
+	replaced := &tfprotov6.PlanResourceChangeResponse{
+		RequiresReplace: []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("name")},
+	}
+
+	resp, err := replacementWarningServer{ProviderServer: planServer{resp: replaced}}.
+		PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+			TypeName:   "azuredevops_team",
+			PriorState: testRepositoryState(t, "1", "old"),
+		})
+	require.NoError(t, err)
+	require.Empty(t, resp.Diagnostics)
+
+	resp, err = replacementWarningServer{ProviderServer: planServer{resp: &tfprotov6.PlanResourceChangeResponse{}}}.
+		PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+			TypeName:   "azuredevops_project",
+			PriorState: testRepositoryState(t, "1", "old"),
+		})
+	require.NoError(t, err)
+	require.Empty(t, resp.Diagnostics)
+}
+
+func TestReplacementWarningServer_DoesNotWarnAboutCreatedResources(t *testing.T) {
+	server := replacementWarningServer{ProviderServer: testSdkServer(t)}
+	proposed := testRepositoryState(t, tftypes.UnknownValue, "new")
+	nullState, err := tfprotov6.NewDynamicValue(testRepositoryType, tftypes.NewValue(testRepositoryType, nil))
+	require.NoError(t, err)
+
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "azuredevops_git_repository",
+		PriorState:       &nullState,
+		ProposedNewState: proposed,
+		Config:           testRepositoryState(t, nil, "new"),
+	})
+	require.NoError(t, err)
+	// the SDK requires the replacement of the unknown id of created resources
+	require.NotEmpty(t, resp.RequiresReplace)
+	require.Empty(t, resp.Diagnostics)
+}
+
+func TestReplacementWarningServer_WarnsAboutReplacementThroughSdkServer(t *testing.T) {
+	server := replacementWarningServer{ProviderServer: testSdkServer(t)}
+
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "azuredevops_git_repository",
+		PriorState:       testRepositoryState(t, "1", "old"),
+		ProposedNewState: testRepositoryState(t, "1", "new"),
+		Config:           testRepositoryState(t, nil, "new"),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Diagnostics, 1)
+	require.Equal(t, tfprotov6.DiagnosticSeverityWarning, resp.Diagnostics[0].Severity)
+	require.Contains(t, resp.Diagnostics[0].Detail, "Changing `name` forces the replacement")
+	require.NotContains(t, resp.Diagnostics[0].Detail, "`id`")
+}
+
+func TestReplacementDataLoss_ReferencesExistingResources(t *testing.T) {
+	resources := Provider().ResourcesMap
+	for name := range replacementDataLoss {
+		require.Contains(t, resources, name)
+	}
+}
@@ -0,0 +1,78 @@
+package azuredevops
+
+import (
+	"strings"
+	"unicode"
+)
+
+// tokenScopeOverrides maps the names of resources which do not require the scope of their prefix or suffix to
+// the scope they require
+var tokenScopeOverrides = map[string]string{
+	"azuredevops_feed_permissions": "vso.packaging_manage",
+}
+
+// tokenScopes maps prefixes of resource names to the scope their resources require, the longest matching
+// prefix applies. Data sources require the read only scope of the same area.
+var tokenScopes = map[string]string{
+	"azuredevops_agent_":                              "vso.agentpools_manage",
+	"azuredevops_area":                                "vso.work_write",
+	"azuredevops_branch_policy_":                      "vso.code_write",
+	"azuredevops_build_":                              "vso.build_execute",
+	"azuredevops_check_":                              "vso.environment_manage",
+	"azuredevops_elastic_pool":                        "vso.agentpools_manage",
+	"azuredevops_environment":                         "vso.environment_manage",
+	"azuredevops_feed":                                "vso.packaging_manage",
+	"azuredevops_git_repositories":                    "vso.code",
+	"azuredevops_git_repository_branch":               "vso.code_write",
+	"azuredevops_git_repository_file":                 "vso.code_write",
+	"azuredevops_git_repository":                      "vso.code_manage",
+	"azuredevops_group":                               "vso.graph_manage",
+	"azuredevops_group_entitlement":                   "vso.memberentitlementmanagement_write",
+	"azuredevops_identity_":                           "vso.identity",
+	"azuredevops_iteration":                           "vso.work_write",
+	"azuredevops_pipeline_authorization":              "vso.build_execute",
+	"azuredevops_project":                             "vso.project_manage",
+	"azuredevops_repository_policy_":                  "vso.code_write",
+	"azuredevops_resource_authorization":              "vso.build_execute",
+	"azuredevops_securityrole_":                       "vso.security_manage",
+	"azuredevops_serviceendpoint_":                    "vso.serviceendpoint_manage",
+	"azuredevops_service_principal":                   "vso.graph",
+	"azuredevops_servicehook_storage_queue_pipelines": "vso.hooks_write",
+	"azuredevops_team":                                "vso.project_manage",
+	"azuredevops_user_entitlement":                    "vso.memberentitlementmanagement_write",
+	"azuredevops_users":                               "vso.graph",
+	"azuredevops_variable_group":                      "vso.variablegroups_manage",
+	"azuredevops_workitem":                            "vso.work_write",
+}
+
+// requiredTokenScope returns the scope required by a resource, or an empty string when it is not known. The
+// permission resources require the security scope, unless they are managed through the API of their area.
+func requiredTokenScope(name string) string {
+	if scope, ok := tokenScopeOverrides[name]; ok {
+		return scope
+	}
+	if strings.HasSuffix(name, "_permissions") {
+		return "vso.security_manage"
+	}
+
+	scope := ""
+	matched := 0
+	for prefix, prefixScope := range tokenScopes {
+		if strings.HasPrefix(name, prefix) && len(prefix) > matched {
+			scope = prefixScope
+			matched = len(prefix)
+		}
+	}
+	return scope
+}
+
+// parseTokenScopes parses scopes separated by spaces or commas, nil is returned when no scope is given
+func parseTokenScopes(value string) []string {
+	scopes := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	if len(scopes) == 0 {
+		return nil
+	}
+	return scopes
+}
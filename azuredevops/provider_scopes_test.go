@@ -0,0 +1,22 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredTokenScope(t *testing.T) {
+	require.Equal(t, "vso.packaging_manage", requiredTokenScope("azuredevops_feed"))
+	require.Equal(t, "vso.packaging_manage", requiredTokenScope("azuredevops_feed_permission"))
+	require.Equal(t, "vso.packaging_manage", requiredTokenScope("azuredevops_feed_permissions"))
+	require.Equal(t, "vso.security_manage", requiredTokenScope("azuredevops_git_permissions"))
+	require.Equal(t, "vso.security_manage", requiredTokenScope("azuredevops_project_permissions"))
+	require.Equal(t, "vso.code_write", requiredTokenScope("azuredevops_git_repository_file"))
+	require.Equal(t, "", requiredTokenScope("azuredevops_unknown"))
+}
+
+func TestParseTokenScopes(t *testing.T) {
+	require.Equal(t, []string{"vso.code_write", "vso.packaging_manage", "vso.build"}, parseTokenScopes("vso.code_write, vso.packaging_manage\tvso.build"))
+	require.Nil(t, parseTokenScopes(" "))
+}
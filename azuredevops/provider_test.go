@@ -36,6 +36,7 @@ func TestProvider_HasChildResources(t *testing.T) {
 		"azuredevops_pipeline_authorization",
 		"azuredevops_build_definition",
 		"azuredevops_build_definition_permissions",
+		"azuredevops_build_definition_variable_group_link",
 		"azuredevops_branch_policy_build_validation",
 		"azuredevops_branch_policy_min_reviewers",
 		"azuredevops_branch_policy_auto_reviewers",
@@ -46,6 +47,7 @@ func TestProvider_HasChildResources(t *testing.T) {
 		"azuredevops_project",
 		"azuredevops_project_features",
 		"azuredevops_project_pipeline_settings",
+		"azuredevops_organization_auditing",
 		"azuredevops_check_approval",
 		"azuredevops_check_exclusive_lock",
 		"azuredevops_check_branch_control",
@@ -91,6 +93,7 @@ func TestProvider_HasChildResources(t *testing.T) {
 		"azuredevops_repository_policy_max_path_length",
 		"azuredevops_repository_policy_reserved_names",
 		"azuredevops_repository_policy_check_credentials",
+		"azuredevops_project_repository_defaults",
 		"azuredevops_git_repository",
 		"azuredevops_git_repository_branch",
 		"azuredevops_git_repository_file",
@@ -101,17 +104,22 @@ func TestProvider_HasChildResources(t *testing.T) {
 		"azuredevops_agent_pool",
 		"azuredevops_agent_queue",
 		"azuredevops_elastic_pool",
+		"azuredevops_deployment_pool",
 		"azuredevops_project_permissions",
 		"azuredevops_git_permissions",
 		"azuredevops_workitemquery_permissions",
 		"azuredevops_area_permissions",
+		"azuredevops_test_plan_permissions",
+		"azuredevops_process_permissions",
 		"azuredevops_iteration_permissions",
 		"azuredevops_team",
 		"azuredevops_team_members",
 		"azuredevops_team_administrators",
 		"azuredevops_serviceendpoint_permissions",
 		"azuredevops_servicehook_permissions",
+		"azuredevops_servicehook_jenkins",
 		"azuredevops_servicehook_storage_queue_pipelines",
+		"azuredevops_servicehook_webhook_work_items",
 		"azuredevops_tagging_permissions",
 		"azuredevops_variable_group_permissions",
 		"azuredevops_library_permissions",
@@ -120,8 +128,11 @@ func TestProvider_HasChildResources(t *testing.T) {
 		"azuredevops_build_folder",
 		"azuredevops_build_folder_permissions",
 		"azuredevops_workitem",
+		"azuredevops_process_backlog_level",
+		"azuredevops_workitem_tag_normalization",
 		"azuredevops_feed",
 		"azuredevops_feed_permission",
+		"azuredevops_feed_permissions",
 	}
 
 	resources := azuredevops.Provider().ResourcesMap
@@ -143,11 +154,13 @@ func TestProvider_HasChildDataSources(t *testing.T) {
 		"azuredevops_git_repositories",
 		"azuredevops_git_repository",
 		"azuredevops_users",
+		"azuredevops_service_principal",
 		"azuredevops_agent_pool",
 		"azuredevops_agent_pools",
 		"azuredevops_agent_queue",
 		"azuredevops_area",
 		"azuredevops_environment",
+		"azuredevops_environments",
 		"azuredevops_iteration",
 		"azuredevops_team",
 		"azuredevops_teams",
@@ -156,13 +169,19 @@ func TestProvider_HasChildDataSources(t *testing.T) {
 		"azuredevops_identity_group",
 		"azuredevops_identity_groups",
 		"azuredevops_variable_group",
+		"azuredevops_variable_groups",
+		"azuredevops_deployment_group_targets",
 		"azuredevops_securityrole_definitions",
+		"azuredevops_securityrole_scopes",
+		"azuredevops_security_namespaces",
 		"azuredevops_serviceendpoint_azurerm",
 		"azuredevops_serviceendpoint_github",
 		"azuredevops_serviceendpoint_npm",
 		"azuredevops_serviceendpoint_sonarcloud",
 		"azuredevops_serviceendpoint_azurecr",
+		"azuredevops_serviceendpoint_oidc_subject",
 		"azuredevops_feed",
+		"azuredevops_servicehook_subscriptions",
 	}
 
 	dataSources := azuredevops.Provider().DataSourcesMap
@@ -174,6 +193,38 @@ func TestProvider_HasChildDataSources(t *testing.T) {
 	require.Equal(t, len(expectedDataSources), len(dataSources), "There are an unexpected number of registered data sources")
 }
 
+func TestProvider_InternalValidate(t *testing.T) {
+	require.NoError(t, azuredevops.Provider().InternalValidate())
+}
+
+func TestProvider_SensitiveArgumentsHaveWriteOnlyCounterparts(t *testing.T) {
+	// secret values of variables are set through the write-only `secret_variable` blocks instead
+	exceptions := map[string]bool{
+		"azuredevops_build_definition.variable.secret_value": true,
+		"azuredevops_variable_group.variable.secret_value":   true,
+	}
+
+	var check func(address string, s map[string]*schema.Schema)
+	check = func(address string, s map[string]*schema.Schema) {
+		for name, arg := range s {
+			if elem, ok := arg.Elem.(*schema.Resource); ok {
+				check(address+"."+name, elem.Schema)
+			}
+			if !arg.Sensitive || arg.WriteOnly || (!arg.Optional && !arg.Required) || exceptions[address+"."+name] {
+				continue
+			}
+			writeOnly, ok := s[name+"_wo"]
+			require.True(t, ok, "The sensitive argument %s.%s has no write-only counterpart", address, name)
+			require.True(t, writeOnly.WriteOnly, "The argument %s.%s_wo is not write-only", address, name)
+			require.Contains(t, s, name+"_wo_version", "The sensitive argument %s.%s has no version argument", address, name)
+		}
+	}
+
+	for name, r := range azuredevops.Provider().ResourcesMap {
+		check(name, r.Schema)
+	}
+}
+
 func TestProvider_SchemaIsValid(t *testing.T) {
 	type testParams struct {
 		name          string
@@ -205,6 +256,22 @@ func TestProvider_SchemaIsValid(t *testing.T) {
 		{"client_secret", false, "ARM_CLIENT_SECRET", true},
 		{"client_secret_path", false, "ARM_CLIENT_SECRET_PATH", false},
 		{"use_msi", false, "ARM_USE_MSI", false},
+		{"msi_resource_id", false, "ARM_MSI_RESOURCE_ID", false},
+		{"max_retries", false, "AZDO_MAX_RETRIES", false},
+		{"retry_base_delay_seconds", false, "AZDO_RETRY_BASE_DELAY_SECONDS", false},
+		{"read_after_write_timeout_seconds", false, "AZDO_READ_AFTER_WRITE_TIMEOUT_SECONDS", false},
+		{"read_after_write_delay_seconds", false, "AZDO_READ_AFTER_WRITE_DELAY_SECONDS", false},
+		{"max_concurrent_requests", false, "AZDO_MAX_CONCURRENT_REQUESTS", false},
+		{"max_concurrent_identity_requests", false, "AZDO_MAX_CONCURRENT_IDENTITY_REQUESTS", false},
+		{"adaptive_throttling", false, "AZDO_ADAPTIVE_THROTTLING", false},
+		{"default_project_id", false, "AZDO_DEFAULT_PROJECT_ID", false},
+		{"http_debug_logging", false, "AZDO_HTTP_DEBUG_LOGGING", false},
+		{"validate_token_scopes", false, "AZDO_VALIDATE_TOKEN_SCOPES", false},
+		{"personal_access_token_scopes", false, "AZDO_PERSONAL_ACCESS_TOKEN_SCOPES", false},
+		{"validate_project_references", false, "AZDO_VALIDATE_PROJECT_REFERENCES", false},
+		{"proxy_url", false, "AZDO_PROXY_URL", true},
+		{"ca_certificate_path", false, "AZDO_CA_CERTIFICATE_PATH", false},
+		{"organization_aliases", false, "", false},
 	}
 
 	schema := azuredevops.Provider().Schema
@@ -218,6 +285,11 @@ func TestProvider_SchemaIsValid(t *testing.T) {
 
 		if test.defaultEnvVar != "" {
 			expectedValue := os.Getenv(test.defaultEnvVar)
+			if defaultValue, _ := schema[test.name].DefaultFunc(); expectedValue == "" && defaultValue != nil {
+				// properties with a default value are only sourced from the environment when the variable is set
+				expectedValue = "5"
+				t.Setenv(test.defaultEnvVar, expectedValue)
+			}
 
 			actualValue, err := schema[test.name].DefaultFunc()
 			if actualValue == nil {
@@ -498,6 +570,49 @@ func TestAuthClientCert(t *testing.T) {
 	assert.Equal(t, "Bearer "+accessToken, token)
 }
 
+func TestAuthClientCertPEM(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIdentityClient := mock_azuredevops.NewMockIdentityFuncsI(ctrl)
+	clientId := "00000000-0000-0000-0000-000000000001"
+	tenantId := "00000000-0000-0000-0000-000000000002"
+	cert := generateCert()
+	accessToken := "thepassword"
+
+	resourceData := schema.TestResourceDataRaw(t, azuredevops.Provider().Schema, nil)
+	resourceData.Set("client_id", clientId)
+	resourceData.Set("tenant_id", tenantId)
+	resourceData.Set("client_certificate", string(cert))
+
+	theseCerts, theseKey, err := azidentity.ParseCertificates(cert, nil)
+	assert.Nil(t, err)
+
+	mockIdentityClient.EXPECT().NewClientCertificateCredential(tenantId, clientId, gomock.Any(), gomock.Any(), nil).DoAndReturn(
+		func(tenantID string, clientID string, certs []*x509.Certificate, key crypto.PrivateKey, options *azidentity.ClientCertificateCredentialOptions) (*simpleTokenGetter, error) {
+			assert.Equal(t, theseCerts, certs)
+			assert.Equal(t, theseKey, key)
+			getter := simpleTokenGetter{token: accessToken}
+			return &getter, nil
+		}).Times(1)
+	resp, err := sdk.GetAuthTokenProvider(context.Background(), resourceData, mockIdentityClient)
+	assert.Nil(t, err)
+	token, err := resp()
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer "+accessToken, token)
+}
+
+func TestAuthClientCertRequiresTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIdentityClient := mock_azuredevops.NewMockIdentityFuncsI(ctrl)
+
+	resourceData := schema.TestResourceDataRaw(t, azuredevops.Provider().Schema, nil)
+	resourceData.Set("client_id", "00000000-0000-0000-0000-000000000001")
+	resourceData.Set("client_certificate", base64.StdEncoding.EncodeToString(generateCert()))
+
+	resp, err := sdk.GetAuthTokenProvider(context.Background(), resourceData, mockIdentityClient)
+	assert.Nil(t, resp)
+	assert.Contains(t, err.Error(), "tenant_id")
+}
+
 func TestAuthClientCertFile(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockIdentityClient := mock_azuredevops.NewMockIdentityFuncsI(ctrl)
@@ -531,6 +646,60 @@ func TestAuthClientCertFile(t *testing.T) {
 	assert.Equal(t, "Bearer "+accessToken, token)
 }
 
+func TestAuthMSI(t *testing.T) {
+	testCases := []struct {
+		name       string
+		clientId   string
+		resourceId string
+		expectedId azidentity.ManagedIDKind
+	}{
+		{
+			name:       "system assigned",
+			expectedId: nil,
+		},
+		{
+			name:       "user assigned by client id",
+			clientId:   "00000000-0000-0000-0000-000000000001",
+			expectedId: azidentity.ClientID("00000000-0000-0000-0000-000000000001"),
+		},
+		{
+			name:       "user assigned by resource id",
+			clientId:   "00000000-0000-0000-0000-000000000001",
+			resourceId: "/subscriptions/00000000-0000-0000-0000-000000000002/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity",
+			expectedId: azidentity.ResourceID("/subscriptions/00000000-0000-0000-0000-000000000002/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity"),
+		},
+	}
+
+	accessToken := "thepassword"
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockIdentityClient := mock_azuredevops.NewMockIdentityFuncsI(ctrl)
+
+			resourceData := schema.TestResourceDataRaw(t, azuredevops.Provider().Schema, nil)
+			resourceData.Set("use_msi", true)
+			if testCase.clientId != "" {
+				resourceData.Set("client_id", testCase.clientId)
+			}
+			if testCase.resourceId != "" {
+				resourceData.Set("msi_resource_id", testCase.resourceId)
+			}
+
+			mockIdentityClient.EXPECT().NewManagedIdentityCredential(gomock.Any()).DoAndReturn(
+				func(options *azidentity.ManagedIdentityCredentialOptions) (*simpleTokenGetter, error) {
+					assert.Equal(t, testCase.expectedId, options.ID)
+					getter := simpleTokenGetter{token: accessToken}
+					return &getter, nil
+				}).Times(1)
+			resp, err := sdk.GetAuthTokenProvider(context.Background(), resourceData, mockIdentityClient)
+			assert.Nil(t, err)
+			token, err := resp()
+			assert.Nil(t, err)
+			assert.Equal(t, "Bearer "+accessToken, token)
+		})
+	}
+}
+
 func TestGHActionsNoAudience(t *testing.T) {
 	testCases := []struct {
 		testAudience     string
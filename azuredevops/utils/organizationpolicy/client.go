@@ -0,0 +1,99 @@
+// Client for the policies of an organization, which are not part of the Azure DevOps Go API. The policies are the
+// settings on the Policies page of the organization settings, like whether audit events are logged.
+
+// This file cannot be under "internal", because azdosdkmocks/organizationpolicy_sdk_mock.go depends on it.
+
+package organizationpolicy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/webapi"
+)
+
+const apiVersion = "5.0-preview.1"
+
+type Client interface {
+	GetPolicy(ctx context.Context, args GetPolicyArgs) (*Policy, error)
+	UpdatePolicy(ctx context.Context, args UpdatePolicyArgs) error
+}
+
+// ClientImpl sends requests to the organization policy service, which has no registered resource location, which
+// is why the url of the organization has to be provided next to the client.
+type ClientImpl struct {
+	Client  azuredevops.Client
+	BaseUrl string
+}
+
+// Arguments for the GetPolicy function
+type GetPolicyArgs struct {
+	// (required) The name of the policy
+	PolicyName *string
+}
+
+func (client *ClientImpl) GetPolicy(ctx context.Context, args GetPolicyArgs) (*Policy, error) {
+	if args.PolicyName == nil || *args.PolicyName == "" {
+		return nil, &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.PolicyName"}
+	}
+
+	req, err := client.Client.CreateRequestMessage(ctx, http.MethodGet, client.policyUrl(*args.PolicyName), apiVersion, nil, "", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Client.SendRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue Policy
+	err = client.Client.UnmarshalBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// Arguments for the UpdatePolicy function
+type UpdatePolicyArgs struct {
+	// (required) The name of the policy
+	PolicyName *string
+	// (required) The value to set
+	Value interface{}
+}
+
+func (client *ClientImpl) UpdatePolicy(ctx context.Context, args UpdatePolicyArgs) error {
+	if args.PolicyName == nil || *args.PolicyName == "" {
+		return &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.PolicyName"}
+	}
+	if args.Value == nil {
+		return &azuredevops.ArgumentNilError{ArgumentName: "args.Value"}
+	}
+
+	path := "/Value"
+	body, marshalErr := json.Marshal([]webapi.JsonPatchOperation{
+		{
+			Op:    &webapi.OperationValues.Replace,
+			Path:  &path,
+			Value: args.Value,
+		},
+	})
+	if marshalErr != nil {
+		return marshalErr
+	}
+
+	req, err := client.Client.CreateRequestMessage(ctx, http.MethodPatch, client.policyUrl(*args.PolicyName), apiVersion, bytes.NewReader(body), "application/json-patch+json", "application/json", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Client.SendRequest(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (client *ClientImpl) policyUrl(policyName string) string {
+	return client.BaseUrl + "/_apis/OrganizationPolicy/Policies/" + url.PathEscape(policyName)
+}
@@ -0,0 +1,15 @@
+package organizationpolicy
+
+// Policy is a setting of the organization, like whether audit events are logged
+type Policy struct {
+	// The name of the policy, e.g. Policy.LogAuditEvents
+	Name *string `json:"name,omitempty"`
+	// The value of the policy set on the organization
+	Value interface{} `json:"value,omitempty"`
+	// The value in effect, which is the default value of the policy when no value is set
+	EffectiveValue interface{} `json:"effectiveValue,omitempty"`
+	// Whether the policy is enforced by a parent policy and cannot be changed
+	Enforce *bool `json:"enforce,omitempty"`
+	// Whether no value is set on the organization
+	IsValueUndefined *bool `json:"isValueUndefined,omitempty"`
+}
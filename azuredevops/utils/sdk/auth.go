@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -39,21 +40,49 @@ type IdentityFuncsI interface {
 	NewManagedIdentityCredential(options *azidentity.ManagedIdentityCredentialOptions) (TokenGetter, error)
 }
 
-type AzIdentityFuncsImpl struct{}
+// AzIdentityFuncsImpl creates Azure AD credentials with the Azure Identity SDK
+type AzIdentityFuncsImpl struct {
+	// Transport sends the requests of the credentials when set, instead of the default transport of the SDK
+	Transport http.RoundTripper
+}
+
+// clientOptions returns the options of the Azure SDK pipeline used by the credentials
+func (a AzIdentityFuncsImpl) clientOptions(options azcore.ClientOptions) azcore.ClientOptions {
+	if a.Transport != nil {
+		options.Transport = &http.Client{Transport: a.Transport}
+	}
+	return options
+}
 
 func (a AzIdentityFuncsImpl) NewClientAssertionCredential(tenantID, clientID string, getAssertion func(context.Context) (string, error), options *azidentity.ClientAssertionCredentialOptions) (TokenGetter, error) {
+	if options == nil {
+		options = &azidentity.ClientAssertionCredentialOptions{}
+	}
+	options.ClientOptions = a.clientOptions(options.ClientOptions)
 	return azidentity.NewClientAssertionCredential(tenantID, clientID, getAssertion, options)
 }
 
 func (a AzIdentityFuncsImpl) NewClientCertificateCredential(tenantID string, clientID string, certs []*x509.Certificate, key crypto.PrivateKey, options *azidentity.ClientCertificateCredentialOptions) (TokenGetter, error) {
+	if options == nil {
+		options = &azidentity.ClientCertificateCredentialOptions{}
+	}
+	options.ClientOptions = a.clientOptions(options.ClientOptions)
 	return azidentity.NewClientCertificateCredential(tenantID, clientID, certs, key, options)
 }
 
 func (a AzIdentityFuncsImpl) NewClientSecretCredential(tenantID string, clientID string, clientSecret string, options *azidentity.ClientSecretCredentialOptions) (TokenGetter, error) {
+	if options == nil {
+		options = &azidentity.ClientSecretCredentialOptions{}
+	}
+	options.ClientOptions = a.clientOptions(options.ClientOptions)
 	return azidentity.NewClientSecretCredential(tenantID, clientID, clientSecret, options)
 }
 
 func (a AzIdentityFuncsImpl) NewManagedIdentityCredential(options *azidentity.ManagedIdentityCredentialOptions) (TokenGetter, error) {
+	if options == nil {
+		options = &azidentity.ManagedIdentityCredentialOptions{}
+	}
+	options.ClientOptions = a.clientOptions(options.ClientOptions)
 	return azidentity.NewManagedIdentityCredential(options)
 }
 
@@ -231,37 +260,23 @@ func GetAuthTokenProvider(ctx context.Context, d *schema.ResourceData, azIdentit
 			return nil, err
 		}
 
-		certPassword := ([]byte)(nil)
-		if password, ok := d.GetOk("client_certificate_password"); ok {
-			certPassword = []byte(password.(string))
-		}
-
-		certs, key, err := azidentity.ParseCertificates(fileBytes, certPassword)
-		if err != nil {
-			return nil, err
-		}
-
-		cred, err = azIdentityFuncs.NewClientCertificateCredential(tenantID, clientID, certs, key, nil)
+		cred, err = newClientCertificateCredential(d, azIdentityFuncs, tenantID, clientID, fileBytes)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	// Certificate from a base64 encoded string
+	// Certificate from a base64 encoded or PEM encoded string
 	if client_certificate, ok := d.GetOk("client_certificate"); ok {
-		cert_bytes, err := base64.StdEncoding.DecodeString(client_certificate.(string))
-		if err != nil {
-			return nil, err
-		}
-		certPassword := ([]byte)(nil)
-		if password, ok := d.GetOk("client_certificate_password"); ok {
-			certPassword = []byte(password.(string))
-		}
-		certs, key, err := azidentity.ParseCertificates(cert_bytes, certPassword)
-		if err != nil {
-			return nil, err
+		cert_bytes := []byte(client_certificate.(string))
+		if !strings.HasPrefix(strings.TrimSpace(client_certificate.(string)), "-----BEGIN") {
+			cert_bytes, err = base64.StdEncoding.DecodeString(client_certificate.(string))
+			if err != nil {
+				return nil, fmt.Errorf(" client_certificate must be either a base64 encoded PKCS#12 bundle or a PEM encoded certificate and private key: %+v", err)
+			}
 		}
-		cred, err = azIdentityFuncs.NewClientCertificateCredential(tenantID, clientID, certs, key, nil)
+
+		cred, err = newClientCertificateCredential(d, azIdentityFuncs, tenantID, clientID, cert_bytes)
 		if err != nil {
 			return nil, err
 		}
@@ -289,8 +304,12 @@ func GetAuthTokenProvider(ctx context.Context, d *schema.ResourceData, azIdentit
 
 	// Azure Managed Service Identity
 	if use_msi, ok := d.GetOk("use_msi"); ok && use_msi.(bool) {
+		// A user assigned identity may be selected either by its resource id or by its client id,
+		// otherwise the system assigned identity of the host is used.
 		options := &azidentity.ManagedIdentityCredentialOptions{}
-		if client_id, ok := d.GetOk("client_id"); ok {
+		if msi_resource_id, ok := d.GetOk("msi_resource_id"); ok {
+			options.ID = azidentity.ResourceID(msi_resource_id.(string))
+		} else if client_id, ok := d.GetOk("client_id"); ok {
 			options.ID = azidentity.ClientID(client_id.(string))
 		}
 
@@ -308,13 +327,38 @@ func GetAuthTokenProvider(ctx context.Context, d *schema.ResourceData, azIdentit
 	return provider.GetToken, nil
 }
 
+// newClientCertificateCredential parses a PKCS#12 or PEM certificate bundle and creates a credential for the service principal.
+func newClientCertificateCredential(d *schema.ResourceData, azIdentityFuncs IdentityFuncsI, tenantID string, clientID string, certData []byte) (TokenGetter, error) {
+	if tenantID == "" || clientID == "" {
+		return nil, fmt.Errorf(" Both client_id and tenant_id must be set when authenticating with a client certificate.")
+	}
+
+	certPassword := ([]byte)(nil)
+	if password, ok := d.GetOk("client_certificate_password"); ok {
+		certPassword = []byte(password.(string))
+	}
+
+	certs, key, err := azidentity.ParseCertificates(certData, certPassword)
+	if err != nil {
+		return nil, fmt.Errorf(" Failed to parse the client certificate: %+v", err)
+	}
+
+	return azIdentityFuncs.NewClientCertificateCredential(tenantID, clientID, certs, key, nil)
+}
+
+// AzTokenProvider caches an Azure AD access token and renews it shortly before it expires.
+// It is safe for concurrent use.
 type AzTokenProvider struct {
 	ctx         context.Context
 	cred        TokenGetter
 	opts        policy.TokenRequestOptions
 	cachedToken *azcore.AccessToken
+	lock        sync.Mutex
 }
 
+// tokenRefreshWindow is how long before its expiration a cached token is renewed.
+const tokenRefreshWindow = 5 * time.Minute
+
 func newAzTokenProvider(cred TokenGetter, ctx context.Context, opts policy.TokenRequestOptions) *AzTokenProvider {
 	return &AzTokenProvider{
 		cred:        cred,
@@ -331,12 +375,15 @@ func AssertionProviderFromString(assertion string) func(context.Context) (string
 }
 
 func (provider *AzTokenProvider) GetToken() (string, error) {
-	if provider.cachedToken == nil || provider.cachedToken.ExpiresOn.Before(time.Now().Local().Add(-5*time.Minute)) {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+
+	if provider.cachedToken == nil || provider.cachedToken.ExpiresOn.Before(time.Now().Add(tokenRefreshWindow)) {
 		cachedToken, err := provider.cred.GetToken(provider.ctx, provider.opts)
-		provider.cachedToken = &cachedToken
 		if err != nil {
 			return "", err
 		}
+		provider.cachedToken = &cachedToken
 	}
 	return "Bearer " + provider.cachedToken.Token, nil
 }
@@ -0,0 +1,96 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// identityServiceHosts are the host prefixes of the identity services of Azure DevOps Services
+var identityServiceHosts = []string{"vssps.", "vsaex."}
+
+// identityServiceAreas are the API areas of the identity services, which Azure DevOps Server hosts in the collection
+var identityServiceAreas = []string{
+	"/_apis/graph/",
+	"/_apis/identities",
+	"/_apis/userentitlements",
+	"/_apis/groupentitlements",
+	"/_apis/memberentitlements",
+	"/_apis/serviceprincipalentitlements",
+}
+
+// ConcurrencyTransport limits the number of requests which are sent to Azure DevOps at the same time. The requests
+// of the identity services, Graph and Member Entitlement Management, are throttled more aggressively than the ones
+// of other services, so they can be limited further.
+//
+// A request holds its slot until the response headers are received, as the Azure DevOps Go API does not close the
+// body of every response.
+type ConcurrencyTransport struct {
+	Next http.RoundTripper
+
+	requests         chan struct{}
+	identityRequests chan struct{}
+}
+
+// NewConcurrencyTransport creates a transport sending at most maxRequests requests, of which at most
+// maxIdentityRequests are requests of the identity services, at the same time. Zero means no limit.
+func NewConcurrencyTransport(next http.RoundTripper, maxRequests int, maxIdentityRequests int) *ConcurrencyTransport {
+	t := &ConcurrencyTransport{Next: next}
+	if maxRequests > 0 {
+		t.requests = make(chan struct{}, maxRequests)
+	}
+	if maxIdentityRequests > 0 {
+		t.identityRequests = make(chan struct{}, maxIdentityRequests)
+	}
+	return t
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *ConcurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isIdentityServiceRequest(req) {
+		if err := acquireSlot(req.Context(), t.identityRequests); err != nil {
+			return nil, err
+		}
+		defer releaseSlot(t.identityRequests)
+	}
+	if err := acquireSlot(req.Context(), t.requests); err != nil {
+		return nil, err
+	}
+	defer releaseSlot(t.requests)
+
+	return nextTransport(t.Next).RoundTrip(req)
+}
+
+func acquireSlot(ctx context.Context, slots chan struct{}) error {
+	if slots == nil {
+		return nil
+	}
+	select {
+	case slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func releaseSlot(slots chan struct{}) {
+	if slots != nil {
+		<-slots
+	}
+}
+
+func isIdentityServiceRequest(req *http.Request) bool {
+	host := strings.ToLower(req.URL.Hostname())
+	for _, prefix := range identityServiceHosts {
+		if strings.HasPrefix(host, prefix) {
+			return true
+		}
+	}
+	path := strings.ToLower(req.URL.Path)
+	for _, area := range identityServiceAreas {
+		if strings.Contains(path, area) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// blockingTransport records the highest number of concurrent requests, requests wait until release is closed
+type blockingTransport struct {
+	lock    sync.Mutex
+	current int
+	max     int
+	release chan struct{}
+}
+
+func (t *blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.lock.Lock()
+	t.current++
+	if t.current > t.max {
+		t.max = t.current
+	}
+	t.lock.Unlock()
+
+	<-t.release
+
+	t.lock.Lock()
+	t.current--
+	t.lock.Unlock()
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+// sendConcurrently sends count requests to url at the same time and waits for all of them to complete
+func sendConcurrently(t *testing.T, transport http.RoundTripper, url string, count int) {
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrencyTransport_LimitsRequests(t *testing.T) {
+	next := &blockingTransport{release: make(chan struct{})}
+	transport := NewConcurrencyTransport(next, 3, 0)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(next.release)
+	}()
+	sendConcurrently(t, transport, "https://dev.azure.com/org/_apis/git/repositories", 10)
+	require.Equal(t, 3, next.max)
+}
+
+func TestConcurrencyTransport_LimitsIdentityRequestsFurther(t *testing.T) {
+	for _, url := range []string{
+		"https://vssps.dev.azure.com/org/_apis/graph/groups",
+		"https://vsaex.dev.azure.com/org/_apis/userentitlements",
+		"https://server.example.com/collection/_apis/graph/users",
+	} {
+		t.Run(url, func(t *testing.T) {
+			next := &blockingTransport{release: make(chan struct{})}
+			transport := NewConcurrencyTransport(next, 5, 1)
+
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				close(next.release)
+			}()
+			sendConcurrently(t, transport, url, 5)
+			require.Equal(t, 1, next.max)
+		})
+	}
+}
+
+func TestConcurrencyTransport_UnlimitedByDefault(t *testing.T) {
+	next := &blockingTransport{release: make(chan struct{})}
+	transport := NewConcurrencyTransport(next, 0, 0)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(next.release)
+	}()
+	sendConcurrently(t, transport, "https://vssps.dev.azure.com/org/_apis/graph/groups", 5)
+	require.Equal(t, 5, next.max)
+}
+
+func TestConcurrencyTransport_StopsWaitingWhenRequestIsCancelled(t *testing.T) {
+	next := &blockingTransport{release: make(chan struct{})}
+	defer close(next.release)
+	transport := NewConcurrencyTransport(next, 1, 0)
+
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, "https://dev.azure.com/org/_apis/projects", nil)
+		_, _ = transport.RoundTrip(req)
+	}()
+	require.Eventually(t, func() bool {
+		next.lock.Lock()
+		defer next.lock.Unlock()
+		return next.current == 1
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://dev.azure.com/org/_apis/projects", nil)
+	require.NoError(t, err)
+	_, err = transport.RoundTrip(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -1,14 +1,17 @@
 package sdk
 
 import (
+	"context"
+	"net/http"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
 )
 
 // Creates a new Azure DevOps connection instance using a function that returns an authorization header string.
 func NewDynamicAuthorizationConnection(organizationUrl string, authProvider func() (string, error)) (*azuredevops.Connection, error) {
-	organizationUrl = strings.ToLower(strings.TrimRight(organizationUrl, "/"))
+	organizationUrl = normalizeUrl(organizationUrl)
 	authorizationString, err := authProvider()
 	if err != nil {
 		return nil, err
@@ -19,3 +22,69 @@ func NewDynamicAuthorizationConnection(organizationUrl string, authProvider func
 		SuppressFedAuthRedirect: true,
 	}, nil
 }
+
+// ClientFactory creates Azure DevOps clients which send all of their requests through a shared http.Client.
+//
+// The clients created by the Azure DevOps Go API always use a default http.Client, which makes it
+// impossible to hook into the requests they send. ClientFactory resolves the resource area locations
+// the same way the Go API does, but lets the caller decide how requests are sent.
+type ClientFactory struct {
+	connection    *azuredevops.Connection
+	httpClient    *http.Client
+	resourceAreas map[uuid.UUID]string
+}
+
+// NewClientFactory creates a ClientFactory and loads the resource areas of the organization.
+func NewClientFactory(ctx context.Context, connection *azuredevops.Connection, httpClient *http.Client) (*ClientFactory, error) {
+	factory := &ClientFactory{
+		connection:    connection,
+		httpClient:    httpClient,
+		resourceAreas: map[uuid.UUID]string{},
+	}
+
+	resourceAreas, err := factory.ClientByUrl(connection.BaseUrl).GetResourceAreas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, resourceArea := range *resourceAreas {
+		if resourceArea.Id != nil && resourceArea.LocationUrl != nil {
+			factory.resourceAreas[*resourceArea.Id] = *resourceArea.LocationUrl
+		}
+	}
+	return factory, nil
+}
+
+// ClientByUrl creates a client for the given service url.
+func (factory *ClientFactory) ClientByUrl(baseUrl string) *azuredevops.Client {
+	return azuredevops.NewClientWithOptions(factory.connection, normalizeUrl(baseUrl), azuredevops.WithHTTPClient(factory.httpClient))
+}
+
+// ClientByResourceAreaId creates a client for the service hosting the given resource area.
+func (factory *ClientFactory) ClientByResourceAreaId(resourceAreaID uuid.UUID) (*azuredevops.Client, error) {
+	locationUrl, err := factory.UrlByResourceAreaId(resourceAreaID)
+	if err != nil {
+		return nil, err
+	}
+	return factory.ClientByUrl(locationUrl), nil
+}
+
+// UrlByResourceAreaId returns the url of the service hosting the given resource area.
+func (factory *ClientFactory) UrlByResourceAreaId(resourceAreaID uuid.UUID) (string, error) {
+	// on prem servers do not return any resource area, all areas are served by the organization url
+	if len(factory.resourceAreas) == 0 {
+		return normalizeUrl(factory.connection.BaseUrl), nil
+	}
+
+	locationUrl, ok := factory.resourceAreas[resourceAreaID]
+	if !ok {
+		return "", &azuredevops.ResourceAreaIdNotRegisteredError{
+			ResourceAreaId: resourceAreaID,
+			Url:            factory.connection.BaseUrl,
+		}
+	}
+	return normalizeUrl(locationUrl), nil
+}
+
+func normalizeUrl(url string) string {
+	return strings.ToLower(strings.TrimRight(url, "/"))
+}
@@ -0,0 +1,88 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ActivityIDProperty is the custom property of the errors returned by the Azure DevOps Go API which holds the
+// activity ID of the failed request, see CorrelationTransport.
+const ActivityIDProperty = "activityId"
+
+// CorrelationTransport adds the activity ID Azure DevOps correlates a failed request with to the custom properties
+// of the error in the response body.
+//
+// The Azure DevOps Go API builds its errors from the response body alone, so the activity ID, which is only part
+// of the response headers, would otherwise be lost. Responses without a body get a body carrying the same message
+// the Go API would have used. Response bodies which are not JSON objects are left as they are.
+type CorrelationTransport struct {
+	Next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *CorrelationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := nextTransport(t.Next).RoundTrip(req)
+	if err != nil || resp.StatusCode < http.StatusBadRequest || req.Method == http.MethodHead {
+		return resp, err
+	}
+	id := activityID(resp.Header)
+	if id == "" {
+		return resp, nil
+	}
+
+	var body []byte
+	if resp.Body != nil {
+		body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	annotated, ok := annotateErrorBody(resp, body, id)
+	if !ok {
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(annotated))
+	resp.ContentLength = int64(len(annotated))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(annotated)))
+	resp.Header.Set("Content-Type", "application/json; charset=utf-8")
+	return resp, nil
+}
+
+// annotateErrorBody returns the error body with the activity ID added to its custom properties
+func annotateErrorBody(resp *http.Response, body []byte, id string) ([]byte, bool) {
+	payload := map[string]interface{}{}
+	if len(bytes.TrimSpace(body)) == 0 {
+		payload["message"] = "Request returned status: " + resp.Status
+	} else {
+		mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+		if !strings.HasSuffix(mediaType, "json") {
+			return nil, false
+		}
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&payload); err != nil || payload == nil {
+			return nil, false
+		}
+	}
+
+	properties, _ := payload["customProperties"].(map[string]interface{})
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	properties[ActivityIDProperty] = id
+	payload["customProperties"] = properties
+
+	annotated, err := json.Marshal(payload)
+	if err != nil {
+		return nil, false
+	}
+	return annotated, true
+}
@@ -0,0 +1,91 @@
+package sdk
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func roundTripCorrelation(t *testing.T, method string, handler http.HandlerFunc) (*http.Response, []byte) {
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	req, err := http.NewRequest(method, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := (&CorrelationTransport{}).RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, body
+}
+
+func TestCorrelationTransport_AddsActivityIDToErrorBody(t *testing.T) {
+	resp, body := roundTripCorrelation(t, http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-VSS-ActivityId", "activity-1")
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"message": "VS402371: invalid", "typeKey": "InvalidArgumentValueException", "errorCode": 0, "customProperties": {"name": "x"}}`)
+	})
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	payload := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	require.Equal(t, "VS402371: invalid", payload["message"])
+	require.Equal(t, "InvalidArgumentValueException", payload["typeKey"])
+	require.Equal(t, map[string]interface{}{"name": "x", ActivityIDProperty: "activity-1"}, payload["customProperties"])
+}
+
+func TestCorrelationTransport_AddsBodyToEmptyErrorResponse(t *testing.T) {
+	_, body := roundTripCorrelation(t, http.MethodDelete, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ActivityId", "activity-2")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	payload := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	require.Equal(t, "Request returned status: 503 Service Unavailable", payload["message"])
+	require.Equal(t, map[string]interface{}{ActivityIDProperty: "activity-2"}, payload["customProperties"])
+}
+
+func TestCorrelationTransport_LeavesOtherResponsesUnchanged(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Method     string
+		StatusCode int
+		ActivityID string
+		Body       string
+	}{
+		{Name: "Success", Method: http.MethodGet, StatusCode: http.StatusOK, ActivityID: "activity-3", Body: `{"id": "1"}`},
+		{Name: "MissingActivityID", Method: http.MethodGet, StatusCode: http.StatusNotFound, Body: `{"message": "not found"}`},
+		{Name: "Head", Method: http.MethodHead, StatusCode: http.StatusNotFound, ActivityID: "activity-4"},
+		{Name: "NotJSON", Method: http.MethodGet, StatusCode: http.StatusBadGateway, ActivityID: "activity-5", Body: "<html>bad gateway</html>"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, body := roundTripCorrelation(t, tc.Method, func(w http.ResponseWriter, r *http.Request) {
+				if tc.ActivityID != "" {
+					w.Header().Set("X-VSS-ActivityId", tc.ActivityID)
+				}
+				if tc.Body != "" && tc.Body[0] == '{' {
+					w.Header().Set("Content-Type", "application/json")
+				} else {
+					w.Header().Set("Content-Type", "text/html")
+				}
+				w.WriteHeader(tc.StatusCode)
+				_, _ = io.WriteString(w, tc.Body)
+			})
+			if tc.Method == http.MethodHead {
+				require.Empty(t, body)
+				return
+			}
+			require.Equal(t, tc.Body, string(body))
+		})
+	}
+}
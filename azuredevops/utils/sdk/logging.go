@@ -0,0 +1,199 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// redacted replaces secrets in the logged requests and responses
+	redacted = "<redacted>"
+	// maxLoggedBodySize caps the size of the logged request and response bodies
+	maxLoggedBodySize = 64 * 1024
+)
+
+// redactedHeaders are the headers whose values are never logged
+var redactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// secretFieldMarkers identify, as part of their lower cased name, the JSON fields holding secrets
+var secretFieldMarkers = []string{"password", "secret", "token", "key", "certificate", "kubeconfig", "credential"}
+
+// LoggingTransport logs the requests sent to Azure DevOps and their responses at the DEBUG level, including
+// the activity ID Azure DevOps correlates a request with in its own logs.
+//
+// Authorization headers are always redacted, as are the JSON fields which may contain secrets: fields named
+// after passwords, secrets, tokens, keys or certificates, the parameters of service endpoint authorizations
+// and the values of secret variables. Bodies which are not JSON are never logged.
+type LoggingTransport struct {
+	Next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+	log.Printf("[DEBUG] Azure DevOps request: %s %s\n%s%s", req.Method, req.URL.Redacted(), formatHeaders(req.Header), formatBody(req.Header, reqBody))
+
+	start := time.Now()
+	resp, err := nextTransport(t.Next).RoundTrip(req)
+	duration := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		log.Printf("[DEBUG] Azure DevOps request %s %s failed after %s: %+v", req.Method, req.URL.Redacted(), duration, err)
+		return resp, err
+	}
+
+	var respBody []byte
+	if resp.Body != nil {
+		respBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	}
+	id := activityID(resp.Header)
+	if id == "" {
+		id = "unknown"
+	}
+	log.Printf("[DEBUG] Azure DevOps response: %s %s returned %s in %s (activity ID: %s)\n%s%s", req.Method, req.URL.Redacted(), resp.Status, duration, id, formatHeaders(resp.Header), formatBody(resp.Header, respBody))
+	return resp, nil
+}
+
+// activityID returns the ID Azure DevOps correlates the request with, or an empty string if the response has none
+func activityID(header http.Header) string {
+	for _, name := range []string{"X-VSS-ActivityId", "ActivityId", "X-MSEdge-Ref"} {
+		if id := header.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+func formatHeaders(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			value = redacted
+		}
+		fmt.Fprintf(&sb, "%s: %s\n", name, value)
+	}
+	return sb.String()
+}
+
+func formatBody(header http.Header, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if !strings.HasSuffix(mediaType, "json") {
+		return fmt.Sprintf("<%d bytes of %s content not logged>", len(body), mediaType)
+	}
+
+	redactedBody, err := RedactJSON(body)
+	if err != nil {
+		return fmt.Sprintf("<%d bytes of malformed JSON content not logged>", len(body))
+	}
+	if len(redactedBody) > maxLoggedBodySize {
+		return fmt.Sprintf("%s... <%d more bytes truncated>", redactedBody[:maxLoggedBodySize], len(redactedBody)-maxLoggedBodySize)
+	}
+	return string(redactedBody)
+}
+
+// RedactJSON replaces the values of the fields of a JSON document which may contain secrets
+func RedactJSON(body []byte) ([]byte, error) {
+	var document interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	return json.Marshal(redactValue(document))
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// the value of a secret variable is only known to be secret through its sibling field
+		isSecret, _ := v["isSecret"].(bool)
+		for name, field := range v {
+			switch {
+			case isSecret && name == "value":
+				v[name] = redactSecret(field)
+			case strings.EqualFold(name, "parameters") && v["scheme"] != nil:
+				// the parameters of an endpoint authorization are all credentials
+				v[name] = redactSecret(field)
+			case isSecretField(name):
+				v[name] = redactSecret(redactValue(field))
+			default:
+				v[name] = redactValue(field)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, element := range v {
+			v[i] = redactValue(element)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// redactSecret replaces the strings of a value, but keeps its structure
+func redactSecret(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return v
+		}
+		return redacted
+	case map[string]interface{}:
+		for name, field := range v {
+			v[name] = redactSecret(field)
+		}
+		return v
+	case []interface{}:
+		for i, element := range v {
+			v[i] = redactSecret(element)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, marker := range secretFieldMarkers {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package sdk
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactJSON(t *testing.T) {
+	body := `{
+		"name": "endpoint",
+		"authorization": {"scheme": "UsernamePassword", "parameters": {"username": "user", "password": "hunter2"}},
+		"data": {"apiToken": "abc", "url": "https://example.com"},
+		"variables": {
+			"plain": {"value": "visible", "isSecret": false},
+			"hidden": {"value": "s3cr3t", "isSecret": true}
+		},
+		"items": [{"clientSecret": "xyz", "count": 3}]
+	}`
+
+	redactedBody, err := RedactJSON([]byte(body))
+	require.NoError(t, err)
+
+	result := string(redactedBody)
+	for _, secret := range []string{"hunter2", "abc", "s3cr3t", "xyz", `"user"`} {
+		require.NotContains(t, result, secret)
+	}
+	for _, visible := range []string{`"endpoint"`, "UsernamePassword", "https://example.com", "visible", `"count":3`} {
+		require.Contains(t, result, visible)
+	}
+}
+
+func TestRedactJSON_RejectsMalformedJSON(t *testing.T) {
+	_, err := RedactJSON([]byte("{"))
+	require.Error(t, err)
+}
+
+func TestLoggingTransport_LogsRedactedTrace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-VSS-ActivityId", "activity-1")
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = io.WriteString(w, `{"id": "1", "token": "response-secret"}`)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/_apis/tokens", strings.NewReader(`{"password": "request-secret"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Basic c2VjcmV0")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := (&LoggingTransport{}).RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// the response body is still readable by the caller
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), "response-secret")
+
+	output := logs.String()
+	require.Contains(t, output, "POST "+server.URL+"/_apis/tokens")
+	require.Contains(t, output, "200 OK")
+	require.Contains(t, output, "activity ID: activity-1")
+	require.Contains(t, output, `"id":"1"`)
+	require.NotContains(t, output, "c2VjcmV0")
+	require.NotContains(t, output, "request-secret")
+	require.NotContains(t, output, "response-secret")
+}
+
+func TestLoggingTransport_DoesNotLogOtherContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, "plain-secret")
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := (&LoggingTransport{}).RoundTrip(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.NotContains(t, logs.String(), "plain-secret")
+	require.Contains(t, logs.String(), "12 bytes of text/plain content not logged")
+}
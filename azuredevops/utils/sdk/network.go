@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// NewHTTPTransport returns the transport used to reach Azure DevOps and Azure AD.
+//
+// Requests are sent through the proxy at proxyURL when set, otherwise the HTTP_PROXY, HTTPS_PROXY and
+// NO_PROXY environment variables are honored. The certificate authorities of the PEM encoded caBundle
+// are trusted in addition to the ones of the system, for networks intercepting TLS connections.
+func NewHTTPTransport(proxyURL string, caBundle []byte) (http.RoundTripper, error) {
+	if proxyURL == "" && len(caBundle) == 0 {
+		return http.DefaultTransport, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxyURL != "" {
+		proxy, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf(" parsing the proxy URL: %+v", err)
+		}
+		if proxy.Scheme == "" || proxy.Host == "" {
+			return nil, fmt.Errorf(" the proxy URL must be an absolute URL, e.g. http://proxy.example.com:8080")
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if len(caBundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			// the system pool is not available on every platform, only the bundle is trusted then
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf(" the CA bundle does not contain any PEM encoded certificate")
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+	return transport, nil
+}
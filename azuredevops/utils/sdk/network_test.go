@@ -0,0 +1,56 @@
+package sdk
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPTransport_DefaultsToDefaultTransport(t *testing.T) {
+	transport, err := NewHTTPTransport("", nil)
+	require.NoError(t, err)
+	require.Equal(t, http.DefaultTransport, transport)
+}
+
+func TestNewHTTPTransport_UsesProxy(t *testing.T) {
+	transport, err := NewHTTPTransport("http://proxy.example.com:8080", nil)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "https://dev.azure.com/org", nil)
+	require.NoError(t, err)
+	proxy, err := transport.(*http.Transport).Proxy(req)
+	require.NoError(t, err)
+	require.Equal(t, "http://proxy.example.com:8080", proxy.String())
+}
+
+func TestNewHTTPTransport_RejectsInvalidProxy(t *testing.T) {
+	_, err := NewHTTPTransport("proxy.example.com", nil)
+	require.Error(t, err)
+}
+
+func TestNewHTTPTransport_TrustsCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := (&http.Client{Transport: http.DefaultTransport}).Get(server.URL)
+	require.Error(t, err, "the certificate of the test server must not be trusted by default")
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	transport, err := NewHTTPTransport("", caBundle)
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNewHTTPTransport_RejectsInvalidCABundle(t *testing.T) {
+	_, err := NewHTTPTransport("", []byte("not a certificate"))
+	require.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package sdk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// fullAccessScope is granted to Microsoft Entra tokens, which act with all permissions of their identity
+const fullAccessScope = "user_impersonation"
+
+// scopeLevels ranks the suffixes of Azure DevOps scopes, a scope grants all scopes of the same area with a
+// lower or equal rank, e.g. vso.code_manage grants vso.code_write and vso.code
+var scopeLevels = map[string]int{
+	"":         0,
+	"_status":  1,
+	"_write":   1,
+	"_execute": 1,
+	"_manage":  2,
+	"_full":    3,
+}
+
+// TokenScopes returns the scopes granted to the token of an authorization header. Only access tokens are
+// JWTs listing their scopes, nil is returned when the scopes cannot be read from the token, like for PATs.
+func TokenScopes(authorization string) []string {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return nil
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil
+	}
+
+	var claims struct {
+		Scp string `json:"scp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Scp == "" {
+		return nil
+	}
+	return strings.Fields(claims.Scp)
+}
+
+// HasTokenScope reports whether the granted scopes include the required scope, or a scope of the same area
+// with a higher level
+func HasTokenScope(granted []string, required string) bool {
+	area, level := splitTokenScope(required)
+	for _, scope := range granted {
+		if scope == fullAccessScope {
+			return true
+		}
+		grantedArea, grantedLevel := splitTokenScope(scope)
+		if grantedArea == area && grantedLevel >= level {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadTokenScope returns the read only scope of the area of a scope, e.g. vso.code for vso.code_manage
+func ReadTokenScope(scope string) string {
+	area, _ := splitTokenScope(scope)
+	return area
+}
+
+func splitTokenScope(scope string) (string, int) {
+	if i := strings.LastIndex(scope, "_"); i > 0 {
+		if level, ok := scopeLevels[scope[i:]]; ok {
+			return scope[:i], level
+		}
+	}
+	return scope, 0
+}
@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testJWT(claims string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	return "Bearer " + encode([]byte(`{"alg":"none"}`)) + "." + encode([]byte(claims)) + ".signature"
+}
+
+func TestTokenScopes(t *testing.T) {
+	require.Equal(t, []string{"vso.code_manage", "vso.project"}, TokenScopes(testJWT(`{"scp":"vso.code_manage vso.project"}`)))
+	require.Equal(t, []string{"user_impersonation"}, TokenScopes(testJWT(`{"scp":"user_impersonation"}`)))
+	require.Nil(t, TokenScopes(testJWT(`{"roles":[]}`)))
+	require.Nil(t, TokenScopes("Bearer not-a-jwt"))
+	require.Nil(t, TokenScopes("Basic "+base64.StdEncoding.EncodeToString([]byte("_:pat"))))
+}
+
+func TestHasTokenScope(t *testing.T) {
+	granted := []string{"vso.code_manage", "vso.packaging", "vso.security_manage"}
+
+	require.True(t, HasTokenScope(granted, "vso.code"))
+	require.True(t, HasTokenScope(granted, "vso.code_write"))
+	require.True(t, HasTokenScope(granted, "vso.code_manage"))
+	require.False(t, HasTokenScope(granted, "vso.code_full"))
+	require.True(t, HasTokenScope(granted, "vso.packaging"))
+	require.False(t, HasTokenScope(granted, "vso.packaging_manage"))
+	require.False(t, HasTokenScope(granted, "vso.project"))
+	require.True(t, HasTokenScope([]string{"user_impersonation"}, "vso.packaging_manage"))
+}
+
+func TestReadTokenScope(t *testing.T) {
+	require.Equal(t, "vso.packaging", ReadTokenScope("vso.packaging_manage"))
+	require.Equal(t, "vso.memberentitlementmanagement", ReadTokenScope("vso.memberentitlementmanagement_write"))
+	require.Equal(t, "vso.graph", ReadTokenScope("vso.graph"))
+	require.Equal(t, "", ReadTokenScope(""))
+}
@@ -0,0 +1,232 @@
+package sdk
+
+import (
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// AuthorizationTransport sets the authorization header of every request it sends.
+//
+// Azure AD access tokens expire after roughly an hour. Requesting the header for each request
+// lets the token provider renew the token transparently during long running operations.
+type AuthorizationTransport struct {
+	Next          http.RoundTripper
+	TokenProvider func() (string, error)
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *AuthorizationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	authorization, err := t.TokenProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	// a RoundTripper must not modify the request it was given
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", authorization)
+	return nextTransport(t.Next).RoundTrip(req)
+}
+
+// maxRetryDelay caps the time waited between two attempts of a request.
+const maxRetryDelay = 5 * time.Minute
+
+// RetryTransport retries requests which failed with a transient status, see IsRetryableStatusCode.
+// Requests which may have been processed already, like POST and PATCH, are only retried when
+// they were throttled (429) or rejected because the service was temporarily unavailable (503).
+//
+// The delay between attempts honors the Retry-After and X-RateLimit-Reset headers returned by
+// Azure DevOps, and falls back to an exponential backoff starting at BaseDelay.
+type RetryTransport struct {
+	Next       http.RoundTripper
+	MaxRetries int
+	BaseDelay  time.Duration
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := nextTransport(t.Next).RoundTrip(req)
+	for attempt := 0; attempt < t.MaxRetries && err == nil && isRetryableRequest(req, resp.StatusCode); attempt++ {
+		// requests with a body which cannot be replayed are not retried
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			break
+		}
+
+		delay := retryDelay(resp, attempt, t.BaseDelay)
+		log.Printf("[DEBUG] %s %s returned %d, retrying in %s (retry %d of %d)", req.Method, req.URL.Redacted(), resp.StatusCode, delay, attempt+1, t.MaxRetries)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		retryReq := req.Clone(req.Context())
+		if req.GetBody != nil {
+			retryReq.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+		resp, err = nextTransport(t.Next).RoundTrip(retryReq)
+	}
+	return resp, err
+}
+
+// retryableStatusCodes are the status codes of the responses to requests which may succeed when they are sent again
+var retryableStatusCodes = map[int]bool{
+	http.StatusRequestTimeout:      true,
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
+// IsRetryableStatusCode reports whether a request which failed with statusCode may succeed when it is sent again
+func IsRetryableStatusCode(statusCode int) bool {
+	return retryableStatusCodes[statusCode]
+}
+
+func isRetryableRequest(req *http.Request, statusCode int) bool {
+	if statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable {
+		return true
+	}
+	return isIdempotent(req.Method) && IsRetryableStatusCode(statusCode)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before retrying the request which produced resp.
+func retryDelay(resp *http.Response, attempt int, baseDelay time.Duration) time.Duration {
+	delay := baseDelay * time.Duration(1<<uint(attempt))
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			delay = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(retryAfter); err == nil {
+			delay = time.Until(date)
+		}
+	} else if reset := resp.Header.Get("X-RateLimit-Reset"); reset != "" {
+		if epoch, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			delay = time.Until(time.Unix(epoch, 0))
+		}
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+// nextTransport returns the transport a request should be handed to, defaulting to http.DefaultTransport.
+func nextTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		return http.DefaultTransport
+	}
+	return next
+}
+
+const (
+	// throttleThreshold is the fraction of the rate limit below which ThrottleTransport starts slowing down
+	throttleThreshold = 0.2
+	// maxThrottleDelay caps the time a single request is held back by ThrottleTransport
+	maxThrottleDelay = time.Minute
+)
+
+// ThrottleTransport proactively slows down the dispatch of requests when Azure DevOps reports,
+// through its X-RateLimit-* headers, that the remaining resource consumption (TSTUs) of the
+// caller is running low. Requests are delayed more as the remaining budget approaches zero,
+// so that large applies spread their requests until the usage window resets instead of
+// being blocked by the service.
+type ThrottleTransport struct {
+	Next http.RoundTripper
+
+	lock      sync.Mutex
+	limit     float64
+	remaining float64
+	reset     time.Time
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *ThrottleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if delay := t.delay(time.Now()); delay > 0 {
+		log.Printf("[DEBUG] Azure DevOps rate limit is nearly exhausted, delaying %s %s by %s", req.Method, req.URL.Redacted(), delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	resp, err := nextTransport(t.Next).RoundTrip(req)
+	if err == nil {
+		t.observe(resp.Header)
+	}
+	return resp, err
+}
+
+// observe records the rate limit state reported by Azure DevOps.
+func (t *ThrottleTransport) observe(header http.Header) {
+	limit, err := strconv.ParseFloat(header.Get("X-RateLimit-Limit"), 64)
+	if err != nil || limit <= 0 {
+		return
+	}
+	remaining, err := strconv.ParseFloat(header.Get("X-RateLimit-Remaining"), 64)
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.limit = limit
+	t.remaining = remaining
+	t.reset = time.Unix(reset, 0)
+}
+
+// delay returns how long the next request should be held back.
+func (t *ThrottleTransport) delay(now time.Time) time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.limit <= 0 || !now.Before(t.reset) {
+		return 0
+	}
+
+	threshold := t.limit * throttleThreshold
+	if t.remaining >= threshold {
+		return 0
+	}
+
+	untilReset := t.reset.Sub(now)
+	if untilReset > maxThrottleDelay {
+		untilReset = maxThrottleDelay
+	}
+
+	// the delay grows linearly from nothing at the threshold to the time left in the usage window
+	pressure := 1 - math.Max(t.remaining, 0)/threshold
+	return time.Duration(pressure * float64(untilReset))
+}
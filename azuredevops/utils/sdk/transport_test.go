@@ -0,0 +1,245 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingTokenGetter struct {
+	calls    int
+	lifetime time.Duration
+}
+
+func (c *countingTokenGetter) GetToken(ctx context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	c.calls++
+	return azcore.AccessToken{
+		Token:     fmt.Sprintf("token%d", c.calls),
+		ExpiresOn: time.Now().Add(c.lifetime),
+	}, nil
+}
+
+func TestAzTokenProvider_CachesValidToken(t *testing.T) {
+	cred := &countingTokenGetter{lifetime: time.Hour}
+	provider := newAzTokenProvider(cred, context.Background(), policy.TokenRequestOptions{})
+
+	for i := 0; i < 3; i++ {
+		token, err := provider.GetToken()
+		require.Nil(t, err)
+		assert.Equal(t, "Bearer token1", token)
+	}
+	assert.Equal(t, 1, cred.calls)
+}
+
+func TestAzTokenProvider_RenewsExpiringToken(t *testing.T) {
+	cred := &countingTokenGetter{lifetime: time.Minute}
+	provider := newAzTokenProvider(cred, context.Background(), policy.TokenRequestOptions{})
+
+	token, err := provider.GetToken()
+	require.Nil(t, err)
+	assert.Equal(t, "Bearer token1", token)
+
+	token, err = provider.GetToken()
+	require.Nil(t, err)
+	assert.Equal(t, "Bearer token2", token)
+}
+
+func TestAuthorizationTransport_SetsHeaderForEveryRequest(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	calls := 0
+	client := &http.Client{
+		Transport: &AuthorizationTransport{
+			TokenProvider: func() (string, error) {
+				calls++
+				return fmt.Sprintf("Bearer token%d", calls), nil
+			},
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.Nil(t, err)
+		req.Header.Set("Authorization", "Bearer stale")
+		resp, err := client.Do(req)
+		require.Nil(t, err)
+		resp.Body.Close()
+		assert.Equal(t, "Bearer stale", req.Header.Get("Authorization"))
+	}
+	assert.Equal(t, []string{"Bearer token1", "Bearer token2"}, received)
+}
+
+func TestAuthorizationTransport_ReturnsTokenError(t *testing.T) {
+	client := &http.Client{
+		Transport: &AuthorizationTransport{
+			TokenProvider: func() (string, error) {
+				return "", fmt.Errorf("token expired")
+			},
+		},
+	}
+
+	_, err := client.Get("http://localhost")
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "token expired")
+}
+
+func TestRetryTransport_RetriesThrottledRequests(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &RetryTransport{MaxRetries: 3, BaseDelay: time.Millisecond},
+	}
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+	require.Nil(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"{}", "{}", "{}"}, bodies)
+}
+
+func TestRetryTransport_StopsAfterMaxRetries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &RetryTransport{MaxRetries: 2, BaseDelay: time.Millisecond},
+	}
+	resp, err := client.Get(server.URL)
+	require.Nil(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryTransport_DoesNotRetryOtherErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &RetryTransport{MaxRetries: 2, BaseDelay: time.Millisecond},
+	}
+	resp, err := client.Get(server.URL)
+	require.Nil(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryTransport_RetriesServerErrorsOfIdempotentRequests(t *testing.T) {
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.Method]++
+		if calls[r.Method] == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &RetryTransport{MaxRetries: 2, BaseDelay: time.Millisecond},
+	}
+	resp, err := client.Get(server.URL)
+	require.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = client.Post(server.URL, "application/json", strings.NewReader("{}"))
+	require.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+
+	assert.Equal(t, map[string]int{http.MethodGet: 2, http.MethodPost: 1}, calls)
+}
+
+func TestRetryDelay(t *testing.T) {
+	newResponse := func(headers map[string]string) *http.Response {
+		resp := &http.Response{Header: http.Header{}}
+		for k, v := range headers {
+			resp.Header.Set(k, v)
+		}
+		return resp
+	}
+
+	assert.Equal(t, 2*time.Second, retryDelay(newResponse(nil), 0, 2*time.Second))
+	assert.Equal(t, 8*time.Second, retryDelay(newResponse(nil), 2, 2*time.Second))
+	assert.Equal(t, 10*time.Second, retryDelay(newResponse(map[string]string{"Retry-After": "10"}), 3, 2*time.Second))
+	assert.Equal(t, maxRetryDelay, retryDelay(newResponse(map[string]string{"Retry-After": "100000"}), 0, 2*time.Second))
+	assert.Equal(t, time.Duration(0), retryDelay(newResponse(map[string]string{"X-RateLimit-Reset": "1"}), 0, 2*time.Second))
+
+	reset := retryDelay(newResponse(map[string]string{"X-RateLimit-Reset": strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)}), 0, 2*time.Second)
+	assert.InDelta(t, time.Minute.Seconds(), reset.Seconds(), 2)
+}
+
+func TestThrottleTransport_Delay(t *testing.T) {
+	now := time.Now()
+	reset := strconv.FormatInt(now.Add(30*time.Second).Unix(), 10)
+	newHeader := func(limit, remaining string) http.Header {
+		header := http.Header{}
+		header.Set("X-RateLimit-Limit", limit)
+		header.Set("X-RateLimit-Remaining", remaining)
+		header.Set("X-RateLimit-Reset", reset)
+		return header
+	}
+
+	transport := &ThrottleTransport{}
+	assert.Equal(t, time.Duration(0), transport.delay(now))
+
+	transport.observe(newHeader("200", "100"))
+	assert.Equal(t, time.Duration(0), transport.delay(now))
+
+	transport.observe(newHeader("200", "20"))
+	halfWay := transport.delay(now)
+	assert.InDelta(t, (15 * time.Second).Seconds(), halfWay.Seconds(), 1)
+
+	transport.observe(newHeader("200", "0"))
+	assert.InDelta(t, (30 * time.Second).Seconds(), transport.delay(now).Seconds(), 1)
+
+	assert.Equal(t, time.Duration(0), transport.delay(now.Add(time.Minute)))
+}
+
+func TestThrottleTransport_IgnoresMissingHeaders(t *testing.T) {
+	transport := &ThrottleTransport{}
+	transport.observe(http.Header{})
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "200")
+	transport.observe(header)
+	assert.Equal(t, time.Duration(0), transport.delay(time.Now()))
+}
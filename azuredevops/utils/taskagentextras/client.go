@@ -0,0 +1,158 @@
+// Client for the agent pool maintenance API, the Azure DevOps Go API only contains its models.
+// https://learn.microsoft.com/en-us/rest/api/azure/devops/distributedtask/pools
+
+// This file cannot be under "internal", because azdosdkmocks/taskagentextras_sdk_mock.go depends on it.
+
+package taskagentextras
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/taskagent"
+)
+
+var maintenanceDefinitionsLocationId, _ = uuid.Parse("80572e16-58f0-4419-ac07-d19fde32195c")
+
+const apiVersion = "7.1-preview.1"
+
+type Client interface {
+	// [Preview API] Create a maintenance definition of an agent pool
+	CreateAgentPoolMaintenanceDefinition(context.Context, CreateAgentPoolMaintenanceDefinitionArgs) (*taskagent.TaskAgentPoolMaintenanceDefinition, error)
+	// [Preview API] Delete a maintenance definition of an agent pool
+	DeleteAgentPoolMaintenanceDefinition(context.Context, DeleteAgentPoolMaintenanceDefinitionArgs) error
+	// [Preview API] Get the maintenance definitions of an agent pool
+	GetAgentPoolMaintenanceDefinitions(context.Context, GetAgentPoolMaintenanceDefinitionsArgs) (*[]taskagent.TaskAgentPoolMaintenanceDefinition, error)
+	// [Preview API] Update a maintenance definition of an agent pool
+	UpdateAgentPoolMaintenanceDefinition(context.Context, UpdateAgentPoolMaintenanceDefinitionArgs) (*taskagent.TaskAgentPoolMaintenanceDefinition, error)
+}
+
+type ClientImpl struct {
+	Client azuredevops.Client
+}
+
+// Arguments for the CreateAgentPoolMaintenanceDefinition function
+type CreateAgentPoolMaintenanceDefinitionArgs struct {
+	// (required) The maintenance definition to create
+	Definition *taskagent.TaskAgentPoolMaintenanceDefinition
+	// (required) The ID of the agent pool
+	PoolId *int
+}
+
+// [Preview API] Create a maintenance definition of an agent pool
+func (client *ClientImpl) CreateAgentPoolMaintenanceDefinition(ctx context.Context, args CreateAgentPoolMaintenanceDefinitionArgs) (*taskagent.TaskAgentPoolMaintenanceDefinition, error) {
+	if args.Definition == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.Definition"}
+	}
+	routeValues := make(map[string]string)
+	if args.PoolId == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.PoolId"}
+	}
+	routeValues["poolId"] = strconv.Itoa(*args.PoolId)
+
+	body, marshalErr := json.Marshal(*args.Definition)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	resp, err := client.Client.Send(ctx, http.MethodPost, maintenanceDefinitionsLocationId, apiVersion, routeValues, nil, bytes.NewReader(body), "application/json", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue taskagent.TaskAgentPoolMaintenanceDefinition
+	err = client.Client.UnmarshalBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// Arguments for the DeleteAgentPoolMaintenanceDefinition function
+type DeleteAgentPoolMaintenanceDefinitionArgs struct {
+	// (required) The ID of the agent pool
+	PoolId *int
+	// (required) The ID of the maintenance definition
+	DefinitionId *int
+}
+
+// [Preview API] Delete a maintenance definition of an agent pool
+func (client *ClientImpl) DeleteAgentPoolMaintenanceDefinition(ctx context.Context, args DeleteAgentPoolMaintenanceDefinitionArgs) error {
+	routeValues := make(map[string]string)
+	if args.PoolId == nil {
+		return &azuredevops.ArgumentNilError{ArgumentName: "args.PoolId"}
+	}
+	routeValues["poolId"] = strconv.Itoa(*args.PoolId)
+	if args.DefinitionId == nil {
+		return &azuredevops.ArgumentNilError{ArgumentName: "args.DefinitionId"}
+	}
+	routeValues["definitionId"] = strconv.Itoa(*args.DefinitionId)
+
+	_, err := client.Client.Send(ctx, http.MethodDelete, maintenanceDefinitionsLocationId, apiVersion, routeValues, nil, nil, "", "application/json", nil)
+	return err
+}
+
+// Arguments for the GetAgentPoolMaintenanceDefinitions function
+type GetAgentPoolMaintenanceDefinitionsArgs struct {
+	// (required) The ID of the agent pool
+	PoolId *int
+}
+
+// [Preview API] Get the maintenance definitions of an agent pool
+func (client *ClientImpl) GetAgentPoolMaintenanceDefinitions(ctx context.Context, args GetAgentPoolMaintenanceDefinitionsArgs) (*[]taskagent.TaskAgentPoolMaintenanceDefinition, error) {
+	routeValues := make(map[string]string)
+	if args.PoolId == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.PoolId"}
+	}
+	routeValues["poolId"] = strconv.Itoa(*args.PoolId)
+
+	resp, err := client.Client.Send(ctx, http.MethodGet, maintenanceDefinitionsLocationId, apiVersion, routeValues, url.Values{}, nil, "", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue []taskagent.TaskAgentPoolMaintenanceDefinition
+	err = client.Client.UnmarshalCollectionBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// Arguments for the UpdateAgentPoolMaintenanceDefinition function
+type UpdateAgentPoolMaintenanceDefinitionArgs struct {
+	// (required) The updated maintenance definition
+	Definition *taskagent.TaskAgentPoolMaintenanceDefinition
+	// (required) The ID of the agent pool
+	PoolId *int
+	// (required) The ID of the maintenance definition
+	DefinitionId *int
+}
+
+// [Preview API] Update a maintenance definition of an agent pool
+func (client *ClientImpl) UpdateAgentPoolMaintenanceDefinition(ctx context.Context, args UpdateAgentPoolMaintenanceDefinitionArgs) (*taskagent.TaskAgentPoolMaintenanceDefinition, error) {
+	if args.Definition == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.Definition"}
+	}
+	routeValues := make(map[string]string)
+	if args.PoolId == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.PoolId"}
+	}
+	routeValues["poolId"] = strconv.Itoa(*args.PoolId)
+	if args.DefinitionId == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.DefinitionId"}
+	}
+	routeValues["definitionId"] = strconv.Itoa(*args.DefinitionId)
+
+	body, marshalErr := json.Marshal(*args.Definition)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	resp, err := client.Client.Send(ctx, http.MethodPut, maintenanceDefinitionsLocationId, apiVersion, routeValues, nil, bytes.NewReader(body), "application/json", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue taskagent.TaskAgentPoolMaintenanceDefinition
+	err = client.Client.UnmarshalBody(resp, &responseValue)
+	return &responseValue, err
+}
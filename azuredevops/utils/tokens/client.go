@@ -0,0 +1,85 @@
+// Client for the personal access token lifecycle API, which is not part of the Azure DevOps Go API.
+// https://learn.microsoft.com/en-us/rest/api/azure/devops/tokens/pats
+
+// This file cannot be under "internal", because azdosdkmocks/tokens_sdk_mock.go depends on it.
+
+package tokens
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+)
+
+const apiVersion = "7.1-preview.1"
+
+type Client interface {
+	CreatePersonalAccessToken(ctx context.Context, args CreatePersonalAccessTokenArgs) (*PatTokenResult, error)
+	RevokePersonalAccessToken(ctx context.Context, args RevokePersonalAccessTokenArgs) error
+}
+
+// ClientImpl sends requests to the token service of the organization. The API is only available
+// on the vssps host, which is why the url has to be provided next to the client.
+type ClientImpl struct {
+	Client  azuredevops.Client
+	BaseUrl string
+}
+
+// Arguments for the CreatePersonalAccessToken function
+type CreatePersonalAccessTokenArgs struct {
+	// (required) The personal access token to create
+	Token *PatTokenCreateRequest
+}
+
+func (client *ClientImpl) CreatePersonalAccessToken(ctx context.Context, args CreatePersonalAccessTokenArgs) (*PatTokenResult, error) {
+	if args.Token == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.Token"}
+	}
+
+	body, marshalErr := json.Marshal(*args.Token)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+
+	req, err := client.Client.CreateRequestMessage(ctx, http.MethodPost, client.BaseUrl+"/_apis/tokens/pats", apiVersion, bytes.NewReader(body), "application/json", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Client.SendRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue PatTokenResult
+	err = client.Client.UnmarshalBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// Arguments for the RevokePersonalAccessToken function
+type RevokePersonalAccessTokenArgs struct {
+	// (required) The authorization ID of the personal access token
+	AuthorizationId *string
+}
+
+func (client *ClientImpl) RevokePersonalAccessToken(ctx context.Context, args RevokePersonalAccessTokenArgs) error {
+	if args.AuthorizationId == nil || *args.AuthorizationId == "" {
+		return &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.AuthorizationId"}
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add("authorizationId", *args.AuthorizationId)
+
+	req, err := client.Client.CreateRequestMessage(ctx, http.MethodDelete, client.BaseUrl+"/_apis/tokens/pats?"+queryParams.Encode(), apiVersion, nil, "", "application/json", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Client.SendRequest(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
@@ -0,0 +1,31 @@
+package tokens
+
+import (
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+)
+
+// PatTokenCreateRequest encapsulates the request parameters for creating a new personal access token
+type PatTokenCreateRequest struct {
+	AllOrgs     *bool             `json:"allOrgs,omitempty"`
+	DisplayName *string           `json:"displayName,omitempty"`
+	Scope       *string           `json:"scope,omitempty"`
+	ValidTo     *azuredevops.Time `json:"validTo,omitempty"`
+}
+
+// PatToken represents a personal access token
+type PatToken struct {
+	AuthorizationId *uuid.UUID        `json:"authorizationId,omitempty"`
+	DisplayName     *string           `json:"displayName,omitempty"`
+	Scope           *string           `json:"scope,omitempty"`
+	TargetAccounts  *[]uuid.UUID      `json:"targetAccounts,omitempty"`
+	Token           *string           `json:"token,omitempty"`
+	ValidFrom       *azuredevops.Time `json:"validFrom,omitempty"`
+	ValidTo         *azuredevops.Time `json:"validTo,omitempty"`
+}
+
+// PatTokenResult contains the resulting personal access token or an error explaining why it could not be created
+type PatTokenResult struct {
+	PatToken      *PatToken `json:"patToken,omitempty"`
+	PatTokenError *string   `json:"patTokenError,omitempty"`
+}
@@ -1,10 +1,11 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"log"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops"
 )
 
@@ -14,11 +15,19 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	plugin.Serve(&plugin.ServeOpts{
-		Debug:        debug,
-		ProviderAddr: "registry.terraform.io/microsoft/azuredevops",
-		ProviderFunc: func() *schema.Provider {
-			return azuredevops.Provider()
-		},
-	})
+	ctx := context.Background()
+	muxServer, err := azuredevops.NewMuxProviderServer(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf6server.ServeOpt
+	if debug {
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
+	}
+
+	err = tf6server.Serve("registry.terraform.io/microsoft/azuredevops", muxServer, serveOpts...)
+	if err != nil {
+		log.Fatal(err)
+	}
 }